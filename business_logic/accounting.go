@@ -0,0 +1,329 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+	"tourmate/payment-service/constant/accounting"
+	file_support "tourmate/payment-service/constant/file/file_support"
+	"tourmate/payment-service/constant/ledger"
+	"tourmate/payment-service/constant/noti"
+	accounting_journal "tourmate/payment-service/infrastructure/accounting"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type accountingService struct {
+	logger      *log.Logger
+	journalRepo repo.IJournalRepo
+}
+
+func InitializeAccountingService(db *sql.DB, logger *log.Logger) business_logic.IAccountingService {
+	return &accountingService{
+		logger:      logger,
+		journalRepo: repository.InitializeJournalRepo(db, logger),
+	}
+}
+
+func GenerateAccountingService() (business_logic.IAccountingService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializeAccountingService(cnn, logger), nil
+}
+
+// GetAccountMappings implements businesslogic.IAccountingService.
+func (a *accountingService) GetAccountMappings(ctx context.Context) []response.AccountMappingResponse {
+	var codes map[string]string = accounting_journal.GetAccountCodes()
+
+	var res []response.AccountMappingResponse
+	for _, account := range accounting_journal.GetJournalAccounts() {
+		res = append(res, response.AccountMappingResponse{
+			Account: account,
+			Code:    codes[account],
+		})
+	}
+
+	return res
+}
+
+// ExportJournal implements businesslogic.IAccountingService.
+func (a *accountingService) ExportJournal(req request.ExportJournalRequest, ctx context.Context) (response.FileResponse, error) {
+	if req.To.Before(req.From) {
+		return response.FileResponse{}, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
+	}
+
+	// The end date is inclusive
+	lines, err := a.getJournalLines(req.From, req.To.AddDate(0, 0, 1), ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	var content []byte
+	var contentType, extension string
+	switch req.Format {
+	case file_support.CSV_FORMAT:
+		content, err = accounting_journal.GenerateJournalCsv(lines)
+		contentType, extension = file_support.CSV_CONTENT_TYPE, file_support.CSV_FORMAT
+	case accounting.MISA_FORMAT:
+		content, err = accounting_journal.GenerateJournalMisaXlsx(lines)
+		contentType, extension = file_support.XLSX_CONTENT_TYPE, file_support.XLSX_FORMAT
+	default:
+		return response.FileResponse{}, errors.New(noti.UNSUPPORTED_FILE_FORMAT_WARN_MSG)
+	}
+
+	if err != nil {
+		a.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, req.Format) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return response.FileResponse{
+		FileName: fmt.Sprintf("journal_%s_%s_%s.%s",
+			req.Format, req.From.Format(utils.DATE_FORMAT), req.To.Format(utils.DATE_FORMAT), extension),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// Build journal lines of the records in [from, to). Only stored data is used so that exporting the same period
+// again gives the same file
+func (a *accountingService) getJournalLines(from, to time.Time, ctx context.Context) ([]accounting_journal.JournalLine, error) {
+	var codes map[string]string = accounting_journal.GetAccountCodes()
+	var res []accounting_journal.JournalLine
+
+	payments, err := a.journalRepo.GetJournalPayments(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payment := range *payments {
+		res = append(res, generatePaymentJournalLines(payment, codes)...)
+	}
+
+	refunds, err := a.journalRepo.GetJournalRefunds(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, refund := range *refunds {
+		var customer string = accounting_journal.GenerateObjectCode(accounting.CUSTOMER_OBJECT, refund.CustomerId)
+		res = appendJournalLine(res, accounting_journal.JournalLine{
+			Date:          refund.RefundedAt,
+			VoucherNo:     accounting_journal.GenerateVoucherNo(accounting.REFUND_VOUCHER, refund.PaymentId),
+			VoucherMemo:   fmt.Sprintf("Hoàn tiền hóa đơn %d", refund.InvoiceId),
+			Description:   fmt.Sprintf("Trả lại tiền khách hàng hóa đơn %d", refund.InvoiceId),
+			DebitAccount:  codes[ledger.CUSTOMER_RECEIVABLE],
+			CreditAccount: codes[ledger.GATEWAY_CLEARING],
+			Amount:        refund.Price,
+			DebitObject:   customer,
+		})
+	}
+
+	adjustments, err := a.journalRepo.GetJournalAdjustments(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, adjustment := range *adjustments {
+		res = append(res, generateAdjustmentJournalLines(adjustment, codes)...)
+	}
+
+	payouts, err := a.journalRepo.GetJournalPayouts(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payout := range *payouts {
+		var guide string = accounting_journal.GenerateObjectCode(accounting.GUIDE_OBJECT, payout.TourGuideId)
+		var line accounting_journal.JournalLine = accounting_journal.JournalLine{
+			Date:         *payout.ProcessedAt,
+			VoucherNo:    accounting_journal.GenerateVoucherNo(accounting.PAYOUT_VOUCHER, payout.PayoutItemId),
+			VoucherMemo:  "Chi trả hướng dẫn viên " + payout.Reference,
+			DebitAccount: codes[ledger.GUIDE_PAYABLE],
+			DebitObject:  guide,
+		}
+
+		line.Description, line.CreditAccount, line.Amount = "Chuyển khoản cho hướng dẫn viên", codes[ledger.GATEWAY_CLEARING], payout.Amount
+		res = appendJournalLine(res, line)
+
+		line.Description, line.CreditAccount, line.Amount = "Thuế TNCN khấu trừ", codes[ledger.TAX_PAYABLE], payout.TaxAmount
+		res = appendJournalLine(res, line)
+	}
+
+	agencyReversals, err := a.journalRepo.GetJournalAgencyReversals(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reversal := range *agencyReversals {
+		res = appendJournalLine(res, accounting_journal.JournalLine{
+			Date:          reversal.CreatedAt,
+			VoucherNo:     accounting_journal.GenerateVoucherNo(accounting.AGENCY_REVERSAL_VOUCHER, reversal.AgencyRevenueId),
+			VoucherMemo:   fmt.Sprintf("Điều chỉnh doanh thu đại lý hóa đơn %d", reversal.InvoiceId),
+			Description:   "Điều chỉnh phải trả đại lý",
+			DebitAccount:  codes[ledger.AGENCY_PAYABLE],
+			CreditAccount: codes[ledger.CUSTOMER_RECEIVABLE],
+			Amount:        -reversal.Amount,
+			DebitObject:   accounting_journal.GenerateObjectCode(accounting.AGENCY_OBJECT, reversal.AgencyId),
+			CreditObject:  accounting_journal.GenerateObjectCode(accounting.CUSTOMER_OBJECT, reversal.CustomerId),
+		})
+	}
+
+	agencyPayouts, err := a.journalRepo.GetJournalAgencyPayouts(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payout := range *agencyPayouts {
+		res = appendJournalLine(res, accounting_journal.JournalLine{
+			Date:          *payout.ProcessedAt,
+			VoucherNo:     accounting_journal.GenerateVoucherNo(accounting.AGENCY_PAYOUT_VOUCHER, payout.AgencyPayoutId),
+			VoucherMemo:   "Chi trả đại lý " + payout.Reference,
+			Description:   "Chuyển khoản cho đại lý",
+			DebitAccount:  codes[ledger.AGENCY_PAYABLE],
+			CreditAccount: codes[ledger.GATEWAY_CLEARING],
+			Amount:        payout.Amount,
+			DebitObject:   accounting_journal.GenerateObjectCode(accounting.AGENCY_OBJECT, payout.AgencyId),
+		})
+	}
+
+	subscriptionInvoices, err := a.journalRepo.GetJournalSubscriptionInvoices(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invoice := range *subscriptionInvoices {
+		res = appendJournalLine(res, accounting_journal.JournalLine{
+			Date:          *invoice.PaidAt,
+			VoucherNo:     accounting_journal.GenerateVoucherNo(accounting.SUBSCRIPTION_VOUCHER, invoice.SubscriptionInvoiceId),
+			VoucherMemo:   fmt.Sprintf("Phí gói thành viên hóa đơn %d", invoice.SubscriptionInvoiceId),
+			Description:   "Thu phí gói thành viên của hướng dẫn viên",
+			DebitAccount:  codes[ledger.GATEWAY_CLEARING],
+			CreditAccount: codes[ledger.SUBSCRIPTION_REVENUE],
+			Amount:        invoice.Amount,
+			CreditObject:  accounting_journal.GenerateObjectCode(accounting.GUIDE_OBJECT, invoice.TourGuideId),
+		})
+	}
+
+	accounting_journal.SortJournalLines(res)
+
+	return res, nil
+}
+
+// Money is received from the customer, then split into the guide payable, the agency payable and the platform commission.
+// The commission takes the rounding difference so that the customer receivable is cleared. The gateway keeps its fee out of
+// the money collected, the surcharge paid by the customer on top of the price offsets it
+func generatePaymentJournalLines(payment entity.JournalPayment, codes map[string]string) []accounting_journal.JournalLine {
+	var customer string = accounting_journal.GenerateObjectCode(accounting.CUSTOMER_OBJECT, payment.CustomerId)
+	var guideAmount float64 = math.Round(payment.ActualReceived)
+	var line accounting_journal.JournalLine = accounting_journal.JournalLine{
+		Date:        payment.CreatedAt,
+		VoucherNo:   accounting_journal.GenerateVoucherNo(accounting.PAYMENT_VOUCHER, payment.PaymentId),
+		VoucherMemo: fmt.Sprintf("Thanh toán hóa đơn %d", payment.InvoiceId),
+	}
+
+	var res []accounting_journal.JournalLine
+
+	line.Description = fmt.Sprintf("Thu tiền khách hàng hóa đơn %d", payment.InvoiceId)
+	line.DebitAccount, line.CreditAccount = codes[ledger.GATEWAY_CLEARING], codes[ledger.CUSTOMER_RECEIVABLE]
+	line.DebitObject, line.CreditObject = "", customer
+	line.Amount = payment.Price
+	res = appendJournalLine(res, line)
+
+	line.Description = "Phải trả hướng dẫn viên"
+	line.DebitAccount, line.CreditAccount = codes[ledger.CUSTOMER_RECEIVABLE], codes[ledger.GUIDE_PAYABLE]
+	line.DebitObject, line.CreditObject = customer, accounting_journal.GenerateObjectCode(accounting.GUIDE_OBJECT, payment.TourGuideId)
+	line.Amount = guideAmount
+	res = appendJournalLine(res, line)
+
+	if payment.AgencyId != nil {
+		line.Description = "Phải trả đại lý"
+		line.DebitAccount, line.CreditAccount = codes[ledger.CUSTOMER_RECEIVABLE], codes[ledger.AGENCY_PAYABLE]
+		line.DebitObject, line.CreditObject = customer, accounting_journal.GenerateObjectCode(accounting.AGENCY_OBJECT, *payment.AgencyId)
+		line.Amount = payment.AgencyAmount
+		res = appendJournalLine(res, line)
+	}
+
+	line.Description = "Hoa hồng nền tảng"
+	line.DebitAccount, line.CreditAccount = codes[ledger.CUSTOMER_RECEIVABLE], codes[ledger.PLATFORM_COMMISSION]
+	line.DebitObject, line.CreditObject = customer, ""
+	line.Amount = math.Round(payment.ActualReceived+payment.PlatformCommission+payment.AgencyAmount) - guideAmount - math.Round(payment.AgencyAmount)
+	res = appendJournalLine(res, line)
+
+	line.Description = "Phụ phí thanh toán"
+	line.DebitAccount, line.CreditAccount = codes[ledger.GATEWAY_CLEARING], codes[ledger.GATEWAY_FEE]
+	line.DebitObject, line.CreditObject = "", ""
+	line.Amount = payment.Surcharge
+	res = appendJournalLine(res, line)
+
+	line.Description = "Phí cổng thanh toán"
+	line.DebitAccount, line.CreditAccount = codes[ledger.GATEWAY_FEE], codes[ledger.GATEWAY_CLEARING]
+	line.Amount = payment.FeeAmount
+	res = appendJournalLine(res, line)
+
+	return res
+}
+
+// Guide payable and commission follow the adjustment deltas against the customer receivable.
+// Reversals of refunded payments are included, the refund itself only returns the money to the customer
+func generateAdjustmentJournalLines(adjustment entity.JournalAdjustment, codes map[string]string) []accounting_journal.JournalLine {
+	var customer string = accounting_journal.GenerateObjectCode(accounting.CUSTOMER_OBJECT, adjustment.CustomerId)
+	var res []accounting_journal.JournalLine
+
+	for _, x := range []struct {
+		description string
+		account     string
+		object      string
+		delta       float64
+	}{
+		{"Điều chỉnh phải trả hướng dẫn viên", ledger.GUIDE_PAYABLE,
+			accounting_journal.GenerateObjectCode(accounting.GUIDE_OBJECT, adjustment.TourGuideId), adjustment.ActualReceivedDelta},
+		{"Điều chỉnh hoa hồng nền tảng", ledger.PLATFORM_COMMISSION, "", adjustment.PlatformCommissionDelta},
+	} {
+		var line accounting_journal.JournalLine = accounting_journal.JournalLine{
+			Date:        adjustment.CreatedAt,
+			VoucherNo:   accounting_journal.GenerateVoucherNo(accounting.ADJUSTMENT_VOUCHER, adjustment.RevenueAdjustmentId),
+			VoucherMemo: fmt.Sprintf("Điều chỉnh doanh thu hóa đơn %d - %s", adjustment.InvoiceId, adjustment.Reason),
+			Description: x.description,
+			Amount:      math.Abs(x.delta),
+		}
+
+		if x.delta > 0 {
+			line.DebitAccount, line.CreditAccount = codes[ledger.CUSTOMER_RECEIVABLE], codes[x.account]
+			line.DebitObject, line.CreditObject = customer, x.object
+		} else {
+			line.DebitAccount, line.CreditAccount = codes[x.account], codes[ledger.CUSTOMER_RECEIVABLE]
+			line.DebitObject, line.CreditObject = x.object, customer
+		}
+
+		res = appendJournalLine(res, line)
+	}
+
+	return res
+}
+
+// Amounts are rounded to whole đồng, empty lines are left out
+func appendJournalLine(lines []accounting_journal.JournalLine, line accounting_journal.JournalLine) []accounting_journal.JournalLine {
+	line.Amount = math.Round(line.Amount)
+	if line.Amount == 0 {
+		return lines
+	}
+
+	return append(lines, line)
+}
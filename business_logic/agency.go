@@ -0,0 +1,558 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"tourmate/payment-service/constant/commission"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type agencyService struct {
+	logger      *log.Logger
+	userService business_logic.IUserService
+	agencyRepo  repo.IAgencyRepo
+	ledgerRepo  repo.ILedgerRepo
+}
+
+func InitializeAgencyService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IAgencyService {
+	return &agencyService{
+		logger:      logger,
+		userService: userService,
+		agencyRepo:  repository.InitializeAgencyRepo(db, logger),
+		ledgerRepo:  repository.InitializeLedgerRepo(db, logger),
+	}
+}
+
+func GenerateAgencyService() (business_logic.IAgencyService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeAgencyService(cnn, userService, logger), nil
+}
+
+// GetAgencies implements businesslogic.IAgencyService.
+func (a *agencyService) GetAgencies(ctx context.Context) ([]response.AgencyResponse, error) {
+	agencies, err := a.agencyRepo.GetAgencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []response.AgencyResponse
+	for _, agency := range *agencies {
+		x, err := a.toAgencyResponse(agency)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *x)
+	}
+
+	return res, nil
+}
+
+// CreateAgency implements businesslogic.IAgencyService.
+func (a *agencyService) CreateAgency(req request.UpsertAgencyRequest, ctx context.Context) (*response.AgencyResponse, error) {
+	if req.AccountNumber == "" {
+		return nil, errors.New(noti.AGENCY_BANK_ACCOUNT_REQUIRED_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	agency, err := a.toAgency(req, entity.Agency{
+		CreatedBy: req.ActorId,
+		CreatedAt: curTime,
+	}, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := a.agencyRepo.CreateAgency(*agency, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agency.AgencyId = id
+
+	return a.toAgencyResponse(*agency)
+}
+
+// UpdateAgency implements businesslogic.IAgencyService.
+func (a *agencyService) UpdateAgency(req request.UpsertAgencyRequest, ctx context.Context) (*response.AgencyResponse, error) {
+	existedAgency, err := a.agencyRepo.GetAgencyById(req.AgencyId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if existedAgency == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Agency{}.GetAgencyTable()))
+	}
+
+	// New rates only apply to payments made from now on, recorded shares are kept
+	agency, err := a.toAgency(req, *existedAgency, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.agencyRepo.UpdateAgency(*agency, ctx); err != nil {
+		return nil, err
+	}
+
+	return a.toAgencyResponse(*agency)
+}
+
+// GetAgencyReport implements businesslogic.IAgencyService.
+func (a *agencyService) GetAgencyReport(req request.GetAgencyReportRequest, ctx context.Context) (*response.AgencyReportResponse, error) {
+	from, to, err := utils.GenerateDatePeriod(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	agency, err := a.agencyRepo.GetAgencyById(req.AgencyId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if agency == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Agency{}.GetAgencyTable()))
+	}
+
+	summary, err := a.agencyRepo.GetAgencyRevenueSummary(agency.AgencyId, from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	guideRevenues, err := a.agencyRepo.GetAgencyGuideRevenues(agency.AgencyId, from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agencyRes, err := a.toAgencyResponse(*agency)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.AgencyReportResponse = response.AgencyReportResponse{
+		Agency:  *agencyRes,
+		From:    req.From,
+		To:      req.To,
+		Summary: *summary,
+	}
+
+	for _, guideRevenue := range *guideRevenues {
+		var tourguideName string
+		if tourguideInfo, _ := a.userService.GetTourGuideById(ctx, &user_pb.GetTourGuideByIdRequest{
+			TourGuideId: int32(guideRevenue.TourGuideId),
+		}); tourguideInfo != nil {
+			tourguideName = tourguideInfo.FullName
+		}
+
+		res.Guides = append(res.Guides, response.AgencyGuideRevenueResponse{
+			AgencyGuideRevenue: guideRevenue,
+			TourGuideName:      tourguideName,
+		})
+	}
+
+	return &res, nil
+}
+
+// GetAgencyRevenues implements businesslogic.IAgencyService.
+func (a *agencyService) GetAgencyRevenues(req request.GetAgencyRevenuesRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.AgencyRevenue{}.GetAgencyRevenueLimitRecords()
+
+	from, to, err := utils.GenerateDatePeriod(req.From, req.To)
+	if err != nil {
+		return response.PaginationDataResponse{}, err
+	}
+
+	data, pages, totalRecords, err := a.agencyRepo.GetAgencyRevenues(req, from, to, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// CreateAgencyPayout implements businesslogic.IAgencyService.
+func (a *agencyService) CreateAgencyPayout(req request.CreateAgencyPayoutRequest, ctx context.Context) (*response.AgencyPayoutResponse, error) {
+	agency, err := a.agencyRepo.GetAgencyById(req.AgencyId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if agency == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Agency{}.GetAgencyTable()))
+	}
+
+	revenues, err := a.agencyRepo.GetPayableAgencyRevenues(agency.AgencyId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reversals of refunded payments are netted against the shares they reverse
+	var amount float64
+	var revenueIds []int
+	for _, revenue := range *revenues {
+		amount += revenue.Amount
+		revenueIds = append(revenueIds, revenue.AgencyRevenueId)
+	}
+
+	amount = utils.RoundMoney(amount)
+	if amount <= 0 {
+		return nil, errors.New(noti.NO_PAYABLE_AGENCY_REVENUE_WARN_MSG)
+	}
+
+	var payout entity.AgencyPayout = entity.AgencyPayout{
+		AgencyId:      agency.AgencyId,
+		Amount:        amount,
+		BankCode:      agency.BankCode,
+		AccountNumber: agency.AccountNumber,
+		AccountHolder: agency.AccountHolder,
+		Status:        domain_status.AGENCY_PAYOUT_PENDING,
+		Note:          req.Note,
+		CreatedBy:     req.ActorId,
+		CreatedAt:     time.Now(),
+	}
+
+	id, err := a.agencyRepo.CreateAgencyPayout(payout, revenueIds, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.GetAgencyPayout(id, ctx)
+}
+
+// GetAgencyPayouts implements businesslogic.IAgencyService.
+func (a *agencyService) GetAgencyPayouts(req request.GetAgencyPayoutsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.AgencyPayout{}.GetAgencyPayoutLimitRecords()
+
+	data, pages, totalRecords, err := a.agencyRepo.GetAgencyPayouts(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetAgencyPayout implements businesslogic.IAgencyService.
+func (a *agencyService) GetAgencyPayout(id int, ctx context.Context) (*response.AgencyPayoutResponse, error) {
+	payout, err := a.agencyRepo.GetAgencyPayoutById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payout == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.AgencyPayout{}.GetAgencyPayoutTable()))
+	}
+
+	revenueIds, err := a.agencyRepo.GetAgencyRevenueIdsByPayoutId(payout.AgencyPayoutId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accountNumber, err := decryptAccountNumber(payout.AccountNumber, a.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AgencyPayoutResponse{
+		AgencyPayoutId: payout.AgencyPayoutId,
+		AgencyId:       payout.AgencyId,
+		Amount:         payout.Amount,
+		BankCode:       payout.BankCode,
+		AccountNumber:  accountNumber,
+		AccountHolder:  payout.AccountHolder,
+		Reference:      payout.Reference,
+		Status:         payout.Status,
+		Note:           payout.Note,
+		CreatedBy:      payout.CreatedBy,
+		ProcessedBy:    payout.ProcessedBy,
+		CreatedAt:      payout.CreatedAt,
+		ProcessedAt:    payout.ProcessedAt,
+		RevenueIds:     revenueIds,
+	}, nil
+}
+
+// ConfirmAgencyPayout implements businesslogic.IAgencyService.
+func (a *agencyService) ConfirmAgencyPayout(req request.ProcessAgencyPayoutRequest, ctx context.Context) (*response.AgencyPayoutResponse, error) {
+	payout, err := a.processAgencyPayout(req, domain_status.AGENCY_PAYOUT_PAID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postAgencyPayoutLedgerEntry(a.ledgerRepo, *payout, ctx); err != nil {
+		return nil, err
+	}
+
+	return a.GetAgencyPayout(payout.AgencyPayoutId, ctx)
+}
+
+// CancelAgencyPayout implements businesslogic.IAgencyService.
+func (a *agencyService) CancelAgencyPayout(req request.ProcessAgencyPayoutRequest, ctx context.Context) (*response.AgencyPayoutResponse, error) {
+	payout, err := a.processAgencyPayout(req, domain_status.AGENCY_PAYOUT_CANCELLED, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.GetAgencyPayout(payout.AgencyPayoutId, ctx)
+}
+
+// Move a pending payout to its final status, a transfer is confirmed by another admin than the one who created it
+func (a *agencyService) processAgencyPayout(req request.ProcessAgencyPayoutRequest, status string, ctx context.Context) (*entity.AgencyPayout, error) {
+	payout, err := a.agencyRepo.GetAgencyPayoutById(req.AgencyPayoutId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payout == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.AgencyPayout{}.GetAgencyPayoutTable()))
+	}
+
+	if payout.Status != domain_status.AGENCY_PAYOUT_PENDING {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	if status == domain_status.AGENCY_PAYOUT_PAID && payout.CreatedBy == req.ActorId {
+		return nil, errors.New(noti.SAME_ACTOR_APPROVAL_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	payout.Status = status
+	payout.ProcessedBy = &req.ActorId
+	payout.ProcessedAt = &curTime
+	if req.Note != "" {
+		payout.Note = req.Note
+	}
+
+	if err := a.agencyRepo.UpdateAgencyPayout(*payout, domain_status.AGENCY_PAYOUT_PENDING, ctx); err != nil {
+		return nil, err
+	}
+
+	return payout, nil
+}
+
+// Apply the request on the agency, the name must be unique and the rates cannot take more than the price
+func (a *agencyService) toAgency(req request.UpsertAgencyRequest, agency entity.Agency, ctx context.Context) (*entity.Agency, error) {
+	if req.CommissionRate+req.ShareRate > 100 {
+		return nil, errors.New(noti.INVALID_AGENCY_RATE_WARN_MSG)
+	}
+
+	var bankCode string = strings.ToUpper(strings.TrimSpace(req.BankCode))
+	if !utils.IsBankCodeValid(bankCode) {
+		return nil, errors.New(noti.UNSUPPORTED_BANK_WARN_MSG)
+	}
+
+	var name string = strings.TrimSpace(req.Name)
+	existedAgency, err := a.agencyRepo.GetAgencyByName(name, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if existedAgency != nil && existedAgency.AgencyId != agency.AgencyId {
+		return nil, errors.New(noti.ITEM_EXISTED_WARN_MSG)
+	}
+
+	if req.AccountNumber != "" {
+		encryptedNumber, err := encryptAccountNumber(req.AccountNumber, a.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		agency.AccountNumber = encryptedNumber
+	}
+
+	agency.Name = name
+	agency.CommissionRate = req.CommissionRate
+	agency.ShareRate = req.ShareRate
+	agency.BankCode = bankCode
+	agency.AccountHolder = strings.ToUpper(strings.TrimSpace(req.AccountHolder))
+	agency.IsActive = req.IsActive
+	agency.UpdatedAt = time.Now()
+
+	return &agency, nil
+}
+
+func (a *agencyService) toAgencyResponse(agency entity.Agency) (*response.AgencyResponse, error) {
+	accountNumber, err := decryptAccountNumber(agency.AccountNumber, a.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AgencyResponse{
+		AgencyId:       agency.AgencyId,
+		Name:           agency.Name,
+		CommissionRate: agency.CommissionRate,
+		ShareRate:      agency.ShareRate,
+		BankCode:       agency.BankCode,
+		AccountNumber:  utils.MaskAccountNumber(accountNumber),
+		AccountHolder:  agency.AccountHolder,
+		IsActive:       agency.IsActive,
+		CreatedBy:      agency.CreatedBy,
+		CreatedAt:      agency.CreatedAt,
+		UpdatedAt:      agency.UpdatedAt,
+	}, nil
+}
+
+// Split the price of a payment between the platform commission, the agency of the tour guide and the tour guide.
+// The revenue of the tour guide only holds their share, the agency share is recorded separately once the revenue is created.
+// A subscribed tour guide pays the plan commission when it is lower, the configured share of the gateway fee is charged on top
+func splitPaymentRevenue(agencyRepo repo.IAgencyRepo, subscriptionRepo repo.ISubscriptionRepo, userService business_logic.IUserService, payment entity.Payment, paymentFee *entity.PaymentFee, tourGuideId int, ctx context.Context) (entity.Revenue, *entity.AgencyRevenue, error) {
+	var rate float64 = getPlatformCommissionRate()
+	var agencyRevenue *entity.AgencyRevenue
+
+	agency, err := getTourGuideAgency(agencyRepo, userService, tourGuideId, ctx)
+	if err != nil {
+		return entity.Revenue{}, nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	if agency != nil {
+		rate = agency.CommissionRate
+		agencyRevenue = &entity.AgencyRevenue{
+			AgencyId:    agency.AgencyId,
+			PaymentId:   payment.PaymentId,
+			TourGuideId: tourGuideId,
+			InvoiceId:   payment.InvoiceId,
+			TotalAmount: payment.Price,
+			ShareRate:   agency.ShareRate,
+			Amount:      utils.RoundMoney(payment.Price * agency.ShareRate / 100),
+			CreatedAt:   curTime,
+		}
+	}
+
+	plan, err := subscriptionRepo.GetActiveSubscriptionPlan(tourGuideId, ctx)
+	if err != nil {
+		return entity.Revenue{}, nil, err
+	}
+
+	if plan != nil {
+		rate = min(rate, plan.CommissionRate)
+	}
+
+	var totalAmount float64 = payment.Price
+	if agencyRevenue != nil {
+		totalAmount = utils.RoundMoney(payment.Price - agencyRevenue.Amount)
+	}
+
+	var platformCommission float64 = utils.RoundMoney(payment.Price * rate / 100)
+
+	// The guide share of the gateway fee left after the surcharge is moved from their part to the commission
+	if paymentFee != nil {
+		var guideFee float64 = utils.RoundMoney(max(paymentFee.FeeAmount-paymentFee.Surcharge, 0) * getGatewayFeeGuideShare() / 100)
+		platformCommission = utils.RoundMoney(platformCommission + min(guideFee, max(totalAmount-platformCommission, 0)))
+	}
+
+	return entity.Revenue{
+		PaymentId:          payment.PaymentId,
+		TourGuideId:        tourGuideId,
+		InvoiceId:          payment.InvoiceId,
+		TotalAmount:        totalAmount,
+		ActualReceived:     utils.RoundMoney(totalAmount - platformCommission),
+		PlatformCommission: platformCommission,
+		PaymentStatus:      false,
+		CreatedAt:          curTime,
+	}, agencyRevenue, nil
+}
+
+// The agency of a tour guide is the active agency named as their company
+func getTourGuideAgency(agencyRepo repo.IAgencyRepo, userService business_logic.IUserService, tourGuideId int, ctx context.Context) (*entity.Agency, error) {
+	tourguideInfo, _ := userService.GetTourGuideById(ctx, &user_pb.GetTourGuideByIdRequest{
+		TourGuideId: int32(tourGuideId),
+	})
+
+	if tourguideInfo == nil || strings.TrimSpace(tourguideInfo.Company) == "" {
+		return nil, nil
+	}
+
+	agency, err := agencyRepo.GetAgencyByName(strings.TrimSpace(tourguideInfo.Company), ctx)
+	if err != nil || agency == nil || !agency.IsActive {
+		return nil, err
+	}
+
+	return agency, nil
+}
+
+// Take back the agency share of a refunded payment. The original share is returned so that the refund entry can
+// reverse it, the reversal is only recorded once
+func reverseAgencyRevenue(agencyRepo repo.IAgencyRepo, paymentId int, ctx context.Context) (*entity.AgencyRevenue, error) {
+	revenues, err := agencyRepo.GetAgencyRevenuesByPaymentId(paymentId, ctx)
+	if err != nil || len(*revenues) == 0 {
+		return nil, err
+	}
+
+	var share entity.AgencyRevenue = (*revenues)[0]
+	var netAmount, netTotalAmount float64
+	for _, revenue := range *revenues {
+		netAmount += revenue.Amount
+		netTotalAmount += revenue.TotalAmount
+	}
+
+	if utils.RoundMoney(netAmount) != 0 {
+		if _, err := agencyRepo.CreateAgencyRevenue(entity.AgencyRevenue{
+			AgencyId:    share.AgencyId,
+			RevenueId:   share.RevenueId,
+			PaymentId:   share.PaymentId,
+			TourGuideId: share.TourGuideId,
+			InvoiceId:   share.InvoiceId,
+			TotalAmount: -utils.RoundMoney(netTotalAmount),
+			ShareRate:   share.ShareRate,
+			Amount:      -utils.RoundMoney(netAmount),
+			CreatedAt:   time.Now(),
+		}, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &share, nil
+}
+
+func getPlatformCommissionRate() float64 {
+	if rate, err := strconv.ParseFloat(os.Getenv(payment_env.PLATFORM_COMMISSION_RATE), 64); err == nil && rate >= 0 && rate <= 100 {
+		return rate
+	}
+
+	return commission.DEFAULT_PLATFORM_RATE
+}
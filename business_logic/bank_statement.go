@@ -0,0 +1,567 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	file_support "tourmate/payment-service/constant/file/file_support"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/infrastructure/bank"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	"tourmate/payment-service/infrastructure/vietqr"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type bankStatementService struct {
+	logger            *log.Logger
+	recorder          *paymentRecorder
+	bankStatementRepo repo.IBankStatementRepo
+	paymentRepo       repo.IPaymentRepo
+}
+
+func InitializeBankStatementService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IBankStatementService {
+	return &bankStatementService{
+		logger:            logger,
+		recorder:          initializePaymentRecorder(db, userService, logger),
+		bankStatementRepo: repository.InitializeBankStatementRepo(db, logger),
+		paymentRepo:       repository.InitializePaymentRepo(db, logger),
+	}
+}
+
+func GenerateBankStatementService() (business_logic.IBankStatementService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeBankStatementService(cnn, userService, logger), nil
+}
+
+// RegisterPendingInvoice implements businesslogic.IBankStatementService.
+func (b *bankStatementService) RegisterPendingInvoice(req request.RegisterPendingInvoiceRequest, ctx context.Context) (*entity.PendingInvoice, error) {
+	return b.registerPendingInvoice(req, payment_method.BANK_TRANSFER, ctx)
+}
+
+// CreateVietQrTransaction implements businesslogic.IBankStatementService.
+func (b *bankStatementService) CreateVietQrTransaction(req request.RegisterPendingInvoiceRequest, ctx context.Context) (*response.VietQrResponse, error) {
+	account, err := vietqr.GetAccount()
+	if err != nil {
+		b.logger.Println(fmt.Sprintf(noti.PAYMENT_INIT_ENV_ERR_MSG, payment_method.VIETQR) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	invoice, err := b.registerPendingInvoice(req, payment_method.VIETQR, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var note string = utils.GenerateInvoiceNote(invoice.InvoiceId)
+	var payload string = vietqr.GeneratePayload(account, invoice.Amount, note)
+
+	image, err := vietqr.GeneratePng(payload)
+	if err != nil {
+		b.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, file_support.PNG_FORMAT) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &response.VietQrResponse{
+		PendingInvoiceId: invoice.PendingInvoiceId,
+		InvoiceId:        invoice.InvoiceId,
+		BankCode:         account.BankCode,
+		BankBin:          account.BankBin,
+		AccountNo:        account.AccountNo,
+		AccountName:      account.AccountName,
+		Amount:           math.Round(invoice.Amount),
+		Note:             note,
+		Payload:          payload,
+		QrImage:          "data:" + file_support.PNG_CONTENT_TYPE + ";base64," + base64.StdEncoding.EncodeToString(image),
+	}, nil
+}
+
+// GetVietQrImage implements businesslogic.IBankStatementService.
+func (b *bankStatementService) GetVietQrImage(pendingInvoiceId int, ctx context.Context) (response.FileResponse, error) {
+	invoice, err := b.bankStatementRepo.GetPendingInvoiceById(pendingInvoiceId, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	if invoice == nil {
+		return response.FileResponse{}, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PendingInvoice{}.GetPendingInvoiceTable()))
+	}
+
+	// A code of a paid or cancelled invoice would take the money without paying anything
+	if invoice.Status != domain_status.PENDING_INVOICE_PENDING {
+		return response.FileResponse{}, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	account, err := vietqr.GetAccount()
+	if err != nil {
+		b.logger.Println(fmt.Sprintf(noti.PAYMENT_INIT_ENV_ERR_MSG, payment_method.VIETQR) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	image, err := vietqr.GeneratePng(vietqr.GeneratePayload(account, invoice.Amount, utils.GenerateInvoiceNote(invoice.InvoiceId)))
+	if err != nil {
+		b.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, file_support.PNG_FORMAT) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return response.FileResponse{
+		FileName:    fmt.Sprintf("vietqr_invoice_%d.%s", invoice.InvoiceId, file_support.PNG_FORMAT),
+		ContentType: file_support.PNG_CONTENT_TYPE,
+		Content:     image,
+	}, nil
+}
+
+// ConfirmPendingInvoice implements businesslogic.IBankStatementService.
+func (b *bankStatementService) ConfirmPendingInvoice(req request.ConfirmPendingInvoiceRequest, ctx context.Context) (*entity.Payment, error) {
+	invoice, err := b.bankStatementRepo.GetPendingInvoiceById(req.PendingInvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PendingInvoice{}.GetPendingInvoiceTable()))
+	}
+
+	if invoice.Status != domain_status.PENDING_INVOICE_PENDING {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	payment, err := b.paymentRepo.GetPaidPaymentByInvoiceId(invoice.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment != nil {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	payment, err = b.payPendingInvoice(*invoice, invoice.Amount, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b.logger.Printf("Pending invoice %d confirmed manually by %d - %s", invoice.PendingInvoiceId, req.ActorId, req.Note)
+	return payment, nil
+}
+
+// Register an invoice paid by a transfer of the method, a pending registration of the same invoice is updated
+func (b *bankStatementService) registerPendingInvoice(req request.RegisterPendingInvoiceRequest, method string, ctx context.Context) (*entity.PendingInvoice, error) {
+	payment, err := b.paymentRepo.GetPaidPaymentByInvoiceId(req.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment != nil {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	invoice, err := b.bankStatementRepo.GetPendingInvoiceByInvoiceId(req.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	if invoice != nil && invoice.Status == domain_status.PENDING_INVOICE_PAID {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	if invoice != nil && invoice.Status == domain_status.PENDING_INVOICE_PENDING {
+		invoice.CustomerId = req.CustomerId
+		invoice.TourGuideId = req.TourGuideId
+		invoice.ServiceId = req.ServiceId
+		invoice.Amount = req.Amount
+		invoice.PaymentMethod = method
+		invoice.UpdatedAt = curTime
+
+		if err := b.bankStatementRepo.UpdatePendingInvoice(*invoice, domain_status.PENDING_INVOICE_PENDING, ctx); err != nil {
+			return nil, err
+		}
+
+		return invoice, nil
+	}
+
+	var res entity.PendingInvoice = entity.PendingInvoice{
+		InvoiceId:     req.InvoiceId,
+		CustomerId:    req.CustomerId,
+		TourGuideId:   req.TourGuideId,
+		ServiceId:     req.ServiceId,
+		Amount:        req.Amount,
+		PaymentMethod: method,
+		Status:        domain_status.PENDING_INVOICE_PENDING,
+		CreatedAt:     curTime,
+		UpdatedAt:     curTime,
+	}
+
+	id, err := b.bankStatementRepo.CreatePendingInvoice(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.PendingInvoiceId = id
+	return &res, nil
+}
+
+// CancelPendingInvoice implements businesslogic.IBankStatementService.
+func (b *bankStatementService) CancelPendingInvoice(id int, ctx context.Context) error {
+	invoice, err := b.bankStatementRepo.GetPendingInvoiceById(id, ctx)
+	if err != nil {
+		return err
+	}
+
+	if invoice == nil {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PendingInvoice{}.GetPendingInvoiceTable()))
+	}
+
+	invoice.Status = domain_status.PENDING_INVOICE_CANCELLED
+	invoice.UpdatedAt = time.Now()
+
+	return b.bankStatementRepo.UpdatePendingInvoice(*invoice, domain_status.PENDING_INVOICE_PENDING, ctx)
+}
+
+// ImportBankStatement implements businesslogic.IBankStatementService.
+func (b *bankStatementService) ImportBankStatement(req request.ImportBankStatementRequest, ctx context.Context) (*response.BankStatementImportResponse, error) {
+	bankFile, err := bank.GenerateBankTransferFile(req.Bank)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := utils.ReadTabularFile(req.FileName, req.Content)
+	if err != nil {
+		b.logger.Println(fmt.Sprintf(noti.FILE_READ_ERR_MSG, req.FileName) + err.Error())
+		return nil, errors.New(noti.INVALID_FILE_CONTENT_WARN_MSG)
+	}
+
+	lines, err := bankFile.ParseStatementRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.BankStatementImportResponse = response.BankStatementImportResponse{
+		TotalLines:   len(lines),
+		Transactions: []entity.BankTransaction{},
+	}
+
+	var curTime time.Time = time.Now()
+	for _, line := range lines {
+		var transaction entity.BankTransaction = entity.BankTransaction{
+			BankCode:        strings.ToUpper(req.Bank),
+			TransactionRef:  line.Reference,
+			TransactionDate: line.TransactionDate,
+			Amount:          line.Amount,
+			Description:     line.Description,
+			ImportedBy:      req.ActorId,
+			ImportedAt:      curTime,
+		}
+
+		// The same statement is often exported again with overlapping dates
+		isImported, err := b.bankStatementRepo.IsBankTransactionImported(transaction, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if isImported {
+			res.DuplicateLines++
+			continue
+		}
+
+		invoice, err := b.matchBankTransaction(&transaction, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// The line is saved for review before the invoice is paid so that a payment never exists without its bank line
+		id, err := b.bankStatementRepo.CreateBankTransaction(transaction, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		transaction.BankTransactionId = id
+		if invoice != nil {
+			if err := b.payMatchedBankTransaction(&transaction, *invoice, ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		res.Transactions = append(res.Transactions, transaction)
+
+		switch transaction.Status {
+		case domain_status.BANK_TRANSACTION_MATCHED:
+			res.MatchedLines++
+		case domain_status.BANK_TRANSACTION_REVIEW:
+			res.ReviewLines++
+		default:
+			res.UnmatchedLines++
+		}
+	}
+
+	return &res, nil
+}
+
+// GetBankTransactions implements businesslogic.IBankStatementService.
+func (b *bankStatementService) GetBankTransactions(req request.GetBankTransactionsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.Status = strings.ToUpper(req.Status)
+	req.PageSize = entity.BankTransaction{}.GetBankTransactionLimitRecords()
+
+	data, pages, totalRecords, err := b.bankStatementRepo.GetBankTransactions(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// AcceptBankTransaction implements businesslogic.IBankStatementService.
+func (b *bankStatementService) AcceptBankTransaction(req request.AcceptBankTransactionRequest, ctx context.Context) (*entity.BankTransaction, error) {
+	transaction, err := b.getReviewableBankTransaction(req.BankTransactionId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingInvoiceId *int = transaction.PendingInvoiceId
+	if req.PendingInvoiceId != nil {
+		pendingInvoiceId = req.PendingInvoiceId
+	}
+
+	if pendingInvoiceId == nil {
+		return nil, errors.New(noti.PENDING_INVOICE_REQUIRED_WARN_MSG)
+	}
+
+	invoice, err := b.bankStatementRepo.GetPendingInvoiceById(*pendingInvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PendingInvoice{}.GetPendingInvoiceTable()))
+	}
+
+	if invoice.Status != domain_status.PENDING_INVOICE_PENDING {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	payment, err := b.paymentRepo.GetPaidPaymentByInvoiceId(invoice.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment != nil {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	// The transaction is taken first so that two reviewers cannot accept it at the same time
+	var curTime time.Time = time.Now()
+	var previous entity.BankTransaction = *transaction
+	transaction.Status = domain_status.BANK_TRANSACTION_ACCEPTED
+	transaction.PendingInvoiceId = &invoice.PendingInvoiceId
+	transaction.ReviewedBy = &req.ActorId
+	transaction.ReviewedAt = &curTime
+	if req.Note != "" {
+		transaction.MatchNote = req.Note
+	}
+
+	if err := b.bankStatementRepo.UpdateBankTransaction(*transaction, previous.Status, ctx); err != nil {
+		return nil, err
+	}
+
+	// The customer is credited with what actually arrived on the account
+	payment, err = b.payPendingInvoice(*invoice, transaction.Amount, ctx)
+	if err != nil {
+		b.bankStatementRepo.UpdateBankTransaction(previous, domain_status.BANK_TRANSACTION_ACCEPTED, ctx)
+		return nil, err
+	}
+
+	transaction.PaymentId = &payment.PaymentId
+	if err := b.bankStatementRepo.UpdateBankTransaction(*transaction, domain_status.BANK_TRANSACTION_ACCEPTED, ctx); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// RejectBankTransaction implements businesslogic.IBankStatementService.
+func (b *bankStatementService) RejectBankTransaction(req request.RejectBankTransactionRequest, ctx context.Context) (*entity.BankTransaction, error) {
+	transaction, err := b.getReviewableBankTransaction(req.BankTransactionId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentStatus string = transaction.Status
+	var curTime time.Time = time.Now()
+	transaction.Status = domain_status.BANK_TRANSACTION_REJECTED
+	transaction.MatchNote = req.Note
+	transaction.ReviewedBy = &req.ActorId
+	transaction.ReviewedAt = &curTime
+
+	if err := b.bankStatementRepo.UpdateBankTransaction(*transaction, currentStatus, ctx); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// Transactions waiting for review and unmatched ones can be decided by an accountant
+func (b *bankStatementService) getReviewableBankTransaction(id int, ctx context.Context) (*entity.BankTransaction, error) {
+	transaction, err := b.bankStatementRepo.GetBankTransactionById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.BankTransaction{}.GetBankTransactionTable()))
+	}
+
+	if transaction.Status != domain_status.BANK_TRANSACTION_REVIEW && transaction.Status != domain_status.BANK_TRANSACTION_UNMATCHED {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return transaction, nil
+}
+
+// Decide the status of an incoming transaction. The invoice is only returned to be paid automatically when the note
+// names a single pending invoice with the same amount which has not been paid yet
+func (b *bankStatementService) matchBankTransaction(transaction *entity.BankTransaction, ctx context.Context) (*entity.PendingInvoice, error) {
+	var candidates []entity.PendingInvoice
+	for _, invoiceId := range utils.GetNoteInvoiceIds(transaction.Description) {
+		invoice, err := b.bankStatementRepo.GetPendingInvoiceByInvoiceId(invoiceId, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if invoice != nil && invoice.Status != domain_status.PENDING_INVOICE_CANCELLED {
+			candidates = append(candidates, *invoice)
+		}
+	}
+
+	if len(candidates) == 0 {
+		transaction.Status = domain_status.BANK_TRANSACTION_UNMATCHED
+		transaction.MatchNote = "No pending invoice found in the transfer note"
+		return nil, nil
+	}
+
+	// The suggestion for the reviewer is the first candidate with the same amount, or the first one
+	var invoice entity.PendingInvoice = candidates[0]
+	for _, candidate := range candidates {
+		if isSameAmount(candidate.Amount, transaction.Amount) {
+			invoice = candidate
+			break
+		}
+	}
+
+	transaction.Status = domain_status.BANK_TRANSACTION_REVIEW
+	transaction.PendingInvoiceId = &invoice.PendingInvoiceId
+
+	if len(candidates) > 1 {
+		transaction.MatchNote = "Several invoices found in the transfer note"
+		return nil, nil
+	}
+
+	if invoice.Status != domain_status.PENDING_INVOICE_PENDING {
+		transaction.MatchNote = fmt.Sprintf("Invoice %d is already paid", invoice.InvoiceId)
+		return nil, nil
+	}
+
+	if !isSameAmount(invoice.Amount, transaction.Amount) {
+		transaction.MatchNote = fmt.Sprintf("Transferred amount %s differs from invoice amount %s",
+			utils.FormatMoney(transaction.Amount), utils.FormatMoney(invoice.Amount))
+		return nil, nil
+	}
+
+	payment, err := b.paymentRepo.GetPaidPaymentByInvoiceId(invoice.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment != nil {
+		transaction.MatchNote = fmt.Sprintf("Invoice %d is already paid by payment %d", invoice.InvoiceId, payment.PaymentId)
+		return nil, nil
+	}
+
+	transaction.MatchNote = fmt.Sprintf("Matched invoice %d by reference and amount", invoice.InvoiceId)
+	return &invoice, nil
+}
+
+// Pay the invoice of a saved transaction waiting for review and mark the transaction as matched with the payment
+func (b *bankStatementService) payMatchedBankTransaction(transaction *entity.BankTransaction, invoice entity.PendingInvoice, ctx context.Context) error {
+	payment, err := b.payPendingInvoice(invoice, transaction.Amount, ctx)
+	if err != nil {
+		// Another import has just paid the invoice, the transaction stays for review
+		if err.Error() == noti.INVALID_STATUS_WARN_MSG {
+			transaction.MatchNote = fmt.Sprintf("Invoice %d is already paid", invoice.InvoiceId)
+			return b.bankStatementRepo.UpdateBankTransaction(*transaction, domain_status.BANK_TRANSACTION_REVIEW, ctx)
+		}
+
+		return err
+	}
+
+	transaction.Status = domain_status.BANK_TRANSACTION_MATCHED
+	transaction.PaymentId = &payment.PaymentId
+	return b.bankStatementRepo.UpdateBankTransaction(*transaction, domain_status.BANK_TRANSACTION_REVIEW, ctx)
+}
+
+// Mark the invoice as paid then record the payment, the invoice is given back when the payment cannot be recorded
+func (b *bankStatementService) payPendingInvoice(invoice entity.PendingInvoice, amount float64, ctx context.Context) (*entity.Payment, error) {
+	invoice.Status = domain_status.PENDING_INVOICE_PAID
+	invoice.UpdatedAt = time.Now()
+	if err := b.bankStatementRepo.UpdatePendingInvoice(invoice, domain_status.PENDING_INVOICE_PENDING, ctx); err != nil {
+		return nil, err
+	}
+
+	payment, err := b.recorder.createPaidPayment(request.CreatePaymentRequest{
+		CustomerId:    invoice.CustomerId,
+		TourGuideId:   invoice.TourGuideId,
+		InvoiceId:     invoice.InvoiceId,
+		ServiceId:     invoice.ServiceId,
+		Price:         amount,
+		PaymentMethod: invoice.PaymentMethod,
+	}, ctx)
+
+	if err != nil {
+		invoice.Status = domain_status.PENDING_INVOICE_PENDING
+		b.bankStatementRepo.UpdatePendingInvoice(invoice, domain_status.PENDING_INVOICE_PAID, ctx)
+		return nil, err
+	}
+
+	invoice.PaymentId = &payment.PaymentId
+	if err := b.bankStatementRepo.UpdatePendingInvoice(invoice, domain_status.PENDING_INVOICE_PAID, ctx); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// Bank amounts are whole đồng, a difference below one đồng is noise from parsing
+func isSameAmount(a, b float64) bool {
+	return math.Abs(a-b) < 1
+}
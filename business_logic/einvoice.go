@@ -0,0 +1,361 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/einvoice"
+	file_support "tourmate/payment-service/constant/file/file_support"
+	"tourmate/payment-service/constant/noti"
+	einvoice_render "tourmate/payment-service/infrastructure/einvoice"
+	"tourmate/payment-service/infrastructure/grpc/tour"
+	tour_pb "tourmate/payment-service/infrastructure/grpc/tour/pb"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type eInvoiceService struct {
+	logger       *log.Logger
+	userService  business_logic.IUserService
+	tourService  business_logic.ITourService
+	eInvoiceRepo repo.IEInvoiceRepo
+	paymentRepo  repo.IPaymentRepo
+}
+
+func InitializeEInvoiceService(db *sql.DB, userService business_logic.IUserService, tourService business_logic.ITourService, logger *log.Logger) business_logic.IEInvoiceService {
+	return &eInvoiceService{
+		logger:       logger,
+		userService:  userService,
+		tourService:  tourService,
+		eInvoiceRepo: repository.InitializeEInvoiceRepo(db, logger),
+		paymentRepo:  repository.InitializePaymentRepo(db, logger),
+	}
+}
+
+func GenerateEInvoiceService() (business_logic.IEInvoiceService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+	tourService, _ := tour.GenerateTourService(logger)
+
+	return InitializeEInvoiceService(cnn, userService, tourService, logger), nil
+}
+
+// GetEInvoice implements businesslogic.IEInvoiceService.
+func (e *eInvoiceService) GetEInvoice(id int, ctx context.Context) (*entity.EInvoice, error) {
+	invoice, err := e.eInvoiceRepo.GetEInvoiceById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.EInvoice{}.GetEInvoiceTable()))
+	}
+
+	return invoice, nil
+}
+
+// GetPaymentEInvoices implements businesslogic.IEInvoiceService.
+func (e *eInvoiceService) GetPaymentEInvoices(paymentId int, ctx context.Context) (*[]entity.EInvoice, error) {
+	return e.eInvoiceRepo.GetEInvoicesByPaymentId(paymentId, ctx)
+}
+
+// IssueEInvoice implements businesslogic.IEInvoiceService.
+func (e *eInvoiceService) IssueEInvoice(req request.IssueEInvoiceRequest, ctx context.Context) (*entity.EInvoice, error) {
+	if err := validateEInvoiceBuyer(req.BuyerTaxCode, req.BuyerCompanyName); err != nil {
+		return nil, err
+	}
+
+	payment, err := e.paymentRepo.GetPaymentById(req.PaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Payment{}.GetPaymentTable()))
+	}
+
+	if payment.Status != domain_status.PAYMENT_PAID {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	invoices, err := e.eInvoiceRepo.GetEInvoicesByPaymentId(payment.PaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*invoices) > 0 {
+		return nil, errors.New(noti.EINVOICE_EXISTED_WARN_MSG)
+	}
+
+	var buyerName, buyerEmail string = strings.TrimSpace(req.BuyerName), strings.TrimSpace(req.BuyerEmail)
+	if buyerName == "" || buyerEmail == "" {
+		if userInfo, _ := e.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
+			CustomerId: int32(payment.CustomerId),
+		}); userInfo != nil {
+			if buyerName == "" {
+				buyerName = userInfo.FullName
+			}
+
+			if buyerEmail == "" {
+				buyerEmail = userInfo.Email
+			}
+		}
+	}
+
+	var itemName string = fmt.Sprintf("Tour service %d", payment.ServiceId)
+	if serviceInfo, _ := e.tourService.GetTourById(ctx, &tour_pb.TourServiceIdRequest{
+		ServiceId: int32(payment.ServiceId),
+	}); serviceInfo != nil {
+		itemName = serviceInfo.ServiceName
+	}
+
+	var curTime time.Time = time.Now()
+	var vatRate float64 = einvoice_render.GetVatRate()
+	amountBeforeTax, vatAmount := calculateEInvoiceAmounts(payment.Price, vatRate)
+
+	return e.eInvoiceRepo.CreateEInvoice(entity.EInvoice{
+		PaymentId:        payment.PaymentId,
+		InvoiceType:      einvoice.ORIGINAL,
+		TemplateCode:     einvoice_render.GetTemplateCode(),
+		Series:           einvoice_render.GetSeries(curTime),
+		Status:           domain_status.EINVOICE_ISSUED,
+		BuyerName:        buyerName,
+		BuyerCompanyName: strings.TrimSpace(req.BuyerCompanyName),
+		BuyerTaxCode:     strings.TrimSpace(req.BuyerTaxCode),
+		BuyerAddress:     strings.TrimSpace(req.BuyerAddress),
+		BuyerEmail:       buyerEmail,
+		ItemName:         itemName,
+		AmountBeforeTax:  amountBeforeTax,
+		VatRate:          vatRate,
+		VatAmount:        vatAmount,
+		TotalAmount:      math.Round(payment.Price),
+		IssuedBy:         req.ActorId,
+		IssuedAt:         curTime,
+	}, "", ctx)
+}
+
+// AdjustEInvoice implements businesslogic.IEInvoiceService.
+func (e *eInvoiceService) AdjustEInvoice(req request.AdjustEInvoiceRequest, ctx context.Context) (*entity.EInvoice, error) {
+	invoice, invoices, err := e.getAdjustableEInvoice(req.EInvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return createEInvoiceAdjustment(e.eInvoiceRepo, *invoice, *invoices, req.Amount, req.ActorId, req.Reason, ctx)
+}
+
+// ReplaceEInvoice implements businesslogic.IEInvoiceService.
+func (e *eInvoiceService) ReplaceEInvoice(req request.ReplaceEInvoiceRequest, ctx context.Context) (*entity.EInvoice, error) {
+	invoice, invoices, err := e.getAdjustableEInvoice(req.EInvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replacement carries the amount after all adjustments unless a new one is given
+	var totalAmount float64 = getEInvoiceNetTotal(*invoice, *invoices)
+	if req.TotalAmount != nil {
+		totalAmount = math.Round(*req.TotalAmount)
+	}
+
+	var replacement entity.EInvoice = *invoice
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&replacement.BuyerName, req.BuyerName},
+		{&replacement.BuyerCompanyName, req.BuyerCompanyName},
+		{&replacement.BuyerTaxCode, req.BuyerTaxCode},
+		{&replacement.BuyerAddress, req.BuyerAddress},
+		{&replacement.BuyerEmail, req.BuyerEmail},
+	} {
+		if value := strings.TrimSpace(field.value); value != "" {
+			*field.target = value
+		}
+	}
+
+	if err := validateEInvoiceBuyer(replacement.BuyerTaxCode, replacement.BuyerCompanyName); err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	replacement.EInvoiceId = 0
+	replacement.InvoiceType = einvoice.REPLACEMENT
+	replacement.TemplateCode = einvoice_render.GetTemplateCode()
+	replacement.Series = einvoice_render.GetSeries(curTime)
+	replacement.Status = domain_status.EINVOICE_ISSUED
+	replacement.AmountBeforeTax, replacement.VatAmount = calculateEInvoiceAmounts(totalAmount, invoice.VatRate)
+	replacement.TotalAmount = totalAmount
+	replacement.RelatedEInvoiceId = &invoice.EInvoiceId
+	replacement.Reason = req.Reason
+	replacement.IssuedBy = req.ActorId
+	replacement.IssuedAt = curTime
+
+	return e.eInvoiceRepo.CreateEInvoice(replacement, domain_status.EINVOICE_REPLACED, ctx)
+}
+
+// ExportEInvoice implements businesslogic.IEInvoiceService.
+func (e *eInvoiceService) ExportEInvoice(req request.ExportEInvoiceRequest, ctx context.Context) (response.FileResponse, error) {
+	invoice, err := e.GetEInvoice(req.EInvoiceId, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	var related *entity.EInvoice
+	if invoice.RelatedEInvoiceId != nil {
+		if related, err = e.GetEInvoice(*invoice.RelatedEInvoiceId, ctx); err != nil {
+			return response.FileResponse{}, err
+		}
+	}
+
+	var seller einvoice_render.Seller = einvoice_render.GetSeller()
+	var content []byte
+	var contentType string
+	switch req.Format {
+	case file_support.XML_FORMAT:
+		content, err = einvoice_render.GenerateXml(*invoice, related, seller)
+		contentType = file_support.XML_CONTENT_TYPE
+	case file_support.HTML_FORMAT:
+		content, err = einvoice_render.GenerateHtml(*invoice, related, seller)
+		contentType = file_support.HTML_CONTENT_TYPE
+	case file_support.PDF_FORMAT:
+		content, err = einvoice_render.GeneratePdf(*invoice, related, seller)
+		contentType = file_support.PDF_CONTENT_TYPE
+	default:
+		return response.FileResponse{}, errors.New(noti.UNSUPPORTED_FILE_FORMAT_WARN_MSG)
+	}
+
+	if err != nil {
+		e.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, req.Format) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return response.FileResponse{
+		FileName:    fmt.Sprintf("einvoice_%s_%s_%08d.%s", invoice.TemplateCode, invoice.Series, invoice.InvoiceNumber, req.Format),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// Get an invoice which can still be adjusted or replaced together with all invoices of its payment
+func (e *eInvoiceService) getAdjustableEInvoice(id int, ctx context.Context) (*entity.EInvoice, *[]entity.EInvoice, error) {
+	invoice, err := e.GetEInvoice(id, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if invoice.InvoiceType == einvoice.ADJUSTMENT || invoice.Status == domain_status.EINVOICE_REPLACED {
+		return nil, nil, errors.New(noti.EINVOICE_NOT_ADJUSTABLE_WARN_MSG)
+	}
+
+	invoices, err := e.eInvoiceRepo.GetEInvoicesByPaymentId(invoice.PaymentId, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return invoice, invoices, nil
+}
+
+func validateEInvoiceBuyer(taxCode, companyName string) error {
+	taxCode = strings.TrimSpace(taxCode)
+	if taxCode == "" {
+		return nil
+	}
+
+	if !utils.IsTaxCodeValid(taxCode) {
+		return errors.New(noti.INVALID_TAX_CODE_WARN_MSG)
+	}
+
+	if strings.TrimSpace(companyName) == "" {
+		return errors.New(noti.BUYER_COMPANY_REQUIRED_WARN_MSG)
+	}
+
+	return nil
+}
+
+// Split a total including VAT into the amount before tax and the VAT, rounded to whole đồng
+func calculateEInvoiceAmounts(totalAmount, vatRate float64) (float64, float64) {
+	totalAmount = math.Round(totalAmount)
+
+	var amountBeforeTax float64 = math.Round(totalAmount / (1 + vatRate/100))
+	return amountBeforeTax, totalAmount - amountBeforeTax
+}
+
+// Get total of the invoice after the adjustment invoices issued for it
+func getEInvoiceNetTotal(invoice entity.EInvoice, invoices []entity.EInvoice) float64 {
+	var res float64 = invoice.TotalAmount
+	for _, x := range invoices {
+		if x.InvoiceType == einvoice.ADJUSTMENT && x.RelatedEInvoiceId != nil && *x.RelatedEInvoiceId == invoice.EInvoiceId {
+			res += x.TotalAmount
+		}
+	}
+
+	return res
+}
+
+func createEInvoiceAdjustment(eInvoiceRepo repo.IEInvoiceRepo, invoice entity.EInvoice, invoices []entity.EInvoice, amount float64, actorId int, reason string, ctx context.Context) (*entity.EInvoice, error) {
+	amount = math.Round(amount)
+	if amount == 0 || getEInvoiceNetTotal(invoice, invoices)+amount < 0 {
+		return nil, errors.New(noti.INVALID_EINVOICE_ADJUSTMENT_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	var adjustment entity.EInvoice = invoice
+	adjustment.EInvoiceId = 0
+	adjustment.InvoiceType = einvoice.ADJUSTMENT
+	adjustment.TemplateCode = einvoice_render.GetTemplateCode()
+	adjustment.Series = einvoice_render.GetSeries(curTime)
+	adjustment.Status = domain_status.EINVOICE_ISSUED
+	adjustment.AmountBeforeTax, adjustment.VatAmount = calculateEInvoiceAmounts(amount, invoice.VatRate)
+	adjustment.TotalAmount = amount
+	adjustment.RelatedEInvoiceId = &invoice.EInvoiceId
+	adjustment.Reason = reason
+	adjustment.IssuedBy = actorId
+	adjustment.IssuedAt = curTime
+
+	return eInvoiceRepo.CreateEInvoice(adjustment, domain_status.EINVOICE_ADJUSTED, ctx)
+}
+
+// Issue an adjustment invoice bringing the active invoice of a refunded payment down to zero, nothing is done when
+// the payment has no invoice
+func adjustEInvoiceOnRefund(eInvoiceRepo repo.IEInvoiceRepo, paymentId, actorId int, reason string, ctx context.Context) error {
+	invoices, err := eInvoiceRepo.GetEInvoicesByPaymentId(paymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, invoice := range *invoices {
+		if invoice.InvoiceType == einvoice.ADJUSTMENT || invoice.Status == domain_status.EINVOICE_REPLACED {
+			continue
+		}
+
+		if netTotal := getEInvoiceNetTotal(invoice, *invoices); netTotal > 0 {
+			if _, err := createEInvoiceAdjustment(eInvoiceRepo, invoice, *invoices, -netTotal, actorId, "Refund - "+reason, ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"strings"
 	"time"
 	filter_property "tourmate/payment-service/constant/filter_property"
 	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
 	"tourmate/payment-service/infrastructure/grpc/tour"
 	tour_pb "tourmate/payment-service/infrastructure/grpc/tour/pb"
 	"tourmate/payment-service/infrastructure/grpc/user"
@@ -62,13 +64,15 @@ func (f *feedbackService) GetTourGuideFeedbacks(req request.GetTourGuideFeedback
 		req.TourGuideId = 1
 	}
 
+	req.PageSize = utils.ParsePageSize(req.PageSize)
+
 	f.logger.Printf("GetTourGuideFeedbacks: Starting with TourGuideId=%d, PageIndex=%d, PageSize=%d", req.TourGuideId, req.PageIndex, req.PageSize)
 
 	feedbacks, pages, totalRecords, err := f.feedbackRepo.GetFeedbacks(request.GetFeedbacksRequest{
 		Request: request.SearchPaginationRequest{
 			Page:       req.PageIndex,
-			FilterProp: assignFilterProperty(""),
-			Order:      utils.AssignOrder(""),
+			FilterProp: filter_property.FEEDBACK_SORT_COLUMNS[filter_property.DATE_FILTER],
+			Order:      order.ASCENDING_ORDER,
 		},
 		PageSize:    req.PageSize,
 		TourGuideId: &req.TourGuideId,
@@ -187,10 +191,10 @@ func (f *feedbackService) GetFeedbacks(req request.GetFeedbacksRequest, ctx cont
 		req.Request.Page = 1
 	}
 
-	req.Request.FilterProp = assignFilterProperty(req.Request.FilterProp)
-	req.Request.Order = utils.AssignOrder(req.Request.Order)
-
-	req.PageSize = req.Request.Page
+	req.Request.FilterProp, req.Request.Order = utils.ParseSortSpec(req.Request.FilterProp, req.Request.Order,
+		filter_property.FEEDBACK_SORT_COLUMNS, "createdDate", order.ASCENDING_ORDER)
+	req.Request.Keyword = strings.TrimSpace(req.Request.Keyword)
+	req.PageSize = utils.ParsePageSize(req.PageSize)
 
 	data, pages, totalRecords, err := f.feedbackRepo.GetFeedbacks(req, ctx)
 
@@ -198,7 +202,7 @@ func (f *feedbackService) GetFeedbacks(req request.GetFeedbacksRequest, ctx cont
 		Data:        data,
 		TotalCount:  totalRecords,
 		Page:        req.Request.Page,
-		PerPage:     10,
+		PerPage:     req.PageSize,
 		TotalPages:  pages,
 		HasNext:     req.Request.Page < pages,
 		HasPrevious: req.Request.Page > 1,
@@ -240,24 +244,3 @@ func (f *feedbackService) UpdateFeedback(req request.UpdateFeedbackRequest, ctx
 
 	return feedback, f.feedbackRepo.UpdateFeedback(*feedback, ctx)
 }
-
-func assignFilterProperty(filterProp string) string {
-	var res string
-
-	switch filterProp {
-	case filter_property.DATE_FILTER:
-		res = "createdDate"
-	case filter_property.ACTION_DATE_FILTER:
-		res = "date"
-	case filter_property.PRICE_FILTER:
-		res = "price"
-	case filter_property.RATE_FILTER:
-		res = "rate"
-	case filter_property.AMOUNT_FILTER:
-		res = "amount"
-	default:
-		res = "createdDate"
-	}
-
-	return res
-}
@@ -0,0 +1,185 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type fiscalPeriodService struct {
+	logger           *log.Logger
+	fiscalPeriodRepo repo.IFiscalPeriodRepo
+}
+
+func InitializeFiscalPeriodService(db *sql.DB, logger *log.Logger) business_logic.IFiscalPeriodService {
+	return &fiscalPeriodService{
+		logger:           logger,
+		fiscalPeriodRepo: repository.InitializeFiscalPeriodRepo(db, logger),
+	}
+}
+
+func GenerateFiscalPeriodService() (business_logic.IFiscalPeriodService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializeFiscalPeriodService(cnn, logger), nil
+}
+
+// GetFiscalPeriods implements businesslogic.IFiscalPeriodService.
+func (f *fiscalPeriodService) GetFiscalPeriods(req request.GetFiscalPeriodsRequest, ctx context.Context) ([]entity.FiscalPeriod, error) {
+	periods, err := f.fiscalPeriodRepo.GetFiscalPeriodsByYear(req.Year, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var periodByMonth map[int]entity.FiscalPeriod = make(map[int]entity.FiscalPeriod)
+	for _, period := range *periods {
+		periodByMonth[period.Month] = period
+	}
+
+	var res []entity.FiscalPeriod
+	for month := 1; month <= 12; month++ {
+		period, isExist := periodByMonth[month]
+		if !isExist {
+			period = entity.FiscalPeriod{
+				Year:   req.Year,
+				Month:  month,
+				Status: domain_status.FISCAL_PERIOD_OPEN,
+			}
+		}
+
+		res = append(res, period)
+	}
+
+	return res, nil
+}
+
+// GetFiscalPeriodAudits implements businesslogic.IFiscalPeriodService.
+func (f *fiscalPeriodService) GetFiscalPeriodAudits(id int, ctx context.Context) (*[]entity.FiscalPeriodAudit, error) {
+	period, err := f.fiscalPeriodRepo.GetFiscalPeriodById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if period == nil {
+		return nil, errors.New(noti.GENERIC_ERROR_WARN_MSG)
+	}
+
+	return f.fiscalPeriodRepo.GetFiscalPeriodAudits(id, ctx)
+}
+
+// CloseFiscalPeriod implements businesslogic.IFiscalPeriodService.
+func (f *fiscalPeriodService) CloseFiscalPeriod(req request.FiscalPeriodActionRequest, ctx context.Context) error {
+	var curTime time.Time = time.Now()
+	var periodEnd time.Time = time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, curTime.Location()).AddDate(0, 1, 0)
+	if curTime.Before(periodEnd) {
+		return errors.New(noti.FISCAL_PERIOD_NOT_ENDED_WARN_MSG)
+	}
+
+	period, err := f.getFiscalPeriod(req.Year, req.Month, ctx)
+	if err != nil {
+		return err
+	}
+
+	if period.Status == domain_status.FISCAL_PERIOD_CLOSED {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	period.Status = domain_status.FISCAL_PERIOD_CLOSED
+	period.ClosedBy = &req.ActorId
+	period.ClosedAt = &curTime
+	period.UpdatedAt = curTime
+
+	_, err = f.fiscalPeriodRepo.SaveFiscalPeriod(*period, entity.FiscalPeriodAudit{
+		FromStatus: domain_status.FISCAL_PERIOD_OPEN,
+		ToStatus:   domain_status.FISCAL_PERIOD_CLOSED,
+		Reason:     strings.TrimSpace(req.Reason),
+		ActorId:    req.ActorId,
+		CreatedAt:  curTime,
+	}, ctx)
+
+	return err
+}
+
+// ReopenFiscalPeriod implements businesslogic.IFiscalPeriodService.
+func (f *fiscalPeriodService) ReopenFiscalPeriod(req request.FiscalPeriodActionRequest, ctx context.Context) error {
+	if strings.TrimSpace(req.Reason) == "" {
+		return errors.New(noti.REOPEN_REASON_REQUIRED_WARN_MSG)
+	}
+
+	period, err := f.getFiscalPeriod(req.Year, req.Month, ctx)
+	if err != nil {
+		return err
+	}
+
+	if period.Status != domain_status.FISCAL_PERIOD_CLOSED {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	period.Status = domain_status.FISCAL_PERIOD_OPEN
+	period.ClosedBy = nil
+	period.ClosedAt = nil
+	period.UpdatedAt = curTime
+
+	_, err = f.fiscalPeriodRepo.SaveFiscalPeriod(*period, entity.FiscalPeriodAudit{
+		FromStatus: domain_status.FISCAL_PERIOD_CLOSED,
+		ToStatus:   domain_status.FISCAL_PERIOD_OPEN,
+		Reason:     strings.TrimSpace(req.Reason),
+		ActorId:    req.ActorId,
+		CreatedAt:  curTime,
+	}, ctx)
+
+	return err
+}
+
+// Get the stored period or a new open one which has not been saved yet
+func (f *fiscalPeriodService) getFiscalPeriod(year, month int, ctx context.Context) (*entity.FiscalPeriod, error) {
+	period, err := f.fiscalPeriodRepo.GetFiscalPeriod(year, month, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if period == nil {
+		period = &entity.FiscalPeriod{
+			Year:   year,
+			Month:  month,
+			Status: domain_status.FISCAL_PERIOD_OPEN,
+		}
+	}
+
+	return period, nil
+}
+
+// Reject changes to records dated in a closed period
+func ensureFiscalPeriodOpen(fiscalPeriodRepo repo.IFiscalPeriodRepo, date time.Time, ctx context.Context) error {
+	period, err := fiscalPeriodRepo.GetFiscalPeriod(date.Year(), int(date.Month()), ctx)
+	if err != nil {
+		return err
+	}
+
+	if period != nil && period.Status == domain_status.FISCAL_PERIOD_CLOSED {
+		return errors.New(fmt.Sprintf(noti.CLOSED_FISCAL_PERIOD_WARN_MSG, period.Month, period.Year))
+	}
+
+	return nil
+}
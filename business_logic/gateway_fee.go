@@ -0,0 +1,375 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	gateway_fee "tourmate/payment-service/constant/gateway_fee"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/infrastructure/settlement"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type gatewayFeeService struct {
+	logger         *log.Logger
+	gatewayFeeRepo repo.IGatewayFeeRepo
+	paymentRepo    repo.IPaymentRepo
+	ledgerRepo     repo.ILedgerRepo
+}
+
+func InitializeGatewayFeeService(db *sql.DB, logger *log.Logger) business_logic.IGatewayFeeService {
+	return &gatewayFeeService{
+		logger:         logger,
+		gatewayFeeRepo: repository.InitializeGatewayFeeRepo(db, logger),
+		paymentRepo:    repository.InitializePaymentRepo(db, logger),
+		ledgerRepo:     repository.InitializeLedgerRepo(db, logger),
+	}
+}
+
+func GenerateGatewayFeeService() (business_logic.IGatewayFeeService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializeGatewayFeeService(cnn, logger), nil
+}
+
+// GetPaymentMethodFees implements businesslogic.IGatewayFeeService.
+func (g *gatewayFeeService) GetPaymentMethodFees(ctx context.Context) (*[]entity.PaymentMethodFee, error) {
+	return g.gatewayFeeRepo.GetPaymentMethodFees(ctx)
+}
+
+// UpsertPaymentMethodFee implements businesslogic.IGatewayFeeService.
+func (g *gatewayFeeService) UpsertPaymentMethodFee(req request.UpsertPaymentMethodFeeRequest, ctx context.Context) (*entity.PaymentMethodFee, error) {
+	req.PaymentMethod = strings.ToUpper(req.PaymentMethod)
+	if !isGatewayPaymentMethod(req.PaymentMethod) {
+		return nil, errors.New(noti.UNSUPPORTED_GATEWAY_FEE_METHOD_WARN_MSG)
+	}
+
+	var res entity.PaymentMethodFee = entity.PaymentMethodFee{
+		PaymentMethod:  req.PaymentMethod,
+		FeeRate:        req.FeeRate,
+		FixedFee:       utils.RoundMoney(req.FixedFee),
+		SurchargeRate:  req.SurchargeRate,
+		SurchargeFixed: utils.RoundMoney(req.SurchargeFixed),
+		UpdatedBy:      req.ActorId,
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := g.gatewayFeeRepo.UpsertPaymentMethodFee(res, ctx); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetPaymentFees implements businesslogic.IGatewayFeeService.
+func (g *gatewayFeeService) GetPaymentFees(req request.GetPaymentFeesRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	from, to, err := utils.GenerateDatePeriod(req.From, req.To)
+	if err != nil {
+		return response.PaginationDataResponse{}, err
+	}
+
+	req.From, req.To = from, to
+	req.PaymentMethod = strings.ToUpper(req.PaymentMethod)
+	req.Source = strings.ToUpper(req.Source)
+	req.PageSize = entity.PaymentFee{}.GetPaymentFeeLimitRecords()
+
+	data, pages, totalRecords, err := g.gatewayFeeRepo.GetPaymentFees(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetGatewayFeeReport implements businesslogic.IGatewayFeeService.
+func (g *gatewayFeeService) GetGatewayFeeReport(req request.GetGatewayFeeReportRequest, ctx context.Context) (*response.GatewayFeeReportResponse, error) {
+	from, to, err := utils.GenerateDatePeriod(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := g.gatewayFeeRepo.GetPaymentFeeSummaries(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.GatewayFeeReportResponse = response.GatewayFeeReportResponse{
+		From:    req.From,
+		To:      req.To,
+		Methods: []entity.PaymentFeeSummary{},
+	}
+
+	for _, summary := range *summaries {
+		res.Summary.GrossAmount += summary.GrossAmount
+		res.Summary.Surcharge += summary.Surcharge
+		res.Summary.FeeAmount += summary.FeeAmount
+		res.Summary.NetAmount += summary.NetAmount
+		res.Summary.PaymentCount += summary.PaymentCount
+		res.Methods = append(res.Methods, summary)
+	}
+
+	res.Summary.GrossAmount = utils.RoundMoney(res.Summary.GrossAmount)
+	res.Summary.Surcharge = utils.RoundMoney(res.Summary.Surcharge)
+	res.Summary.FeeAmount = utils.RoundMoney(res.Summary.FeeAmount)
+	res.Summary.NetAmount = utils.RoundMoney(res.Summary.NetAmount)
+
+	return &res, nil
+}
+
+// GetSurcharge implements businesslogic.IGatewayFeeService.
+func (g *gatewayFeeService) GetSurcharge(req request.GetSurchargeRequest, ctx context.Context) (*response.SurchargeResponse, error) {
+	req.PaymentMethod = strings.ToUpper(req.PaymentMethod)
+
+	surcharge, err := getPaymentSurcharge(g.gatewayFeeRepo, req.PaymentMethod, req.Amount, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.SurchargeResponse{
+		PaymentMethod: req.PaymentMethod,
+		Amount:        req.Amount,
+		Surcharge:     surcharge,
+		TotalAmount:   utils.RoundMoney(req.Amount + surcharge),
+	}, nil
+}
+
+// ImportSettlement implements businesslogic.IGatewayFeeService.
+func (g *gatewayFeeService) ImportSettlement(req request.ImportSettlementRequest, ctx context.Context) (*response.SettlementImportResponse, error) {
+	req.PaymentMethod = strings.ToUpper(req.PaymentMethod)
+	if !isGatewayPaymentMethod(req.PaymentMethod) {
+		return nil, errors.New(noti.UNSUPPORTED_GATEWAY_FEE_METHOD_WARN_MSG)
+	}
+
+	rows, err := utils.ReadTabularFile(req.FileName, req.Content)
+	if err != nil {
+		g.logger.Println(fmt.Sprintf(noti.FILE_READ_ERR_MSG, req.FileName) + err.Error())
+		return nil, errors.New(noti.INVALID_FILE_CONTENT_WARN_MSG)
+	}
+
+	lines, err := settlement.ParseSettlementRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.SettlementImportResponse = response.SettlementImportResponse{
+		TotalLines: len(lines),
+		Unmatched:  []response.SettlementLine{},
+	}
+
+	for _, line := range lines {
+		isSettled, isDuplicate, difference, err := g.settlePaymentFee(line, req.PaymentMethod, req.ActorId, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case isSettled:
+			res.SettledLines++
+			res.FeeDifference += difference
+		case isDuplicate:
+			res.DuplicateLines++
+		default:
+			res.UnmatchedLines++
+			res.Unmatched = append(res.Unmatched, line)
+		}
+	}
+
+	res.FeeDifference = utils.RoundMoney(res.FeeDifference)
+	return &res, nil
+}
+
+// Settle the fee of the paid payment of the line's invoice, the difference is the settled fee minus the estimate it replaces
+func (g *gatewayFeeService) settlePaymentFee(line response.SettlementLine, paymentMethod string, actorId int, ctx context.Context) (bool, bool, float64, error) {
+	if line.InvoiceId <= 0 {
+		return false, false, 0, nil
+	}
+
+	payment, err := g.paymentRepo.GetPaidPaymentByInvoiceId(line.InvoiceId, ctx)
+	if err != nil {
+		return false, false, 0, err
+	}
+
+	if payment == nil || payment.PaymentMethod != paymentMethod {
+		return false, false, 0, nil
+	}
+
+	fee, err := g.gatewayFeeRepo.GetPaymentFeeByPaymentId(payment.PaymentId, ctx)
+	if err != nil {
+		return false, false, 0, err
+	}
+
+	var curTime time.Time = time.Now()
+	var feeAmount float64 = utils.RoundMoney(line.FeeAmount)
+
+	// The payment was made before its method had a fee, anything collected above the price was surcharged
+	if fee == nil {
+		if line.Amount < payment.Price {
+			return false, false, 0, nil
+		}
+
+		var newFee entity.PaymentFee = entity.PaymentFee{
+			PaymentId:           payment.PaymentId,
+			PaymentMethod:       payment.PaymentMethod,
+			GrossAmount:         utils.RoundMoney(line.Amount),
+			Surcharge:           utils.RoundMoney(line.Amount - payment.Price),
+			FeeAmount:           feeAmount,
+			NetAmount:           utils.RoundMoney(line.Amount - feeAmount),
+			Source:              gateway_fee.SETTLEMENT_SOURCE,
+			SettlementReference: line.Reference,
+			CreatedAt:           curTime,
+			UpdatedAt:           curTime,
+		}
+
+		newFee.PaymentFeeId, err = g.gatewayFeeRepo.CreatePaymentFee(newFee, ctx)
+		if err != nil {
+			return false, false, 0, err
+		}
+
+		return true, false, feeAmount, postGatewayFeeLedgerEntry(g.ledgerRepo, newFee, ctx)
+	}
+
+	if fee.Source == gateway_fee.SETTLEMENT_SOURCE {
+		return false, true, 0, nil
+	}
+
+	// The gateway must have collected what the customer was charged
+	if math.Abs(line.Amount-fee.GrossAmount) >= 1 {
+		return false, false, 0, nil
+	}
+
+	var difference float64 = utils.RoundMoney(feeAmount - fee.FeeAmount)
+	fee.FeeAmount = feeAmount
+	fee.NetAmount = utils.RoundMoney(fee.GrossAmount - feeAmount)
+	fee.SettlementReference = line.Reference
+	fee.UpdatedAt = curTime
+
+	if err := g.gatewayFeeRepo.SettlePaymentFee(*fee, ctx); err != nil {
+		return false, false, 0, err
+	}
+
+	return true, false, difference, postGatewaySettlementLedgerEntry(g.ledgerRepo, *fee, difference, actorId, ctx)
+}
+
+// Record the fee of a payment made through a gateway from the fee configured for its method and post it to the ledger,
+// nil when the method has no fee configured. The revenue split is not changed when the fee is settled later
+func recordPaymentFee(gatewayFeeRepo repo.IGatewayFeeRepo, ledgerRepo repo.ILedgerRepo, payment entity.Payment, ctx context.Context) (*entity.PaymentFee, error) {
+	if !isGatewayPaymentMethod(payment.PaymentMethod) {
+		return nil, nil
+	}
+
+	// A fee recorded by an earlier attempt is reused so that a failed confirmation can be retried
+	existedFee, err := gatewayFeeRepo.GetPaymentFeeByPaymentId(payment.PaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if existedFee != nil {
+		return existedFee, postGatewayFeeLedgerEntry(ledgerRepo, *existedFee, ctx)
+	}
+
+	methodFee, err := gatewayFeeRepo.GetPaymentMethodFee(payment.PaymentMethod, ctx)
+	if err != nil || methodFee == nil {
+		return nil, err
+	}
+
+	var surcharge float64 = calculateSurcharge(*methodFee, payment.Price)
+	var grossAmount float64 = utils.RoundMoney(payment.Price + surcharge)
+	var feeAmount float64 = utils.RoundMoney(grossAmount*methodFee.FeeRate/100 + methodFee.FixedFee)
+	var curTime time.Time = time.Now()
+
+	var res entity.PaymentFee = entity.PaymentFee{
+		PaymentId:     payment.PaymentId,
+		PaymentMethod: payment.PaymentMethod,
+		GrossAmount:   grossAmount,
+		Surcharge:     surcharge,
+		FeeAmount:     feeAmount,
+		NetAmount:     utils.RoundMoney(grossAmount - feeAmount),
+		Source:        gateway_fee.CONFIGURED_SOURCE,
+		CreatedAt:     curTime,
+		UpdatedAt:     curTime,
+	}
+
+	res.PaymentFeeId, err = gatewayFeeRepo.CreatePaymentFee(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postGatewayFeeLedgerEntry(ledgerRepo, res, ctx); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Surcharge the customer pays on top of the price, zero when the method has no fee configured
+func getPaymentSurcharge(gatewayFeeRepo repo.IGatewayFeeRepo, paymentMethod string, price float64, ctx context.Context) (float64, error) {
+	if !isGatewayPaymentMethod(paymentMethod) {
+		return 0, nil
+	}
+
+	methodFee, err := gatewayFeeRepo.GetPaymentMethodFee(paymentMethod, ctx)
+	if err != nil || methodFee == nil {
+		return 0, err
+	}
+
+	return calculateSurcharge(*methodFee, price), nil
+}
+
+func calculateSurcharge(methodFee entity.PaymentMethodFee, price float64) float64 {
+	if methodFee.SurchargeRate <= 0 && methodFee.SurchargeFixed <= 0 {
+		return 0
+	}
+
+	// PayOS only takes whole VND amounts
+	return math.Round(price*methodFee.SurchargeRate/100 + methodFee.SurchargeFixed)
+}
+
+// Balances held by the platform and cash are not collected through a gateway
+func isGatewayPaymentMethod(paymentMethod string) bool {
+	switch paymentMethod {
+	case payment_method.WALLET, payment_method.GIFT_CARD, payment_method.LOYALTY_POINT, payment_method.CASH:
+		return false
+	default:
+		return paymentMethod != ""
+	}
+}
+
+func getGatewayFeeGuideShare() float64 {
+	if share, err := strconv.ParseFloat(os.Getenv(payment_env.GATEWAY_FEE_GUIDE_SHARE), 64); err == nil && share >= 0 && share <= 100 {
+		return share
+	}
+
+	return gateway_fee.DEFAULT_GUIDE_FEE_SHARE
+}
@@ -0,0 +1,488 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"strconv"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	gift_card "tourmate/payment-service/constant/gift_card"
+	mail_const "tourmate/payment-service/constant/mail_const"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/constant/wallet"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+
+	"github.com/payOSHQ/payos-lib-golang"
+)
+
+type giftCardService struct {
+	logger       *log.Logger
+	recorder     *paymentRecorder
+	userService  business_logic.IUserService
+	giftCardRepo repo.IGiftCardRepo
+	walletRepo   repo.IWalletRepo
+	paymentRepo  repo.IPaymentRepo
+	ledgerRepo   repo.ILedgerRepo
+}
+
+func InitializeGiftCardService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IGiftCardService {
+	return &giftCardService{
+		logger:       logger,
+		recorder:     initializePaymentRecorder(db, userService, logger),
+		userService:  userService,
+		giftCardRepo: repository.InitializeGiftCardRepo(db, logger),
+		walletRepo:   repository.InitializeWalletRepo(db, logger),
+		paymentRepo:  repository.InitializePaymentRepo(db, logger),
+		ledgerRepo:   repository.InitializeLedgerRepo(db, logger),
+	}
+}
+
+func GenerateGiftCardService() (business_logic.IGiftCardService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeGiftCardService(cnn, userService, logger), nil
+}
+
+// GetGiftCards implements businesslogic.IGiftCardService.
+func (g *giftCardService) GetGiftCards(req request.GetGiftCardsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.GiftCard{}.GetGiftCardLimitRecords()
+
+	data, pages, totalRecords, err := g.giftCardRepo.GetGiftCards(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetGiftCardByCode implements businesslogic.IGiftCardService.
+func (g *giftCardService) GetGiftCardByCode(code string, ctx context.Context) (*entity.GiftCard, error) {
+	res, err := g.giftCardRepo.GetGiftCardByCode(utils.NormalizeGiftCardCode(code), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.GiftCard{}.GetGiftCardTable()))
+	}
+
+	return res, nil
+}
+
+// PurchaseGiftCard implements businesslogic.IGiftCardService.
+func (g *giftCardService) PurchaseGiftCard(req request.PurchaseGiftCardRequest, ctx context.Context) (*entity.GiftCard, error) {
+	var orderCode int64 = int64(utils.GenerateNumber())
+	var amount int = int(req.Amount)
+
+	data, err := createPayosPaymentLink(orderCode, amount, fmt.Sprintf("Gift card %d", orderCode), g.logger)
+	if err != nil {
+		g.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	var res entity.GiftCard = entity.GiftCard{
+		PurchaserId:    req.PurchaserId,
+		RecipientName:  req.RecipientName,
+		RecipientEmail: req.RecipientEmail,
+		Message:        req.Message,
+		InitialAmount:  float64(amount),
+		OrderCode:      orderCode,
+		CheckoutUrl:    data.CheckoutUrl,
+		Status:         domain_status.GIFT_CARD_PENDING,
+		CreatedAt:      curTime,
+		UpdatedAt:      curTime,
+	}
+
+	id, err := g.giftCardRepo.CreateGiftCard(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.GiftCardId = id
+	return &res, nil
+}
+
+// ConfirmGiftCardPurchase implements businesslogic.IGiftCardService.
+func (g *giftCardService) ConfirmGiftCardPurchase(id int, ctx context.Context) (*entity.GiftCard, error) {
+	card, err := g.giftCardRepo.GetGiftCardById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if card == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.GiftCard{}.GetGiftCardTable()))
+	}
+
+	// Confirming again is harmless
+	if card.Status != domain_status.GIFT_CARD_PENDING {
+		return card, nil
+	}
+
+	link, err := payos.GetPaymentLinkInformation(strconv.FormatInt(card.OrderCode, 10))
+	if err != nil {
+		g.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	switch link.Status {
+	case wallet.PAYOS_PAID_STATUS:
+	case wallet.PAYOS_CANCELLED_STATUS, wallet.PAYOS_EXPIRED_STATUS:
+		card.Status = domain_status.GIFT_CARD_CANCELLED
+		card.UpdatedAt = curTime
+		return card, g.giftCardRepo.UpdateGiftCard(*card, domain_status.GIFT_CARD_PENDING, ctx)
+	default:
+		return nil, errors.New(noti.GIFT_CARD_NOT_PAID_WARN_MSG)
+	}
+
+	code, err := g.generateUniqueCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt time.Time = curTime.AddDate(0, getGiftCardValidityMonths(), 0)
+	card.Code = &code
+	card.Status = domain_status.GIFT_CARD_ACTIVE
+	card.ExpiresAt = &expiresAt
+	card.PaidAt = &curTime
+	card.UpdatedAt = curTime
+
+	// The card is taken first so that it is issued once
+	if err := g.giftCardRepo.UpdateGiftCard(*card, domain_status.GIFT_CARD_PENDING, ctx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := changeGiftCardBalance(g.giftCardRepo, g.ledgerRepo, entity.GiftCardTransaction{
+		GiftCardId:      card.GiftCardId,
+		TransactionType: gift_card.PURCHASE,
+		Amount:          card.InitialAmount,
+		CreatedBy:       card.PurchaserId,
+	}, ctx)
+
+	if err != nil {
+		card.Code, card.ExpiresAt, card.PaidAt = nil, nil, nil
+		card.Status = domain_status.GIFT_CARD_PENDING
+		g.giftCardRepo.UpdateGiftCard(*card, domain_status.GIFT_CARD_ACTIVE, ctx)
+		return nil, err
+	}
+
+	card.Balance = transaction.BalanceAfter
+	g.sendGiftCardMail(*card, ctx)
+
+	return card, nil
+}
+
+// RedeemGiftCard implements businesslogic.IGiftCardService.
+func (g *giftCardService) RedeemGiftCard(req request.RedeemGiftCardRequest, ctx context.Context) (*response.GiftCardRedemptionResponse, error) {
+	card, err := g.getUsableGiftCard(req.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount float64 = card.Balance
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	if amount > card.Balance {
+		return nil, errors.New(noti.INSUFFICIENT_GIFT_CARD_BALANCE_WARN_MSG)
+	}
+
+	var res response.GiftCardRedemptionResponse
+	res.GiftCardTransaction, err = changeGiftCardBalance(g.giftCardRepo, g.ledgerRepo, entity.GiftCardTransaction{
+		GiftCardId:      card.GiftCardId,
+		TransactionType: gift_card.WALLET_REDEMPTION,
+		Amount:          -amount,
+		CustomerId:      &req.CustomerId,
+		CreatedBy:       req.CustomerId,
+	}, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.WalletTransaction, err = changeWalletBalance(g.walletRepo, g.ledgerRepo, entity.WalletTransaction{
+		CustomerId:      req.CustomerId,
+		TransactionType: wallet.GIFT_CARD,
+		Amount:          amount,
+		ReferenceId:     &card.GiftCardId,
+		Description:     fmt.Sprintf("Gift card %s", *card.Code),
+		CreatedBy:       req.CustomerId,
+	}, ctx)
+
+	if err != nil {
+		// Give the money back to the card, the wallet was not credited
+		changeGiftCardBalance(g.giftCardRepo, g.ledgerRepo, entity.GiftCardTransaction{
+			GiftCardId:      card.GiftCardId,
+			TransactionType: gift_card.RESTORE,
+			Amount:          amount,
+			CustomerId:      &req.CustomerId,
+			CreatedBy:       systemActorId,
+		}, ctx)
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PayWithGiftCard implements businesslogic.IGiftCardService.
+func (g *giftCardService) PayWithGiftCard(req request.PayWithGiftCardRequest, ctx context.Context) (*response.GiftCardPaymentResponse, error) {
+	paidPayment, err := g.paymentRepo.GetPaidPaymentByInvoiceId(req.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if paidPayment != nil {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	card, err := g.getUsableGiftCard(req.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount float64 = req.Price
+	if card.Balance < amount {
+		amount = card.Balance
+	}
+
+	var res response.GiftCardPaymentResponse = response.GiftCardPaymentResponse{
+		RemainingAmount: utils.RoundMoney(req.Price - amount),
+	}
+
+	// The gateway link is created before touching the card, an unused link costs nothing
+	if res.RemainingAmount > 0 {
+		data, err := createPayosPaymentLink(int64(utils.GenerateNumber()), int(res.RemainingAmount), utils.GenerateInvoiceNote(req.InvoiceId), g.logger)
+		if err != nil {
+			g.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res.CheckoutUrl = data.CheckoutUrl
+	}
+
+	res.GiftCardTransaction, err = changeGiftCardBalance(g.giftCardRepo, g.ledgerRepo, entity.GiftCardTransaction{
+		GiftCardId:      card.GiftCardId,
+		TransactionType: gift_card.INVOICE_REDEMPTION,
+		Amount:          -amount,
+		CustomerId:      &req.CustomerId,
+		ReferenceId:     &req.InvoiceId,
+		CreatedBy:       req.CustomerId,
+	}, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Payment, err = g.recorder.createPaidPayment(request.CreatePaymentRequest{
+		CustomerId:    req.CustomerId,
+		TourGuideId:   req.TourGuideId,
+		InvoiceId:     req.InvoiceId,
+		ServiceId:     req.ServiceId,
+		Price:         amount,
+		PaymentMethod: payment_method.GIFT_CARD,
+	}, ctx)
+
+	if err != nil {
+		// Give the money back to the card, the payment was not recorded
+		changeGiftCardBalance(g.giftCardRepo, g.ledgerRepo, entity.GiftCardTransaction{
+			GiftCardId:      card.GiftCardId,
+			TransactionType: gift_card.RESTORE,
+			Amount:          amount,
+			CustomerId:      &req.CustomerId,
+			ReferenceId:     &req.InvoiceId,
+			CreatedBy:       systemActorId,
+		}, ctx)
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetGiftCardLiability implements businesslogic.IGiftCardService.
+func (g *giftCardService) GetGiftCardLiability(ctx context.Context) (*response.GiftCardLiabilityResponse, error) {
+	var curTime time.Time = time.Now()
+	var res response.GiftCardLiabilityResponse = response.GiftCardLiabilityResponse{
+		AsOf:           curTime,
+		ExpiringBefore: curTime.AddDate(0, 0, gift_card.EXPIRING_SOON_DAYS),
+	}
+
+	summaries, err := g.giftCardRepo.GetGiftCardStatusSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Statuses = *summaries
+	for _, summary := range res.Statuses {
+		if summary.Status == domain_status.GIFT_CARD_ACTIVE {
+			res.OutstandingBalance = summary.Balance
+			res.OutstandingCount = summary.CardCount
+		}
+	}
+
+	res.ExpiringBalance, res.ExpiringCount, err = g.giftCardRepo.GetExpiringGiftCardBalance(curTime, res.ExpiringBefore, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ExpireGiftCards implements businesslogic.IGiftCardService.
+func (g *giftCardService) ExpireGiftCards(ctx context.Context) (int, error) {
+	var curTime time.Time = time.Now()
+	ids, err := g.giftCardRepo.GetExpiredGiftCardIds(curTime, ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var res int
+	for _, id := range ids {
+		transaction, err := g.giftCardRepo.ExpireGiftCard(id, curTime, ctx)
+		if err != nil {
+			return res, err
+		}
+
+		// Used in the meantime
+		if transaction == nil {
+			continue
+		}
+
+		if err := postGiftCardLedgerEntry(g.ledgerRepo, *transaction, ctx); err != nil {
+			return res, err
+		}
+
+		res++
+	}
+
+	return res, nil
+}
+
+// Get a card which can be spent, the redemption itself checks the balance again
+func (g *giftCardService) getUsableGiftCard(code string, ctx context.Context) (*entity.GiftCard, error) {
+	card, err := g.GetGiftCardByCode(code, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if card.Status == domain_status.GIFT_CARD_EXPIRED || (card.ExpiresAt != nil && !card.ExpiresAt.After(time.Now())) {
+		return nil, errors.New(noti.GIFT_CARD_EXPIRED_WARN_MSG)
+	}
+
+	if card.Status != domain_status.GIFT_CARD_ACTIVE {
+		return nil, errors.New(noti.GIFT_CARD_NOT_ACTIVE_WARN_MSG)
+	}
+
+	return card, nil
+}
+
+// Codes are random, a clash with an issued card is only retried a few times
+func (g *giftCardService) generateUniqueCode(ctx context.Context) (string, error) {
+	for i := 0; i < gift_card.MAX_CODE_ATTEMPTS; i++ {
+		code, err := utils.GenerateGiftCardCode()
+		if err != nil {
+			g.logger.Println(fmt.Sprintf(noti.REPO_ERR_MSG, entity.GiftCard{}.GetGiftCardTable()) + "generateUniqueCode - " + err.Error())
+			return "", errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		existedCard, err := g.giftCardRepo.GetGiftCardByCode(code, ctx)
+		if err != nil {
+			return "", err
+		}
+
+		if existedCard == nil {
+			return code, nil
+		}
+	}
+
+	return "", errors.New(noti.INTERNALL_ERR_MSG)
+}
+
+func (g *giftCardService) sendGiftCardMail(card entity.GiftCard, ctx context.Context) {
+	var senderName string = "A friend"
+	if userInfo, _ := g.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
+		CustomerId: int32(card.PurchaserId),
+	}); userInfo != nil && userInfo.FullName != "" {
+		senderName = userInfo.FullName
+	}
+
+	// The template is not escaped, the texts typed by the purchaser are
+	utils.SendMail(request.SendMailRequest{
+		Body: request.MailBody{ // Mail body
+			Subject:  noti.GIFT_CARD_MAIL_SUBJECT,
+			Email:    card.RecipientEmail,
+			Username: html.EscapeString(card.RecipientName),
+			GiftCard: request.GiftCardMailBody{
+				Code:       *card.Code,
+				Amount:     utils.FormatMoney(card.InitialAmount) + "đ",
+				ExpiresAt:  card.ExpiresAt.Format("02/01/2006"),
+				SenderName: html.EscapeString(senderName),
+				Message:    html.EscapeString(card.Message),
+			},
+		},
+		TemplatePath: mail_const.GIFT_CARD_MAIL_TEMPLATE,
+		Logger:       g.logger, // Logger
+	})
+}
+
+func getGiftCardValidityMonths() int {
+	if months, err := strconv.Atoi(os.Getenv(payment_env.GIFT_CARD_VALIDITY_MONTHS)); err == nil && months > 0 {
+		return months
+	}
+
+	return gift_card.DEFAULT_VALIDITY_MONTHS
+}
+
+// Apply a gift card transaction and post its journal entry
+func changeGiftCardBalance(giftCardRepo repo.IGiftCardRepo, ledgerRepo repo.ILedgerRepo, transaction entity.GiftCardTransaction, ctx context.Context) (*entity.GiftCardTransaction, error) {
+	transaction.Amount = utils.RoundMoney(transaction.Amount)
+	transaction.CreatedAt = time.Now()
+
+	res, err := giftCardRepo.ChangeGiftCardBalance(transaction, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postGiftCardLedgerEntry(ledgerRepo, *res, ctx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
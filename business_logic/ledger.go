@@ -0,0 +1,544 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+	gift_card "tourmate/payment-service/constant/gift_card"
+	"tourmate/payment-service/constant/ledger"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/wallet"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type ledgerService struct {
+	logger           *log.Logger
+	ledgerRepo       repo.ILedgerRepo
+	fiscalPeriodRepo repo.IFiscalPeriodRepo
+}
+
+func InitializeLedgerService(db *sql.DB, logger *log.Logger) business_logic.ILedgerService {
+	return &ledgerService{
+		logger:           logger,
+		ledgerRepo:       repository.InitializeLedgerRepo(db, logger),
+		fiscalPeriodRepo: repository.InitializeFiscalPeriodRepo(db, logger),
+	}
+}
+
+func GenerateLedgerService() (business_logic.ILedgerService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializeLedgerService(cnn, logger), nil
+}
+
+// GetAccountBalances implements businesslogic.ILedgerService.
+func (l *ledgerService) GetAccountBalances(ctx context.Context) ([]response.LedgerBalanceResponse, error) {
+	var res []response.LedgerBalanceResponse
+	for _, account := range []string{
+		ledger.CUSTOMER_RECEIVABLE,
+		ledger.GUIDE_PAYABLE,
+		ledger.PLATFORM_COMMISSION,
+		ledger.GATEWAY_CLEARING,
+		ledger.REFUNDS,
+		ledger.TAX_PAYABLE,
+		ledger.CUSTOMER_WALLET,
+		ledger.GIFT_CARD_LIABILITY,
+		ledger.AGENCY_PAYABLE,
+		ledger.SUBSCRIPTION_REVENUE,
+		ledger.GATEWAY_FEE,
+	} {
+		balance, err := l.GetAccountBalance(request.GetLedgerAccountRequest{Account: account}, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *balance)
+	}
+
+	return res, nil
+}
+
+// GetAccountBalance implements businesslogic.ILedgerService.
+func (l *ledgerService) GetAccountBalance(req request.GetLedgerAccountRequest, ctx context.Context) (*response.LedgerBalanceResponse, error) {
+	req.Account = strings.ToUpper(req.Account)
+	if !utils.IsLedgerAccountValid(req.Account) {
+		return nil, errors.New(noti.UNSUPPORTED_LEDGER_ACCOUNT_WARN_MSG)
+	}
+
+	// The end date is inclusive as in the statement
+	_, to, err := utils.GenerateDatePeriod(nil, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	debit, credit, err := l.ledgerRepo.GetAccountTotals(req.Account, req.OwnerId, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.LedgerBalanceResponse{
+		Account:     req.Account,
+		OwnerId:     req.OwnerId,
+		TotalDebit:  utils.RoundMoney(debit),
+		TotalCredit: utils.RoundMoney(credit),
+		Balance:     utils.GetLedgerBalance(req.Account, debit, credit),
+	}, nil
+}
+
+// GetAccountStatement implements businesslogic.ILedgerService.
+func (l *ledgerService) GetAccountStatement(req request.GetLedgerAccountRequest, ctx context.Context) (*response.LedgerStatementResponse, error) {
+	req.Account = strings.ToUpper(req.Account)
+	if !utils.IsLedgerAccountValid(req.Account) {
+		return nil, errors.New(noti.UNSUPPORTED_LEDGER_ACCOUNT_WARN_MSG)
+	}
+
+	// Current month by default
+	var curTime time.Time = time.Now()
+	var from time.Time = time.Date(curTime.Year(), curTime.Month(), 1, 0, 0, 0, 0, curTime.Location())
+	var to time.Time = from.AddDate(0, 1, 0)
+	if req.From != nil {
+		from = *req.From
+	}
+
+	if req.To != nil {
+		// The end date is inclusive
+		to = req.To.AddDate(0, 0, 1)
+	}
+
+	if !from.Before(to) {
+		return nil, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
+	}
+
+	openingDebit, openingCredit, err := l.ledgerRepo.GetAccountTotals(req.Account, req.OwnerId, &from, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := l.ledgerRepo.GetAccountLines(req.Account, req.OwnerId, from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entryIds []int
+	var isEntryIncluded map[int]bool = make(map[int]bool)
+	for _, line := range *lines {
+		if !isEntryIncluded[line.LedgerEntryId] {
+			isEntryIncluded[line.LedgerEntryId] = true
+			entryIds = append(entryIds, line.LedgerEntryId)
+		}
+	}
+
+	entries, err := l.ledgerRepo.GetLedgerEntriesByIds(entryIds, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entryById map[int]entity.LedgerEntry = make(map[int]entity.LedgerEntry)
+	for _, entry := range *entries {
+		entryById[entry.LedgerEntryId] = entry
+	}
+
+	var openingBalance float64 = utils.GetLedgerBalance(req.Account, openingDebit, openingCredit)
+	var balance float64 = openingBalance
+	var res []response.LedgerStatementLineResponse
+	for _, line := range *lines {
+		balance = utils.RoundMoney(balance + utils.GetLedgerBalance(req.Account, line.Debit, line.Credit))
+		var entry entity.LedgerEntry = entryById[line.LedgerEntryId]
+
+		res = append(res, response.LedgerStatementLineResponse{
+			LedgerEntryId: line.LedgerEntryId,
+			EntryType:     entry.EntryType,
+			ReferenceId:   entry.ReferenceId,
+			Description:   entry.Description,
+			OwnerId:       line.OwnerId,
+			Debit:         line.Debit,
+			Credit:        line.Credit,
+			Balance:       balance,
+			CreatedAt:     line.CreatedAt,
+		})
+	}
+
+	return &response.LedgerStatementResponse{
+		Account:        req.Account,
+		OwnerId:        req.OwnerId,
+		From:           from,
+		To:             to.AddDate(0, 0, -1),
+		OpeningBalance: openingBalance,
+		ClosingBalance: balance,
+		Lines:          res,
+	}, nil
+}
+
+// CheckInvariants implements businesslogic.ILedgerService.
+func (l *ledgerService) CheckInvariants(ctx context.Context) (*response.LedgerInvariantResponse, error) {
+	debit, credit, err := l.ledgerRepo.GetLedgerTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unbalancedEntryIds, err := l.ledgerRepo.GetUnbalancedEntryIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := l.GetAccountBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var difference float64 = utils.RoundMoney(debit - credit)
+	if len(unbalancedEntryIds) > 0 || difference != 0 {
+		l.logger.Println(fmt.Sprintf("Ledger invariant violated - difference: %.2f, unbalanced entries: %v", difference, unbalancedEntryIds))
+	}
+
+	return &response.LedgerInvariantResponse{
+		TotalDebit:         utils.RoundMoney(debit),
+		TotalCredit:        utils.RoundMoney(credit),
+		Difference:         difference,
+		IsBalanced:         difference == 0 && len(unbalancedEntryIds) == 0,
+		UnbalancedEntryIds: unbalancedEntryIds,
+		AccountBalances:    balances,
+	}, nil
+}
+
+// CreateAdjustment implements businesslogic.ILedgerService.
+func (l *ledgerService) CreateAdjustment(req request.CreateLedgerAdjustmentRequest, ctx context.Context) (int, error) {
+	if err := ensureFiscalPeriodOpen(l.fiscalPeriodRepo, time.Now(), ctx); err != nil {
+		return 0, err
+	}
+
+	var lines []entity.LedgerLine
+	for _, line := range req.Lines {
+		var account string = strings.ToUpper(line.Account)
+		if !utils.IsLedgerAccountValid(account) {
+			return 0, errors.New(noti.UNSUPPORTED_LEDGER_ACCOUNT_WARN_MSG)
+		}
+
+		lines = append(lines, entity.LedgerLine{
+			Account: account,
+			OwnerId: line.OwnerId,
+			Debit:   line.Debit,
+			Credit:  line.Credit,
+		})
+	}
+
+	return postLedgerEntry(l.ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.ADJUSTMENT_ENTRY,
+		ReferenceId: req.ReferenceId,
+		Description: req.Description,
+		CreatedBy:   req.ActorId,
+	}, lines, ctx)
+}
+
+// Post a balanced journal entry, every line must be either a debit or a credit
+func postLedgerEntry(ledgerRepo repo.ILedgerRepo, entry entity.LedgerEntry, lines []entity.LedgerLine, ctx context.Context) (int, error) {
+	var totalDebit, totalCredit float64
+	for i, line := range lines {
+		lines[i].Debit = utils.RoundMoney(line.Debit)
+		lines[i].Credit = utils.RoundMoney(line.Credit)
+
+		if lines[i].Debit < 0 || lines[i].Credit < 0 || (lines[i].Debit == 0) == (lines[i].Credit == 0) {
+			return 0, errors.New(noti.UNBALANCED_LEDGER_ENTRY_WARN_MSG)
+		}
+
+		totalDebit += lines[i].Debit
+		totalCredit += lines[i].Credit
+	}
+
+	if len(lines) < 2 || math.Abs(totalDebit-totalCredit) >= 0.005 {
+		return 0, errors.New(noti.UNBALANCED_LEDGER_ENTRY_WARN_MSG)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	return ledgerRepo.CreateLedgerEntry(entry, lines, ctx)
+}
+
+// Post an entry for a business event only once, e.g. a payment is received a single time
+func postLedgerEntryOnce(ledgerRepo repo.ILedgerRepo, entry entity.LedgerEntry, lines []entity.LedgerLine, ctx context.Context) error {
+	existedEntry, err := ledgerRepo.GetLedgerEntryByReference(entry.EntryType, entry.ReferenceId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if existedEntry != nil {
+		return nil
+	}
+
+	_, err = postLedgerEntry(ledgerRepo, entry, lines, ctx)
+	return err
+}
+
+// Customer is charged and pays through the gateway, the amount is split between guide payable, agency payable
+// and platform commission
+func postPaymentLedgerEntry(ledgerRepo repo.ILedgerRepo, payment entity.Payment, revenue entity.Revenue, agencyRevenue *entity.AgencyRevenue, ctx context.Context) error {
+	var agencyLine entity.LedgerLine = entity.LedgerLine{Account: ledger.AGENCY_PAYABLE}
+	if agencyRevenue != nil {
+		agencyLine.OwnerId, agencyLine.Credit = agencyRevenue.AgencyId, agencyRevenue.Amount
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.PAYMENT_ENTRY,
+		ReferenceId: payment.PaymentId,
+		Description: fmt.Sprintf("Payment of invoice %d", payment.InvoiceId),
+		CreatedBy:   payment.CustomerId,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		{Account: ledger.CUSTOMER_RECEIVABLE, OwnerId: payment.CustomerId, Debit: payment.Price},
+		{Account: ledger.GUIDE_PAYABLE, OwnerId: revenue.TourGuideId, Credit: revenue.ActualReceived},
+		agencyLine,
+		{Account: ledger.PLATFORM_COMMISSION, OwnerId: ledger.PLATFORM_OWNER_ID, Credit: revenue.PlatformCommission},
+		{Account: ledger.GATEWAY_CLEARING, OwnerId: ledger.PLATFORM_OWNER_ID, Debit: payment.Price},
+		{Account: ledger.CUSTOMER_RECEIVABLE, OwnerId: payment.CustomerId, Credit: payment.Price},
+	}), ctx)
+}
+
+// Guide share and agency share are taken back and the money is returned to the customer through the gateway. The commission
+// given back is recorded in refunds of the customer, so refunds reduce the commission income instead of reversing it.
+// The part of the price which was already reversed by a revenue adjustment is taken from customer receivable
+func postRefundLedgerEntry(ledgerRepo repo.ILedgerRepo, payment entity.Payment, revenue entity.Revenue, agencyRevenue *entity.AgencyRevenue, actorId int, reason string, ctx context.Context) error {
+	var agencyLine entity.LedgerLine = entity.LedgerLine{Account: ledger.AGENCY_PAYABLE}
+	var agencyAmount float64
+	if agencyRevenue != nil {
+		agencyLine = generateSignedLedgerLine(ledger.AGENCY_PAYABLE, agencyRevenue.AgencyId, agencyRevenue.Amount)
+		agencyAmount = agencyRevenue.Amount
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.REFUND_ENTRY,
+		ReferenceId: payment.PaymentId,
+		Description: fmt.Sprintf("Refund of invoice %d - %s", payment.InvoiceId, reason),
+		CreatedBy:   actorId,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		generateSignedLedgerLine(ledger.GUIDE_PAYABLE, revenue.TourGuideId, revenue.ActualReceived),
+		agencyLine,
+		generateSignedLedgerLine(ledger.REFUNDS, payment.CustomerId, revenue.PlatformCommission),
+		generateSignedLedgerLine(ledger.CUSTOMER_RECEIVABLE, payment.CustomerId, payment.Price-revenue.TotalAmount-agencyAmount),
+		{Account: ledger.GATEWAY_CLEARING, OwnerId: ledger.PLATFORM_OWNER_ID, Credit: payment.Price},
+	}), ctx)
+}
+
+// Guide share and commission follow the adjustment deltas, the customer receivable takes the difference of the total amount
+func postRevenueAdjustmentLedgerEntry(ledgerRepo repo.ILedgerRepo, adjustment entity.RevenueAdjustment, revenue entity.Revenue, customerId int, ctx context.Context) error {
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.REVENUE_ADJUSTMENT_ENTRY,
+		ReferenceId: adjustment.RevenueAdjustmentId,
+		Description: fmt.Sprintf("%s of revenue %d - %s", adjustment.AdjustmentType, revenue.RevenueId, adjustment.Reason),
+		CreatedBy:   adjustment.CreatedBy,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		generateSignedLedgerLine(ledger.CUSTOMER_RECEIVABLE, customerId, adjustment.TotalAmountDelta),
+		generateSignedLedgerLine(ledger.GUIDE_PAYABLE, revenue.TourGuideId, -adjustment.ActualReceivedDelta),
+		generateSignedLedgerLine(ledger.PLATFORM_COMMISSION, ledger.PLATFORM_OWNER_ID, -adjustment.PlatformCommissionDelta),
+	}), ctx)
+}
+
+// Guide payable is settled by a bank transfer
+func postPayoutLedgerEntry(ledgerRepo repo.ILedgerRepo, item entity.PayoutItem, withholding *entity.TaxWithholding, ctx context.Context) error {
+	// The guide is settled with the gross amount, the withheld tax is owed to the state until it is remitted
+	var taxAmount float64
+	if withholding != nil {
+		taxAmount = withholding.TaxAmount
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.PAYOUT_ENTRY,
+		ReferenceId: item.PayoutItemId,
+		Description: "Payout " + item.Reference,
+		CreatedBy:   systemActorId,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		{Account: ledger.GUIDE_PAYABLE, OwnerId: item.TourGuideId, Debit: item.Amount + taxAmount},
+		{Account: ledger.GATEWAY_CLEARING, OwnerId: ledger.PLATFORM_OWNER_ID, Credit: item.Amount},
+		{Account: ledger.TAX_PAYABLE, OwnerId: item.TourGuideId, Credit: taxAmount},
+	}), ctx)
+}
+
+// Agency payable is settled by a bank transfer
+func postAgencyPayoutLedgerEntry(ledgerRepo repo.ILedgerRepo, payout entity.AgencyPayout, ctx context.Context) error {
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.AGENCY_PAYOUT_ENTRY,
+		ReferenceId: payout.AgencyPayoutId,
+		Description: "Agency payout " + payout.Reference,
+		CreatedBy:   *payout.ProcessedBy,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		{Account: ledger.AGENCY_PAYABLE, OwnerId: payout.AgencyId, Debit: payout.Amount},
+		{Account: ledger.GATEWAY_CLEARING, OwnerId: ledger.PLATFORM_OWNER_ID, Credit: payout.Amount},
+	}), ctx)
+}
+
+// Subscription fee of a tour guide is received through the gateway
+func postSubscriptionLedgerEntry(ledgerRepo repo.ILedgerRepo, invoice entity.SubscriptionInvoice, ctx context.Context) error {
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.SUBSCRIPTION_ENTRY,
+		ReferenceId: invoice.SubscriptionInvoiceId,
+		Description: fmt.Sprintf("Subscription invoice %d of subscription %d", invoice.SubscriptionInvoiceId, invoice.SubscriptionId),
+		CreatedBy:   systemActorId,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		{Account: ledger.GATEWAY_CLEARING, OwnerId: ledger.PLATFORM_OWNER_ID, Debit: invoice.Amount},
+		{Account: ledger.SUBSCRIPTION_REVENUE, OwnerId: invoice.TourGuideId, Credit: invoice.Amount},
+	}), ctx)
+}
+
+// The gateway keeps its fee out of the money collected, the surcharge paid by the customer offsets it
+func postGatewayFeeLedgerEntry(ledgerRepo repo.ILedgerRepo, fee entity.PaymentFee, ctx context.Context) error {
+	var amount float64 = utils.RoundMoney(fee.FeeAmount - fee.Surcharge)
+	if amount == 0 {
+		return nil
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.GATEWAY_FEE_ENTRY,
+		ReferenceId: fee.PaymentFeeId,
+		Description: fmt.Sprintf("%s fee of payment %d", fee.PaymentMethod, fee.PaymentId),
+		CreatedBy:   systemActorId,
+	}, []entity.LedgerLine{
+		generateSignedLedgerLine(ledger.GATEWAY_FEE, ledger.PLATFORM_OWNER_ID, amount),
+		generateSignedLedgerLine(ledger.GATEWAY_CLEARING, ledger.PLATFORM_OWNER_ID, -amount),
+	}, ctx)
+}
+
+// The settled fee replaces the estimate, only the difference is posted
+func postGatewaySettlementLedgerEntry(ledgerRepo repo.ILedgerRepo, fee entity.PaymentFee, difference float64, actorId int, ctx context.Context) error {
+	difference = utils.RoundMoney(difference)
+	if difference == 0 {
+		return nil
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.GATEWAY_SETTLEMENT_ENTRY,
+		ReferenceId: fee.PaymentFeeId,
+		Description: fmt.Sprintf("%s settlement %s of payment %d", fee.PaymentMethod, fee.SettlementReference, fee.PaymentId),
+		CreatedBy:   actorId,
+	}, []entity.LedgerLine{
+		generateSignedLedgerLine(ledger.GATEWAY_FEE, ledger.PLATFORM_OWNER_ID, difference),
+		generateSignedLedgerLine(ledger.GATEWAY_CLEARING, ledger.PLATFORM_OWNER_ID, -difference),
+	}, ctx)
+}
+
+// Wallet money is held on the platform account, so top-ups, payments and refunds only move it between the customer
+// wallet and gateway clearing. Admin adjustments and referral rewards are paid by the platform commission
+func postWalletLedgerEntry(ledgerRepo repo.ILedgerRepo, transaction entity.WalletTransaction, ctx context.Context) error {
+	var counterAccount string = ledger.GATEWAY_CLEARING
+	switch transaction.TransactionType {
+	case wallet.ADJUSTMENT, wallet.REFERRAL:
+		counterAccount = ledger.PLATFORM_COMMISSION
+	case wallet.GIFT_CARD:
+		counterAccount = ledger.GIFT_CARD_LIABILITY
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.WALLET_ENTRY,
+		ReferenceId: transaction.WalletTransactionId,
+		Description: fmt.Sprintf("Wallet %s of customer %d - %s", strings.ToLower(transaction.TransactionType), transaction.CustomerId, transaction.Description),
+		CreatedBy:   transaction.CreatedBy,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		generateSignedLedgerLine(counterAccount, ledger.PLATFORM_OWNER_ID, transaction.Amount),
+		generateSignedLedgerLine(ledger.CUSTOMER_WALLET, transaction.CustomerId, -transaction.Amount),
+	}), ctx)
+}
+
+// Gift card money is held by the platform until it is redeemed, the balance of an expired card becomes commission.
+// Wallet redemptions are posted with the wallet transaction
+func postGiftCardLedgerEntry(ledgerRepo repo.ILedgerRepo, transaction entity.GiftCardTransaction, ctx context.Context) error {
+	var counterAccount string = ledger.GATEWAY_CLEARING
+	switch transaction.TransactionType {
+	case gift_card.WALLET_REDEMPTION:
+		return nil
+	case gift_card.EXPIRY:
+		counterAccount = ledger.PLATFORM_COMMISSION
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.GIFT_CARD_ENTRY,
+		ReferenceId: transaction.GiftCardTransactionId,
+		Description: fmt.Sprintf("Gift card %s of card %d", strings.ToLower(strings.ReplaceAll(transaction.TransactionType, "_", " ")), transaction.GiftCardId),
+		CreatedBy:   transaction.CreatedBy,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		generateSignedLedgerLine(counterAccount, ledger.PLATFORM_OWNER_ID, transaction.Amount),
+		generateSignedLedgerLine(ledger.GIFT_CARD_LIABILITY, ledger.PLATFORM_OWNER_ID, -transaction.Amount),
+	}), ctx)
+}
+
+// The discount of redeemed points is paid by the platform out of its commission, restored points give it back
+func postLoyaltyLedgerEntry(ledgerRepo repo.ILedgerRepo, transaction entity.LoyaltyTransaction, ctx context.Context) error {
+	if utils.RoundMoney(transaction.Amount) == 0 {
+		return nil
+	}
+
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.LOYALTY_ENTRY,
+		ReferenceId: transaction.LoyaltyTransactionId,
+		Description: fmt.Sprintf("Loyalty point %s of customer %d - %s", strings.ToLower(transaction.TransactionType), transaction.CustomerId, transaction.Description),
+		CreatedBy:   transaction.CreatedBy,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		generateSignedLedgerLine(ledger.PLATFORM_COMMISSION, ledger.PLATFORM_OWNER_ID, -transaction.Amount),
+		generateSignedLedgerLine(ledger.GATEWAY_CLEARING, ledger.PLATFORM_OWNER_ID, transaction.Amount),
+	}), ctx)
+}
+
+// Referral rewards of tour guides are paid by the platform commission and settled with the next payout,
+// rewards of customers are posted with their wallet transaction
+func postReferralLedgerEntry(ledgerRepo repo.ILedgerRepo, reward entity.ReferralReward, ctx context.Context) error {
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.REFERRAL_ENTRY,
+		ReferenceId: reward.ReferralRewardId,
+		Description: fmt.Sprintf("Referral reward of invoice %d", reward.InvoiceId),
+		CreatedBy:   systemActorId,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		{Account: ledger.PLATFORM_COMMISSION, OwnerId: ledger.PLATFORM_OWNER_ID, Debit: reward.Amount},
+		{Account: ledger.GUIDE_PAYABLE, OwnerId: reward.ReferrerId, Credit: reward.Amount},
+	}), ctx)
+}
+
+// A cancelled reward of a tour guide goes back to the platform commission
+func postReferralReversalLedgerEntry(ledgerRepo repo.ILedgerRepo, reward entity.ReferralReward, actorId int, ctx context.Context) error {
+	return postLedgerEntryOnce(ledgerRepo, entity.LedgerEntry{
+		EntryType:   ledger.REFERRAL_REVERSAL_ENTRY,
+		ReferenceId: reward.ReferralRewardId,
+		Description: fmt.Sprintf("Cancelled referral reward of invoice %d", reward.InvoiceId),
+		CreatedBy:   actorId,
+	}, removeEmptyLedgerLines([]entity.LedgerLine{
+		{Account: ledger.GUIDE_PAYABLE, OwnerId: reward.ReferrerId, Debit: reward.Amount},
+		{Account: ledger.PLATFORM_COMMISSION, OwnerId: ledger.PLATFORM_OWNER_ID, Credit: reward.Amount},
+	}), ctx)
+}
+
+// Positive amount is posted as a debit, negative amount as a credit
+func generateSignedLedgerLine(account string, ownerId int, amount float64) entity.LedgerLine {
+	if amount < 0 {
+		return entity.LedgerLine{Account: account, OwnerId: ownerId, Credit: -amount}
+	}
+
+	return entity.LedgerLine{Account: account, OwnerId: ownerId, Debit: amount}
+}
+
+// Zero amount lines, e.g. no commission, are not worth recording
+func removeEmptyLedgerLines(lines []entity.LedgerLine) []entity.LedgerLine {
+	var res []entity.LedgerLine
+	for _, line := range lines {
+		if utils.RoundMoney(line.Debit) != 0 || utils.RoundMoney(line.Credit) != 0 {
+			res = append(res, line)
+		}
+	}
+
+	return res
+}
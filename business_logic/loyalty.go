@@ -0,0 +1,315 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	"tourmate/payment-service/constant/loyalty"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type loyaltyService struct {
+	logger      *log.Logger
+	loyaltyRepo repo.ILoyaltyRepo
+}
+
+func InitializeLoyaltyService(db *sql.DB, logger *log.Logger) business_logic.ILoyaltyService {
+	return &loyaltyService{
+		logger:      logger,
+		loyaltyRepo: repository.InitializeLoyaltyRepo(db, logger),
+	}
+}
+
+func GenerateLoyaltyService() (business_logic.ILoyaltyService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializeLoyaltyService(cnn, logger), nil
+}
+
+// GetLoyaltyBalance implements businesslogic.ILoyaltyService.
+func (l *loyaltyService) GetLoyaltyBalance(customerId int, ctx context.Context) (*response.LoyaltyBalanceResponse, error) {
+	var curTime time.Time = time.Now()
+	var res response.LoyaltyBalanceResponse = response.LoyaltyBalanceResponse{
+		CustomerId:     customerId,
+		PointValue:     getLoyaltyPointValue(),
+		ExpiringBefore: curTime.AddDate(0, 0, loyalty.EXPIRING_SOON_DAYS),
+	}
+
+	account, err := l.loyaltyRepo.GetLoyaltyAccount(customerId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A customer without an account has no points
+	if account == nil {
+		return &res, nil
+	}
+
+	res.Balance = account.Balance
+	res.BalanceValue = utils.RoundMoney(float64(account.Balance) * res.PointValue)
+	res.ExpiringPoints, err = l.loyaltyRepo.GetExpiringLoyaltyPoints(customerId, curTime, res.ExpiringBefore, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetLoyaltyTransactions implements businesslogic.ILoyaltyService.
+func (l *loyaltyService) GetLoyaltyTransactions(req request.GetLoyaltyTransactionsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.LoyaltyTransaction{}.GetLoyaltyTransactionLimitRecords()
+
+	data, pages, totalRecords, err := l.loyaltyRepo.GetLoyaltyTransactions(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetLoyaltyRules implements businesslogic.ILoyaltyService.
+func (l *loyaltyService) GetLoyaltyRules(ctx context.Context) (*[]entity.LoyaltyRule, error) {
+	return l.loyaltyRepo.GetLoyaltyRules(ctx)
+}
+
+// CreateLoyaltyRule implements businesslogic.ILoyaltyService.
+func (l *loyaltyService) CreateLoyaltyRule(req request.UpsertLoyaltyRuleRequest, ctx context.Context) (*entity.LoyaltyRule, error) {
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, errors.New(noti.INVALID_LOYALTY_RULE_PERIOD_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	var res entity.LoyaltyRule = entity.LoyaltyRule{
+		Name:       req.Name,
+		ServiceId:  req.ServiceId,
+		Multiplier: req.Multiplier,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		IsActive:   req.IsActive,
+		CreatedBy:  req.ActorId,
+		CreatedAt:  curTime,
+		UpdatedAt:  curTime,
+	}
+
+	id, err := l.loyaltyRepo.CreateLoyaltyRule(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.LoyaltyRuleId = id
+	return &res, nil
+}
+
+// UpdateLoyaltyRule implements businesslogic.ILoyaltyService.
+func (l *loyaltyService) UpdateLoyaltyRule(req request.UpsertLoyaltyRuleRequest, ctx context.Context) (*entity.LoyaltyRule, error) {
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, errors.New(noti.INVALID_LOYALTY_RULE_PERIOD_WARN_MSG)
+	}
+
+	res, err := l.loyaltyRepo.GetLoyaltyRuleById(req.LoyaltyRuleId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.LoyaltyRule{}.GetLoyaltyRuleTable()))
+	}
+
+	res.Name = req.Name
+	res.ServiceId = req.ServiceId
+	res.Multiplier = req.Multiplier
+	res.StartsAt = req.StartsAt
+	res.EndsAt = req.EndsAt
+	res.IsActive = req.IsActive
+	res.UpdatedAt = time.Now()
+
+	return res, l.loyaltyRepo.UpdateLoyaltyRule(*res, ctx)
+}
+
+// ExpireLoyaltyPoints implements businesslogic.ILoyaltyService.
+func (l *loyaltyService) ExpireLoyaltyPoints(ctx context.Context) (int, error) {
+	var curTime time.Time = time.Now()
+	credits, err := l.loyaltyRepo.GetExpiredLoyaltyPoints(curTime, ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var res int
+	for _, credit := range *credits {
+		transaction, err := l.loyaltyRepo.ExpireLoyaltyPoints(credit.LoyaltyTransactionId, curTime, ctx)
+		if err != nil {
+			return res, err
+		}
+
+		// Spent in the meantime
+		if transaction != nil {
+			res -= transaction.Points
+		}
+	}
+
+	return res, nil
+}
+
+// Give the customer points for a paid payment once, the discount paid with points earns nothing
+func earnLoyaltyPoints(loyaltyRepo repo.ILoyaltyRepo, payment entity.Payment, ctx context.Context) error {
+	if payment.PaymentMethod == payment_method.LOYALTY_POINT {
+		return nil
+	}
+
+	existedTransaction, err := loyaltyRepo.GetLoyaltyTransactionByReference(loyalty.EARN, payment.PaymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if existedTransaction != nil {
+		return nil
+	}
+
+	var curTime time.Time = time.Now()
+	var multiplier float64 = loyalty.BASE_MULTIPLIER
+	ruleMultiplier, err := loyaltyRepo.GetLoyaltyMultiplier(payment.ServiceId, curTime, ctx)
+	if err != nil {
+		return err
+	}
+
+	if ruleMultiplier != nil {
+		multiplier = *ruleMultiplier
+	}
+
+	var points int = int(math.Floor(payment.Price / getLoyaltyEarnAmount() * multiplier))
+	if points <= 0 {
+		return nil
+	}
+
+	var expiresAt time.Time = curTime.AddDate(0, loyalty.VALIDITY_MONTHS, 0)
+	_, err = loyaltyRepo.ChangeLoyaltyPoints(entity.LoyaltyTransaction{
+		CustomerId:      payment.CustomerId,
+		TransactionType: loyalty.EARN,
+		Points:          points,
+		Multiplier:      multiplier,
+		ReferenceId:     &payment.PaymentId,
+		Description:     fmt.Sprintf("Payment of invoice %d", payment.InvoiceId),
+		ExpiresAt:       &expiresAt,
+		CreatedBy:       payment.CustomerId,
+		CreatedAt:       curTime,
+	}, nil, false, ctx)
+
+	return err
+}
+
+// Take back the points earned on a refunded payment once, points already spent are not taken from the customer again
+func revokeLoyaltyPoints(loyaltyRepo repo.ILoyaltyRepo, payment entity.Payment, actorId int, ctx context.Context) error {
+	earning, err := loyaltyRepo.GetLoyaltyTransactionByReference(loyalty.EARN, payment.PaymentId, ctx)
+	if err != nil || earning == nil {
+		return err
+	}
+
+	revocation, err := loyaltyRepo.GetLoyaltyTransactionByReference(loyalty.REVOKE, payment.PaymentId, ctx)
+	if err != nil || revocation != nil {
+		return err
+	}
+
+	_, err = loyaltyRepo.ChangeLoyaltyPoints(entity.LoyaltyTransaction{
+		CustomerId:      payment.CustomerId,
+		TransactionType: loyalty.REVOKE,
+		Points:          -earning.Points,
+		ReferenceId:     &payment.PaymentId,
+		Description:     fmt.Sprintf("Refund of invoice %d", payment.InvoiceId),
+		CreatedBy:       actorId,
+		CreatedAt:       time.Now(),
+	}, &earning.LoyaltyTransactionId, true, ctx)
+
+	return err
+}
+
+// Give back the points redeemed for an invoice, they can be used for another 12 months
+func restoreLoyaltyPoints(loyaltyRepo repo.ILoyaltyRepo, ledgerRepo repo.ILedgerRepo, redemption entity.LoyaltyTransaction, actorId int, ctx context.Context) error {
+	var curTime time.Time = time.Now()
+	var expiresAt time.Time = curTime.AddDate(0, loyalty.VALIDITY_MONTHS, 0)
+	res, err := loyaltyRepo.ChangeLoyaltyPoints(entity.LoyaltyTransaction{
+		CustomerId:      redemption.CustomerId,
+		TransactionType: loyalty.RESTORE,
+		Points:          -redemption.Points,
+		Multiplier:      loyalty.BASE_MULTIPLIER,
+		Amount:          -redemption.Amount,
+		ReferenceId:     redemption.ReferenceId,
+		Description:     redemption.Description,
+		ExpiresAt:       &expiresAt,
+		CreatedBy:       actorId,
+		CreatedAt:       curTime,
+	}, nil, false, ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return postLoyaltyLedgerEntry(ledgerRepo, *res, ctx)
+}
+
+// Spend points on the invoice, the discount is recorded on the transaction
+func redeemLoyaltyPoints(loyaltyRepo repo.ILoyaltyRepo, ledgerRepo repo.ILedgerRepo, customerId, invoiceId, points int, discount float64, ctx context.Context) (*entity.LoyaltyTransaction, error) {
+	res, err := loyaltyRepo.ChangeLoyaltyPoints(entity.LoyaltyTransaction{
+		CustomerId:      customerId,
+		TransactionType: loyalty.REDEEM,
+		Points:          -points,
+		Amount:          -discount,
+		ReferenceId:     &invoiceId,
+		Description:     fmt.Sprintf("Discount of invoice %d", invoiceId),
+		CreatedBy:       customerId,
+		CreatedAt:       time.Now(),
+	}, nil, false, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, postLoyaltyLedgerEntry(ledgerRepo, *res, ctx)
+}
+
+func getLoyaltyEarnAmount() float64 {
+	if amount, err := strconv.ParseFloat(os.Getenv(payment_env.LOYALTY_EARN_AMOUNT), 64); err == nil && amount > 0 {
+		return amount
+	}
+
+	return loyalty.DEFAULT_EARN_AMOUNT
+}
+
+func getLoyaltyPointValue() float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(payment_env.LOYALTY_POINT_VALUE), 64); err == nil && value > 0 {
+		return value
+	}
+
+	return loyalty.DEFAULT_POINT_VALUE
+}
@@ -0,0 +1,342 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	base64_format "tourmate/payment-service/constant/file/file_format/base64"
+	file_support "tourmate/payment-service/constant/file/file_support"
+	"tourmate/payment-service/constant/noti"
+	offline_payment "tourmate/payment-service/constant/offline_payment"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+// Data URL prefixes of the accepted proof images
+var proofImageFormats map[string]string = map[string]string{
+	file_support.JPEG_FORMAT: base64_format.JPEG_FILE_FORMAT,
+	file_support.JPG_FORMAT:  base64_format.JPG_FILE_FORMAT,
+	file_support.PNG_FORMAT:  base64_format.PNG_FILE_FORMAT,
+	file_support.GIF_FORMAT:  base64_format.GIF_FILE_FORMAT,
+	file_support.WEBP_FORMAT: base64_format.WEBP_FILE_FORMAT,
+}
+
+type offlinePaymentService struct {
+	logger             *log.Logger
+	recorder           *paymentRecorder
+	offlinePaymentRepo repo.IOfflinePaymentRepo
+	paymentRepo        repo.IPaymentRepo
+}
+
+func InitializeOfflinePaymentService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IOfflinePaymentService {
+	return &offlinePaymentService{
+		logger:             logger,
+		recorder:           initializePaymentRecorder(db, userService, logger),
+		offlinePaymentRepo: repository.InitializeOfflinePaymentRepo(db, logger),
+		paymentRepo:        repository.InitializePaymentRepo(db, logger),
+	}
+}
+
+func GenerateOfflinePaymentService() (business_logic.IOfflinePaymentService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeOfflinePaymentService(cnn, userService, logger), nil
+}
+
+// CreateOfflinePayment implements businesslogic.IOfflinePaymentService.
+func (o *offlinePaymentService) CreateOfflinePayment(req request.CreateOfflinePaymentRequest, ctx context.Context) (*entity.OfflinePayment, error) {
+	var proofImage string
+	if len(req.ProofContent) > 0 {
+		prefix, isSupported := proofImageFormats[utils.GetFileExtension(req.ProofFileName)]
+		if !isSupported || len(req.ProofContent) > offline_payment.MAX_PROOF_IMAGE_SIZE {
+			return nil, errors.New(noti.INVALID_PROOF_IMAGE_WARN_MSG)
+		}
+
+		proofImage = prefix + base64.StdEncoding.EncodeToString(req.ProofContent)
+	}
+
+	paidPayment, err := o.paymentRepo.GetPaidPaymentByInvoiceId(req.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if paidPayment != nil {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	payment, err := o.paymentRepo.CreatePayment(entity.Payment{
+		CustomerId:    req.CustomerId,
+		InvoiceId:     req.InvoiceId,
+		ServiceId:     req.ServiceId,
+		Price:         req.Price,
+		PaymentMethod: req.PaymentMethod,
+		CreatedAt:     curTime,
+		Status:        domain_status.PAYMENT_PENDING,
+	}, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var res entity.OfflinePayment = entity.OfflinePayment{
+		PaymentId:     payment.PaymentId,
+		TourGuideId:   req.TourGuideId,
+		Amount:        req.Price,
+		PaymentMethod: req.PaymentMethod,
+		Note:          req.Note,
+		Status:        domain_status.OFFLINE_PAYMENT_PENDING,
+		RecordedBy:    req.ActorId,
+		RecordedAt:    curTime,
+		UpdatedAt:     curTime,
+	}
+
+	id, err := o.offlinePaymentRepo.CreateOfflinePayment(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.OfflinePaymentId = id
+
+	if proofImage != "" {
+		if err := o.offlinePaymentRepo.CreateOfflinePaymentProof(entity.OfflinePaymentProof{
+			OfflinePaymentId: id,
+			FileName:         req.ProofFileName,
+			Image:            proofImage,
+			UploadedAt:       curTime,
+		}, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
+
+// GetOfflinePayments implements businesslogic.IOfflinePaymentService.
+func (o *offlinePaymentService) GetOfflinePayments(req request.GetOfflinePaymentsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.Status = strings.ToUpper(req.Status)
+	req.PageSize = entity.OfflinePayment{}.GetOfflinePaymentLimitRecords()
+
+	data, pages, totalRecords, err := o.offlinePaymentRepo.GetOfflinePayments(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetOfflinePaymentProof implements businesslogic.IOfflinePaymentService.
+func (o *offlinePaymentService) GetOfflinePaymentProof(id int, ctx context.Context) (response.FileResponse, error) {
+	proof, err := o.offlinePaymentRepo.GetOfflinePaymentProof(id, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	if proof == nil {
+		return response.FileResponse{}, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.OfflinePaymentProof{}.GetOfflinePaymentProofTable()))
+	}
+
+	// data:image/png;base64,<content>
+	header, data, _ := strings.Cut(proof.Image, ",")
+	content, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		o.logger.Println(fmt.Sprintf(noti.FILE_READ_ERR_MSG, proof.FileName) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return response.FileResponse{
+		FileName:    proof.FileName,
+		ContentType: strings.TrimSuffix(strings.TrimPrefix(header, "data:"), ";base64"),
+		Content:     content,
+	}, nil
+}
+
+// ConfirmOfflinePayment implements businesslogic.IOfflinePaymentService.
+func (o *offlinePaymentService) ConfirmOfflinePayment(req request.ConfirmOfflinePaymentRequest, ctx context.Context) (*entity.OfflinePayment, error) {
+	offlinePayment, err := o.getOfflinePayment(req.OfflinePaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentStatus string = offlinePayment.Status
+	var curTime time.Time = time.Now()
+	switch currentStatus {
+	case domain_status.OFFLINE_PAYMENT_PENDING:
+		offlinePayment.ConfirmedBy = &req.ActorId
+		offlinePayment.ConfirmedAt = &curTime
+		offlinePayment.UpdatedAt = curTime
+
+		// Large amounts wait for a second admin before any money is recognised
+		if offlinePayment.Amount > getOfflinePaymentApprovalAmount() {
+			offlinePayment.Status = domain_status.OFFLINE_PAYMENT_AWAITING_APPROVAL
+			return offlinePayment, o.offlinePaymentRepo.UpdateOfflinePayment(*offlinePayment, currentStatus, ctx)
+		}
+	case domain_status.OFFLINE_PAYMENT_AWAITING_APPROVAL:
+		// Four-eyes principle
+		if offlinePayment.ConfirmedBy != nil && *offlinePayment.ConfirmedBy == req.ActorId {
+			return nil, errors.New(noti.SECOND_APPROVER_REQUIRED_WARN_MSG)
+		}
+
+		offlinePayment.ApprovedBy = &req.ActorId
+		offlinePayment.ApprovedAt = &curTime
+		offlinePayment.UpdatedAt = curTime
+	default:
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	payment, err := o.getPendingPayment(offlinePayment.PaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paidPayment, err := o.paymentRepo.GetPaidPaymentByInvoiceId(payment.InvoiceId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if paidPayment != nil {
+		return nil, errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	}
+
+	// The offline payment is taken first so that two admins cannot confirm it at the same time
+	offlinePayment.Status = domain_status.OFFLINE_PAYMENT_CONFIRMED
+	if err := o.offlinePaymentRepo.UpdateOfflinePayment(*offlinePayment, currentStatus, ctx); err != nil {
+		return nil, err
+	}
+
+	var paymentStatus string = payment.Status
+	payment.Status = domain_status.PAYMENT_PAID
+	if err := o.paymentRepo.UpdatePayment(*payment, ctx); err != nil {
+		o.rollbackConfirmation(*offlinePayment, currentStatus, nil, "", ctx)
+		return nil, err
+	}
+
+	if err := o.recorder.recordPaymentRevenue(*payment, offlinePayment.TourGuideId, ctx); err != nil {
+		o.rollbackConfirmation(*offlinePayment, currentStatus, payment, paymentStatus, ctx)
+		return nil, err
+	}
+
+	return offlinePayment, nil
+}
+
+// Put the offline payment and its payment back to the status they had before a confirmation which could not be completed,
+// the revenue recorded so far is reused when the confirmation is retried
+func (o *offlinePaymentService) rollbackConfirmation(offlinePayment entity.OfflinePayment, offlineStatus string, payment *entity.Payment, paymentStatus string, ctx context.Context) {
+	if payment != nil {
+		payment.Status = paymentStatus
+		o.paymentRepo.UpdatePayment(*payment, ctx)
+	}
+
+	offlinePayment.Status = offlineStatus
+	o.offlinePaymentRepo.UpdateOfflinePayment(offlinePayment, domain_status.OFFLINE_PAYMENT_CONFIRMED, ctx)
+}
+
+// RejectOfflinePayment implements businesslogic.IOfflinePaymentService.
+func (o *offlinePaymentService) RejectOfflinePayment(req request.RejectOfflinePaymentRequest, ctx context.Context) (*entity.OfflinePayment, error) {
+	offlinePayment, err := o.getOfflinePayment(req.OfflinePaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentStatus string = offlinePayment.Status
+	if currentStatus != domain_status.OFFLINE_PAYMENT_PENDING && currentStatus != domain_status.OFFLINE_PAYMENT_AWAITING_APPROVAL {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	payment, err := o.getPendingPayment(offlinePayment.PaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	offlinePayment.Status = domain_status.OFFLINE_PAYMENT_REJECTED
+	offlinePayment.RejectedBy = &req.ActorId
+	offlinePayment.RejectedAt = &curTime
+	offlinePayment.RejectReason = req.Reason
+	offlinePayment.UpdatedAt = curTime
+
+	if err := o.offlinePaymentRepo.UpdateOfflinePayment(*offlinePayment, currentStatus, ctx); err != nil {
+		return nil, err
+	}
+
+	payment.Status = domain_status.PAYMENT_CANCELLED
+	if err := o.paymentRepo.UpdatePayment(*payment, ctx); err != nil {
+		return nil, err
+	}
+
+	return offlinePayment, nil
+}
+
+func (o *offlinePaymentService) getOfflinePayment(id int, ctx context.Context) (*entity.OfflinePayment, error) {
+	res, err := o.offlinePaymentRepo.GetOfflinePaymentById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.OfflinePayment{}.GetOfflinePaymentTable()))
+	}
+
+	return res, nil
+}
+
+// The payment of an offline payment which has not been decided must still be PENDING
+func (o *offlinePaymentService) getPendingPayment(id int, ctx context.Context) (*entity.Payment, error) {
+	res, err := o.paymentRepo.GetPaymentById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Payment{}.GetPaymentTable()))
+	}
+
+	if res.Status != domain_status.PAYMENT_PENDING {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return res, nil
+}
+
+// Offline payments above this amount need a second admin to confirm
+func getOfflinePaymentApprovalAmount() float64 {
+	if amount, err := strconv.ParseFloat(os.Getenv(payment_env.OFFLINE_PAYMENT_APPROVAL_AMOUNT), 64); err == nil && amount >= 0 {
+		return amount
+	}
+
+	return offline_payment.DEFAULT_APPROVAL_AMOUNT
+}
@@ -6,12 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
-	"time"
+	"strings"
 	domain_status "tourmate/payment-service/constant/domain_status"
 	payment_env "tourmate/payment-service/constant/env/payment"
-	mail_const "tourmate/payment-service/constant/mail_const"
+	filter_property "tourmate/payment-service/constant/filter_property"
+	"tourmate/payment-service/constant/loyalty"
 	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/constant/wallet"
 	"tourmate/payment-service/infrastructure/grpc/tour"
 	tour_pb "tourmate/payment-service/infrastructure/grpc/tour/pb"
 	"tourmate/payment-service/infrastructure/grpc/user"
@@ -32,20 +37,40 @@ import (
 )
 
 type paymentService struct {
-	logger      *log.Logger
-	userService business_logic.IUserService
-	tourService business_logic.ITourService
-	revenueRepo repo.IRevenueRepo
-	paymentRepo repo.IPaymentRepo
+	logger           *log.Logger
+	recorder         *paymentRecorder
+	userService      business_logic.IUserService
+	tourService      business_logic.ITourService
+	revenueRepo      repo.IRevenueRepo
+	agencyRepo       repo.IAgencyRepo
+	gatewayFeeRepo   repo.IGatewayFeeRepo
+	paymentRepo      repo.IPaymentRepo
+	ledgerRepo       repo.ILedgerRepo
+	loyaltyRepo      repo.ILoyaltyRepo
+	referralRepo     repo.IReferralRepo
+	fiscalPeriodRepo repo.IFiscalPeriodRepo
+	eInvoiceRepo     repo.IEInvoiceRepo
+	walletRepo       repo.IWalletRepo
+	splitPaymentRepo repo.ISplitPaymentRepo
 }
 
 func InitializePaymentService(db *sql.DB, userService business_logic.IUserService, tourService business_logic.ITourService, logger *log.Logger) business_logic.IPaymentService {
 	return &paymentService{
-		logger:      logger,
-		userService: userService,
-		tourService: tourService,
-		revenueRepo: repository.InitializeRevenueRepo(db, logger),
-		paymentRepo: repository.InitializePaymentRepo(db, logger),
+		logger:           logger,
+		recorder:         initializePaymentRecorder(db, userService, logger),
+		userService:      userService,
+		tourService:      tourService,
+		revenueRepo:      repository.InitializeRevenueRepo(db, logger),
+		agencyRepo:       repository.InitializeAgencyRepo(db, logger),
+		gatewayFeeRepo:   repository.InitializeGatewayFeeRepo(db, logger),
+		paymentRepo:      repository.InitializePaymentRepo(db, logger),
+		ledgerRepo:       repository.InitializeLedgerRepo(db, logger),
+		loyaltyRepo:      repository.InitializeLoyaltyRepo(db, logger),
+		referralRepo:     repository.InitializeReferralRepo(db, logger),
+		fiscalPeriodRepo: repository.InitializeFiscalPeriodRepo(db, logger),
+		eInvoiceRepo:     repository.InitializeEInvoiceRepo(db, logger),
+		walletRepo:       repository.InitializeWalletRepo(db, logger),
+		splitPaymentRepo: repository.InitializeSplitPaymentRepo(db, logger),
 	}
 }
 
@@ -75,11 +100,21 @@ func (p *paymentService) GetPayments(req request.GetPaymentsRequest, ctx context
 		req.Request.Page = 1
 	}
 
-	req.Request.FilterProp = utils.AssignFilterProperty(req.Request.FilterProp)
-	req.Request.Order = utils.AssignOrder(req.Request.Order)
-	req.PageSize = 10
+	req.Request.FilterProp, req.Request.Order = utils.ParseSortSpec(req.Request.FilterProp, req.Request.Order,
+		filter_property.PAYMENT_SORT_COLUMNS, "createdAt", order.ASCENDING_ORDER)
+	req.PageSize = utils.ParsePageSize(req.PageSize)
+	req.Request.Keyword = strings.TrimSpace(req.Request.Keyword)
+	req.Status = strings.ToUpper(strings.TrimSpace(req.Status))
+
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return response.PaginationDataResponse{}, errors.New(noti.INVALID_AMOUNT_RANGE_WARN_MSG)
+	}
 
-	p.logger.Println("GetPayments Request: ", req)
+	from, to, err := utils.GenerateDatePeriod(req.From, req.To)
+	if err != nil {
+		return response.PaginationDataResponse{}, err
+	}
+	req.From, req.To = from, to
 
 	if req.CustomerId != nil {
 		user, err := p.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
@@ -119,6 +154,10 @@ func (p *paymentService) UpdatePayment(req request.UpdatePaymentRequest, ctx con
 		return errors.New(noti.GENERIC_ERROR_WARN_MSG)
 	}
 
+	if err := ensureFiscalPeriodOpen(p.fiscalPeriodRepo, payment.CreatedAt, ctx); err != nil {
+		return err
+	}
+
 	// Must validate(implement later)
 	if req.Method != "" {
 		payment.PaymentMethod = req.Method
@@ -127,54 +166,136 @@ func (p *paymentService) UpdatePayment(req request.UpdatePaymentRequest, ctx con
 	return p.paymentRepo.UpdatePayment(*payment, ctx)
 }
 
-// CreatePayment implements businesslogic.IPaymentService.
-func (p *paymentService) CreatePayment(req request.CreatePaymentRequest, ctx context.Context) (*entity.Payment, error) {
-	var curTime time.Time = time.Now()
-	res, err := p.paymentRepo.CreatePayment(entity.Payment{
-		CustomerId:    req.CustomerId,
-		InvoiceId:     req.InvoiceId,
-		ServiceId:     req.ServiceId,
-		Price:         req.Price,
-		PaymentMethod: req.PaymentMethod,
-		CreatedAt:     curTime,
-		Status:        domain_status.PAYMENT_PAID,
-	}, ctx)
+// RefundPayment implements businesslogic.IPaymentService.
+func (p *paymentService) RefundPayment(req request.RefundPaymentRequest, ctx context.Context) error {
+	payment, err := p.paymentRepo.GetPaymentById(req.PaymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if payment == nil {
+		return errors.New(noti.GENERIC_ERROR_WARN_MSG)
+	}
+
+	if payment.Status != domain_status.PAYMENT_PAID {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
 
+	revenue, err := p.revenueRepo.GetRevenueByPaymentId(payment.PaymentId, ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if _, err := p.revenueRepo.CreateRevenue(entity.Revenue{
-		PaymentId:          res.PaymentId,
-		TourGuideId:        req.TourGuideId,
-		InvoiceId:          req.InvoiceId,
-		TotalAmount:        req.Price,
-		ActualReceived:     req.Price * 0.85,
-		PlatformCommission: req.Price * 0.15,
-		PaymentStatus:      false,
-		CreatedAt:          curTime,
-	}, ctx); err != nil {
-		return nil, err
+	if revenue == nil {
+		return errors.New(noti.GENERIC_ERROR_WARN_MSG)
 	}
 
-	userInfo, _ := p.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
-		CustomerId: int32(req.CustomerId),
-	})
+	// Every step is done once so that a refund which failed halfway can be retried, the payment is only marked refunded
+	// at the end
+	agencyRevenue, err := reverseAgencyRevenue(p.agencyRepo, payment.PaymentId, ctx)
+	if err != nil {
+		return err
+	}
 
-	if userInfo != nil {
-		utils.SendMail(request.SendMailRequest{
-			Body: request.MailBody{ // Mail body
-				Subject:       noti.NOTI_PAYMENT_MAIL_SUBJECT,
-				Email:         userInfo.Email,
-				Username:      userInfo.FullName,
-				TransactionId: res.InvoiceId,
-			},
-			TemplatePath: mail_const.PAYMENT_CALLBACK_CANCEL_TEMPLATE,
-			Logger:       p.logger, // Logger
-		})
+	// The entry is posted with the revenue before its reversal, a retry finds it posted
+	if err := postRefundLedgerEntry(p.ledgerRepo, *payment, *revenue, agencyRevenue, req.ActorId, req.Reason, ctx); err != nil {
+		return err
 	}
 
-	return res, nil
+	// The refund is recorded in the current period even when the payment belongs to a closed one.
+	// The refund entry already moves the guide share and commission back, the reversal only records the change on the revenue
+	if _, err := reverseRevenue(p.revenueRepo, p.fiscalPeriodRepo, *revenue, req.ActorId, req.Reason, ctx); err != nil {
+		return err
+	}
+
+	switch {
+	case payment.PaymentMethod == payment_method.LOYALTY_POINT:
+		// The discount goes back as points, it was never paid in money
+		if err := p.restoreRefundedPoints(*payment, req.ActorId, ctx); err != nil {
+			return err
+		}
+	case req.ToWallet || payment.PaymentMethod == payment_method.WALLET || payment.PaymentMethod == payment_method.GIFT_CARD:
+		// Payments made from the wallet or a gift card go back to the wallet
+		if err := p.refundToWallet(*payment, req.ActorId, req.Reason, ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := revokeLoyaltyPoints(p.loyaltyRepo, *payment, req.ActorId, ctx); err != nil {
+		return err
+	}
+
+	if err := cancelReferralRewards(p.referralRepo, p.ledgerRepo, payment.PaymentId, req.ActorId, ctx); err != nil {
+		return err
+	}
+
+	if err := adjustEInvoiceOnRefund(p.eInvoiceRepo, payment.PaymentId, req.ActorId, req.Reason, ctx); err != nil {
+		return err
+	}
+
+	payment.Status = domain_status.PAYMENT_REFUNDED
+	return p.paymentRepo.UpdatePayment(*payment, ctx)
+}
+
+// Give back the points of the invoice redemption paid by the payment, nothing is done when they were restored after it
+func (p *paymentService) restoreRefundedPoints(payment entity.Payment, actorId int, ctx context.Context) error {
+	redemption, err := p.loyaltyRepo.GetLoyaltyTransactionByReference(loyalty.REDEEM, payment.InvoiceId, ctx)
+	if err != nil || redemption == nil {
+		return err
+	}
+
+	restoration, err := p.loyaltyRepo.GetLoyaltyTransactionByReference(loyalty.RESTORE, payment.InvoiceId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if restoration != nil && restoration.LoyaltyTransactionId > redemption.LoyaltyTransactionId {
+		return nil
+	}
+
+	return restoreLoyaltyPoints(p.loyaltyRepo, p.ledgerRepo, *redemption, actorId, ctx)
+}
+
+// Credit the refunded payment to the wallet of the customer, the refund references the payment so it is credited once
+func (p *paymentService) refundToWallet(payment entity.Payment, actorId int, reason string, ctx context.Context) error {
+	refund, err := p.walletRepo.GetWalletTransactionByReference(wallet.REFUND, payment.PaymentId, ctx)
+	if err != nil || refund != nil {
+		return err
+	}
+
+	_, err = changeWalletBalance(p.walletRepo, p.ledgerRepo, entity.WalletTransaction{
+		CustomerId:      payment.CustomerId,
+		TransactionType: wallet.REFUND,
+		Amount:          payment.Price,
+		ReferenceId:     &payment.PaymentId,
+		Description:     reason,
+		CreatedBy:       actorId,
+	}, ctx)
+
+	return err
+}
+
+// CreatePayment implements businesslogic.IPaymentService.
+func (p *paymentService) CreatePayment(req request.CreatePaymentRequest, ctx context.Context) (*entity.Payment, error) {
+	// Money collected outside the gateways goes through the offline payment confirmation
+	if req.PaymentMethod == payment_method.CASH || req.PaymentMethod == payment_method.BANK_TRANSFER {
+		return nil, errors.New(noti.OFFLINE_PAYMENT_METHOD_WARN_MSG)
+	}
+
+	// The wallet and gift card balances are only taken by their own payments
+	if req.PaymentMethod == payment_method.WALLET {
+		return nil, errors.New(noti.WALLET_PAYMENT_METHOD_WARN_MSG)
+	}
+
+	if req.PaymentMethod == payment_method.GIFT_CARD {
+		return nil, errors.New(noti.GIFT_CARD_PAYMENT_METHOD_WARN_MSG)
+	}
+
+	if req.PaymentMethod == payment_method.LOYALTY_POINT {
+		return nil, errors.New(noti.LOYALTY_POINT_PAYMENT_METHOD_WARN_MSG)
+	}
+
+	return p.recorder.createPaidPayment(req, ctx)
 }
 
 // // CreatePaymentDirect implements businesslogic.IPaymentService.
@@ -364,34 +485,167 @@ func (p *paymentService) CreatePayment(req request.CreatePaymentRequest, ctx con
 // }
 
 // CreatePayosTransaction implements businesslogic.IPaymentService.
-func (p *paymentService) CreatePayosTransaction(req request.CreatePayosTransactionRequest, ctx context.Context) (response.UrlResponse, error) {
-	var description string = fmt.Sprintf("Invoice %d", req.InvoiceId)
+func (p *paymentService) CreatePayosTransaction(req request.CreatePayosTransactionRequest, ctx context.Context) (response.PayosTransactionResponse, error) {
+	var description string = utils.GenerateInvoiceNote(req.InvoiceId)
 	p.logger.Println("Description: ", description)
 	p.logger.Printf("Request data - Amount: %f, InvoiceId: %d", req.Amount, req.InvoiceId)
 
 	// Validate input data
 	if req.Amount <= 0 {
 		p.logger.Println("Invalid amount: amount must be greater than 0")
-		return response.UrlResponse{}, errors.New("amount must be greater than 0")
+		return response.PayosTransactionResponse{}, errors.New("amount must be greater than 0")
 	}
 
 	if req.InvoiceId <= 0 {
 		p.logger.Println("Invalid invoice ID: invoice ID must be greater than 0")
-		return response.UrlResponse{}, errors.New("invoice ID must be greater than 0")
+		return response.PayosTransactionResponse{}, errors.New("invoice ID must be greater than 0")
 	}
 
+	var res response.PayosTransactionResponse = response.PayosTransactionResponse{
+		Amount: req.Amount,
+	}
+
+	// The discount of the redeemed points is taken off the amount paid through PayOS
+	if req.RedeemPoints > 0 {
+		if req.CustomerId <= 0 || req.TourGuideId <= 0 || req.ServiceId <= 0 {
+			return response.PayosTransactionResponse{}, errors.New(noti.LOYALTY_REDEMPTION_DETAILS_REQUIRED_WARN_MSG)
+		}
+
+		res.DiscountAmount = utils.RoundMoney(float64(req.RedeemPoints) * getLoyaltyPointValue())
+		if res.DiscountAmount >= req.Amount {
+			return response.PayosTransactionResponse{}, errors.New(noti.LOYALTY_DISCOUNT_EXCEEDS_AMOUNT_WARN_MSG)
+		}
+
+		if err := p.recorder.checkPendingSplitPayment(req.InvoiceId, ctx); err != nil {
+			return response.PayosTransactionResponse{}, err
+		}
+
+		res.Amount = utils.RoundMoney(req.Amount - res.DiscountAmount)
+	}
+
+	surcharge, err := getPaymentSurcharge(p.gatewayFeeRepo, payment_method.PAYOS, res.Amount, ctx)
+	if err != nil {
+		return response.PayosTransactionResponse{}, err
+	}
+
+	res.Surcharge = surcharge
+
 	// Convert amount to integer (PayOS expects amount in VND, not cents for VN)
-	amount := int(req.Amount)
+	amount := int(math.Round(res.Amount + res.Surcharge))
+
+	if req.RedeemPoints > 0 {
+		return p.createRedeemedPointsTransaction(req, res, amount, ctx)
+	}
 
 	// Generate unique order code
 	orderCode := int64(utils.GenerateNumber())
 	p.logger.Printf("Generated OrderCode: %d", orderCode)
 
+	data, err := createPayosPaymentLink(orderCode, amount, description, p.logger)
+
+	if err != nil {
+		p.logger.Printf("Failed to create PayOS link: %v", err)
+		return response.PayosTransactionResponse{}, fmt.Errorf("failed to create payment link: %v", err)
+	}
+
+	p.logger.Println("Payos link: ", data.CheckoutUrl)
+	res.Url = data.CheckoutUrl
+	return res, nil
+}
+
+// The invoice is claimed and the points are redeemed before the link is created so that the link is never handed out
+// for a discount which was not held. The discount is only recorded as paid when the link is paid
+func (p *paymentService) createRedeemedPointsTransaction(req request.CreatePayosTransactionRequest, res response.PayosTransactionResponse, amount int, ctx context.Context) (response.PayosTransactionResponse, error) {
+	payment, err := p.recorder.claimInvoice(entity.Payment{
+		CustomerId:    req.CustomerId,
+		InvoiceId:     req.InvoiceId,
+		ServiceId:     req.ServiceId,
+		Price:         res.DiscountAmount,
+		PaymentMethod: payment_method.LOYALTY_POINT,
+	}, ctx)
+
+	if err != nil {
+		return response.PayosTransactionResponse{}, err
+	}
+
+	redemption, err := redeemLoyaltyPoints(p.loyaltyRepo, p.ledgerRepo, req.CustomerId, req.InvoiceId, req.RedeemPoints, res.DiscountAmount, ctx)
+	if err != nil {
+		p.recorder.releaseInvoice(*payment, ctx)
+		return response.PayosTransactionResponse{}, err
+	}
+
+	res.SplitPayment, err = p.recorder.openSplitPayment(*payment, entity.SplitPayment{
+		TourGuideId:   req.TourGuideId,
+		Points:        req.RedeemPoints,
+		GatewayAmount: res.Amount,
+	}, amount, ctx)
+
+	if err != nil {
+		// Give the points back and free the invoice, the discount was not recorded
+		restoreLoyaltyPoints(p.loyaltyRepo, p.ledgerRepo, *redemption, systemActorId, ctx)
+		p.recorder.releaseInvoice(*payment, ctx)
+		return response.PayosTransactionResponse{}, err
+	}
+
+	res.Payment = payment
+	res.Url = res.SplitPayment.CheckoutUrl
+	res.RedeemedPoints = req.RedeemPoints
+	return res, nil
+}
+
+// ConfirmSplitPayment implements businesslogic.IPaymentService.
+func (p *paymentService) ConfirmSplitPayment(id int, ctx context.Context) (*entity.SplitPayment, error) {
+	splitPayment, err := p.splitPaymentRepo.GetSplitPaymentById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if splitPayment == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.SplitPayment{}.GetSplitPaymentTable()))
+	}
+
+	res, err := p.recorder.settleSplitPayment(*splitPayment, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Status == domain_status.SPLIT_PAYMENT_PENDING {
+		return nil, errors.New(noti.SPLIT_PAYMENT_NOT_PAID_WARN_MSG)
+	}
+
+	return res, nil
+}
+
+// ReleaseExpiredSplitPayments implements businesslogic.IPaymentService.
+func (p *paymentService) ReleaseExpiredSplitPayments(ctx context.Context) (int, error) {
+	splitPayments, err := p.splitPaymentRepo.GetPendingSplitPayments(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var res int
+	for _, splitPayment := range *splitPayments {
+		settled, err := p.recorder.settleSplitPayment(splitPayment, ctx)
+		if err != nil {
+			return res, err
+		}
+
+		if settled.Status == domain_status.SPLIT_PAYMENT_RELEASED {
+			res++
+		}
+	}
+
+	return res, nil
+}
+
+// Create a PayOS payment link of a single item, the customer is sent back to the payment callback pages
+func createPayosPaymentLink(orderCode int64, amount int, description string, logger *log.Logger) (*payos.CheckoutResponseDataType, error) {
 	returnUrl := os.Getenv(payment_env.PAYMENT_CALLBACK_SUCCESS)
 	cancelUrl := os.Getenv(payment_env.PAYMENT_CALLBACK_CANCEL)
-	p.logger.Printf("PayOS Request: Amount=%d, OrderCode=%d, Description=%s, ReturnUrl=%s, CancelUrl=%s", amount, orderCode, description, returnUrl, cancelUrl)
-	p.logger.Printf("PayOS Items: %+v", []payos.Item{{Name: description, Quantity: 1, Price: amount}})
-	data, err := payos.CreatePaymentLink(payos.CheckoutRequestType{
+	logger.Printf("PayOS Request: Amount=%d, OrderCode=%d, Description=%s, ReturnUrl=%s, CancelUrl=%s", amount, orderCode, description, returnUrl, cancelUrl)
+	logger.Printf("PayOS Items: %+v", []payos.Item{{Name: description, Quantity: 1, Price: amount}})
+
+	return payos.CreatePaymentLink(payos.CheckoutRequestType{
 		Amount:    amount,
 		OrderCode: orderCode,
 		Items: []payos.Item{
@@ -405,16 +659,85 @@ func (p *paymentService) CreatePayosTransaction(req request.CreatePayosTransacti
 		ReturnUrl:   returnUrl,
 		CancelUrl:   cancelUrl,
 	})
+}
 
+// Cancel a PayOS payment link which is no longer backed by a payment, a failure is only logged since an unpaid link
+// expires on its own
+func cancelPayosPaymentLink(orderCode int64, logger *log.Logger) {
+	if _, err := payos.CancelPaymentLink(fmt.Sprint(orderCode), nil); err != nil {
+		logger.Println(fmt.Sprintf("Cancel PayOS payment link %d - ", orderCode) + err.Error())
+	}
+}
+
+// GetCustomerPaymentSummary implements businesslogic.IPaymentService.
+func (p *paymentService) GetCustomerPaymentSummary(req request.GetCustomerPaymentSummaryRequest, ctx context.Context) (*response.CustomerPaymentSummaryResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	from, to, err := utils.GenerateDatePeriod(req.From, req.To)
 	if err != nil {
-		p.logger.Printf("Failed to create PayOS link: %v", err)
-		return response.UrlResponse{}, fmt.Errorf("failed to create payment link: %v", err)
+		return nil, err
 	}
 
-	p.logger.Println("Payos link: ", data.CheckoutUrl)
+	req.From, req.To = from, to
+	req.Status = strings.ToUpper(req.Status)
+	req.PageSize = 10
 
-	return response.UrlResponse{
-		Url: data.CheckoutUrl,
+	user, err := p.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
+		CustomerId: int32(req.CustomerId),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New(noti.GENERIC_ERROR_WARN_MSG)
+	}
+
+	summary, err := p.paymentRepo.GetCustomerSpendingSummary(req.CustomerId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, pages, totalRecords, err := p.paymentRepo.GetCustomerPaymentHistory(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A customer often books the same tour again, so each service is looked up once
+	var serviceNames map[int]string = make(map[int]string)
+	var history []response.PaymentHistoryResponse = []response.PaymentHistoryResponse{}
+	for _, payment := range *payments {
+		serviceName, isExisted := serviceNames[payment.ServiceId]
+		if !isExisted {
+			if serviceInfo, _ := p.tourService.GetTourById(ctx, &tour_pb.TourServiceIdRequest{
+				ServiceId: int32(payment.ServiceId),
+			}); serviceInfo != nil {
+				serviceName = serviceInfo.ServiceName
+			}
+
+			serviceNames[payment.ServiceId] = serviceName
+		}
+
+		history = append(history, response.PaymentHistoryResponse{
+			Payment:     payment,
+			ServiceName: serviceName,
+		})
+	}
+
+	return &response.CustomerPaymentSummaryResponse{
+		Summary: *summary,
+		History: response.PaginationDataResponse{
+			Data:        history,
+			Page:        req.Request.Page,
+			TotalPages:  pages,
+			TotalCount:  totalRecords,
+			PerPage:     req.PageSize,
+			HasNext:     req.Request.Page < pages,
+			HasPrevious: req.Request.Page > 1,
+		},
 	}, nil
 }
 
@@ -0,0 +1,337 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	mail_const "tourmate/payment-service/constant/mail_const"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/constant/wallet"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/utils"
+
+	"github.com/payOSHQ/payos-lib-golang"
+)
+
+// Shared by every service which takes money so that a paid payment is recorded the same way whichever way it was paid
+type paymentRecorder struct {
+	logger           *log.Logger
+	userService      business_logic.IUserService
+	paymentRepo      repo.IPaymentRepo
+	revenueRepo      repo.IRevenueRepo
+	agencyRepo       repo.IAgencyRepo
+	subscriptionRepo repo.ISubscriptionRepo
+	gatewayFeeRepo   repo.IGatewayFeeRepo
+	ledgerRepo       repo.ILedgerRepo
+	loyaltyRepo      repo.ILoyaltyRepo
+	referralRepo     repo.IReferralRepo
+	walletRepo       repo.IWalletRepo
+	splitPaymentRepo repo.ISplitPaymentRepo
+}
+
+func initializePaymentRecorder(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) *paymentRecorder {
+	return &paymentRecorder{
+		logger:           logger,
+		userService:      userService,
+		paymentRepo:      repository.InitializePaymentRepo(db, logger),
+		revenueRepo:      repository.InitializeRevenueRepo(db, logger),
+		agencyRepo:       repository.InitializeAgencyRepo(db, logger),
+		subscriptionRepo: repository.InitializeSubscriptionRepo(db, logger),
+		gatewayFeeRepo:   repository.InitializeGatewayFeeRepo(db, logger),
+		ledgerRepo:       repository.InitializeLedgerRepo(db, logger),
+		loyaltyRepo:      repository.InitializeLoyaltyRepo(db, logger),
+		referralRepo:     repository.InitializeReferralRepo(db, logger),
+		walletRepo:       repository.InitializeWalletRepo(db, logger),
+		splitPaymentRepo: repository.InitializeSplitPaymentRepo(db, logger),
+	}
+}
+
+// Record a completed payment with the revenue of the tour guide and its journal entry, then notify the customer
+func (r *paymentRecorder) createPaidPayment(req request.CreatePaymentRequest, ctx context.Context) (*entity.Payment, error) {
+	var curTime time.Time = time.Now()
+	res, err := r.paymentRepo.CreatePayment(entity.Payment{
+		CustomerId:    req.CustomerId,
+		InvoiceId:     req.InvoiceId,
+		ServiceId:     req.ServiceId,
+		Price:         req.Price,
+		PaymentMethod: req.PaymentMethod,
+		CreatedAt:     curTime,
+		Status:        domain_status.PAYMENT_PAID,
+	}, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordPaymentRevenue(*res, req.TourGuideId, ctx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Record the gateway fee, the revenue of the tour guide and their agency, the journal entry, the loyalty points and the referral rewards
+// of a payment which has just been paid, then notify the customer
+func (r *paymentRecorder) recordPaymentRevenue(payment entity.Payment, tourGuideId int, ctx context.Context) error {
+	paymentFee, err := recordPaymentFee(r.gatewayFeeRepo, r.ledgerRepo, payment, ctx)
+	if err != nil {
+		return err
+	}
+
+	revenue, agencyRevenue, err := splitPaymentRevenue(r.agencyRepo, r.subscriptionRepo, r.userService, payment, paymentFee, tourGuideId, ctx)
+	if err != nil {
+		return err
+	}
+
+	// Every step below is skipped when an earlier attempt has already done it so that a failed confirmation can be retried
+	existedRevenue, err := r.revenueRepo.GetRevenueByPaymentId(payment.PaymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if existedRevenue != nil {
+		revenue.RevenueId = existedRevenue.RevenueId
+	} else {
+		revenue.RevenueId, err = r.revenueRepo.CreateRevenue(revenue, ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if agencyRevenue != nil {
+		existedAgencyRevenues, err := r.agencyRepo.GetAgencyRevenuesByPaymentId(payment.PaymentId, ctx)
+		if err != nil {
+			return err
+		}
+
+		agencyRevenue.RevenueId = revenue.RevenueId
+		if existedAgencyRevenues == nil || len(*existedAgencyRevenues) == 0 {
+			if _, err := r.agencyRepo.CreateAgencyRevenue(*agencyRevenue, ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := postPaymentLedgerEntry(r.ledgerRepo, payment, revenue, agencyRevenue, ctx); err != nil {
+		return err
+	}
+
+	if err := earnLoyaltyPoints(r.loyaltyRepo, payment, ctx); err != nil {
+		return err
+	}
+
+	if err := rewardReferrals(r.referralRepo, r.walletRepo, r.ledgerRepo, payment, revenue, ctx); err != nil {
+		return err
+	}
+
+	userInfo, _ := r.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
+		CustomerId: int32(payment.CustomerId),
+	})
+
+	if userInfo != nil {
+		utils.SendMail(request.SendMailRequest{
+			Body: request.MailBody{ // Mail body
+				Subject:       noti.NOTI_PAYMENT_MAIL_SUBJECT,
+				Email:         userInfo.Email,
+				Username:      userInfo.FullName,
+				TransactionId: payment.InvoiceId,
+			},
+			TemplatePath: mail_const.PAYMENT_CALLBACK_CANCEL_TEMPLATE,
+			Logger:       r.logger, // Logger
+		})
+	}
+
+	return nil
+}
+
+// Hold the invoice with a pending payment before any money is taken, a second request for the same invoice is refused
+// until the payment is cancelled
+func (r *paymentRecorder) claimInvoice(payment entity.Payment, ctx context.Context) (*entity.Payment, error) {
+	payment.CreatedAt = time.Now()
+	payment.Status = domain_status.PAYMENT_PENDING
+	return r.paymentRepo.ClaimInvoicePayment(payment, ctx)
+}
+
+// Mark a claimed payment as paid and record its revenue
+func (r *paymentRecorder) completeClaimedPayment(payment *entity.Payment, tourGuideId int, ctx context.Context) error {
+	payment.Status = domain_status.PAYMENT_PAID
+	if err := r.paymentRepo.UpdatePayment(*payment, ctx); err != nil {
+		return err
+	}
+
+	return r.recordPaymentRevenue(*payment, tourGuideId, ctx)
+}
+
+// Cancel a claimed payment so that the invoice can be paid again, the caller gives the money taken for it back
+func (r *paymentRecorder) releaseInvoice(payment entity.Payment, ctx context.Context) {
+	payment.Status = domain_status.PAYMENT_CANCELLED
+	r.paymentRepo.UpdatePayment(payment, ctx)
+}
+
+// Create the payment link of the rest of a claimed invoice and record it as a split payment of the claimed payment. The
+// link is cancelled again when the split payment cannot be recorded
+func (r *paymentRecorder) openSplitPayment(payment entity.Payment, splitPayment entity.SplitPayment, linkAmount int, ctx context.Context) (*entity.SplitPayment, error) {
+	var orderCode int64 = int64(utils.GenerateNumber())
+	checkoutData, err := createPayosPaymentLink(orderCode, linkAmount, utils.GenerateInvoiceNote(payment.InvoiceId), r.logger)
+	if err != nil {
+		r.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	splitPayment.PaymentId = payment.PaymentId
+	splitPayment.OrderCode = orderCode
+	splitPayment.CheckoutUrl = checkoutData.CheckoutUrl
+	splitPayment.Status = domain_status.SPLIT_PAYMENT_PENDING
+	splitPayment.CreatedAt = time.Now()
+	splitPayment.SplitPaymentId, err = r.splitPaymentRepo.CreateSplitPayment(splitPayment, ctx)
+	if err != nil {
+		cancelPayosPaymentLink(orderCode, r.logger)
+		return nil, err
+	}
+
+	return &splitPayment, nil
+}
+
+// A new split of the invoice waits until the previous one is settled so that the invoice is not paid twice
+func (r *paymentRecorder) checkPendingSplitPayment(invoiceId int, ctx context.Context) error {
+	splitPayment, err := r.splitPaymentRepo.GetPendingSplitPaymentByInvoiceId(invoiceId, ctx)
+	if err != nil || splitPayment == nil {
+		return err
+	}
+
+	res, err := r.settleSplitPayment(*splitPayment, ctx)
+	if err != nil {
+		return err
+	}
+
+	switch res.Status {
+	case domain_status.SPLIT_PAYMENT_PENDING:
+		return errors.New(noti.SPLIT_PAYMENT_PENDING_WARN_MSG)
+	case domain_status.SPLIT_PAYMENT_PAID:
+		return errors.New(noti.INVOICE_ALREADY_PAID_WARN_MSG)
+	default:
+		return nil
+	}
+}
+
+// Check the payment link of a pending split payment. A paid link records the held part as paid, a cancelled or expired
+// one gives the held part back, a split payment still waiting for its link is returned as it is
+func (r *paymentRecorder) settleSplitPayment(splitPayment entity.SplitPayment, ctx context.Context) (*entity.SplitPayment, error) {
+	if splitPayment.Status != domain_status.SPLIT_PAYMENT_PENDING {
+		return &splitPayment, nil
+	}
+
+	payment, err := r.paymentRepo.GetPaymentById(splitPayment.PaymentId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Payment{}.GetPaymentTable()))
+	}
+
+	link, err := payos.GetPaymentLinkInformation(strconv.FormatInt(splitPayment.OrderCode, 10))
+	if err != nil {
+		r.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	switch link.Status {
+	case wallet.PAYOS_PAID_STATUS:
+		// The split payment is taken first so that the held part is recorded once
+		splitPayment.Status = domain_status.SPLIT_PAYMENT_PAID
+		splitPayment.CompletedAt = &curTime
+		if err := r.splitPaymentRepo.UpdateSplitPayment(splitPayment, domain_status.SPLIT_PAYMENT_PENDING, ctx); err != nil {
+			return nil, err
+		}
+
+		payment.Status = domain_status.PAYMENT_PAID
+		if err := r.paymentRepo.UpdatePayment(*payment, ctx); err != nil {
+			r.reopenSplitPayment(splitPayment, ctx)
+			return nil, err
+		}
+
+		if err := r.recordPaymentRevenue(*payment, splitPayment.TourGuideId, ctx); err != nil {
+			payment.Status = domain_status.PAYMENT_PENDING
+			r.paymentRepo.UpdatePayment(*payment, ctx)
+			r.reopenSplitPayment(splitPayment, ctx)
+			return nil, err
+		}
+
+		return &splitPayment, nil
+	case wallet.PAYOS_CANCELLED_STATUS, wallet.PAYOS_EXPIRED_STATUS:
+		splitPayment.Status = domain_status.SPLIT_PAYMENT_RELEASED
+		splitPayment.CompletedAt = &curTime
+		if err := r.splitPaymentRepo.UpdateSplitPayment(splitPayment, domain_status.SPLIT_PAYMENT_PENDING, ctx); err != nil {
+			return nil, err
+		}
+
+		payment.Status = domain_status.PAYMENT_CANCELLED
+		if link.Status == wallet.PAYOS_EXPIRED_STATUS {
+			payment.Status = domain_status.PAYMENT_EXPIRED
+		}
+
+		if err := r.paymentRepo.UpdatePayment(*payment, ctx); err != nil {
+			r.reopenSplitPayment(splitPayment, ctx)
+			return nil, err
+		}
+
+		if err := r.releaseSplitPaymentHold(*payment, splitPayment, ctx); err != nil {
+			payment.Status = domain_status.PAYMENT_PENDING
+			r.paymentRepo.UpdatePayment(*payment, ctx)
+			r.reopenSplitPayment(splitPayment, ctx)
+			return nil, err
+		}
+
+		return &splitPayment, nil
+	default:
+		return &splitPayment, nil
+	}
+}
+
+// Give back the loyalty points or the wallet money held by the payment of a split payment
+func (r *paymentRecorder) releaseSplitPaymentHold(payment entity.Payment, splitPayment entity.SplitPayment, ctx context.Context) error {
+	switch payment.PaymentMethod {
+	case payment_method.LOYALTY_POINT:
+		return restoreLoyaltyPoints(r.loyaltyRepo, r.ledgerRepo, entity.LoyaltyTransaction{
+			CustomerId:  payment.CustomerId,
+			Points:      -splitPayment.Points,
+			Amount:      -payment.Price,
+			ReferenceId: &payment.InvoiceId,
+			Description: fmt.Sprintf("Discount of invoice %d", payment.InvoiceId),
+		}, systemActorId, ctx)
+	case payment_method.WALLET:
+		_, err := changeWalletBalance(r.walletRepo, r.ledgerRepo, entity.WalletTransaction{
+			CustomerId:      payment.CustomerId,
+			TransactionType: wallet.REVERSAL,
+			Amount:          payment.Price,
+			ReferenceId:     &payment.InvoiceId,
+			Description:     fmt.Sprintf("Released payment of invoice %d", payment.InvoiceId),
+			CreatedBy:       systemActorId,
+		}, ctx)
+
+		return err
+	default:
+		return nil
+	}
+}
+
+// Put a split payment back to pending after settling it failed so that it is settled again later
+func (r *paymentRecorder) reopenSplitPayment(splitPayment entity.SplitPayment, ctx context.Context) {
+	var currentStatus string = splitPayment.Status
+	splitPayment.Status = domain_status.SPLIT_PAYMENT_PENDING
+	splitPayment.CompletedAt = nil
+	r.splitPaymentRepo.UpdateSplitPayment(splitPayment, currentStatus, ctx)
+}
@@ -0,0 +1,637 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	payout_schedule "tourmate/payment-service/constant/payout_schedule"
+	"tourmate/payment-service/infrastructure/bank"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+// Actor of the batches created by the payout scheduler
+const systemActorId int = 0
+
+type payoutService struct {
+	logger            *log.Logger
+	userService       business_logic.IUserService
+	payoutRepo        repo.IPayoutRepo
+	payoutAccountRepo repo.IPayoutAccountRepo
+	payoutPolicyRepo  repo.IPayoutPolicyRepo
+	revenueRepo       repo.IRevenueRepo
+	ledgerRepo        repo.ILedgerRepo
+	taxRepo           repo.ITaxRepo
+	referralRepo      repo.IReferralRepo
+}
+
+func InitializePayoutService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IPayoutService {
+	return &payoutService{
+		logger:            logger,
+		userService:       userService,
+		payoutRepo:        repository.InitializePayoutRepo(db, logger),
+		payoutAccountRepo: repository.InitializePayoutAccountRepo(db, logger),
+		payoutPolicyRepo:  repository.InitializePayoutPolicyRepo(db, logger),
+		revenueRepo:       repository.InitializeRevenueRepo(db, logger),
+		ledgerRepo:        repository.InitializeLedgerRepo(db, logger),
+		taxRepo:           repository.InitializeTaxRepo(db, logger),
+		referralRepo:      repository.InitializeReferralRepo(db, logger),
+	}
+}
+
+func GeneratePayoutService() (business_logic.IPayoutService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializePayoutService(cnn, userService, logger), nil
+}
+
+// GetPayoutBatches implements businesslogic.IPayoutService.
+func (p *payoutService) GetPayoutBatches(req request.GetPayoutBatchesRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.PayoutBatch{}.GetPayoutBatchLimitRecords()
+
+	data, pages, totalRecords, err := p.payoutRepo.GetPayoutBatches(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetPayoutBatch implements businesslogic.IPayoutService.
+func (p *payoutService) GetPayoutBatch(id int, ctx context.Context) (*response.PayoutBatchResponse, error) {
+	batch, err := p.getPayoutBatch(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := p.payoutRepo.GetPayoutItems(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	withholdings, err := p.taxRepo.GetTaxWithholdingsByPayoutBatchId(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var taxByItem map[int]float64 = make(map[int]float64)
+	for _, withholding := range *withholdings {
+		taxByItem[withholding.PayoutItemId] = withholding.TaxAmount
+	}
+
+	var res []response.PayoutItemResponse
+	for _, item := range *items {
+		revenueIds, err := p.payoutRepo.GetPayoutItemRevenueIds(item.PayoutItemId, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		rewardIds, err := p.referralRepo.GetReferralRewardIdsByPayoutItemId(item.PayoutItemId, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		accountNumber, err := decryptAccountNumber(item.AccountNumber, p.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		var tourguideName string
+		if tourguideInfo, _ := p.userService.GetTourGuideById(ctx, &user_pb.GetTourGuideByIdRequest{
+			TourGuideId: int32(item.TourGuideId),
+		}); tourguideInfo != nil {
+			tourguideName = tourguideInfo.FullName
+		}
+
+		res = append(res, response.PayoutItemResponse{
+			PayoutItemId:  item.PayoutItemId,
+			TourGuideId:   item.TourGuideId,
+			TourGuideName: tourguideName,
+			Amount:        item.Amount,
+			TaxWithheld:   taxByItem[item.PayoutItemId],
+			BankCode:      item.BankCode,
+			AccountNumber: accountNumber,
+			AccountHolder: item.AccountHolder,
+			Reference:     item.Reference,
+			Status:        item.Status,
+			FailureReason: item.FailureReason,
+			ProcessedAt:   item.ProcessedAt,
+			RevenueIds:    revenueIds,
+			RewardIds:     rewardIds,
+		})
+	}
+
+	return &response.PayoutBatchResponse{
+		Batch: *batch,
+		Items: res,
+	}, nil
+}
+
+// CreatePayoutBatch implements businesslogic.IPayoutService.
+func (p *payoutService) CreatePayoutBatch(req request.CreatePayoutBatchRequest, ctx context.Context) (*response.PayoutBatchResponse, error) {
+	var isGuideIncluded map[int]bool = make(map[int]bool)
+	for _, tourGuideId := range req.TourGuideIds {
+		if isGuideIncluded[tourGuideId] {
+			return nil, errors.New(noti.GENERIC_ERROR_WARN_MSG)
+		}
+		isGuideIncluded[tourGuideId] = true
+	}
+
+	policy, err := getPayoutPolicy(p.payoutPolicyRepo, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.createPayoutBatch(req.TourGuideIds, req.CreatedBy, req.Note, *policy, true, ctx)
+}
+
+// CreateScheduledPayoutBatch implements businesslogic.IPayoutService.
+func (p *payoutService) CreateScheduledPayoutBatch(ctx context.Context) (*response.PayoutBatchResponse, error) {
+	policy, err := getPayoutPolicy(p.payoutPolicyRepo, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Policy has never been configured or auto payout is turned off
+	if policy.PayoutPolicyId == 0 || !policy.IsAutoPayoutEnabled {
+		return nil, nil
+	}
+
+	var curTime time.Time = time.Now()
+	var from time.Time = policy.UpdatedAt
+	if policy.LastRunAt != nil {
+		from = *policy.LastRunAt
+	}
+
+	if utils.GetNextPayoutDate(policy.Schedule, policy.PayoutDay, policy.LastRunAt, from).After(curTime) {
+		return nil, nil
+	}
+
+	var cutoff time.Time = getPayableCutoff(*policy, curTime)
+	tourGuideIds, err := p.revenueRepo.GetPayableTourGuideIds(cutoff, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Guides who only earned referral rewards are paid as well
+	referrerIds, err := p.referralRepo.GetPayableReferrerIds(cutoff, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var isGuideIncluded map[int]bool = make(map[int]bool)
+	for _, tourGuideId := range tourGuideIds {
+		isGuideIncluded[tourGuideId] = true
+	}
+
+	for _, referrerId := range referrerIds {
+		if !isGuideIncluded[referrerId] {
+			tourGuideIds = append(tourGuideIds, referrerId)
+		}
+	}
+
+	var res *response.PayoutBatchResponse
+	if len(tourGuideIds) > 0 {
+		res, err = p.createPayoutBatch(tourGuideIds, systemActorId, "Scheduled payout "+curTime.Format("02/01/2006"), *policy, false, ctx)
+
+		// Nobody reaches the threshold this cycle, wait for the next one
+		if err != nil && err.Error() != noti.NO_PAYABLE_REVENUE_WARN_MSG {
+			return nil, err
+		}
+	}
+
+	policy.LastRunAt = &curTime
+
+	return res, p.payoutPolicyRepo.UpdatePayoutPolicy(*policy, ctx)
+}
+
+// GetPayoutPolicy implements businesslogic.IPayoutService.
+func (p *payoutService) GetPayoutPolicy(ctx context.Context) (*entity.PayoutPolicy, error) {
+	return getPayoutPolicy(p.payoutPolicyRepo, ctx)
+}
+
+// UpdatePayoutPolicy implements businesslogic.IPayoutService.
+func (p *payoutService) UpdatePayoutPolicy(req request.UpdatePayoutPolicyRequest, ctx context.Context) (*entity.PayoutPolicy, error) {
+	if (req.Schedule == payout_schedule.MONTHLY && req.PayoutDay < 1) || (req.Schedule != payout_schedule.MONTHLY && req.PayoutDay > 6) {
+		return nil, errors.New(noti.INVALID_PAYOUT_DAY_WARN_MSG)
+	}
+
+	policy, err := getPayoutPolicy(p.payoutPolicyRepo, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.MinPayableBalance = req.MinPayableBalance
+	policy.HoldDays = req.HoldDays
+	policy.Schedule = req.Schedule
+	policy.PayoutDay = req.PayoutDay
+	policy.IsAutoPayoutEnabled = req.IsAutoPayoutEnabled
+	policy.UpdatedBy = req.ActorId
+	policy.UpdatedAt = time.Now()
+
+	if policy.PayoutPolicyId != 0 {
+		return policy, p.payoutPolicyRepo.UpdatePayoutPolicy(*policy, ctx)
+	}
+
+	id, err := p.payoutPolicyRepo.CreatePayoutPolicy(*policy, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.PayoutPolicyId = id
+
+	return policy, nil
+}
+
+// ApprovePayoutBatch implements businesslogic.IPayoutService.
+func (p *payoutService) ApprovePayoutBatch(req request.PayoutBatchActionRequest, ctx context.Context) error {
+	batch, err := p.getPayoutBatch(req.PayoutBatchId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if batch.Status != domain_status.PAYOUT_BATCH_DRAFT {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	// Four-eyes principle
+	if batch.CreatedBy == req.ActorId {
+		return errors.New(noti.SAME_ACTOR_APPROVAL_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	batch.Status = domain_status.PAYOUT_BATCH_APPROVED
+	batch.ApprovedBy = &req.ActorId
+	batch.ApprovedAt = &curTime
+	batch.UpdatedAt = curTime
+
+	return p.payoutRepo.UpdatePayoutBatch(*batch, ctx)
+}
+
+// CancelPayoutBatch implements businesslogic.IPayoutService.
+func (p *payoutService) CancelPayoutBatch(req request.PayoutBatchActionRequest, ctx context.Context) error {
+	batch, err := p.getPayoutBatch(req.PayoutBatchId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if batch.Status != domain_status.PAYOUT_BATCH_DRAFT && batch.Status != domain_status.PAYOUT_BATCH_APPROVED {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	items, err := p.payoutRepo.GetPayoutItems(batch.PayoutBatchId, ctx)
+	if err != nil {
+		return err
+	}
+
+	// Release the revenues held by the items
+	for _, item := range *items {
+		item.Status = domain_status.PAYOUT_ITEM_CANCELLED
+		if err := p.payoutRepo.UpdatePayoutItem(item, ctx); err != nil {
+			return err
+		}
+	}
+
+	batch.Status = domain_status.PAYOUT_BATCH_CANCELLED
+	batch.UpdatedAt = time.Now()
+
+	return p.payoutRepo.UpdatePayoutBatch(*batch, ctx)
+}
+
+// ExportPayoutBatch implements businesslogic.IPayoutService.
+func (p *payoutService) ExportPayoutBatch(req request.ExportPayoutBatchRequest, ctx context.Context) (response.FileResponse, error) {
+	bankFile, err := bank.GenerateBankTransferFile(req.Bank)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	batch, err := p.getPayoutBatch(req.PayoutBatchId, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	if batch.Status != domain_status.PAYOUT_BATCH_APPROVED && batch.Status != domain_status.PAYOUT_BATCH_EXPORTED {
+		return response.FileResponse{}, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	items, err := p.payoutRepo.GetPayoutItems(batch.PayoutBatchId, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	var transferItems []request.BankTransferItem
+	for _, item := range *items {
+		if item.Status != domain_status.PAYOUT_ITEM_PENDING {
+			continue
+		}
+
+		accountNumber, err := decryptAccountNumber(item.AccountNumber, p.logger)
+		if err != nil {
+			return response.FileResponse{}, err
+		}
+
+		transferItems = append(transferItems, request.BankTransferItem{
+			Reference:     item.Reference,
+			BankCode:      item.BankCode,
+			AccountNumber: accountNumber,
+			AccountHolder: item.AccountHolder,
+			Amount:        item.Amount,
+			Note:          "TourMate payout " + item.Reference,
+		})
+	}
+
+	headers, rows := bankFile.GenerateTransferRows(transferItems)
+	content, contentType, err := utils.GenerateTabularFile(req.Format, "Payout", headers, rows)
+	if err != nil {
+		p.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, req.Format) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	if batch.Status != domain_status.PAYOUT_BATCH_EXPORTED {
+		batch.Status = domain_status.PAYOUT_BATCH_EXPORTED
+		batch.UpdatedAt = time.Now()
+
+		if err := p.payoutRepo.UpdatePayoutBatch(*batch, ctx); err != nil {
+			return response.FileResponse{}, err
+		}
+	}
+
+	return response.FileResponse{
+		FileName:    fmt.Sprintf("payout_%d_%s.%s", batch.PayoutBatchId, strings.ToLower(req.Bank), req.Format),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// ImportPayoutResult implements businesslogic.IPayoutService.
+func (p *payoutService) ImportPayoutResult(req request.ImportPayoutResultRequest, ctx context.Context) (*response.PayoutImportResponse, error) {
+	bankFile, err := bank.GenerateBankTransferFile(req.Bank)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := p.getPayoutBatch(req.PayoutBatchId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch.Status != domain_status.PAYOUT_BATCH_EXPORTED {
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	rows, err := utils.ReadTabularFile(req.FileName, req.Content)
+	if err != nil {
+		p.logger.Println(fmt.Sprintf(noti.FILE_READ_ERR_MSG, req.FileName) + err.Error())
+		return nil, errors.New(noti.INVALID_FILE_CONTENT_WARN_MSG)
+	}
+
+	results, err := bankFile.ParseResultRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := p.payoutRepo.GetPayoutItems(batch.PayoutBatchId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var itemByReference map[string]*entity.PayoutItem = make(map[string]*entity.PayoutItem)
+	for i := range *items {
+		itemByReference[(*items)[i].Reference] = &(*items)[i]
+	}
+
+	var res response.PayoutImportResponse
+	var curTime time.Time = time.Now()
+	for _, result := range results {
+		item, isExist := itemByReference[result.Reference]
+		if !isExist || item.Status != domain_status.PAYOUT_ITEM_PENDING {
+			res.UnmatchedLines = append(res.UnmatchedLines, result.Reference)
+			continue
+		}
+
+		item.ProcessedAt = &curTime
+		if result.IsSuccess {
+			item.Status = domain_status.PAYOUT_ITEM_PAID
+			res.PaidItems++
+		} else {
+			item.Status = domain_status.PAYOUT_ITEM_FAILED
+			item.FailureReason = result.Message
+			res.FailedItems++
+		}
+
+		// The bank has already sent the money, so the transfer is journaled first and a failed import can be retried
+		if item.Status == domain_status.PAYOUT_ITEM_PAID {
+			withholding, err := p.taxRepo.GetTaxWithholdingByPayoutItemId(item.PayoutItemId, ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := postPayoutLedgerEntry(p.ledgerRepo, *item, withholding, ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := p.payoutRepo.ProcessPayoutItem(*item, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Batch is done when the bank has answered for every item
+	var isCompleted bool = true
+	for _, item := range *items {
+		if item.Status == domain_status.PAYOUT_ITEM_PENDING {
+			isCompleted = false
+			break
+		}
+	}
+
+	if isCompleted {
+		batch.Status = domain_status.PAYOUT_BATCH_COMPLETED
+		batch.UpdatedAt = curTime
+
+		if err := p.payoutRepo.UpdatePayoutBatch(*batch, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	res.BatchStatus = batch.Status
+
+	return &res, nil
+}
+
+func (p *payoutService) getPayoutBatch(id int, ctx context.Context) (*entity.PayoutBatch, error) {
+	batch, err := p.payoutRepo.GetPayoutBatchById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PayoutBatch{}.GetPayoutBatchTable()))
+	}
+
+	return batch, nil
+}
+
+// Create a draft batch for the given guides. When isStrict is set, a guide without a verified account
+// fails the whole batch, otherwise the guide is skipped until the account is verified.
+func (p *payoutService) createPayoutBatch(tourGuideIds []int, createdBy int, note string, policy entity.PayoutPolicy, isStrict bool, ctx context.Context) (*response.PayoutBatchResponse, error) {
+	var curTime time.Time = time.Now()
+	var cutoff time.Time = getPayableCutoff(policy, curTime)
+	var items []entity.PayoutItem
+	var revenueIds [][]int
+	var adjustmentIds [][]int
+	var rewardIds [][]int
+	var withholdings []entity.TaxWithholding
+	var totalAmount float64
+
+	for _, tourGuideId := range tourGuideIds {
+		// Money is only sent to accounts verified by admins
+		account, err := p.payoutAccountRepo.GetVerifiedPayoutAccount(tourGuideId, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if account == nil {
+			if isStrict {
+				return nil, errors.New(fmt.Sprintf(noti.UNVERIFIED_PAYOUT_ACCOUNT_WARN_MSG, tourGuideId))
+			}
+
+			continue
+		}
+
+		revenues, err := p.revenueRepo.GetPayableRevenues(tourGuideId, cutoff, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Adjustments made after a revenue was paid out are netted into this payout
+		adjustments, err := p.revenueRepo.GetPayableRevenueAdjustments(tourGuideId, cutoff, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		rewards, err := p.referralRepo.GetPayableReferralRewards(tourGuideId, cutoff, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var amount float64
+		var ids []int
+		for _, revenue := range *revenues {
+			amount += revenue.ActualReceived
+			ids = append(ids, revenue.RevenueId)
+		}
+
+		var guideAdjustmentIds []int
+		for _, adjustment := range *adjustments {
+			amount += adjustment.ActualReceivedDelta
+			guideAdjustmentIds = append(guideAdjustmentIds, adjustment.RevenueAdjustmentId)
+		}
+
+		var guideRewardIds []int
+		for _, reward := range *rewards {
+			amount += reward.Amount
+			guideRewardIds = append(guideRewardIds, reward.ReferralRewardId)
+		}
+
+		// Tiny balances wait for the next cycle to save bank fees
+		if amount <= 0 || amount < policy.MinPayableBalance {
+			continue
+		}
+
+		withholding, err := calculateTaxWithholding(p.taxRepo, tourGuideId, amount, curTime, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Snapshot of the account at creation, the number stays encrypted. The bank transfers the amount after tax
+		items = append(items, entity.PayoutItem{
+			TourGuideId:   tourGuideId,
+			Amount:        utils.RoundMoney(amount - withholding.TaxAmount),
+			BankCode:      account.BankCode,
+			AccountNumber: account.AccountNumber,
+			AccountHolder: account.AccountHolder,
+			Status:        domain_status.PAYOUT_ITEM_PENDING,
+			CreatedAt:     curTime,
+		})
+		revenueIds = append(revenueIds, ids)
+		adjustmentIds = append(adjustmentIds, guideAdjustmentIds)
+		rewardIds = append(rewardIds, guideRewardIds)
+		withholdings = append(withholdings, withholding)
+		totalAmount += amount - withholding.TaxAmount
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New(noti.NO_PAYABLE_REVENUE_WARN_MSG)
+	}
+
+	id, err := p.payoutRepo.CreatePayoutBatch(entity.PayoutBatch{
+		Status:      domain_status.PAYOUT_BATCH_DRAFT,
+		TotalAmount: utils.RoundMoney(totalAmount),
+		ItemCount:   len(items),
+		Note:        note,
+		CreatedBy:   createdBy,
+		CreatedAt:   curTime,
+		UpdatedAt:   curTime,
+	}, items, revenueIds, adjustmentIds, rewardIds, withholdings, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetPayoutBatch(id, ctx)
+}
+
+// Get the configured policy or the default one when it has never been configured
+func getPayoutPolicy(payoutPolicyRepo repo.IPayoutPolicyRepo, ctx context.Context) (*entity.PayoutPolicy, error) {
+	policy, err := payoutPolicyRepo.GetPayoutPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		policy = &entity.PayoutPolicy{
+			Schedule:  payout_schedule.WEEKLY,
+			PayoutDay: int(time.Monday),
+		}
+	}
+
+	return policy, nil
+}
+
+// Revenues created after the cutoff are still in the hold period
+func getPayableCutoff(policy entity.PayoutPolicy, curTime time.Time) time.Time {
+	return curTime.AddDate(0, 0, -policy.HoldDays)
+}
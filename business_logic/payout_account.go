@@ -0,0 +1,324 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type payoutAccountService struct {
+	logger            *log.Logger
+	payoutAccountRepo repo.IPayoutAccountRepo
+}
+
+func InitializePayoutAccountService(db *sql.DB, logger *log.Logger) business_logic.IPayoutAccountService {
+	return &payoutAccountService{
+		logger:            logger,
+		payoutAccountRepo: repository.InitializePayoutAccountRepo(db, logger),
+	}
+}
+
+func GeneratePayoutAccountService() (business_logic.IPayoutAccountService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializePayoutAccountService(cnn, logger), nil
+}
+
+// GetPayoutAccounts implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) GetPayoutAccounts(req request.GetPayoutAccountsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.PayoutAccount{}.GetPayoutAccountLimitRecords()
+
+	data, pages, totalRecords, err := p.payoutAccountRepo.GetPayoutAccounts(req, ctx)
+	if err != nil {
+		return response.PaginationDataResponse{}, err
+	}
+
+	// Admins need the full account number to verify it with the bank
+	res, err := p.toPayoutAccountResponses(*data, false)
+	if err != nil {
+		return response.PaginationDataResponse{}, err
+	}
+
+	return response.PaginationDataResponse{
+		Data:        res,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, nil
+}
+
+// GetPayoutAccountsByTourGuide implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) GetPayoutAccountsByTourGuide(tourGuideId int, ctx context.Context) (*[]response.PayoutAccountResponse, error) {
+	data, err := p.payoutAccountRepo.GetPayoutAccountsByTourGuide(tourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.toPayoutAccountResponses(*data, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreatePayoutAccount implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) CreatePayoutAccount(req request.CreatePayoutAccountRequest, ctx context.Context) (*response.PayoutAccountResponse, error) {
+	var bankCode string = strings.ToUpper(strings.TrimSpace(req.BankCode))
+	if !utils.IsBankCodeValid(bankCode) {
+		return nil, errors.New(noti.UNSUPPORTED_BANK_WARN_MSG)
+	}
+
+	existedAccounts, err := p.payoutAccountRepo.GetPayoutAccountsByTourGuide(req.TourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range *existedAccounts {
+		accountNumber, err := decryptAccountNumber(account.AccountNumber, p.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		if account.BankCode == bankCode && accountNumber == req.AccountNumber {
+			return nil, errors.New(noti.ITEM_EXISTED_WARN_MSG)
+		}
+	}
+
+	encryptedNumber, err := encryptAccountNumber(req.AccountNumber, p.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	var account entity.PayoutAccount = entity.PayoutAccount{
+		TourGuideId:   req.TourGuideId,
+		BankCode:      bankCode,
+		AccountNumber: encryptedNumber,
+		AccountHolder: strings.ToUpper(strings.TrimSpace(req.AccountHolder)),
+		Status:        domain_status.PAYOUT_ACCOUNT_PENDING,
+		// The first account is always the default one
+		IsDefault: req.IsDefault || len(*existedAccounts) == 0,
+		CreatedAt: curTime,
+		UpdatedAt: curTime,
+	}
+
+	id, err := p.payoutAccountRepo.CreatePayoutAccount(account, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account.PayoutAccountId = id
+
+	return p.toPayoutAccountResponse(account, true)
+}
+
+// UpdatePayoutAccount implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) UpdatePayoutAccount(req request.UpdatePayoutAccountRequest, ctx context.Context) (*response.PayoutAccountResponse, error) {
+	account, err := p.getTourGuidePayoutAccount(req.Request, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var isDetailChanged bool
+	if req.BankCode != "" {
+		var bankCode string = strings.ToUpper(strings.TrimSpace(req.BankCode))
+		if !utils.IsBankCodeValid(bankCode) {
+			return nil, errors.New(noti.UNSUPPORTED_BANK_WARN_MSG)
+		}
+
+		isDetailChanged = isDetailChanged || account.BankCode != bankCode
+		account.BankCode = bankCode
+	}
+
+	if req.AccountNumber != "" {
+		encryptedNumber, err := encryptAccountNumber(req.AccountNumber, p.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		isDetailChanged = true
+		account.AccountNumber = encryptedNumber
+	}
+
+	if req.AccountHolder != "" {
+		var accountHolder string = strings.ToUpper(strings.TrimSpace(req.AccountHolder))
+		isDetailChanged = isDetailChanged || account.AccountHolder != accountHolder
+		account.AccountHolder = accountHolder
+	}
+
+	if req.IsDefault != nil && *req.IsDefault {
+		account.IsDefault = true
+	}
+
+	// Changed bank details must be verified again
+	if isDetailChanged {
+		account.Status = domain_status.PAYOUT_ACCOUNT_PENDING
+		account.RejectReason = ""
+		account.VerifiedBy = nil
+		account.VerifiedAt = nil
+	}
+
+	account.UpdatedAt = time.Now()
+
+	if err := p.payoutAccountRepo.UpdatePayoutAccount(*account, ctx); err != nil {
+		return nil, err
+	}
+
+	return p.toPayoutAccountResponse(*account, true)
+}
+
+// RemovePayoutAccount implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) RemovePayoutAccount(req request.RemovePayoutAccountRequest, ctx context.Context) error {
+	if _, err := p.getTourGuidePayoutAccount(req, ctx); err != nil {
+		return err
+	}
+
+	return p.payoutAccountRepo.RemovePayoutAccount(req.PayoutAccountId, req.TourGuideId, ctx)
+}
+
+// VerifyPayoutAccount implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) VerifyPayoutAccount(req request.VerifyPayoutAccountRequest, ctx context.Context) error {
+	return p.reviewPayoutAccount(req, domain_status.PAYOUT_ACCOUNT_VERIFIED, ctx)
+}
+
+// RejectPayoutAccount implements businesslogic.IPayoutAccountService.
+func (p *payoutAccountService) RejectPayoutAccount(req request.VerifyPayoutAccountRequest, ctx context.Context) error {
+	if strings.TrimSpace(req.RejectReason) == "" {
+		return errors.New(noti.FIELD_EMPTY_WARN_MSG)
+	}
+
+	return p.reviewPayoutAccount(req, domain_status.PAYOUT_ACCOUNT_REJECTED, ctx)
+}
+
+func (p *payoutAccountService) reviewPayoutAccount(req request.VerifyPayoutAccountRequest, status string, ctx context.Context) error {
+	account, err := p.payoutAccountRepo.GetPayoutAccountById(req.PayoutAccountId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if account == nil {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PayoutAccount{}.GetPayoutAccountTable()))
+	}
+
+	if account.Status != domain_status.PAYOUT_ACCOUNT_PENDING {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	account.Status = status
+	account.VerifiedBy = &req.ActorId
+	account.VerifiedAt = &curTime
+	account.UpdatedAt = curTime
+	if status == domain_status.PAYOUT_ACCOUNT_REJECTED {
+		account.RejectReason = strings.TrimSpace(req.RejectReason)
+	}
+
+	return p.payoutAccountRepo.UpdatePayoutAccount(*account, ctx)
+}
+
+// Get the account and make sure it belongs to the requesting tour guide
+func (p *payoutAccountService) getTourGuidePayoutAccount(req request.RemovePayoutAccountRequest, ctx context.Context) (*entity.PayoutAccount, error) {
+	account, err := p.payoutAccountRepo.GetPayoutAccountById(req.PayoutAccountId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.PayoutAccount{}.GetPayoutAccountTable()))
+	}
+
+	if account.TourGuideId != req.TourGuideId {
+		return nil, errors.New(noti.GENERIC_RIGHT_ACCESS_WARN_MSG)
+	}
+
+	return account, nil
+}
+
+func encryptAccountNumber(accountNumber string, logger *log.Logger) (string, error) {
+	res, err := utils.EncryptString(accountNumber)
+	if err != nil {
+		logger.Println(noti.DATA_ENCRYPTION_ERR_MSG + err.Error())
+		return "", errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+func decryptAccountNumber(accountNumber string, logger *log.Logger) (string, error) {
+	res, err := utils.DecryptString(accountNumber)
+	if err != nil {
+		logger.Println(noti.DATA_DECRYPTION_ERR_MSG + err.Error())
+		return "", errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+func (p *payoutAccountService) toPayoutAccountResponses(accounts []entity.PayoutAccount, isMasked bool) ([]response.PayoutAccountResponse, error) {
+	var res []response.PayoutAccountResponse
+	for _, account := range accounts {
+		x, err := p.toPayoutAccountResponse(account, isMasked)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *x)
+	}
+
+	return res, nil
+}
+
+func (p *payoutAccountService) toPayoutAccountResponse(account entity.PayoutAccount, isMasked bool) (*response.PayoutAccountResponse, error) {
+	accountNumber, err := decryptAccountNumber(account.AccountNumber, p.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if isMasked {
+		accountNumber = utils.MaskAccountNumber(accountNumber)
+	}
+
+	return &response.PayoutAccountResponse{
+		PayoutAccountId: account.PayoutAccountId,
+		TourGuideId:     account.TourGuideId,
+		BankCode:        account.BankCode,
+		AccountNumber:   accountNumber,
+		AccountHolder:   account.AccountHolder,
+		Status:          account.Status,
+		RejectReason:    account.RejectReason,
+		IsDefault:       account.IsDefault,
+		VerifiedBy:      account.VerifiedBy,
+		VerifiedAt:      account.VerifiedAt,
+		CreatedAt:       account.CreatedAt,
+		UpdatedAt:       account.UpdatedAt,
+	}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"log"
 	"time"
+	filter_property "tourmate/payment-service/constant/filter_property"
+	"tourmate/payment-service/constant/order"
 	business_logic "tourmate/payment-service/interface/business_logic"
 	"tourmate/payment-service/interface/repo"
 	"tourmate/payment-service/model/dto/request"
@@ -61,8 +63,8 @@ func (p *platformFeedbackService) GetPlatformFeedbackById(id int, ctx context.Co
 
 // GetPlatformFeedbacks implements businesslogic.IPlatformFeedbackService.
 func (p *platformFeedbackService) GetPlatformFeedbacks(req request.GetPlatformFeedbacksRequest, ctx context.Context) (response.PaginationDataResponse, error) {
-	req.Request.FilterProp = utils.AssignFilterProperty(req.Request.FilterProp)
-	req.Request.Order = utils.AssignOrder(req.Request.Order)
+	req.Request.FilterProp, req.Request.Order = utils.ParseSortSpec(req.Request.FilterProp, req.Request.Order,
+		filter_property.PLATFORM_FEEDBACK_SORT_COLUMNS, "createdAt", order.ASCENDING_ORDER)
 
 	data, pages, totalRecords, err := p.platformFeedbackRepo.GetPlatformFeedbacks(req, ctx)
 
@@ -0,0 +1,415 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/constant/referral"
+	"tourmate/payment-service/constant/wallet"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type referralService struct {
+	logger       *log.Logger
+	userService  business_logic.IUserService
+	referralRepo repo.IReferralRepo
+}
+
+func InitializeReferralService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IReferralService {
+	return &referralService{
+		logger:       logger,
+		userService:  userService,
+		referralRepo: repository.InitializeReferralRepo(db, logger),
+	}
+}
+
+func GenerateReferralService() (business_logic.IReferralService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeReferralService(cnn, userService, logger), nil
+}
+
+// GetReferralCode implements businesslogic.IReferralService.
+func (r *referralService) GetReferralCode(req request.GetReferralCodeRequest, ctx context.Context) (*entity.ReferralCode, error) {
+	res, err := r.referralRepo.GetReferralCodeByOwner(req.OwnerType, req.OwnerId, ctx)
+	if err != nil || res != nil {
+		return res, err
+	}
+
+	code, err := r.generateUniqueCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	res = &entity.ReferralCode{
+		Code:      code,
+		OwnerType: req.OwnerType,
+		OwnerId:   req.OwnerId,
+		IsActive:  true,
+		CreatedAt: curTime,
+		UpdatedAt: curTime,
+	}
+
+	id, err := r.referralRepo.CreateReferralCode(*res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ReferralCodeId = id
+	return res, nil
+}
+
+// UpdateReferralCodeStatus implements businesslogic.IReferralService.
+func (r *referralService) UpdateReferralCodeStatus(req request.UpdateReferralCodeStatusRequest, ctx context.Context) (*entity.ReferralCode, error) {
+	res, err := r.referralRepo.GetReferralCodeById(req.ReferralCodeId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.ReferralCode{}.GetReferralCodeTable()))
+	}
+
+	res.IsActive = req.IsActive
+	res.UpdatedAt = time.Now()
+
+	return res, r.referralRepo.UpdateReferralCode(*res, ctx)
+}
+
+// ClaimReferralCode implements businesslogic.IReferralService.
+func (r *referralService) ClaimReferralCode(req request.ClaimReferralCodeRequest, ctx context.Context) (*entity.Referral, error) {
+	code, err := r.referralRepo.GetReferralCodeByCode(utils.NormalizeReferralCode(req.Code), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if code == nil || !code.IsActive {
+		return nil, errors.New(noti.REFERRAL_CODE_NOT_ACTIVE_WARN_MSG)
+	}
+
+	// The same person may hold a customer and a tour guide account
+	if code.OwnerType == req.RefereeType && code.OwnerId == req.RefereeId {
+		return nil, errors.New(noti.SELF_REFERRAL_WARN_MSG)
+	}
+
+	if ownerPhone := r.getPartyPhone(code.OwnerType, code.OwnerId, ctx); ownerPhone != "" && ownerPhone == r.getPartyPhone(req.RefereeType, req.RefereeId, ctx) {
+		return nil, errors.New(noti.SELF_REFERRAL_WARN_MSG)
+	}
+
+	isNew, err := r.referralRepo.IsNewReferee(req.RefereeType, req.RefereeId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isNew {
+		return nil, errors.New(noti.REFEREE_NOT_NEW_WARN_MSG)
+	}
+
+	var res entity.Referral = entity.Referral{
+		ReferralCodeId: code.ReferralCodeId,
+		ReferrerType:   code.OwnerType,
+		ReferrerId:     code.OwnerId,
+		RefereeType:    req.RefereeType,
+		RefereeId:      req.RefereeId,
+		Status:         domain_status.REFERRAL_PENDING,
+		CreatedAt:      time.Now(),
+	}
+
+	id, err := r.referralRepo.CreateReferral(res, getReferralMaxReferrals(), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ReferralId = id
+	return &res, nil
+}
+
+// GetReferralSummary implements businesslogic.IReferralService.
+func (r *referralService) GetReferralSummary(req request.GetReferralCodeRequest, ctx context.Context) (*response.ReferralSummaryResponse, error) {
+	var res response.ReferralSummaryResponse = response.ReferralSummaryResponse{
+		Referrals:          []entity.Referral{},
+		RemainingReferrals: getReferralMaxReferrals(),
+		RemainingReward:    getReferralMaxRewardAmount(),
+	}
+
+	code, err := r.referralRepo.GetReferralCodeByOwner(req.OwnerType, req.OwnerId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// An owner who has never shared a code has not referred anyone
+	if code == nil {
+		return &res, nil
+	}
+
+	referrals, err := r.referralRepo.GetReferralsByCodeId(code.ReferralCodeId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.PaidReward, res.PendingReward, err = r.referralRepo.GetReferralRewardTotals(req.OwnerType, req.OwnerId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ReferralCode = code
+	if len(*referrals) > 0 {
+		res.Referrals = *referrals
+	}
+
+	res.RemainingReferrals = max(res.RemainingReferrals-len(res.Referrals), 0)
+	res.RemainingReward = max(utils.RoundMoney(res.RemainingReward-res.PaidReward-res.PendingReward), 0)
+
+	return &res, nil
+}
+
+// GetReferralRewards implements businesslogic.IReferralService.
+func (r *referralService) GetReferralRewards(req request.GetReferralRewardsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.ReferralReward{}.GetReferralRewardLimitRecords()
+
+	data, pages, totalRecords, err := r.referralRepo.GetReferralRewards(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// Codes are random, a clash with an existing code is only retried a few times
+func (r *referralService) generateUniqueCode(ctx context.Context) (string, error) {
+	for i := 0; i < referral.MAX_CODE_ATTEMPTS; i++ {
+		code, err := utils.GenerateReferralCode()
+		if err != nil {
+			r.logger.Println(fmt.Sprintf(noti.REPO_ERR_MSG, entity.ReferralCode{}.GetReferralCodeTable()) + "generateUniqueCode - " + err.Error())
+			return "", errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		existedCode, err := r.referralRepo.GetReferralCodeByCode(code, ctx)
+		if err != nil {
+			return "", err
+		}
+
+		if existedCode == nil {
+			return code, nil
+		}
+	}
+
+	return "", errors.New(noti.INTERNALL_ERR_MSG)
+}
+
+// Phone number of the account, empty when the user service cannot tell
+func (r *referralService) getPartyPhone(partyType string, partyId int, ctx context.Context) string {
+	if partyType == referral.TOUR_GUIDE {
+		if tourguideInfo, _ := r.userService.GetTourGuideById(ctx, &user_pb.GetTourGuideByIdRequest{
+			TourGuideId: int32(partyId),
+		}); tourguideInfo != nil {
+			return strings.TrimSpace(tourguideInfo.Phone)
+		}
+
+		return ""
+	}
+
+	if userInfo, _ := r.userService.GetCustomerById(ctx, &user_pb.GetCustomerByIdRequest{
+		CustomerId: int32(partyId),
+	}); userInfo != nil {
+		return strings.TrimSpace(userInfo.Phone)
+	}
+
+	return ""
+}
+
+// Reward the referrers of the customer and of the tour guide of a paid tour
+func rewardReferrals(referralRepo repo.IReferralRepo, walletRepo repo.IWalletRepo, ledgerRepo repo.ILedgerRepo, payment entity.Payment, revenue entity.Revenue, ctx context.Context) error {
+	if err := rewardReferral(referralRepo, walletRepo, ledgerRepo, referral.CUSTOMER, payment.CustomerId, payment, revenue, ctx); err != nil {
+		return err
+	}
+
+	return rewardReferral(referralRepo, walletRepo, ledgerRepo, referral.TOUR_GUIDE, revenue.TourGuideId, payment, revenue, ctx)
+}
+
+// A pending referral is attributed to the first payment of the referee, then the referrer earns a share of the
+// commission of each tour until the referral has rewarded its tours
+func rewardReferral(referralRepo repo.IReferralRepo, walletRepo repo.IWalletRepo, ledgerRepo repo.ILedgerRepo, refereeType string, refereeId int, payment entity.Payment, revenue entity.Revenue, ctx context.Context) error {
+	item, err := referralRepo.GetReferralByReferee(refereeType, refereeId, ctx)
+	if err != nil || item == nil || item.Status == domain_status.REFERRAL_COMPLETED {
+		return err
+	}
+
+	var curTime time.Time = time.Now()
+	if item.Status == domain_status.REFERRAL_PENDING {
+		if err := referralRepo.AttributeReferral(item.ReferralId, payment.PaymentId, curTime, ctx); err != nil {
+			return err
+		}
+	}
+
+	// A referrer earns nothing from a tour it takes part in, and the loyalty discount was already paid by the platform
+	if (item.ReferrerType == referral.CUSTOMER && item.ReferrerId == payment.CustomerId) ||
+		(item.ReferrerType == referral.TOUR_GUIDE && item.ReferrerId == revenue.TourGuideId) ||
+		payment.PaymentMethod == payment_method.LOYALTY_POINT {
+		return nil
+	}
+
+	var rate float64 = getReferralRewardRate()
+	var amount float64 = utils.RoundMoney(revenue.PlatformCommission * rate)
+	if amount <= 0 {
+		return nil
+	}
+
+	// Codes disabled by admins stop earning
+	code, err := referralRepo.GetReferralCodeById(item.ReferralCodeId, ctx)
+	if err != nil || code == nil || !code.IsActive {
+		return err
+	}
+
+	existedRewards, err := referralRepo.GetReferralRewardsByPaymentId(payment.PaymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, existedReward := range *existedRewards {
+		if existedReward.ReferralId == item.ReferralId {
+			return nil
+		}
+	}
+
+	reward, err := referralRepo.CreateReferralReward(entity.ReferralReward{
+		ReferralId:       item.ReferralId,
+		ReferrerType:     item.ReferrerType,
+		ReferrerId:       item.ReferrerId,
+		PaymentId:        payment.PaymentId,
+		InvoiceId:        payment.InvoiceId,
+		RevenueId:        revenue.RevenueId,
+		CommissionAmount: revenue.PlatformCommission,
+		RewardRate:       rate,
+		Amount:           amount,
+		Status:           domain_status.REFERRAL_REWARD_PENDING,
+		CreatedAt:        curTime,
+	}, getReferralRewardedTours(), getReferralMaxRewardAmount(), ctx)
+
+	if err != nil || reward == nil {
+		return err
+	}
+
+	// Tour guides are paid with their next payout
+	if reward.ReferrerType == referral.TOUR_GUIDE {
+		return postReferralLedgerEntry(ledgerRepo, *reward, ctx)
+	}
+
+	transaction, err := changeWalletBalance(walletRepo, ledgerRepo, entity.WalletTransaction{
+		CustomerId:      reward.ReferrerId,
+		TransactionType: wallet.REFERRAL,
+		Amount:          reward.Amount,
+		ReferenceId:     &reward.ReferralRewardId,
+		Description:     fmt.Sprintf("Referral reward of invoice %d", reward.InvoiceId),
+		CreatedBy:       systemActorId,
+	}, ctx)
+
+	if err != nil {
+		return err
+	}
+
+	reward.Status = domain_status.REFERRAL_REWARD_PAID
+	reward.WalletTransactionId = &transaction.WalletTransactionId
+	reward.PaidAt = &curTime
+
+	return referralRepo.UpdateReferralReward(*reward, domain_status.REFERRAL_REWARD_PENDING, ctx)
+}
+
+// Cancel the rewards of a refunded payment which have not been paid yet. Rewards already in the wallet of a customer or
+// held by a payout are kept, and the tour still counts toward the rewarded tours of the referral
+func cancelReferralRewards(referralRepo repo.IReferralRepo, ledgerRepo repo.ILedgerRepo, paymentId, actorId int, ctx context.Context) error {
+	rewards, err := referralRepo.GetReferralRewardsByPaymentId(paymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, reward := range *rewards {
+		if reward.ReferrerType != referral.TOUR_GUIDE || reward.Status != domain_status.REFERRAL_REWARD_PENDING {
+			continue
+		}
+
+		if err := referralRepo.CancelReferralReward(reward.ReferralRewardId, ctx); err != nil {
+			if err.Error() == noti.INVALID_STATUS_WARN_MSG {
+				continue
+			}
+
+			return err
+		}
+
+		if err := postReferralReversalLedgerEntry(ledgerRepo, reward, actorId, ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getReferralRewardRate() float64 {
+	if rate, err := strconv.ParseFloat(os.Getenv(payment_env.REFERRAL_REWARD_RATE), 64); err == nil && rate >= 0 && rate <= 1 {
+		return rate
+	}
+
+	return referral.DEFAULT_REWARD_RATE
+}
+
+func getReferralRewardedTours() int {
+	if tours, err := strconv.Atoi(os.Getenv(payment_env.REFERRAL_REWARDED_TOURS)); err == nil && tours >= 0 {
+		return tours
+	}
+
+	return referral.DEFAULT_REWARDED_TOURS
+}
+
+func getReferralMaxReferrals() int {
+	if referrals, err := strconv.Atoi(os.Getenv(payment_env.REFERRAL_MAX_REFERRALS)); err == nil && referrals >= 0 {
+		return referrals
+	}
+
+	return referral.DEFAULT_MAX_REFERRALS
+}
+
+func getReferralMaxRewardAmount() float64 {
+	if amount, err := strconv.ParseFloat(os.Getenv(payment_env.REFERRAL_MAX_REWARD_AMOUNT), 64); err == nil && amount >= 0 {
+		return amount
+	}
+
+	return referral.DEFAULT_MAX_REWARD_AMOUNT
+}
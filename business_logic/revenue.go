@@ -4,9 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	file_support "tourmate/payment-service/constant/file/file_support"
+	filter_property "tourmate/payment-service/constant/filter_property"
+	"tourmate/payment-service/constant/granularity"
+	"tourmate/payment-service/constant/ledger"
 	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	revenue_adjustment "tourmate/payment-service/constant/revenue_adjustment"
+	"tourmate/payment-service/infrastructure/grpc/tour"
+	tour_pb "tourmate/payment-service/infrastructure/grpc/tour/pb"
 	"tourmate/payment-service/infrastructure/grpc/user"
 	"tourmate/payment-service/infrastructure/grpc/user/pb"
 	business_logic "tourmate/payment-service/interface/business_logic"
@@ -21,16 +33,34 @@ import (
 )
 
 type revenueService struct {
-	logger      *log.Logger
-	userService business_logic.IUserService
-	revenueRepo repo.IRevenueRepo
+	logger            *log.Logger
+	userService       business_logic.IUserService
+	tourService       business_logic.ITourService
+	revenueRepo       repo.IRevenueRepo
+	payoutAccountRepo repo.IPayoutAccountRepo
+	payoutPolicyRepo  repo.IPayoutPolicyRepo
+	payoutRepo        repo.IPayoutRepo
+	paymentRepo       repo.IPaymentRepo
+	gatewayFeeRepo    repo.IGatewayFeeRepo
+	ledgerRepo        repo.ILedgerRepo
+	fiscalPeriodRepo  repo.IFiscalPeriodRepo
+	taxRepo           repo.ITaxRepo
 }
 
-func InitializeRevenueService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IRevenueService {
+func InitializeRevenueService(db *sql.DB, userService business_logic.IUserService, tourService business_logic.ITourService, logger *log.Logger) business_logic.IRevenueService {
 	return &revenueService{
-		logger:      logger,
-		userService: userService,
-		revenueRepo: repository.InitializeRevenueRepo(db, logger),
+		logger:            logger,
+		userService:       userService,
+		tourService:       tourService,
+		revenueRepo:       repository.InitializeRevenueRepo(db, logger),
+		payoutAccountRepo: repository.InitializePayoutAccountRepo(db, logger),
+		payoutPolicyRepo:  repository.InitializePayoutPolicyRepo(db, logger),
+		payoutRepo:        repository.InitializePayoutRepo(db, logger),
+		paymentRepo:       repository.InitializePaymentRepo(db, logger),
+		gatewayFeeRepo:    repository.InitializeGatewayFeeRepo(db, logger),
+		ledgerRepo:        repository.InitializeLedgerRepo(db, logger),
+		fiscalPeriodRepo:  repository.InitializeFiscalPeriodRepo(db, logger),
+		taxRepo:           repository.InitializeTaxRepo(db, logger),
 	}
 }
 
@@ -44,8 +74,9 @@ func GenerateRevenueService() (business_logic.IRevenueService, error) {
 	}
 
 	userService, _ := user.GenerateUserService(logger)
+	tourService, _ := tour.GenerateTourService(logger)
 
-	return InitializeRevenueService(cnn, userService, logger), nil
+	return InitializeRevenueService(cnn, userService, tourService, logger), nil
 }
 
 // GetRevenueStats implements businesslogic.IRevenueService.
@@ -56,22 +87,14 @@ func (r *revenueService) GetRevenueStats(req request.GetMonthlyRevenueRequest, c
 		return nil, err
 	}
 
-	var previousMonth int
-	var year int = req.Year
-	if req.Month == 1 {
-		previousMonth = 12
-		year -= 1
-	} else {
-		previousMonth -= 1
-	}
-	previousMonthAmount, err := r.revenueRepo.GetRevenueTotalAmountByMonth(req.TourGuideId, year, previousMonth, ctx)
+	// Totals come from the same series as the growth so that adjustments are counted in the month they were made
+	bucket, err := r.getMonthlyRevenueBucket(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var totalRevenue, platformFee, netRevenue float64
-	var completedPayments, pendingPayments int
 	var revenuesResponse []response.RevenueResponse
+	var paymentIds []int
 	var tourguideName string
 	if tourguideInfo, _ := r.userService.GetTourGuideById(ctx, &pb.GetTourGuideByIdRequest{
 		TourGuideId: int32(req.TourGuideId),
@@ -80,15 +103,7 @@ func (r *revenueService) GetRevenueStats(req request.GetMonthlyRevenueRequest, c
 	}
 
 	for _, rev := range *revenues {
-		totalRevenue += rev.TotalAmount
-		platformFee += rev.PlatformCommission
-		netRevenue += rev.ActualReceived
-
-		if rev.PaymentStatus {
-			completedPayments++
-		} else {
-			pendingPayments++
-		}
+		paymentIds = append(paymentIds, rev.PaymentId)
 
 		revenuesResponse = append(revenuesResponse, response.RevenueResponse{
 			RevenueId:          rev.RevenueId,
@@ -104,32 +119,116 @@ func (r *revenueService) GetRevenueStats(req request.GetMonthlyRevenueRequest, c
 		})
 	}
 
-	var growthPercentage float64
-	if previousMonthAmount > 0 {
-		growthPercentage = ((totalRevenue - previousMonthAmount) / previousMonthAmount) * 100
+	// The platform bears the gateway fees of the payments, the guide share of them is already in the platform fee
+	paymentFees, err := r.gatewayFeeRepo.GetPaymentFeesByPaymentIds(paymentIds, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var gatewayFee float64
+	for _, fee := range *paymentFees {
+		gatewayFee += fee.FeeAmount - fee.Surcharge
 	}
 
 	return &response.RevenueStatusResponse{
-		TotalRevenue:      totalRevenue,
-		PlatformFee:       platformFee,
-		NetRevenue:        netRevenue,
-		TotalRecords:      len(*revenues),
-		CompletedPayments: completedPayments,
-		PendingPayments:   pendingPayments,
+		TotalRevenue:      bucket.TotalRevenue,
+		PlatformFee:       bucket.PlatformFee,
+		GatewayFee:        utils.RoundMoney(gatewayFee),
+		NetPlatformFee:    utils.RoundMoney(bucket.PlatformFee - gatewayFee),
+		NetRevenue:        bucket.NetRevenue,
+		TotalRecords:      bucket.TotalRecords,
+		CompletedPayments: bucket.CompletedRecords,
+		PendingPayments:   bucket.TotalRecords - bucket.CompletedRecords,
 		RevenueList:       revenuesResponse,
-		MonthlyGrowth:     growthPercentage,
+		MonthlyGrowth:     getBucketGrowth(bucket),
+	}, nil
+}
+
+// GetPayoutSummary implements businesslogic.IRevenueService.
+func (r *revenueService) GetPayoutSummary(tourGuideId int, ctx context.Context) (*response.PayoutSummaryResponse, error) {
+	policy, err := getPayoutPolicy(r.payoutPolicyRepo, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	eligibleRevenues, err := r.revenueRepo.GetPayableRevenues(tourGuideId, getPayableCutoff(*policy, curTime), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payableRevenues, err := r.revenueRepo.GetPayableRevenues(tourGuideId, curTime, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eligibleAdjustments, err := r.revenueRepo.GetPayableRevenueAdjustments(tourGuideId, getPayableCutoff(*policy, curTime), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payableAdjustments, err := r.revenueRepo.GetPayableRevenueAdjustments(tourGuideId, curTime, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := r.payoutAccountRepo.GetVerifiedPayoutAccount(tourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var amountEligible, amountPayable float64
+	for _, revenue := range *eligibleRevenues {
+		amountEligible += revenue.ActualReceived
+	}
+
+	for _, revenue := range *payableRevenues {
+		amountPayable += revenue.ActualReceived
+	}
+
+	// Unsettled adjustments are netted the same way the payout batch does
+	for _, adjustment := range *eligibleAdjustments {
+		amountEligible += adjustment.ActualReceivedDelta
+	}
+
+	for _, adjustment := range *payableAdjustments {
+		amountPayable += adjustment.ActualReceivedDelta
+	}
+
+	var nextPayoutDate *time.Time
+	if policy.IsAutoPayoutEnabled {
+		var date time.Time = utils.GetNextPayoutDate(policy.Schedule, policy.PayoutDay, policy.LastRunAt, curTime)
+		nextPayoutDate = &date
+	}
+
+	return &response.PayoutSummaryResponse{
+		TourGuideId:        tourGuideId,
+		AmountEligible:     amountEligible,
+		AmountOnHold:       amountPayable - amountEligible,
+		MinPayableBalance:  policy.MinPayableBalance,
+		HoldDays:           policy.HoldDays,
+		Schedule:           policy.Schedule,
+		NextPayoutDate:     nextPayoutDate,
+		HasVerifiedAccount: account != nil,
 	}, nil
 }
 
 // GetRevenues implements businesslogic.IRevenueService.
 func (r *revenueService) GetRevenues(req request.GetRevenuesRequest, ctx context.Context) (*[]response.RevenueResponse, error) {
-	if req.PageNumber == nil {
-		*req.PageNumber = 1
+	var pageNumber int = 1
+	if req.PageNumber != nil {
+		pageNumber = *req.PageNumber
 	}
 
-	if req.PageSize == nil {
-		*req.PageSize = 10
+	var pageSize int = 0
+	if req.PageSize != nil {
+		pageSize = *req.PageSize
 	}
+	pageSize = utils.ParsePageSize(pageSize)
+
+	req.PageNumber, req.PageSize = &pageNumber, &pageSize
+	req.FilterProp, req.Order = utils.ParseSortSpec(req.FilterProp, req.Order,
+		filter_property.REVENUE_SORT_COLUMNS, "createdAt", order.DESCENDING_ORDER)
 
 	data, err := r.revenueRepo.GetRevenues(req, ctx)
 
@@ -149,7 +248,7 @@ func (r *revenueService) GetRevenues(req request.GetRevenuesRequest, ctx context
 				InvoiceId:          item.InvoiceId,
 				TourGuideId:        item.TourGuideId,
 				TotalAmount:        item.TotalAmount,
-				ActualReceived:     item.TotalAmount,
+				ActualReceived:     item.ActualReceived,
 				PlatformCommission: item.PlatformCommission,
 				CreatedAt:          item.CreatedAt,
 				PaymentStatus:      item.PaymentStatus,
@@ -162,88 +261,424 @@ func (r *revenueService) GetRevenues(req request.GetRevenuesRequest, ctx context
 }
 
 func (r *revenueService) GetMonthlyRevenue(req request.GetMonthlyRevenueRequest, ctx context.Context) (*response.MonthlyRevenueResponse, error) {
-	revenues, err := r.revenueRepo.GetRevenuesByMonth(req.TourGuideId, req.Month, req.Year, ctx)
+	bucket, err := r.getMonthlyRevenueBucket(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var previousMonth int
-	var year int = req.Year
-	if req.Month == 1 {
-		previousMonth = 12
-		year -= 1
-	} else {
-		previousMonth -= 1
+	return &response.MonthlyRevenueResponse{
+		Month:             req.Month,
+		Year:              req.Year,
+		TotalRevenue:      bucket.TotalRevenue,
+		PlatformFee:       bucket.PlatformFee,
+		NetRevenue:        bucket.NetRevenue,
+		TotalRecords:      bucket.TotalRecords,
+		CompletedPayments: bucket.CompletedRecords,
+		PendingPayments:   bucket.TotalRecords - bucket.CompletedRecords,
+		GrowthPercentage:  getBucketGrowth(bucket),
+	}, nil
+}
+
+// GetGrowthPercentage implements businesslogic.IRevenueService.
+func (r *revenueService) GetGrowthPercentage(req request.GetMonthlyRevenueRequest, ctx context.Context) (response.RevenueGrowthPercentageResponse, error) {
+	growthPercentage, err := r.getMonthlyGrowth(req, ctx)
+
+	return response.RevenueGrowthPercentageResponse{
+		GrowthPercentage: growthPercentage,
+	}, err
+}
+
+// GetRevenueSeries implements businesslogic.IRevenueService.
+func (r *revenueService) GetRevenueSeries(req request.GetRevenueSeriesRequest, ctx context.Context) (*response.RevenueSeriesResponse, error) {
+	req.Granularity = strings.ToUpper(req.Granularity)
+	if !utils.IsGranularityValid(req.Granularity) {
+		return nil, errors.New(noti.UNSUPPORTED_GRANULARITY_WARN_MSG)
+	}
+
+	if req.To.Before(req.From) {
+		return nil, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
 	}
 
-	previousTotalAmount, err := r.revenueRepo.GetRevenueTotalAmountByMonth(req.TourGuideId, year, previousMonth, ctx)
+	// The end date is inclusive
+	buckets, err := getRevenueSeries(r.revenueRepo, &req.TourGuideId, req.Granularity, req.From, req.To.AddDate(0, 0, 1), ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var totalRevenue, platformFee, netRevenue float64
-	var completedPayments, pendingPayments int
+	return &response.RevenueSeriesResponse{
+		TourGuideId: req.TourGuideId,
+		Granularity: req.Granularity,
+		From:        req.From,
+		To:          req.To,
+		Buckets:     buckets,
+	}, nil
+}
 
-	for _, rev := range *revenues {
-		totalRevenue += rev.TotalAmount
-		platformFee += rev.PlatformCommission
-		netRevenue += rev.ActualReceived
-
-		if rev.PaymentStatus {
-			completedPayments++
-		} else {
-			pendingPayments++
-		}
+// GetPlatformRevenueSummary implements businesslogic.IRevenueService.
+func (r *revenueService) GetPlatformRevenueSummary(req request.GetPlatformRevenueRequest, ctx context.Context) (*response.PlatformRevenueSummaryResponse, error) {
+	if req.To.Before(req.From) {
+		return nil, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
 	}
 
-	var growthPercentage float64
-	if previousTotalAmount > 0 {
-		growthPercentage = ((totalRevenue - previousTotalAmount) / previousTotalAmount) * 100
+	// The end date is inclusive
+	var end time.Time = req.To.AddDate(0, 0, 1)
+	summary, err := r.revenueRepo.GetRevenueSummary(req.From, end, ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return &response.MonthlyRevenueResponse{
-		Month:             req.Month,
-		Year:              req.Year,
-		TotalRevenue:      totalRevenue,
-		PlatformFee:       platformFee,
-		NetRevenue:        netRevenue,
-		TotalRecords:      len(*revenues),
-		CompletedPayments: completedPayments,
-		PendingPayments:   pendingPayments,
-		GrowthPercentage:  growthPercentage,
+	feeSummaries, err := r.gatewayFeeRepo.GetPaymentFeeSummaries(&req.From, &end, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var gatewayFee float64
+	for _, feeSummary := range *feeSummaries {
+		gatewayFee += feeSummary.FeeAmount - feeSummary.Surcharge
+	}
+
+	return &response.PlatformRevenueSummaryResponse{
+		From:           req.From,
+		To:             req.To,
+		TotalRevenue:   utils.RoundMoney(summary.TotalAmount),
+		PlatformFee:    utils.RoundMoney(summary.PlatformCommission),
+		GatewayFee:     utils.RoundMoney(gatewayFee),
+		NetPlatformFee: utils.RoundMoney(summary.PlatformCommission - gatewayFee),
+		NetRevenue:     utils.RoundMoney(summary.ActualReceived),
+		TotalRecords:   summary.RevenueCount,
+		SettledAmount:  utils.RoundMoney(summary.SettledAmount),
+		PendingAmount:  utils.RoundMoney(summary.PendingAmount),
 	}, nil
 }
 
-// GetGrowthPercentage implements businesslogic.IRevenueService.
-func (r *revenueService) GetGrowthPercentage(req request.GetMonthlyRevenueRequest, ctx context.Context) (response.RevenueGrowthPercentageResponse, error) {
-	var previousMonth int
-	var year int = req.Year
-	if req.Month == 1 {
-		previousMonth = 12
-		year -= 1
-	} else {
-		previousMonth -= 1
+// GetTopTourGuideRevenues implements businesslogic.IRevenueService.
+func (r *revenueService) GetTopTourGuideRevenues(req request.GetPlatformRevenueRequest, ctx context.Context) ([]response.TourGuideRevenueResponse, error) {
+	if req.To.Before(req.From) {
+		return nil, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
+	}
+
+	if req.Top == 0 {
+		req.Top = 10
 	}
 
-	previousMonthAmount, err := r.revenueRepo.GetRevenueTotalAmountByMonth(req.TourGuideId, year, previousMonth, ctx)
+	groups, err := r.revenueRepo.GetTopTourGuideRevenues(req.From, req.To.AddDate(0, 0, 1), req.Top, ctx)
 	if err != nil {
-		return response.RevenueGrowthPercentageResponse{}, err
+		return nil, err
+	}
+
+	var res []response.TourGuideRevenueResponse
+	for _, group := range *groups {
+		var tourguideName string
+		if tourguideInfo, _ := r.userService.GetTourGuideById(ctx, &pb.GetTourGuideByIdRequest{
+			TourGuideId: int32(group.GroupId),
+		}); tourguideInfo != nil {
+			tourguideName = tourguideInfo.FullName
+		}
+
+		res = append(res, response.TourGuideRevenueResponse{
+			TourGuideId:   group.GroupId,
+			TourGuideName: tourguideName,
+			TotalRevenue:  utils.RoundMoney(group.TotalAmount),
+			PlatformFee:   utils.RoundMoney(group.PlatformCommission),
+			NetRevenue:    utils.RoundMoney(group.ActualReceived),
+			TotalRecords:  group.RevenueCount,
+		})
 	}
 
-	if previousMonthAmount == 0 {
-		return response.RevenueGrowthPercentageResponse{}, nil
+	return res, nil
+}
+
+// GetServiceRevenues implements businesslogic.IRevenueService.
+func (r *revenueService) GetServiceRevenues(req request.GetPlatformRevenueRequest, ctx context.Context) ([]response.ServiceRevenueResponse, error) {
+	if req.To.Before(req.From) {
+		return nil, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
 	}
 
-	currentMonthAmount, err := r.revenueRepo.GetRevenueTotalAmountByMonth(req.TourGuideId, req.Year, req.Month, ctx)
+	groups, err := r.revenueRepo.GetServiceRevenues(req.From, req.To.AddDate(0, 0, 1), ctx)
 	if err != nil {
-		return response.RevenueGrowthPercentageResponse{}, err
+		return nil, err
 	}
 
-	return response.RevenueGrowthPercentageResponse{
-		GrowthPercentage: (currentMonthAmount - previousMonthAmount) / previousMonthAmount * 100,
+	var res []response.ServiceRevenueResponse
+	for _, group := range *groups {
+		var item response.ServiceRevenueResponse = response.ServiceRevenueResponse{
+			ServiceId:    group.GroupId,
+			TotalRevenue: utils.RoundMoney(group.TotalAmount),
+			PlatformFee:  utils.RoundMoney(group.PlatformCommission),
+			NetRevenue:   utils.RoundMoney(group.ActualReceived),
+			TotalRecords: group.RevenueCount,
+		}
+
+		if serviceInfo, _ := r.tourService.GetTourById(ctx, &tour_pb.TourServiceIdRequest{
+			ServiceId: int32(group.GroupId),
+		}); serviceInfo != nil {
+			item.ServiceName = serviceInfo.ServiceName
+			item.AreaId = int(serviceInfo.AreaId)
+		}
+
+		res = append(res, item)
+	}
+
+	return res, nil
+}
+
+// GetAreaRevenues implements businesslogic.IRevenueService.
+func (r *revenueService) GetAreaRevenues(req request.GetPlatformRevenueRequest, ctx context.Context) ([]response.AreaRevenueResponse, error) {
+	services, err := r.GetServiceRevenues(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Area is only known by the tour service, so services are grouped after their area is resolved
+	var res []response.AreaRevenueResponse
+	var indexByArea map[int]int = make(map[int]int)
+	for _, service := range services {
+		index, isExist := indexByArea[service.AreaId]
+		if !isExist {
+			index = len(res)
+			indexByArea[service.AreaId] = index
+			res = append(res, response.AreaRevenueResponse{AreaId: service.AreaId})
+		}
+
+		res[index].TotalRevenue = utils.RoundMoney(res[index].TotalRevenue + service.TotalRevenue)
+		res[index].PlatformFee = utils.RoundMoney(res[index].PlatformFee + service.PlatformFee)
+		res[index].NetRevenue = utils.RoundMoney(res[index].NetRevenue + service.NetRevenue)
+		res[index].TotalRecords += service.TotalRecords
+		res[index].ServiceCount++
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].TotalRevenue > res[j].TotalRevenue
+	})
+
+	return res, nil
+}
+
+// GetEarningsStatement implements businesslogic.IRevenueService.
+func (r *revenueService) GetEarningsStatement(req request.GetEarningsStatementRequest, ctx context.Context) (response.FileResponse, error) {
+	from, to, period := utils.GetReportPeriod(req.Year, req.Month)
+
+	revenues, err := r.revenueRepo.GetRevenueDetails(req.TourGuideId, from, to, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	payouts, err := r.payoutRepo.GetPaidPayoutItems(req.TourGuideId, from, to, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	withholdings, err := r.taxRepo.GetPaidTaxWithholdings(&req.TourGuideId, from, to, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	// Balances owed to the tour guide are read from the ledger so that adjustments of older revenues are included
+	openingBalance, err := r.getGuidePayableBalance(req.TourGuideId, from, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	closingBalance, err := r.getGuidePayableBalance(req.TourGuideId, to, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	var tourguideName string
+	if tourguideInfo, _ := r.userService.GetTourGuideById(ctx, &pb.GetTourGuideByIdRequest{
+		TourGuideId: int32(req.TourGuideId),
+	}); tourguideInfo != nil {
+		tourguideName = tourguideInfo.FullName
+	}
+
+	var formatAmount func(float64) string = utils.FormatMoney
+	if req.Format == file_support.CSV_FORMAT {
+		formatAmount = func(amount float64) string {
+			return strconv.FormatFloat(utils.RoundMoney(amount), 'f', -1, 64)
+		}
+	}
+
+	var headers []string = []string{"No", "Date", "Invoice", "Service", "Gross", "Commission", "Net"}
+	var rows [][]string
+	var serviceNames map[int]string = make(map[int]string)
+	var totalGross, totalCommission, totalNet, totalPayout float64
+	for i, revenue := range *revenues {
+		serviceName, isExisted := serviceNames[revenue.ServiceId]
+		if !isExisted {
+			if serviceInfo, _ := r.tourService.GetTourById(ctx, &tour_pb.TourServiceIdRequest{
+				ServiceId: int32(revenue.ServiceId),
+			}); serviceInfo != nil {
+				serviceName = serviceInfo.ServiceName
+			}
+
+			serviceNames[revenue.ServiceId] = serviceName
+		}
+
+		totalGross += revenue.TotalAmount
+		totalCommission += revenue.PlatformCommission
+		totalNet += revenue.ActualReceived
+
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1),
+			revenue.CreatedAt.Format(utils.DATE_FORMAT),
+			fmt.Sprint(revenue.InvoiceId),
+			serviceName,
+			formatAmount(revenue.TotalAmount),
+			formatAmount(revenue.PlatformCommission),
+			formatAmount(revenue.ActualReceived),
+		})
+	}
+
+	var summaries [][]string = [][]string{
+		{"Opening balance", formatAmount(openingBalance)},
+		{"Total gross", formatAmount(totalGross)},
+		{"Total commission", formatAmount(totalCommission)},
+		{"Total net", formatAmount(totalNet)},
+	}
+
+	for _, payout := range *payouts {
+		totalPayout += payout.Amount
+		summaries = append(summaries, []string{
+			fmt.Sprintf("Payout %s (%s)", payout.Reference, payout.ProcessedAt.Format(utils.DATE_FORMAT)),
+			formatAmount(payout.Amount),
+		})
+	}
+
+	var totalTax float64
+	for _, withholding := range *withholdings {
+		totalTax += withholding.TaxAmount
+	}
+
+	summaries = append(summaries,
+		[]string{"Total payouts", formatAmount(totalPayout)},
+		[]string{"Personal income tax withheld", formatAmount(totalTax)},
+		[]string{"Closing balance", formatAmount(closingBalance)})
+
+	var fileName string = fmt.Sprintf("earnings_statement_%d_%s.%s", req.TourGuideId, strings.ReplaceAll(period, "/", "_"), req.Format)
+	var content []byte
+	var contentType string
+	switch req.Format {
+	case file_support.CSV_FORMAT:
+		// Summary rows are appended after a blank line below the revenue table
+		rows = append(rows, []string{})
+		rows = append(rows, summaries...)
+		content, err = utils.GenerateCsvFile(headers, rows)
+		contentType = file_support.CSV_CONTENT_TYPE
+	case file_support.PDF_FORMAT:
+		content, err = utils.GeneratePdfFile("TourMate - Earnings statement", []string{
+			fmt.Sprintf("Tour guide: %s (ID %d)", tourguideName, req.TourGuideId),
+			"Period: " + period,
+			"Generated at: " + time.Now().Format("2006-01-02 15:04"),
+		}, headers, rows, summaries)
+		contentType = file_support.PDF_CONTENT_TYPE
+	default:
+		return response.FileResponse{}, errors.New(noti.UNSUPPORTED_FILE_FORMAT_WARN_MSG)
+	}
+
+	if err != nil {
+		r.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, req.Format) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return response.FileResponse{
+		FileName:    fileName,
+		ContentType: contentType,
+		Content:     content,
 	}, nil
 }
 
+// Get amount owed to the tour guide before the given time
+func (r *revenueService) getGuidePayableBalance(tourGuideId int, before time.Time, ctx context.Context) (float64, error) {
+	debit, credit, err := r.ledgerRepo.GetAccountTotals(ledger.GUIDE_PAYABLE, &tourGuideId, &before, ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return utils.GetLedgerBalance(ledger.GUIDE_PAYABLE, debit, credit), nil
+}
+
+// Growth of the month compared to the previous month, 0 when the previous month has no revenue
+func (r *revenueService) getMonthlyGrowth(req request.GetMonthlyRevenueRequest, ctx context.Context) (float64, error) {
+	bucket, err := r.getMonthlyRevenueBucket(req, ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return getBucketGrowth(bucket), nil
+}
+
+// Revenue of the requested month taken from the monthly series
+func (r *revenueService) getMonthlyRevenueBucket(req request.GetMonthlyRevenueRequest, ctx context.Context) (response.RevenueSeriesBucketResponse, error) {
+	var monthStart time.Time = time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local)
+
+	buckets, err := getRevenueSeries(r.revenueRepo, &req.TourGuideId, granularity.MONTH, monthStart, monthStart.AddDate(0, 1, 0), ctx)
+	if err != nil || len(buckets) == 0 {
+		return response.RevenueSeriesBucketResponse{}, err
+	}
+
+	return buckets[0], nil
+}
+
+// Period growth of the bucket, 0 when the previous bucket has no revenue
+func getBucketGrowth(bucket response.RevenueSeriesBucketResponse) float64 {
+	if bucket.PeriodGrowth == nil {
+		return 0
+	}
+
+	return *bucket.PeriodGrowth
+}
+
+// Build the buckets covering [from, to) including empty ones, the previous and year ago buckets are
+// aggregated in the same query so that every bucket has its growth
+func getRevenueSeries(revenueRepo repo.IRevenueRepo, tourGuideId *int, bucketSize string, from, to time.Time, ctx context.Context) ([]response.RevenueSeriesBucketResponse, error) {
+	var firstBucket time.Time = utils.GetBucketStart(bucketSize, from)
+
+	var bucketStarts []time.Time
+	for bucketStart := firstBucket; bucketStart.Before(to); bucketStart = utils.GetNextBucketStart(bucketSize, bucketStart) {
+		if len(bucketStarts) == granularity.MAX_BUCKETS {
+			return nil, errors.New(noti.TOO_MANY_BUCKETS_WARN_MSG)
+		}
+
+		bucketStarts = append(bucketStarts, bucketStart)
+	}
+
+	var queryFrom time.Time = utils.GetPreviousBucketStart(bucketSize, firstBucket)
+	if yearAgo := utils.GetYearAgoBucketStart(bucketSize, firstBucket); yearAgo.Before(queryFrom) {
+		queryFrom = yearAgo
+	}
+
+	data, err := revenueRepo.GetRevenueSeries(tourGuideId, bucketSize, queryFrom, utils.GetNextBucketStart(bucketSize, bucketStarts[len(bucketStarts)-1]), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var bucketByDate map[string]entity.RevenueBucket = make(map[string]entity.RevenueBucket)
+	for _, bucket := range *data {
+		bucketByDate[bucket.BucketStart.Format(utils.DATE_FORMAT)] = bucket
+	}
+
+	var res []response.RevenueSeriesBucketResponse
+	for _, bucketStart := range bucketStarts {
+		var bucket entity.RevenueBucket = bucketByDate[bucketStart.Format(utils.DATE_FORMAT)]
+		var previous entity.RevenueBucket = bucketByDate[utils.GetPreviousBucketStart(bucketSize, bucketStart).Format(utils.DATE_FORMAT)]
+		var yearAgo entity.RevenueBucket = bucketByDate[utils.GetYearAgoBucketStart(bucketSize, bucketStart).Format(utils.DATE_FORMAT)]
+
+		res = append(res, response.RevenueSeriesBucketResponse{
+			BucketStart:        bucketStart,
+			BucketEnd:          utils.GetNextBucketStart(bucketSize, bucketStart).AddDate(0, 0, -1),
+			TotalRevenue:       utils.RoundMoney(bucket.TotalAmount),
+			PlatformFee:        utils.RoundMoney(bucket.PlatformCommission),
+			NetRevenue:         utils.RoundMoney(bucket.ActualReceived),
+			TotalRecords:       bucket.RevenueCount,
+			CompletedRecords:   bucket.SettledCount,
+			PeriodGrowth:       utils.GetGrowthPercentage(bucket.TotalAmount, previous.TotalAmount),
+			YearOverYearGrowth: utils.GetGrowthPercentage(bucket.TotalAmount, yearAgo.TotalAmount),
+		})
+	}
+
+	return res, nil
+}
+
 // CreateRevenue implements businesslogic.IRevenueService.
 func (r *revenueService) CreateRevenue(req request.CreateRevenueRequest, ctx context.Context) (*response.RevenueResponse, error) {
 	var curTime time.Time = time.Now()
@@ -289,9 +724,41 @@ func (r *revenueService) GetRevenue(id int, ctx context.Context) (*entity.Revenu
 	return r.revenueRepo.GetRevenue(id, ctx)
 }
 
+// GetRevenueAdjustments implements businesslogic.IRevenueService.
+func (r *revenueService) GetRevenueAdjustments(id int, ctx context.Context) (*[]entity.RevenueAdjustment, error) {
+	revenue, err := r.revenueRepo.GetRevenue(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if revenue == nil {
+		return nil, errors.New(noti.GENERIC_ERROR_WARN_MSG)
+	}
+
+	return r.revenueRepo.GetRevenueAdjustments(id, ctx)
+}
+
 // RemoveRevenue implements businesslogic.IRevenueService.
-func (r *revenueService) RemoveRevenue(id int, ctx context.Context) error {
-	return r.revenueRepo.RemoveRevenue(id, ctx)
+func (r *revenueService) RemoveRevenue(req request.RemoveRevenueRequest, ctx context.Context) error {
+	revenue, err := r.revenueRepo.GetRevenue(req.RevenueId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if revenue == nil {
+		return errors.New(noti.GENERIC_ERROR_WARN_MSG)
+	}
+
+	adjustment, err := reverseRevenue(r.revenueRepo, r.fiscalPeriodRepo, *revenue, req.ActorId, req.Reason, ctx)
+	if err != nil {
+		return err
+	}
+
+	if adjustment == nil {
+		return errors.New(noti.REVENUE_ALREADY_REVERSED_WARN_MSG)
+	}
+
+	return r.postRevenueAdjustment(*adjustment, *revenue, ctx)
 }
 
 // UpdateRevenue implements businesslogic.IRevenueService.
@@ -305,35 +772,41 @@ func (r *revenueService) UpdateRevenue(req request.UpdateRevenueRequest, ctx con
 		return nil, errors.New(noti.GENERIC_ERROR_WARN_MSG)
 	}
 
-	if req.PaymentId != nil {
-		revenue.PaymentId = *req.PaymentId
+	var adjustment entity.RevenueAdjustment = entity.RevenueAdjustment{
+		RevenueId:      revenue.RevenueId,
+		AdjustmentType: revenue_adjustment.CORRECTION,
+		Reason:         req.Reason,
+		CreatedBy:      req.ActorId,
 	}
 
-	if req.TourGuideId != nil {
-		revenue.TourGuideId = *req.TourGuideId
+	if req.TotalAmount != nil {
+		adjustment.TotalAmountDelta = utils.RoundMoney(*req.TotalAmount - revenue.TotalAmount)
 	}
 
-	if req.InvoiceId != nil {
-		revenue.InvoiceId = *req.InvoiceId
+	if req.ActualReceived != nil {
+		adjustment.ActualReceivedDelta = utils.RoundMoney(*req.ActualReceived - revenue.ActualReceived)
 	}
 
-	if req.TotalAmount != nil {
-		revenue.TotalAmount = *req.TotalAmount
+	if req.PlatformCommission != nil {
+		adjustment.PlatformCommissionDelta = utils.RoundMoney(*req.PlatformCommission - revenue.PlatformCommission)
 	}
 
-	if req.ActualReceived != nil {
-		revenue.ActualReceived = *req.ActualReceived
+	if adjustment.TotalAmountDelta == 0 && adjustment.ActualReceivedDelta == 0 && adjustment.PlatformCommissionDelta == 0 {
+		return nil, errors.New(noti.NO_REVENUE_CHANGE_WARN_MSG)
 	}
 
-	if req.PlatformCommission != nil {
-		revenue.PlatformCommission = *req.PlatformCommission
+	// The split must still add up to the total amount
+	if utils.RoundMoney(adjustment.TotalAmountDelta-adjustment.ActualReceivedDelta-adjustment.PlatformCommissionDelta) != 0 {
+		return nil, errors.New(noti.INCONSISTENT_REVENUE_ADJUSTMENT_WARN_MSG)
 	}
 
-	if req.PaymentStatus != nil {
-		revenue.PaymentStatus = *req.PaymentStatus
+	id, err := createRevenueAdjustment(r.revenueRepo, r.fiscalPeriodRepo, *revenue, adjustment, ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := r.revenueRepo.UpdateRevenue(*revenue, ctx); err != nil {
+	adjustment.RevenueAdjustmentId = id
+	if err := r.postRevenueAdjustment(adjustment, *revenue, ctx); err != nil {
 		return nil, err
 	}
 
@@ -350,10 +823,80 @@ func (r *revenueService) UpdateRevenue(req request.UpdateRevenueRequest, ctx con
 		TourGuideId:        revenue.TourGuideId,
 		TourGuideName:      tourguideName,
 		InvoiceId:          revenue.InvoiceId,
-		TotalAmount:        revenue.TotalAmount,
-		ActualReceived:     revenue.ActualReceived,
-		PlatformCommission: revenue.PlatformCommission,
+		TotalAmount:        utils.RoundMoney(revenue.TotalAmount + adjustment.TotalAmountDelta),
+		ActualReceived:     utils.RoundMoney(revenue.ActualReceived + adjustment.ActualReceivedDelta),
+		PlatformCommission: utils.RoundMoney(revenue.PlatformCommission + adjustment.PlatformCommissionDelta),
 		PaymentStatus:      revenue.PaymentStatus,
 		CreatedAt:          revenue.CreatedAt,
 	}, nil
 }
+
+// Post the ledger entry of an adjustment, the customer is taken from the payment of the revenue
+func (r *revenueService) postRevenueAdjustment(adjustment entity.RevenueAdjustment, revenue entity.Revenue, ctx context.Context) error {
+	payment, err := r.paymentRepo.GetPaymentById(revenue.PaymentId, ctx)
+	if err != nil {
+		return err
+	}
+
+	var customerId int
+	if payment != nil {
+		customerId = payment.CustomerId
+	}
+
+	return postRevenueAdjustmentLedgerEntry(r.ledgerRepo, adjustment, revenue, customerId, ctx)
+}
+
+// Store an adjustment of the effective revenue in the current period, revenues waiting for a bank payout result cannot be changed
+func createRevenueAdjustment(revenueRepo repo.IRevenueRepo, fiscalPeriodRepo repo.IFiscalPeriodRepo, revenue entity.Revenue, adjustment entity.RevenueAdjustment, ctx context.Context) (int, error) {
+	if revenue.TotalAmount+adjustment.TotalAmountDelta < 0 ||
+		revenue.ActualReceived+adjustment.ActualReceivedDelta < 0 ||
+		revenue.PlatformCommission+adjustment.PlatformCommissionDelta < 0 {
+
+		return 0, errors.New(noti.NEGATIVE_REVENUE_AMOUNT_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	if err := ensureFiscalPeriodOpen(fiscalPeriodRepo, curTime, ctx); err != nil {
+		return 0, err
+	}
+
+	isHeld, err := revenueRepo.IsRevenueHeldByPayout(revenue.RevenueId, ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if isHeld {
+		return 0, errors.New(noti.REVENUE_HELD_BY_PAYOUT_WARN_MSG)
+	}
+
+	adjustment.RevenueId = revenue.RevenueId
+	adjustment.CreatedAt = curTime
+
+	return revenueRepo.CreateRevenueAdjustment(adjustment, ctx)
+}
+
+// Bring the effective revenue back to zero, nil is returned when there is nothing left to reverse
+func reverseRevenue(revenueRepo repo.IRevenueRepo, fiscalPeriodRepo repo.IFiscalPeriodRepo, revenue entity.Revenue, actorId int, reason string, ctx context.Context) (*entity.RevenueAdjustment, error) {
+	if revenue.TotalAmount == 0 && revenue.ActualReceived == 0 && revenue.PlatformCommission == 0 {
+		return nil, nil
+	}
+
+	var adjustment entity.RevenueAdjustment = entity.RevenueAdjustment{
+		AdjustmentType:          revenue_adjustment.REVERSAL,
+		TotalAmountDelta:        -revenue.TotalAmount,
+		ActualReceivedDelta:     -revenue.ActualReceived,
+		PlatformCommissionDelta: -revenue.PlatformCommission,
+		Reason:                  reason,
+		CreatedBy:               actorId,
+	}
+
+	id, err := createRevenueAdjustment(revenueRepo, fiscalPeriodRepo, revenue, adjustment, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment.RevenueAdjustmentId = id
+	adjustment.RevenueId = revenue.RevenueId
+
+	return &adjustment, nil
+}
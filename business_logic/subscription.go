@@ -0,0 +1,693 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"strconv"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	mail_const "tourmate/payment-service/constant/mail_const"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/constant/subscription"
+	"tourmate/payment-service/constant/wallet"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	user_pb "tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+
+	"github.com/payOSHQ/payos-lib-golang"
+)
+
+type subscriptionService struct {
+	logger           *log.Logger
+	userService      business_logic.IUserService
+	subscriptionRepo repo.ISubscriptionRepo
+	ledgerRepo       repo.ILedgerRepo
+}
+
+func InitializeSubscriptionService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.ISubscriptionService {
+	return &subscriptionService{
+		logger:           logger,
+		userService:      userService,
+		subscriptionRepo: repository.InitializeSubscriptionRepo(db, logger),
+		ledgerRepo:       repository.InitializeLedgerRepo(db, logger),
+	}
+}
+
+func GenerateSubscriptionService() (business_logic.ISubscriptionService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeSubscriptionService(cnn, userService, logger), nil
+}
+
+// GetSubscriptionPlans implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) GetSubscriptionPlans(ctx context.Context) (*[]entity.SubscriptionPlan, error) {
+	return s.subscriptionRepo.GetSubscriptionPlans(ctx)
+}
+
+// CreateSubscriptionPlan implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) CreateSubscriptionPlan(req request.UpsertSubscriptionPlanRequest, ctx context.Context) (*entity.SubscriptionPlan, error) {
+	var curTime time.Time = time.Now()
+	var res entity.SubscriptionPlan = entity.SubscriptionPlan{
+		Name:           req.Name,
+		Description:    req.Description,
+		Price:          utils.RoundMoney(req.Price),
+		CycleMonths:    req.CycleMonths,
+		CommissionRate: req.CommissionRate,
+		IsFeatured:     req.IsFeatured,
+		IsActive:       req.IsActive,
+		CreatedAt:      curTime,
+		UpdatedAt:      curTime,
+	}
+
+	id, err := s.subscriptionRepo.CreateSubscriptionPlan(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.SubscriptionPlanId = id
+	return &res, nil
+}
+
+// UpdateSubscriptionPlan implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) UpdateSubscriptionPlan(req request.UpsertSubscriptionPlanRequest, ctx context.Context) (*entity.SubscriptionPlan, error) {
+	plan, err := s.getSubscriptionPlan(req.SubscriptionPlanId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invoices already issued keep their amount, the new price applies from the next cycle
+	plan.Name = req.Name
+	plan.Description = req.Description
+	plan.Price = utils.RoundMoney(req.Price)
+	plan.CycleMonths = req.CycleMonths
+	plan.CommissionRate = req.CommissionRate
+	plan.IsFeatured = req.IsFeatured
+	plan.IsActive = req.IsActive
+	plan.UpdatedAt = time.Now()
+
+	if err := s.subscriptionRepo.UpdateSubscriptionPlan(*plan, ctx); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// CreateSubscription implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) CreateSubscription(req request.CreateSubscriptionRequest, ctx context.Context) (*response.SubscriptionResponse, error) {
+	plan, err := s.getSubscriptionPlan(req.SubscriptionPlanId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.IsActive {
+		return nil, errors.New(noti.SUBSCRIPTION_PLAN_NOT_ACTIVE_WARN_MSG)
+	}
+
+	// Checked again when the subscription is created, this only saves a payment link
+	openSubscription, err := s.subscriptionRepo.GetOpenSubscription(req.TourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if openSubscription != nil {
+		return nil, errors.New(noti.SUBSCRIPTION_EXISTED_WARN_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	var sub entity.Subscription = entity.Subscription{
+		TourGuideId:        req.TourGuideId,
+		SubscriptionPlanId: plan.SubscriptionPlanId,
+		BillingEmail:       req.BillingEmail,
+		Status:             domain_status.SUBSCRIPTION_PENDING,
+		CreatedAt:          curTime,
+		UpdatedAt:          curTime,
+	}
+
+	var invoice entity.SubscriptionInvoice = entity.SubscriptionInvoice{
+		TourGuideId: req.TourGuideId,
+		Amount:      plan.Price,
+		PeriodStart: curTime,
+		PeriodEnd:   curTime.AddDate(0, plan.CycleMonths, 0),
+		Status:      domain_status.SUBSCRIPTION_INVOICE_PENDING,
+		DueAt:       curTime,
+		CreatedAt:   curTime,
+	}
+
+	if err := s.renewInvoiceLink(&invoice); err != nil {
+		return nil, err
+	}
+
+	sub.SubscriptionId, invoice.SubscriptionInvoiceId, err = s.subscriptionRepo.CreateSubscription(sub, invoice, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.SubscriptionId = sub.SubscriptionId
+	s.sendInvoiceMail(sub, *plan, invoice, false, ctx)
+
+	return &response.SubscriptionResponse{
+		Subscription: sub,
+		Plan:         plan,
+		OpenInvoice:  &invoice,
+	}, nil
+}
+
+// GetSubscriptions implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) GetSubscriptions(req request.GetSubscriptionsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.Subscription{}.GetSubscriptionLimitRecords()
+
+	data, pages, totalRecords, err := s.subscriptionRepo.GetSubscriptions(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// GetTourGuideSubscription implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) GetTourGuideSubscription(tourGuideId int, ctx context.Context) (*response.SubscriptionResponse, error) {
+	sub, err := s.subscriptionRepo.GetOpenSubscription(tourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Subscription{}.GetSubscriptionTable()))
+	}
+
+	return s.toSubscriptionResponse(*sub, ctx)
+}
+
+// CancelSubscription implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) CancelSubscription(req request.CancelSubscriptionRequest, ctx context.Context) (*response.SubscriptionResponse, error) {
+	sub, err := s.subscriptionRepo.GetSubscriptionById(req.SubscriptionId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Subscription{}.GetSubscriptionTable()))
+	}
+
+	if sub.TourGuideId != req.TourGuideId {
+		return nil, errors.New(noti.GENERIC_RIGHT_ACCESS_WARN_MSG)
+	}
+
+	var curStatus string = sub.Status
+	var curTime time.Time = time.Now()
+	switch curStatus {
+	case domain_status.SUBSCRIPTION_ACTIVE:
+		// The paid period is kept, the billing job ends it instead of renewing
+		sub.CancelAtPeriodEnd = true
+	case domain_status.SUBSCRIPTION_PENDING, domain_status.SUBSCRIPTION_PAST_DUE:
+		sub.Status = domain_status.SUBSCRIPTION_CANCELLED
+		sub.EndedAt = &curTime
+	default:
+		return nil, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	sub.UpdatedAt = curTime
+	if err := s.subscriptionRepo.UpdateSubscription(*sub, curStatus, ctx); err != nil {
+		return nil, err
+	}
+
+	if sub.Status == domain_status.SUBSCRIPTION_CANCELLED {
+		if err := s.voidPendingInvoice(sub.SubscriptionId, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.toSubscriptionResponse(*sub, ctx)
+}
+
+// GetSubscriptionInvoices implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) GetSubscriptionInvoices(req request.GetSubscriptionInvoicesRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.SubscriptionInvoice{}.GetSubscriptionInvoiceLimitRecords()
+
+	data, pages, totalRecords, err := s.subscriptionRepo.GetSubscriptionInvoices(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// ConfirmSubscriptionInvoice implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) ConfirmSubscriptionInvoice(id int, ctx context.Context) (*entity.SubscriptionInvoice, error) {
+	invoice, err := s.subscriptionRepo.GetSubscriptionInvoiceById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.SubscriptionInvoice{}.GetSubscriptionInvoiceTable()))
+	}
+
+	switch invoice.Status {
+	case domain_status.SUBSCRIPTION_INVOICE_PAID:
+		// Confirming again is harmless, it also posts the entry if the previous attempt stopped before it
+		return invoice, postSubscriptionLedgerEntry(s.ledgerRepo, *invoice, ctx)
+	case domain_status.SUBSCRIPTION_INVOICE_VOID:
+		return invoice, nil
+	}
+
+	linkStatus, err := s.getInvoiceLinkStatus(*invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	switch linkStatus {
+	case wallet.PAYOS_PAID_STATUS:
+		if err := s.applyInvoicePayment(invoice, ctx); err != nil {
+			return nil, err
+		}
+
+		return invoice, nil
+	case wallet.PAYOS_CANCELLED_STATUS, wallet.PAYOS_EXPIRED_STATUS:
+		// The invoice stays open, a new link is issued so that it can still be paid
+		if err := s.renewInvoiceLink(invoice); err != nil {
+			return nil, err
+		}
+
+		if err := s.subscriptionRepo.UpdateSubscriptionInvoice(*invoice, domain_status.SUBSCRIPTION_INVOICE_PENDING, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, errors.New(noti.SUBSCRIPTION_INVOICE_NOT_PAID_WARN_MSG)
+}
+
+// GetFeaturedTourGuideIds implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) GetFeaturedTourGuideIds(ctx context.Context) ([]int, error) {
+	return s.subscriptionRepo.GetFeaturedTourGuideIds(ctx)
+}
+
+// ProcessSubscriptions implements businesslogic.ISubscriptionService.
+func (s *subscriptionService) ProcessSubscriptions(ctx context.Context) (*response.SubscriptionBillingResponse, error) {
+	var res response.SubscriptionBillingResponse
+	if err := s.renewSubscriptions(&res, ctx); err != nil {
+		return &res, err
+	}
+
+	invoices, err := s.subscriptionRepo.GetPendingSubscriptionInvoices(ctx)
+	if err != nil {
+		return &res, err
+	}
+
+	var graceDays int = getSubscriptionGraceDays()
+	var reminderIntervalDays int = getSubscriptionReminderIntervalDays()
+	for _, invoice := range *invoices {
+		linkStatus, err := s.getInvoiceLinkStatus(invoice)
+		if err != nil {
+			return &res, err
+		}
+
+		if linkStatus == wallet.PAYOS_PAID_STATUS {
+			if err := s.applyInvoicePayment(&invoice, ctx); err != nil {
+				return &res, err
+			}
+
+			res.PaidInvoices++
+			continue
+		}
+
+		var curTime time.Time = time.Now()
+		if !curTime.Before(invoice.DueAt.AddDate(0, 0, graceDays)) {
+			isDowngraded, err := s.expireSubscription(invoice, ctx)
+			if err != nil {
+				return &res, err
+			}
+
+			if isDowngraded {
+				res.DowngradedGuides++
+			} else {
+				res.EndedSubscriptions++
+			}
+
+			continue
+		}
+
+		var lastRemindedAt time.Time = invoice.CreatedAt
+		if invoice.LastRemindedAt != nil {
+			lastRemindedAt = *invoice.LastRemindedAt
+		}
+
+		if curTime.Before(lastRemindedAt.AddDate(0, 0, reminderIntervalDays)) {
+			continue
+		}
+
+		if err := s.remindInvoice(invoice, linkStatus, ctx); err != nil {
+			return &res, err
+		}
+
+		res.SentReminders++
+	}
+
+	return &res, nil
+}
+
+// Issue the invoice of the next cycle for subscriptions whose period is over, the plan is kept during the grace period.
+// Subscriptions cancelled by the tour guide end instead
+func (s *subscriptionService) renewSubscriptions(res *response.SubscriptionBillingResponse, ctx context.Context) error {
+	subscriptions, err := s.subscriptionRepo.GetRenewableSubscriptions(time.Now(), ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range *subscriptions {
+		plan, err := s.getSubscriptionPlan(sub.SubscriptionPlanId, ctx)
+		if err != nil {
+			return err
+		}
+
+		var curTime time.Time = time.Now()
+		sub.UpdatedAt = curTime
+
+		// A retired plan is not renewed
+		if sub.CancelAtPeriodEnd || !plan.IsActive {
+			sub.Status = domain_status.SUBSCRIPTION_CANCELLED
+			sub.EndedAt = &curTime
+			if err := s.subscriptionRepo.UpdateSubscription(sub, domain_status.SUBSCRIPTION_ACTIVE, ctx); err != nil {
+				return err
+			}
+
+			res.EndedSubscriptions++
+			continue
+		}
+
+		var invoice entity.SubscriptionInvoice = entity.SubscriptionInvoice{
+			SubscriptionId: sub.SubscriptionId,
+			TourGuideId:    sub.TourGuideId,
+			Amount:         plan.Price,
+			PeriodStart:    *sub.CurrentPeriodEnd,
+			PeriodEnd:      sub.CurrentPeriodEnd.AddDate(0, plan.CycleMonths, 0),
+			Status:         domain_status.SUBSCRIPTION_INVOICE_PENDING,
+			DueAt:          *sub.CurrentPeriodEnd,
+			CreatedAt:      curTime,
+		}
+
+		if err := s.renewInvoiceLink(&invoice); err != nil {
+			return err
+		}
+
+		// The cycle may already be invoiced by a previous run which stopped before updating the subscription
+		invoice.SubscriptionInvoiceId, err = s.subscriptionRepo.CreateSubscriptionInvoice(invoice, ctx)
+		if err != nil && err.Error() != noti.INVALID_STATUS_WARN_MSG {
+			return err
+		}
+
+		var isCreated bool = err == nil
+
+		sub.Status = domain_status.SUBSCRIPTION_PAST_DUE
+		if err := s.subscriptionRepo.UpdateSubscription(sub, domain_status.SUBSCRIPTION_ACTIVE, ctx); err != nil {
+			return err
+		}
+
+		if isCreated {
+			s.sendInvoiceMail(sub, *plan, invoice, false, ctx)
+			res.CreatedInvoices++
+		}
+	}
+
+	return nil
+}
+
+// The invoice is taken first so that a payment starts or renews the subscription once
+func (s *subscriptionService) applyInvoicePayment(invoice *entity.SubscriptionInvoice, ctx context.Context) error {
+	sub, err := s.subscriptionRepo.GetSubscriptionById(invoice.SubscriptionId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if sub == nil {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Subscription{}.GetSubscriptionTable()))
+	}
+
+	var curTime time.Time = time.Now()
+	invoice.Status = domain_status.SUBSCRIPTION_INVOICE_PAID
+	invoice.PaidAt = &curTime
+	if err := s.subscriptionRepo.UpdateSubscriptionInvoice(*invoice, domain_status.SUBSCRIPTION_INVOICE_PENDING, ctx); err != nil {
+		return err
+	}
+
+	var curStatus string = sub.Status
+	sub.Status = domain_status.SUBSCRIPTION_ACTIVE
+	sub.CurrentPeriodStart = &invoice.PeriodStart
+	sub.CurrentPeriodEnd = &invoice.PeriodEnd
+	sub.EndedAt = nil
+	sub.UpdatedAt = curTime
+
+	if err := s.subscriptionRepo.UpdateSubscription(*sub, curStatus, ctx); err != nil {
+		invoice.Status = domain_status.SUBSCRIPTION_INVOICE_PENDING
+		invoice.PaidAt = nil
+		s.subscriptionRepo.UpdateSubscriptionInvoice(*invoice, domain_status.SUBSCRIPTION_INVOICE_PAID, ctx)
+		return err
+	}
+
+	return postSubscriptionLedgerEntry(s.ledgerRepo, *invoice, ctx)
+}
+
+// Void the unpaid invoice and end the subscription. Tour guides who had the plan are told that it was downgraded
+func (s *subscriptionService) expireSubscription(invoice entity.SubscriptionInvoice, ctx context.Context) (bool, error) {
+	sub, err := s.subscriptionRepo.GetSubscriptionById(invoice.SubscriptionId, ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if sub == nil {
+		return false, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Subscription{}.GetSubscriptionTable()))
+	}
+
+	invoice.Status = domain_status.SUBSCRIPTION_INVOICE_VOID
+	if err := s.subscriptionRepo.UpdateSubscriptionInvoice(invoice, domain_status.SUBSCRIPTION_INVOICE_PENDING, ctx); err != nil {
+		return false, err
+	}
+
+	var curStatus string = sub.Status
+	var curTime time.Time = time.Now()
+	sub.Status = domain_status.SUBSCRIPTION_EXPIRED
+	sub.EndedAt = &curTime
+	sub.UpdatedAt = curTime
+
+	if err := s.subscriptionRepo.UpdateSubscription(*sub, curStatus, ctx); err != nil {
+		return false, err
+	}
+
+	if curStatus != domain_status.SUBSCRIPTION_PAST_DUE {
+		return false, nil
+	}
+
+	if plan, _ := s.subscriptionRepo.GetSubscriptionPlanById(sub.SubscriptionPlanId, ctx); plan != nil {
+		s.sendDowngradeMail(*sub, *plan, ctx)
+	}
+
+	return true, nil
+}
+
+// Send a payment reminder, a cancelled or expired link is replaced first
+func (s *subscriptionService) remindInvoice(invoice entity.SubscriptionInvoice, linkStatus string, ctx context.Context) error {
+	sub, err := s.subscriptionRepo.GetSubscriptionById(invoice.SubscriptionId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if sub == nil {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.Subscription{}.GetSubscriptionTable()))
+	}
+
+	plan, err := s.getSubscriptionPlan(sub.SubscriptionPlanId, ctx)
+	if err != nil {
+		return err
+	}
+
+	if linkStatus == wallet.PAYOS_CANCELLED_STATUS || linkStatus == wallet.PAYOS_EXPIRED_STATUS {
+		if err := s.renewInvoiceLink(&invoice); err != nil {
+			return err
+		}
+	}
+
+	var curTime time.Time = time.Now()
+	invoice.ReminderCount++
+	invoice.LastRemindedAt = &curTime
+	if err := s.subscriptionRepo.UpdateSubscriptionInvoice(invoice, domain_status.SUBSCRIPTION_INVOICE_PENDING, ctx); err != nil {
+		return err
+	}
+
+	s.sendInvoiceMail(*sub, *plan, invoice, true, ctx)
+	return nil
+}
+
+func (s *subscriptionService) voidPendingInvoice(subscriptionId int, ctx context.Context) error {
+	invoice, err := s.subscriptionRepo.GetPendingSubscriptionInvoice(subscriptionId, ctx)
+	if err != nil || invoice == nil {
+		return err
+	}
+
+	invoice.Status = domain_status.SUBSCRIPTION_INVOICE_VOID
+	return s.subscriptionRepo.UpdateSubscriptionInvoice(*invoice, domain_status.SUBSCRIPTION_INVOICE_PENDING, ctx)
+}
+
+// Create a new PayOS link for the invoice, the previous order code is replaced
+func (s *subscriptionService) renewInvoiceLink(invoice *entity.SubscriptionInvoice) error {
+	var orderCode int64 = int64(utils.GenerateNumber())
+
+	data, err := createPayosPaymentLink(orderCode, int(invoice.Amount), fmt.Sprintf("Subscription %d", orderCode), s.logger)
+	if err != nil {
+		s.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	invoice.OrderCode = orderCode
+	invoice.CheckoutUrl = data.CheckoutUrl
+	return nil
+}
+
+func (s *subscriptionService) getInvoiceLinkStatus(invoice entity.SubscriptionInvoice) (string, error) {
+	link, err := payos.GetPaymentLinkInformation(strconv.FormatInt(invoice.OrderCode, 10))
+	if err != nil {
+		s.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return "", errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return link.Status, nil
+}
+
+func (s *subscriptionService) getSubscriptionPlan(id int, ctx context.Context) (*entity.SubscriptionPlan, error) {
+	plan, err := s.subscriptionRepo.GetSubscriptionPlanById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.SubscriptionPlan{}.GetSubscriptionPlanTable()))
+	}
+
+	return plan, nil
+}
+
+func (s *subscriptionService) toSubscriptionResponse(sub entity.Subscription, ctx context.Context) (*response.SubscriptionResponse, error) {
+	plan, err := s.subscriptionRepo.GetSubscriptionPlanById(sub.SubscriptionPlanId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.subscriptionRepo.GetPendingSubscriptionInvoice(sub.SubscriptionId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.SubscriptionResponse{
+		Subscription: sub,
+		Plan:         plan,
+		OpenInvoice:  invoice,
+	}, nil
+}
+
+func (s *subscriptionService) getTourGuideName(tourGuideId int, ctx context.Context) string {
+	var res string = "Tour guide"
+	if tourguideInfo, _ := s.userService.GetTourGuideById(ctx, &user_pb.GetTourGuideByIdRequest{
+		TourGuideId: int32(tourGuideId),
+	}); tourguideInfo != nil && tourguideInfo.FullName != "" {
+		res = tourguideInfo.FullName
+	}
+
+	return html.EscapeString(res)
+}
+
+func (s *subscriptionService) sendInvoiceMail(sub entity.Subscription, plan entity.SubscriptionPlan, invoice entity.SubscriptionInvoice, isReminder bool, ctx context.Context) {
+	var subject string = noti.SUBSCRIPTION_INVOICE_MAIL_SUBJECT
+	if isReminder {
+		subject = noti.SUBSCRIPTION_REMINDER_MAIL_SUBJECT
+	}
+
+	utils.SendMail(request.SendMailRequest{
+		Body: request.MailBody{ // Mail body
+			Subject:  subject,
+			Email:    sub.BillingEmail,
+			Username: s.getTourGuideName(sub.TourGuideId, ctx),
+			Subscription: request.SubscriptionMailBody{
+				PlanName:    html.EscapeString(plan.Name),
+				Amount:      utils.FormatMoney(invoice.Amount) + "đ",
+				PeriodStart: invoice.PeriodStart.Format("02/01/2006"),
+				PeriodEnd:   invoice.PeriodEnd.Format("02/01/2006"),
+				DueAt:       invoice.DueAt.AddDate(0, 0, getSubscriptionGraceDays()).Format("02/01/2006"),
+				CheckoutUrl: invoice.CheckoutUrl,
+				IsReminder:  isReminder,
+			},
+		},
+		TemplatePath: mail_const.SUBSCRIPTION_INVOICE_MAIL_TEMPLATE,
+		Logger:       s.logger, // Logger
+	})
+}
+
+func (s *subscriptionService) sendDowngradeMail(sub entity.Subscription, plan entity.SubscriptionPlan, ctx context.Context) {
+	utils.SendMail(request.SendMailRequest{
+		Body: request.MailBody{ // Mail body
+			Subject:  noti.SUBSCRIPTION_DOWNGRADE_MAIL_SUBJECT,
+			Email:    sub.BillingEmail,
+			Username: s.getTourGuideName(sub.TourGuideId, ctx),
+			Subscription: request.SubscriptionMailBody{
+				PlanName: html.EscapeString(plan.Name),
+			},
+		},
+		TemplatePath: mail_const.SUBSCRIPTION_DOWNGRADE_MAIL_TEMPLATE,
+		Logger:       s.logger, // Logger
+	})
+}
+
+func getSubscriptionGraceDays() int {
+	if days, err := strconv.Atoi(os.Getenv(payment_env.SUBSCRIPTION_GRACE_DAYS)); err == nil && days >= 0 {
+		return days
+	}
+
+	return subscription.DEFAULT_GRACE_DAYS
+}
+
+func getSubscriptionReminderIntervalDays() int {
+	if days, err := strconv.Atoi(os.Getenv(payment_env.SUBSCRIPTION_REMINDER_INTERVAL_DAYS)); err == nil && days > 0 {
+		return days
+	}
+
+	return subscription.DEFAULT_REMINDER_INTERVAL_DAYS
+}
@@ -0,0 +1,338 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/tax"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	"tourmate/payment-service/infrastructure/grpc/user/pb"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+)
+
+type taxService struct {
+	logger      *log.Logger
+	userService business_logic.IUserService
+	taxRepo     repo.ITaxRepo
+}
+
+func InitializeTaxService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.ITaxService {
+	return &taxService{
+		logger:      logger,
+		userService: userService,
+		taxRepo:     repository.InitializeTaxRepo(db, logger),
+	}
+}
+
+func GenerateTaxService() (business_logic.ITaxService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeTaxService(cnn, userService, logger), nil
+}
+
+// GetTaxProfile implements businesslogic.ITaxService.
+func (t *taxService) GetTaxProfile(tourGuideId int, ctx context.Context) (*response.TaxProfileResponse, error) {
+	profile, err := t.taxRepo.GetTaxProfile(tourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.TaxProfile{}.GetTaxProfileTable()))
+	}
+
+	return t.toTaxProfileResponse(*profile)
+}
+
+// SaveTaxProfile implements businesslogic.ITaxService.
+func (t *taxService) SaveTaxProfile(req request.SaveTaxProfileRequest, ctx context.Context) (*response.TaxProfileResponse, error) {
+	var taxCode string = strings.TrimSpace(req.TaxCode)
+	if taxCode != "" && !utils.IsTaxCodeValid(taxCode) {
+		return nil, errors.New(noti.INVALID_TAX_CODE_WARN_MSG)
+	}
+
+	if taxCode == "" && req.TaxpayerType == tax.BUSINESS_TAXPAYER {
+		return nil, errors.New(noti.TAX_CODE_REQUIRED_WARN_MSG)
+	}
+
+	profile, err := t.taxRepo.GetTaxProfile(req.TourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var curTime time.Time = time.Now()
+	if profile == nil {
+		profile = &entity.TaxProfile{
+			TourGuideId: req.TourGuideId,
+			CreatedAt:   curTime,
+		}
+	}
+
+	profile.TaxpayerType = req.TaxpayerType
+	profile.TaxCode = taxCode
+	profile.LegalName = strings.ToUpper(strings.TrimSpace(req.LegalName))
+	profile.Address = strings.TrimSpace(req.Address)
+	profile.UpdatedAt = curTime
+
+	// The identity number is kept when it is not sent again
+	if req.IdentityNumber != "" {
+		encryptedNumber, err := utils.EncryptString(req.IdentityNumber)
+		if err != nil {
+			t.logger.Println(noti.DATA_ENCRYPTION_ERR_MSG + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		profile.IdentityNumber = encryptedNumber
+	}
+
+	id, err := t.taxRepo.SaveTaxProfile(*profile, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.TaxProfileId = id
+
+	return t.toTaxProfileResponse(*profile)
+}
+
+// GetTaxWithholdings implements businesslogic.ITaxService.
+func (t *taxService) GetTaxWithholdings(req request.GetTaxWithholdingsRequest, ctx context.Context) (*response.TaxWithholdingLedgerResponse, error) {
+	from, to, _ := utils.GetReportPeriod(req.Year, req.Month)
+
+	withholdings, err := t.taxRepo.GetPaidTaxWithholdings(req.TourGuideId, from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.TaxWithholdingLedgerResponse = response.TaxWithholdingLedgerResponse{
+		Items: *withholdings,
+	}
+
+	for _, withholding := range *withholdings {
+		res.TotalGrossAmount += withholding.GrossAmount
+		res.TotalTaxAmount += withholding.TaxAmount
+	}
+
+	res.TotalGrossAmount = utils.RoundMoney(res.TotalGrossAmount)
+	res.TotalTaxAmount = utils.RoundMoney(res.TotalTaxAmount)
+
+	return &res, nil
+}
+
+// GetTaxCertificate implements businesslogic.ITaxService.
+func (t *taxService) GetTaxCertificate(req request.GetTaxCertificateRequest, ctx context.Context) (*response.TaxCertificateResponse, error) {
+	from, to, _ := utils.GetReportPeriod(req.Year, 0)
+
+	withholdings, err := t.taxRepo.GetPaidTaxWithholdings(&req.TourGuideId, from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.TaxCertificateResponse = response.TaxCertificateResponse{
+		Year:         req.Year,
+		TourGuideId:  req.TourGuideId,
+		TaxpayerType: tax.INDIVIDUAL_TAXPAYER,
+		Payments:     *withholdings,
+	}
+
+	profile, err := t.taxRepo.GetTaxProfile(req.TourGuideId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile != nil {
+		// The certificate is issued to the guide so the identity number is shown in full
+		identityNumber, err := t.decryptIdentityNumber(profile.IdentityNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		res.TaxpayerType = profile.TaxpayerType
+		res.TaxCode = profile.TaxCode
+		res.LegalName = profile.LegalName
+		res.IdentityNumber = identityNumber
+		res.Address = profile.Address
+	} else if tourguideInfo, _ := t.userService.GetTourGuideById(ctx, &pb.GetTourGuideByIdRequest{
+		TourGuideId: int32(req.TourGuideId),
+	}); tourguideInfo != nil {
+		res.LegalName = strings.ToUpper(tourguideInfo.FullName)
+	}
+
+	for _, withholding := range *withholdings {
+		var month int = int(withholding.PaidAt.Month())
+		if res.FromMonth == 0 || month < res.FromMonth {
+			res.FromMonth = month
+		}
+
+		if month > res.ToMonth {
+			res.ToMonth = month
+		}
+
+		res.TotalIncome += withholding.GrossAmount
+		res.TaxWithheld += withholding.TaxAmount
+		if withholding.TaxAmount > 0 {
+			res.TaxableIncome += withholding.GrossAmount
+		}
+	}
+
+	res.TotalIncome = utils.RoundMoney(res.TotalIncome)
+	res.TaxableIncome = utils.RoundMoney(res.TaxableIncome)
+	res.TaxWithheld = utils.RoundMoney(res.TaxWithheld)
+
+	return &res, nil
+}
+
+// ExportTaxDeclaration implements businesslogic.ITaxService.
+func (t *taxService) ExportTaxDeclaration(req request.ExportTaxDeclarationRequest, ctx context.Context) (response.FileResponse, error) {
+	from, to, period := utils.GetReportPeriod(req.Year, req.Month)
+
+	summaries, err := t.taxRepo.GetIndividualIncomeSummaries(from, to, ctx)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	// Columns follow the list of individuals attached to the PIT declaration
+	var headers []string = []string{
+		"STT", "Họ và tên", "Mã số thuế", "Số CMND/CCCD",
+		"Tổng thu nhập chịu thuế trả cho cá nhân", "Thu nhập thuộc diện khấu trừ thuế", "Số thuế TNCN đã khấu trừ",
+	}
+
+	var rows [][]string
+	var totalIncome, totalTaxableIncome, totalTax float64
+	for i, summary := range *summaries {
+		var legalName, taxCode, identityNumber string
+
+		profile, err := t.taxRepo.GetTaxProfile(summary.TourGuideId, ctx)
+		if err != nil {
+			return response.FileResponse{}, err
+		}
+
+		if profile != nil {
+			legalName = profile.LegalName
+			taxCode = profile.TaxCode
+
+			if identityNumber, err = t.decryptIdentityNumber(profile.IdentityNumber); err != nil {
+				return response.FileResponse{}, err
+			}
+		} else if tourguideInfo, _ := t.userService.GetTourGuideById(ctx, &pb.GetTourGuideByIdRequest{
+			TourGuideId: int32(summary.TourGuideId),
+		}); tourguideInfo != nil {
+			legalName = strings.ToUpper(tourguideInfo.FullName)
+		}
+
+		totalIncome += summary.TotalIncome
+		totalTaxableIncome += summary.TaxableIncome
+		totalTax += summary.TaxAmount
+
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1),
+			legalName,
+			taxCode,
+			identityNumber,
+			fmt.Sprintf("%.0f", summary.TotalIncome),
+			fmt.Sprintf("%.0f", summary.TaxableIncome),
+			fmt.Sprintf("%.0f", summary.TaxAmount),
+		})
+	}
+
+	rows = append(rows, []string{
+		"", "Tổng cộng", "", "",
+		fmt.Sprintf("%.0f", totalIncome),
+		fmt.Sprintf("%.0f", totalTaxableIncome),
+		fmt.Sprintf("%.0f", totalTax),
+	})
+
+	content, contentType, err := utils.GenerateTabularFile(req.Format, "TNCN", headers, rows)
+	if err != nil {
+		t.logger.Println(fmt.Sprintf(noti.FILE_GENERATE_ERR_MSG, req.Format) + err.Error())
+		return response.FileResponse{}, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return response.FileResponse{
+		FileName:    fmt.Sprintf("pit_declaration_%s.%s", strings.ReplaceAll(period, "/", "_"), req.Format),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+func (t *taxService) toTaxProfileResponse(profile entity.TaxProfile) (*response.TaxProfileResponse, error) {
+	identityNumber, err := t.decryptIdentityNumber(profile.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.TaxProfileResponse{
+		TaxProfileId:   profile.TaxProfileId,
+		TourGuideId:    profile.TourGuideId,
+		TaxpayerType:   profile.TaxpayerType,
+		TaxCode:        profile.TaxCode,
+		LegalName:      profile.LegalName,
+		IdentityNumber: utils.MaskAccountNumber(identityNumber),
+		Address:        profile.Address,
+		CreatedAt:      profile.CreatedAt,
+		UpdatedAt:      profile.UpdatedAt,
+	}, nil
+}
+
+func (t *taxService) decryptIdentityNumber(identityNumber string) (string, error) {
+	if identityNumber == "" {
+		return "", nil
+	}
+
+	res, err := utils.DecryptString(identityNumber)
+	if err != nil {
+		t.logger.Println(noti.DATA_DECRYPTION_ERR_MSG + err.Error())
+		return "", errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// Calculate personal income tax of a payout, guides without a tax profile are treated as individuals.
+// Business taxpayers declare their own tax so nothing is withheld
+func calculateTaxWithholding(taxRepo repo.ITaxRepo, tourGuideId int, grossAmount float64, curTime time.Time, ctx context.Context) (entity.TaxWithholding, error) {
+	var res entity.TaxWithholding = entity.TaxWithholding{
+		TourGuideId:  tourGuideId,
+		TaxpayerType: tax.INDIVIDUAL_TAXPAYER,
+		GrossAmount:  utils.RoundMoney(grossAmount),
+		CreatedAt:    curTime,
+	}
+
+	profile, err := taxRepo.GetTaxProfile(tourGuideId, ctx)
+	if err != nil {
+		return res, err
+	}
+
+	if profile != nil {
+		res.TaxpayerType = profile.TaxpayerType
+		res.TaxCode = profile.TaxCode
+	}
+
+	if res.TaxpayerType == tax.INDIVIDUAL_TAXPAYER && grossAmount >= tax.PIT_WITHHOLDING_THRESHOLD {
+		res.TaxRate = tax.PIT_WITHHOLDING_RATE
+		res.TaxAmount = utils.RoundMoney(grossAmount * tax.PIT_WITHHOLDING_RATE)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,288 @@
+package businesslogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	payment_method "tourmate/payment-service/constant/payment_method"
+	"tourmate/payment-service/constant/wallet"
+	"tourmate/payment-service/infrastructure/grpc/user"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/repository"
+	"tourmate/payment-service/repository/db"
+	db_server "tourmate/payment-service/repository/db_server"
+	"tourmate/payment-service/utils"
+
+	"github.com/payOSHQ/payos-lib-golang"
+)
+
+type walletService struct {
+	logger     *log.Logger
+	recorder   *paymentRecorder
+	walletRepo repo.IWalletRepo
+	ledgerRepo repo.ILedgerRepo
+}
+
+func InitializeWalletService(db *sql.DB, userService business_logic.IUserService, logger *log.Logger) business_logic.IWalletService {
+	return &walletService{
+		logger:     logger,
+		recorder:   initializePaymentRecorder(db, userService, logger),
+		walletRepo: repository.InitializeWalletRepo(db, logger),
+		ledgerRepo: repository.InitializeLedgerRepo(db, logger),
+	}
+}
+
+func GenerateWalletService() (business_logic.IWalletService, error) {
+	var logger = utils.GetLogConfig()
+
+	cnn, err := db.ConnectDB(logger, db_server.InitializeMsSQL())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userService, _ := user.GenerateUserService(logger)
+
+	return InitializeWalletService(cnn, userService, logger), nil
+}
+
+// GetWallet implements businesslogic.IWalletService.
+func (w *walletService) GetWallet(customerId int, ctx context.Context) (*entity.Wallet, error) {
+	res, err := w.walletRepo.GetWalletByCustomerId(customerId, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return &entity.Wallet{CustomerId: customerId}, nil
+	}
+
+	return res, nil
+}
+
+// GetWalletTransactions implements businesslogic.IWalletService.
+func (w *walletService) GetWalletTransactions(req request.GetWalletTransactionsRequest, ctx context.Context) (response.PaginationDataResponse, error) {
+	if req.Request.Page < 1 {
+		req.Request.Page = 1
+	}
+
+	req.PageSize = entity.WalletTransaction{}.GetWalletTransactionLimitRecords()
+
+	data, pages, totalRecords, err := w.walletRepo.GetWalletTransactions(req, ctx)
+
+	return response.PaginationDataResponse{
+		Data:        data,
+		Page:        req.Request.Page,
+		TotalPages:  pages,
+		TotalCount:  totalRecords,
+		PerPage:     req.PageSize,
+		HasNext:     req.Request.Page < pages,
+		HasPrevious: req.Request.Page > 1,
+	}, err
+}
+
+// CreateWalletTopUp implements businesslogic.IWalletService.
+func (w *walletService) CreateWalletTopUp(req request.CreateWalletTopUpRequest, ctx context.Context) (*entity.WalletTopUp, error) {
+	var orderCode int64 = int64(utils.GenerateNumber())
+	var amount int = int(req.Amount)
+
+	data, err := createPayosPaymentLink(orderCode, amount, fmt.Sprintf("Wallet topup %d", orderCode), w.logger)
+	if err != nil {
+		w.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	var res entity.WalletTopUp = entity.WalletTopUp{
+		CustomerId:  req.CustomerId,
+		Amount:      float64(amount),
+		OrderCode:   orderCode,
+		CheckoutUrl: data.CheckoutUrl,
+		Status:      domain_status.WALLET_TOP_UP_PENDING,
+		CreatedAt:   time.Now(),
+	}
+
+	id, err := w.walletRepo.CreateWalletTopUp(res, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.WalletTopUpId = id
+	return &res, nil
+}
+
+// ConfirmWalletTopUp implements businesslogic.IWalletService.
+func (w *walletService) ConfirmWalletTopUp(id int, ctx context.Context) (*entity.WalletTopUp, error) {
+	topUp, err := w.walletRepo.GetWalletTopUpById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if topUp == nil {
+		return nil, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, entity.WalletTopUp{}.GetWalletTopUpTable()))
+	}
+
+	// Confirming again is harmless
+	if topUp.Status != domain_status.WALLET_TOP_UP_PENDING {
+		return topUp, nil
+	}
+
+	link, err := payos.GetPaymentLinkInformation(strconv.FormatInt(topUp.OrderCode, 10))
+	if err != nil {
+		w.logger.Println(fmt.Sprintf(noti.PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG, payment_method.PAYOS) + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	var curTime time.Time = time.Now()
+	switch link.Status {
+	case wallet.PAYOS_PAID_STATUS:
+		// The top-up is taken first so that the wallet is credited once
+		topUp.Status = domain_status.WALLET_TOP_UP_PAID
+		topUp.PaidAt = &curTime
+		if err := w.walletRepo.UpdateWalletTopUp(*topUp, domain_status.WALLET_TOP_UP_PENDING, ctx); err != nil {
+			return nil, err
+		}
+
+		if _, err := changeWalletBalance(w.walletRepo, w.ledgerRepo, entity.WalletTransaction{
+			CustomerId:      topUp.CustomerId,
+			TransactionType: wallet.TOP_UP,
+			Amount:          topUp.Amount,
+			ReferenceId:     &topUp.WalletTopUpId,
+			Description:     fmt.Sprintf("Top-up %d", topUp.OrderCode),
+			CreatedBy:       topUp.CustomerId,
+		}, ctx); err != nil {
+			topUp.PaidAt = nil
+			topUp.Status = domain_status.WALLET_TOP_UP_PENDING
+			w.walletRepo.UpdateWalletTopUp(*topUp, domain_status.WALLET_TOP_UP_PAID, ctx)
+			return nil, err
+		}
+
+		return topUp, nil
+	case wallet.PAYOS_CANCELLED_STATUS, wallet.PAYOS_EXPIRED_STATUS:
+		topUp.Status = domain_status.WALLET_TOP_UP_CANCELLED
+		return topUp, w.walletRepo.UpdateWalletTopUp(*topUp, domain_status.WALLET_TOP_UP_PENDING, ctx)
+	default:
+		return nil, errors.New(noti.WALLET_TOP_UP_NOT_PAID_WARN_MSG)
+	}
+}
+
+// PayWithWallet implements businesslogic.IWalletService.
+func (w *walletService) PayWithWallet(req request.PayWithWalletRequest, ctx context.Context) (*response.WalletPaymentResponse, error) {
+	var walletAmount float64 = req.Price
+	if req.WalletAmount != nil {
+		walletAmount = *req.WalletAmount
+	}
+
+	if walletAmount > req.Price {
+		return nil, errors.New(noti.WALLET_AMOUNT_EXCEEDS_PRICE_WARN_MSG)
+	}
+
+	if err := w.recorder.checkPendingSplitPayment(req.InvoiceId, ctx); err != nil {
+		return nil, err
+	}
+
+	// The invoice is claimed before touching the wallet so that two requests cannot both take money for it
+	payment, err := w.recorder.claimInvoice(entity.Payment{
+		CustomerId:    req.CustomerId,
+		InvoiceId:     req.InvoiceId,
+		ServiceId:     req.ServiceId,
+		Price:         walletAmount,
+		PaymentMethod: payment_method.WALLET,
+	}, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.WalletPaymentResponse = response.WalletPaymentResponse{
+		RemainingAmount: utils.RoundMoney(req.Price - walletAmount),
+		Payment:         payment,
+	}
+
+	res.WalletTransaction, err = changeWalletBalance(w.walletRepo, w.ledgerRepo, entity.WalletTransaction{
+		CustomerId:      req.CustomerId,
+		TransactionType: wallet.PAYMENT,
+		Amount:          -walletAmount,
+		ReferenceId:     &req.InvoiceId,
+		Description:     fmt.Sprintf("Payment of invoice %d", req.InvoiceId),
+		CreatedBy:       req.CustomerId,
+	}, ctx)
+
+	if err != nil {
+		w.recorder.releaseInvoice(*payment, ctx)
+		return nil, err
+	}
+
+	if res.RemainingAmount > 0 {
+		// The wallet money stays held by the pending payment, the invoice is only paid once the link is paid
+		res.SplitPayment, err = w.recorder.openSplitPayment(*payment, entity.SplitPayment{
+			TourGuideId:   req.TourGuideId,
+			GatewayAmount: res.RemainingAmount,
+		}, int(math.Round(res.RemainingAmount)), ctx)
+
+		if err == nil {
+			res.CheckoutUrl = res.SplitPayment.CheckoutUrl
+		}
+	} else {
+		err = w.recorder.completeClaimedPayment(payment, req.TourGuideId, ctx)
+	}
+
+	if err != nil {
+		// Give the money back and free the invoice, the payment was not recorded
+		changeWalletBalance(w.walletRepo, w.ledgerRepo, entity.WalletTransaction{
+			CustomerId:      req.CustomerId,
+			TransactionType: wallet.REVERSAL,
+			Amount:          walletAmount,
+			ReferenceId:     &req.InvoiceId,
+			Description:     fmt.Sprintf("Failed payment of invoice %d", req.InvoiceId),
+			CreatedBy:       systemActorId,
+		}, ctx)
+
+		w.recorder.releaseInvoice(*payment, ctx)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// AdjustWallet implements businesslogic.IWalletService.
+func (w *walletService) AdjustWallet(req request.AdjustWalletRequest, ctx context.Context) (*entity.WalletTransaction, error) {
+	if utils.RoundMoney(req.Amount) == 0 {
+		return nil, errors.New(noti.ZERO_WALLET_ADJUSTMENT_WARN_MSG)
+	}
+
+	return changeWalletBalance(w.walletRepo, w.ledgerRepo, entity.WalletTransaction{
+		CustomerId:      req.CustomerId,
+		TransactionType: wallet.ADJUSTMENT,
+		Amount:          req.Amount,
+		Description:     req.Reason,
+		CreatedBy:       req.ActorId,
+	}, ctx)
+}
+
+// Apply a wallet transaction and post its journal entry
+func changeWalletBalance(walletRepo repo.IWalletRepo, ledgerRepo repo.ILedgerRepo, transaction entity.WalletTransaction, ctx context.Context) (*entity.WalletTransaction, error) {
+	transaction.Amount = utils.RoundMoney(transaction.Amount)
+	transaction.CreatedAt = time.Now()
+
+	res, err := walletRepo.ChangeWalletBalance(transaction, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postWalletLedgerEntry(ledgerRepo, *res, ctx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -23,6 +23,9 @@ func Execute() {
 	// Setup gRPC routes
 	go setupGrpc(logger, service)
 
+	// Setup background schedulers
+	setupSchedulers(logger)
+
 	// Setup API routes
 	setupApiRoutes(logger, service)
 }
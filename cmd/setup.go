@@ -12,6 +12,7 @@ import (
 	"tourmate/payment-service/docs"
 	api "tourmate/payment-service/route/api"
 	grpc "tourmate/payment-service/route/gRPC"
+	"tourmate/payment-service/route/scheduler"
 
 	_ "tourmate/payment-service/docs"
 
@@ -46,6 +47,54 @@ func setupApiRoutes(logger *log.Logger, service string) {
 	// Revenue API endpoints
 	api.InitializeRevenueHandlerRoute(server, service)
 
+	// Payout API endpoints
+	api.InitializePayoutHandlerRoute(server, service)
+
+	// Payout Account API endpoints
+	api.InitializePayoutAccountHandlerRoute(server, service)
+
+	// Ledger API endpoints
+	api.InitializeLedgerHandlerRoute(server, service)
+
+	// Fiscal Period API endpoints
+	api.InitializeFiscalPeriodHandlerRoute(server, service)
+
+	// Tax API endpoints
+	api.InitializeTaxHandlerRoute(server, service)
+
+	// E-Invoice API endpoints
+	api.InitializeEInvoiceHandlerRoute(server, service)
+
+	// Accounting API endpoints
+	api.InitializeAccountingHandlerRoute(server, service)
+
+	// Bank Statement API endpoints
+	api.InitializeBankStatementHandlerRoute(server, service)
+
+	// Offline Payment API endpoints
+	api.InitializeOfflinePaymentHandlerRoute(server, service)
+
+	// Wallet API endpoints
+	api.InitializeWalletHandlerRoute(server, service)
+
+	// Gift Card API endpoints
+	api.InitializeGiftCardHandlerRoute(server, service)
+
+	// Loyalty API endpoints
+	api.InitializeLoyaltyHandlerRoute(server, service)
+
+	// Referral API endpoints
+	api.InitializeReferralHandlerRoute(server, service)
+
+	// Agency API endpoints
+	api.InitializeAgencyHandlerRoute(server, service)
+
+	// Subscription API endpoints
+	api.InitializeSubscriptionHandlerRoute(server, service)
+
+	// Gateway fee API endpoints
+	api.InitializeGatewayFeeHandlerRoute(server, service)
+
 	// Default URL
 	server.GET("/", func(ctx *gin.Context) {
 		ctx.Redirect(http.StatusMovedPermanently, "/swagger/index.html#")
@@ -87,6 +136,11 @@ func setupGrpc(logger *log.Logger, service string) {
 
 }
 
+func setupSchedulers(logger *log.Logger) {
+	// Background jobs such as automatic payout batches
+	scheduler.InitializeSchedulers(logger)
+}
+
 func setupPayments(logger *log.Logger) {
 	// Payos
 	if err := payos.Key(os.Getenv(payment_env.PAYOS_CLIENT_ID), os.Getenv(payment_env.PAYOS_API_KEY), os.Getenv(payment_env.PAYOS_CHECKSUM_KEY)); err != nil {
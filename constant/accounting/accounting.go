@@ -0,0 +1,40 @@
+package accounting
+
+// Default account codes of the chart of accounts in Circular 200/2014/TT-BTC
+const (
+	DEFAULT_GATEWAY_CLEARING_CODE    string = "1121" // TIỀN GỬI NGÂN HÀNG
+	DEFAULT_CUSTOMER_RECEIVABLE_CODE string = "131"  // PHẢI THU CỦA KHÁCH HÀNG
+	DEFAULT_GUIDE_PAYABLE_CODE       string = "331"  // PHẢI TRẢ CHO NGƯỜI BÁN
+	DEFAULT_PLATFORM_COMMISSION_CODE string = "5113" // DOANH THU CUNG CẤP DỊCH VỤ
+	DEFAULT_TAX_PAYABLE_CODE         string = "3335" // THUẾ THU NHẬP CÁ NHÂN
+	DEFAULT_AGENCY_PAYABLE_CODE      string = "331"  // PHẢI TRẢ CHO NGƯỜI BÁN
+	DEFAULT_GATEWAY_FEE_CODE         string = "6417" // CHI PHÍ DỊCH VỤ MUA NGOÀI
+
+	DEFAULT_SUBSCRIPTION_REVENUE_CODE string = "5113" // DOANH THU CUNG CẤP DỊCH VỤ
+)
+
+// Voucher number prefixes, the number is the ID of the source record
+const (
+	PAYMENT_VOUCHER    string = "TT"
+	REFUND_VOUCHER     string = "HT"
+	ADJUSTMENT_VOUCHER string = "DC"
+	PAYOUT_VOUCHER     string = "CT"
+
+	AGENCY_REVERSAL_VOUCHER string = "DCDL"
+	AGENCY_PAYOUT_VOUCHER   string = "CTDL"
+	SUBSCRIPTION_VOUCHER    string = "DK"
+)
+
+// Accounting object code prefixes
+const (
+	CUSTOMER_OBJECT string = "KH"
+	GUIDE_OBJECT    string = "HDV"
+	AGENCY_OBJECT   string = "DL"
+)
+
+// MISA import layout of other vouchers
+const (
+	MISA_FORMAT      string = "misa"
+	MISA_SHEET       string = "Chứng từ nghiệp vụ khác"
+	MISA_DATE_FORMAT string = "02/01/2006"
+)
@@ -8,4 +8,6 @@ const (
 	INFORM string = "INFORM"
 
 	CREATE_ACTION string = "CREATE_ACTION"
+
+	FILE_DOWNLOAD string = "FILE_DOWNLOAD"
 )
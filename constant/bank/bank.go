@@ -0,0 +1,39 @@
+package bank
+
+// Supported bank codes
+const (
+	VIETCOMBANK string = "VCB"
+	TECHCOMBANK string = "TCB"
+	BIDV        string = "BIDV"
+	VIETINBANK  string = "ICB"
+	AGRIBANK    string = "VBA"
+	ACB         string = "ACB"
+	MB_BANK     string = "MB"
+	VP_BANK     string = "VPB"
+	TP_BANK     string = "TPB"
+	SACOMBANK   string = "STB"
+	SHB         string = "SHB"
+	HD_BANK     string = "HDB"
+	VIB         string = "VIB"
+	OCB         string = "OCB"
+	MSB         string = "MSB"
+)
+
+// Bank identification numbers (NAPAS BIN)
+const (
+	VIETCOMBANK_BIN string = "970436"
+	TECHCOMBANK_BIN string = "970407"
+	BIDV_BIN        string = "970418"
+	VIETINBANK_BIN  string = "970415"
+	AGRIBANK_BIN    string = "970405"
+	ACB_BIN         string = "970416"
+	MB_BANK_BIN     string = "970422"
+	VP_BANK_BIN     string = "970432"
+	TP_BANK_BIN     string = "970423"
+	SACOMBANK_BIN   string = "970403"
+	SHB_BIN         string = "970443"
+	HD_BANK_BIN     string = "970437"
+	VIB_BIN         string = "970441"
+	OCB_BIN         string = "970448"
+	MSB_BIN         string = "970426"
+)
@@ -0,0 +1,7 @@
+package commission
+
+// Used when the platform commission is not configured
+const (
+	// Platform commission in percent of the tour price
+	DEFAULT_PLATFORM_RATE float64 = 15
+)
@@ -0,0 +1,7 @@
+package domainstatus
+
+const (
+	AGENCY_PAYOUT_PENDING   string = "PENDING"   // CHỜ CHUYỂN KHOẢN CHO ĐẠI LÝ
+	AGENCY_PAYOUT_PAID      string = "PAID"      // ĐÃ CHUYỂN KHOẢN
+	AGENCY_PAYOUT_CANCELLED string = "CANCELLED" // ĐÃ HỦY, DOANH THU ĐƯỢC TRẢ LẠI
+)
@@ -0,0 +1,17 @@
+package domainstatus
+
+// Invoice waiting for a direct bank transfer
+const (
+	PENDING_INVOICE_PENDING   string = "PENDING"   // CHỜ CHUYỂN KHOẢN
+	PENDING_INVOICE_PAID      string = "PAID"      // ĐÃ NHẬN TIỀN
+	PENDING_INVOICE_CANCELLED string = "CANCELLED" // ĐÃ HỦY
+)
+
+// Transaction imported from a bank statement
+const (
+	BANK_TRANSACTION_MATCHED   string = "MATCHED"   // TỰ ĐỘNG KHỚP VỚI HÓA ĐƠN
+	BANK_TRANSACTION_REVIEW    string = "REVIEW"    // CHỜ KẾ TOÁN XÁC NHẬN
+	BANK_TRANSACTION_ACCEPTED  string = "ACCEPTED"  // KẾ TOÁN ĐÃ XÁC NHẬN
+	BANK_TRANSACTION_REJECTED  string = "REJECTED"  // KẾ TOÁN ĐÃ TỪ CHỐI
+	BANK_TRANSACTION_UNMATCHED string = "UNMATCHED" // KHÔNG THUỘC HÓA ĐƠN NÀO
+)
@@ -0,0 +1,7 @@
+package domainstatus
+
+const (
+	EINVOICE_ISSUED   string = "ISSUED"   // ĐÃ PHÁT HÀNH
+	EINVOICE_ADJUSTED string = "ADJUSTED" // ĐÃ CÓ HÓA ĐƠN ĐIỀU CHỈNH
+	EINVOICE_REPLACED string = "REPLACED" // ĐÃ BỊ THAY THẾ
+)
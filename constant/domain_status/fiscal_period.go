@@ -0,0 +1,6 @@
+package domainstatus
+
+const (
+	FISCAL_PERIOD_OPEN   string = "OPEN"   // KỲ KẾ TOÁN ĐANG MỞ
+	FISCAL_PERIOD_CLOSED string = "CLOSED" // KỲ KẾ TOÁN ĐÃ KHÓA SỔ
+)
@@ -0,0 +1,9 @@
+package domainstatus
+
+const (
+	GIFT_CARD_PENDING   string = "PENDING"   // CHỜ THANH TOÁN QUA CỔNG
+	GIFT_CARD_ACTIVE    string = "ACTIVE"    // ĐANG SỬ DỤNG ĐƯỢC
+	GIFT_CARD_REDEEMED  string = "REDEEMED"  // ĐÃ DÙNG HẾT SỐ DƯ
+	GIFT_CARD_EXPIRED   string = "EXPIRED"   // HẾT HẠN, SỐ DƯ CÒN LẠI GHI NHẬN VÀO DOANH THU
+	GIFT_CARD_CANCELLED string = "CANCELLED" // ĐÃ HỦY HOẶC HẾT HẠN THANH TOÁN
+)
@@ -0,0 +1,8 @@
+package domainstatus
+
+const (
+	OFFLINE_PAYMENT_PENDING           string = "PENDING"           // CHỜ XÁC NHẬN
+	OFFLINE_PAYMENT_AWAITING_APPROVAL string = "AWAITING_APPROVAL" // CHỜ NGƯỜI DUYỆT THỨ HAI
+	OFFLINE_PAYMENT_CONFIRMED         string = "CONFIRMED"         // ĐÃ XÁC NHẬN
+	OFFLINE_PAYMENT_REJECTED          string = "REJECTED"          // BỊ TỪ CHỐI
+)
@@ -0,0 +1,16 @@
+package domainstatus
+
+const (
+	PAYOUT_BATCH_DRAFT     string = "DRAFT"     // BẢN NHÁP, CHỜ DUYỆT
+	PAYOUT_BATCH_APPROVED  string = "APPROVED"  // ĐÃ DUYỆT, CHỜ XUẤT FILE NGÂN HÀNG
+	PAYOUT_BATCH_EXPORTED  string = "EXPORTED"  // ĐÃ XUẤT FILE, CHỜ KẾT QUẢ TỪ NGÂN HÀNG
+	PAYOUT_BATCH_COMPLETED string = "COMPLETED" // ĐÃ XỬ LÝ XONG TẤT CẢ GIAO DỊCH
+	PAYOUT_BATCH_CANCELLED string = "CANCELLED" // ĐÃ HỦY
+)
+
+const (
+	PAYOUT_ITEM_PENDING   string = "PENDING"   // CHỜ NGÂN HÀNG XỬ LÝ
+	PAYOUT_ITEM_PAID      string = "PAID"      // ĐÃ CHUYỂN KHOẢN THÀNH CÔNG
+	PAYOUT_ITEM_FAILED    string = "FAILED"    // CHUYỂN KHOẢN THẤT BẠI
+	PAYOUT_ITEM_CANCELLED string = "CANCELLED" // HỦY THEO ĐỢT CHI TRẢ
+)
@@ -0,0 +1,7 @@
+package domainstatus
+
+const (
+	PAYOUT_ACCOUNT_PENDING  string = "PENDING"  // CHỜ QUẢN TRỊ VIÊN XÁC MINH
+	PAYOUT_ACCOUNT_VERIFIED string = "VERIFIED" // ĐÃ XÁC MINH, CÓ THỂ NHẬN TIỀN
+	PAYOUT_ACCOUNT_REJECTED string = "REJECTED" // BỊ TỪ CHỐI XÁC MINH
+)
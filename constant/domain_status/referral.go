@@ -0,0 +1,13 @@
+package domainstatus
+
+const (
+	REFERRAL_PENDING   string = "PENDING"   // ĐÃ NHẬP MÃ, CHỜ THANH TOÁN ĐẦU TIÊN
+	REFERRAL_ACTIVE    string = "ACTIVE"    // ĐÃ GHI NHẬN Ở THANH TOÁN ĐẦU TIÊN
+	REFERRAL_COMPLETED string = "COMPLETED" // ĐÃ THƯỞNG ĐỦ SỐ TOUR
+)
+
+const (
+	REFERRAL_REWARD_PENDING   string = "PENDING"   // CHỜ CHI TRẢ CÙNG ĐỢT THANH TOÁN CHO HƯỚNG DẪN VIÊN
+	REFERRAL_REWARD_PAID      string = "PAID"      // ĐÃ CHI TRẢ VÀO VÍ HOẶC QUA NGÂN HÀNG
+	REFERRAL_REWARD_CANCELLED string = "CANCELLED" // ĐÃ HỦY DO HOÀN TIỀN
+)
@@ -0,0 +1,7 @@
+package domainstatus
+
+const (
+	SPLIT_PAYMENT_PENDING  string = "PENDING"  // CHỜ THANH TOÁN PHẦN CÒN LẠI QUA CỔNG
+	SPLIT_PAYMENT_PAID     string = "PAID"     // ĐÃ THANH TOÁN ĐỦ HÓA ĐƠN
+	SPLIT_PAYMENT_RELEASED string = "RELEASED" // ĐÃ TRẢ LẠI PHẦN GIỮ DO LIÊN KẾT BỊ HỦY HOẶC HẾT HẠN
+)
@@ -0,0 +1,15 @@
+package domainstatus
+
+const (
+	SUBSCRIPTION_PENDING   string = "PENDING"   // CHỜ THANH TOÁN HÓA ĐƠN ĐẦU TIÊN
+	SUBSCRIPTION_ACTIVE    string = "ACTIVE"    // ĐANG HOẠT ĐỘNG
+	SUBSCRIPTION_PAST_DUE  string = "PAST_DUE"  // QUÁ HẠN THANH TOÁN, CÒN TRONG THỜI GIAN ÂN HẠN
+	SUBSCRIPTION_CANCELLED string = "CANCELLED" // ĐÃ HỦY
+	SUBSCRIPTION_EXPIRED   string = "EXPIRED"   // ĐÃ HẠ CẤP DO KHÔNG THANH TOÁN
+)
+
+const (
+	SUBSCRIPTION_INVOICE_PENDING string = "PENDING" // CHỜ THANH TOÁN
+	SUBSCRIPTION_INVOICE_PAID    string = "PAID"    // ĐÃ THANH TOÁN
+	SUBSCRIPTION_INVOICE_VOID    string = "VOID"    // ĐÃ HỦY
+)
@@ -0,0 +1,7 @@
+package domainstatus
+
+const (
+	WALLET_TOP_UP_PENDING   string = "PENDING"   // CHỜ THANH TOÁN QUA CỔNG
+	WALLET_TOP_UP_PAID      string = "PAID"      // ĐÃ NẠP VÀO VÍ
+	WALLET_TOP_UP_CANCELLED string = "CANCELLED" // ĐÃ HỦY HOẶC HẾT HẠN
+)
@@ -0,0 +1,26 @@
+package einvoice
+
+// E-invoice types
+const (
+	ORIGINAL    string = "ORIGINAL"    // HÓA ĐƠN GỐC
+	ADJUSTMENT  string = "ADJUSTMENT"  // HÓA ĐƠN ĐIỀU CHỈNH
+	REPLACEMENT string = "REPLACEMENT" // HÓA ĐƠN THAY THẾ
+)
+
+// Defaults when the seller has not configured the invoice template
+const (
+	DEFAULT_TEMPLATE_CODE string  = "1"   // Ký hiệu mẫu số, 1 là hóa đơn giá trị gia tăng
+	DEFAULT_SERIES_SUFFIX string  = "TTM" // Phần cuối ký hiệu hóa đơn do người bán tự đặt
+	DEFAULT_VAT_RATE      float64 = 10
+)
+
+// Decree 123/2020/ND-CP
+const (
+	XML_VERSION        string = "2.0.0"
+	INVOICE_NAME       string = "Hóa đơn giá trị gia tăng"
+	MAX_INVOICE_NUMBER int    = 99999999
+	CURRENCY_CODE      string = "VND"
+	PAYMENT_METHOD     string = "TM/CK"
+)
+
+const HTML_TEMPLATE string = "html_template/einvoice/invoice.html"
@@ -0,0 +1,7 @@
+package env
+
+// Accounting journal
+const (
+	// Account code overrides such as "GUIDE_PAYABLE=3388,PLATFORM_COMMISSION=5113"
+	ACCOUNTING_ACCOUNT_CODES string = "ACCOUNTING_ACCOUNT_CODES"
+)
@@ -0,0 +1,11 @@
+package env
+
+// E-invoice seller and template
+const (
+	EINVOICE_SELLER_NAME     string = "EINVOICE_SELLER_NAME"
+	EINVOICE_SELLER_TAX_CODE string = "EINVOICE_SELLER_TAX_CODE"
+	EINVOICE_SELLER_ADDRESS  string = "EINVOICE_SELLER_ADDRESS"
+	EINVOICE_TEMPLATE_CODE   string = "EINVOICE_TEMPLATE_CODE"
+	EINVOICE_SERIES_SUFFIX   string = "EINVOICE_SERIES_SUFFIX"
+	EINVOICE_VAT_RATE        string = "EINVOICE_VAT_RATE"
+)
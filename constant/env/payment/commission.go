@@ -0,0 +1,6 @@
+package payment
+
+const (
+	// Platform commission in percent of the tour price, agencies can have their own rate
+	PLATFORM_COMMISSION_RATE string = "PLATFORM_COMMISSION_RATE"
+)
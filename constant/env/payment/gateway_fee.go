@@ -0,0 +1,6 @@
+package payment
+
+const (
+	// Part of the gateway fee in percent taken from the tour guide share, the platform bears the rest
+	GATEWAY_FEE_GUIDE_SHARE string = "GATEWAY_FEE_GUIDE_SHARE"
+)
@@ -0,0 +1,6 @@
+package payment
+
+const (
+	// Number of months a gift card can be used after it is issued
+	GIFT_CARD_VALIDITY_MONTHS string = "GIFT_CARD_VALIDITY_MONTHS"
+)
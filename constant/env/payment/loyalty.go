@@ -0,0 +1,8 @@
+package payment
+
+const (
+	// Amount paid to earn 1 loyalty point before multipliers
+	LOYALTY_EARN_AMOUNT string = "LOYALTY_EARN_AMOUNT"
+	// Discount in VND of 1 redeemed loyalty point
+	LOYALTY_POINT_VALUE string = "LOYALTY_POINT_VALUE"
+)
@@ -0,0 +1,6 @@
+package payment
+
+const (
+	// Offline payments above this amount need a second admin to confirm
+	OFFLINE_PAYMENT_APPROVAL_AMOUNT string = "OFFLINE_PAYMENT_APPROVAL_AMOUNT"
+)
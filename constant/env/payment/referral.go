@@ -0,0 +1,12 @@
+package payment
+
+const (
+	// Share of the platform commission paid to the referrer, e.g. 0.1 for 10%
+	REFERRAL_REWARD_RATE string = "REFERRAL_REWARD_RATE"
+	// Number of paid tours of a referee which earn a reward
+	REFERRAL_REWARDED_TOURS string = "REFERRAL_REWARDED_TOURS"
+	// Number of referees a referrer can bring
+	REFERRAL_MAX_REFERRALS string = "REFERRAL_MAX_REFERRALS"
+	// Total reward a referrer can earn
+	REFERRAL_MAX_REWARD_AMOUNT string = "REFERRAL_MAX_REWARD_AMOUNT"
+)
@@ -0,0 +1,8 @@
+package payment
+
+const (
+	// Days a subscription keeps its plan after an invoice is due
+	SUBSCRIPTION_GRACE_DAYS string = "SUBSCRIPTION_GRACE_DAYS"
+	// Days between two payment reminders of an unpaid invoice
+	SUBSCRIPTION_REMINDER_INTERVAL_DAYS string = "SUBSCRIPTION_REMINDER_INTERVAL_DAYS"
+)
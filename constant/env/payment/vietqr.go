@@ -0,0 +1,8 @@
+package payment
+
+// Platform bank account receiving VietQR transfers
+const (
+	VIETQR_BANK_CODE    string = "VIETQR_BANK_CODE"
+	VIETQR_ACCOUNT_NO   string = "VIETQR_ACCOUNT_NO"
+	VIETQR_ACCOUNT_NAME string = "VIETQR_ACCOUNT_NAME"
+)
@@ -0,0 +1,6 @@
+package env
+
+const (
+	// Key used to encrypt sensitive data at rest such as bank account numbers
+	DATA_ENCRYPTION_KEY string = "DATA_ENCRYPTION_KEY"
+)
@@ -0,0 +1,19 @@
+package filesupport
+
+const (
+	CSV_FORMAT  string = "csv"
+	XLSX_FORMAT string = "xlsx"
+	XLS_FORMAT  string = "xls"
+	PDF_FORMAT  string = "pdf"
+	XML_FORMAT  string = "xml"
+	HTML_FORMAT string = "html"
+)
+
+const (
+	CSV_CONTENT_TYPE  string = "text/csv; charset=utf-8"
+	XLSX_CONTENT_TYPE string = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	PDF_CONTENT_TYPE  string = "application/pdf"
+	XML_CONTENT_TYPE  string = "application/xml; charset=utf-8"
+	HTML_CONTENT_TYPE string = "text/html; charset=utf-8"
+	PNG_CONTENT_TYPE  string = "image/png"
+)
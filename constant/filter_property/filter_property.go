@@ -1,9 +1,55 @@
 package filterproperty
 
 const (
-	DATE_FILTER        string = "DATE"
-	ACTION_DATE_FILTER string = "ACTION_DATE"
-	PRICE_FILTER       string = "PRICE"
-	RATE_FILTER        string = "RATE"
-	AMOUNT_FILTER      string = "AMOUNT"
+	DATE_FILTER            string = "DATE"
+	ACTION_DATE_FILTER     string = "ACTION_DATE"
+	PRICE_FILTER           string = "PRICE"
+	RATE_FILTER            string = "RATE"
+	AMOUNT_FILTER          string = "AMOUNT"
+	STATUS_FILTER          string = "STATUS"
+	METHOD_FILTER          string = "METHOD"
+	INVOICE_FILTER         string = "INVOICE"
+	SERVICE_FILTER         string = "SERVICE"
+	CUSTOMER_FILTER        string = "CUSTOMER"
+	UPDATED_DATE_FILTER    string = "UPDATED_DATE"
+	ACTUAL_RECEIVED_FILTER string = "ACTUAL_RECEIVED"
+	COMMISSION_FILTER      string = "COMMISSION"
+)
+
+// Columns each list can be sorted by, keyed by the filter property sent by the client
+var (
+	PAYMENT_SORT_COLUMNS = map[string]string{
+		DATE_FILTER:     "createdAt",
+		PRICE_FILTER:    "price",
+		AMOUNT_FILTER:   "price",
+		STATUS_FILTER:   "status",
+		METHOD_FILTER:   "paymentMethod",
+		INVOICE_FILTER:  "invoiceId",
+		SERVICE_FILTER:  "serviceId",
+		CUSTOMER_FILTER: "customerId",
+	}
+
+	REVENUE_SORT_COLUMNS = map[string]string{
+		DATE_FILTER:            "createdAt",
+		AMOUNT_FILTER:          "totalAmount",
+		ACTUAL_RECEIVED_FILTER: "actualReceived",
+		COMMISSION_FILTER:      "platformCommission",
+		STATUS_FILTER:          "paymentStatus",
+		INVOICE_FILTER:         "invoiceId",
+	}
+
+	FEEDBACK_SORT_COLUMNS = map[string]string{
+		DATE_FILTER:         "createdDate",
+		UPDATED_DATE_FILTER: "updatedAt",
+		RATE_FILTER:         "rating",
+		INVOICE_FILTER:      "invoiceId",
+		SERVICE_FILTER:      "serviceId",
+		CUSTOMER_FILTER:     "customerId",
+	}
+
+	PLATFORM_FEEDBACK_SORT_COLUMNS = map[string]string{
+		DATE_FILTER:     "createdAt",
+		RATE_FILTER:     "rating",
+		CUSTOMER_FILTER: "customerId",
+	}
 )
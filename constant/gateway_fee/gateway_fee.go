@@ -0,0 +1,15 @@
+package gatewayfee
+
+// Where the fee of a payment comes from
+const (
+	// Estimated from the fee configured for the payment method when the payment is made
+	CONFIGURED_SOURCE string = "CONFIGURED"
+	// Taken from the settlement report of the gateway, it replaces the estimate
+	SETTLEMENT_SOURCE string = "SETTLEMENT"
+)
+
+// Used when the commission split is not configured
+const (
+	// Part of the gateway fee in percent borne by the tour guide, the platform bears the rest
+	DEFAULT_GUIDE_FEE_SHARE float64 = 0
+)
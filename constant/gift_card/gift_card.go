@@ -0,0 +1,26 @@
+package giftcard
+
+// Gift card transaction types, credits are positive and debits negative
+const (
+	PURCHASE           string = "PURCHASE"           // PHÁT HÀNH SAU KHI THANH TOÁN
+	WALLET_REDEMPTION  string = "WALLET_REDEMPTION"  // CHUYỂN VÀO VÍ
+	INVOICE_REDEMPTION string = "INVOICE_REDEMPTION" // THANH TOÁN HÓA ĐƠN
+	RESTORE            string = "RESTORE"            // TRẢ LẠI KHI THANH TOÁN THẤT BẠI
+	EXPIRY             string = "EXPIRY"             // HẾT HẠN
+)
+
+const (
+	// Used when the validity is not configured
+	DEFAULT_VALIDITY_MONTHS int = 12
+	// Cards expiring within this number of days are reported separately
+	EXPIRING_SOON_DAYS int = 30
+)
+
+// Codes look like TM-XXXX-XXXX-XXXX, the alphabet leaves out characters which are easy to mistake for each other
+const (
+	CODE_PREFIX       string = "TM"
+	CODE_ALPHABET     string = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	CODE_GROUPS       int    = 3
+	CODE_GROUP_LENGTH int    = 4
+	MAX_CODE_ATTEMPTS int    = 5
+)
@@ -0,0 +1,13 @@
+package granularity
+
+// Bucket sizes of time series reports
+const (
+	DAY     string = "DAY"
+	WEEK    string = "WEEK" // Tuần bắt đầu từ thứ Hai
+	MONTH   string = "MONTH"
+	QUARTER string = "QUARTER"
+	YEAR    string = "YEAR"
+)
+
+// Upper bound of buckets in a single series
+const MAX_BUCKETS int = 400
@@ -0,0 +1,40 @@
+package ledger
+
+// Ledger accounts
+const (
+	CUSTOMER_RECEIVABLE string = "CUSTOMER_RECEIVABLE" // PHẢI THU KHÁCH HÀNG
+	GUIDE_PAYABLE       string = "GUIDE_PAYABLE"       // PHẢI TRẢ HƯỚNG DẪN VIÊN
+	PLATFORM_COMMISSION string = "PLATFORM_COMMISSION" // DOANH THU HOA HỒNG NỀN TẢNG
+	GATEWAY_CLEARING    string = "GATEWAY_CLEARING"    // TIỀN ĐANG NẰM Ở CỔNG THANH TOÁN / NGÂN HÀNG
+	REFUNDS             string = "REFUNDS"             // HOÀN TIỀN CHO KHÁCH HÀNG, GIẢM TRỪ DOANH THU HOA HỒNG
+	TAX_PAYABLE         string = "TAX_PAYABLE"         // THUẾ TNCN ĐÃ KHẤU TRỪ, PHẢI NỘP NHÀ NƯỚC
+	CUSTOMER_WALLET     string = "CUSTOMER_WALLET"     // SỐ DƯ VÍ CỦA KHÁCH HÀNG, PHẢI TRẢ KHÁCH HÀNG
+	GIFT_CARD_LIABILITY string = "GIFT_CARD_LIABILITY" // SỐ DƯ THẺ QUÀ TẶNG CHƯA SỬ DỤNG
+	AGENCY_PAYABLE      string = "AGENCY_PAYABLE"      // PHẢI TRẢ ĐẠI LÝ
+
+	SUBSCRIPTION_REVENUE string = "SUBSCRIPTION_REVENUE" // DOANH THU PHÍ GÓI THÀNH VIÊN CỦA HƯỚNG DẪN VIÊN
+	GATEWAY_FEE          string = "GATEWAY_FEE"          // PHÍ CỔNG THANH TOÁN, ĐÃ TRỪ PHỤ PHÍ THU CỦA KHÁCH HÀNG
+)
+
+// Journal entry types
+const (
+	PAYMENT_ENTRY    string = "PAYMENT"
+	REFUND_ENTRY     string = "REFUND"
+	PAYOUT_ENTRY     string = "PAYOUT"
+	ADJUSTMENT_ENTRY string = "ADJUSTMENT"
+	WALLET_ENTRY     string = "WALLET"
+	GIFT_CARD_ENTRY  string = "GIFT_CARD"
+	LOYALTY_ENTRY    string = "LOYALTY"
+	REFERRAL_ENTRY   string = "REFERRAL"
+
+	AGENCY_PAYOUT_ENTRY string = "AGENCY_PAYOUT"
+	SUBSCRIPTION_ENTRY  string = "SUBSCRIPTION"
+	GATEWAY_FEE_ENTRY   string = "GATEWAY_FEE"
+
+	REVENUE_ADJUSTMENT_ENTRY string = "REVENUE_ADJUSTMENT"
+	REFERRAL_REVERSAL_ENTRY  string = "REFERRAL_REVERSAL"
+	GATEWAY_SETTLEMENT_ENTRY string = "GATEWAY_SETTLEMENT"
+)
+
+// Owner of platform level accounts
+const PLATFORM_OWNER_ID int = 0
@@ -0,0 +1,23 @@
+package loyalty
+
+// Loyalty transaction types, credits are positive and debits negative
+const (
+	EARN    string = "EARN"    // TÍCH ĐIỂM KHI THANH TOÁN THÀNH CÔNG
+	REDEEM  string = "REDEEM"  // ĐỔI ĐIỂM LẤY GIẢM GIÁ
+	REVOKE  string = "REVOKE"  // THU HỒI ĐIỂM KHI HOÀN TIỀN
+	RESTORE string = "RESTORE" // TRẢ LẠI ĐIỂM ĐÃ ĐỔI
+	EXPIRY  string = "EXPIRY"  // HẾT HẠN
+)
+
+const (
+	// Used when the earning amount is not configured, 1 point per 10.000đ
+	DEFAULT_EARN_AMOUNT float64 = 10000
+	// Used when the point value is not configured
+	DEFAULT_POINT_VALUE float64 = 100
+	// Points expire this number of months after they are earned
+	VALIDITY_MONTHS int = 12
+	// Points expiring within this number of days are shown on the balance
+	EXPIRING_SOON_DAYS int = 30
+	// Multiplier when no earning rule applies
+	BASE_MULTIPLIER float64 = 1
+)
@@ -13,4 +13,10 @@ const (
 	PAYMENT_CALLBACK_SUCCESS_TEMPLATE string = "html_template/mail/payment/success.html"
 
 	PAYMENT_CALLBACK_CANCEL_TEMPLATE string = "html_template/mail/payment/cancel.html"
+
+	GIFT_CARD_MAIL_TEMPLATE string = "html_template/mail/gift_card/gift_card.html"
+
+	SUBSCRIPTION_INVOICE_MAIL_TEMPLATE string = "html_template/mail/subscription/invoice.html"
+
+	SUBSCRIPTION_DOWNGRADE_MAIL_TEMPLATE string = "html_template/mail/subscription/downgrade.html"
 )
@@ -38,3 +38,22 @@ const (
 	PAYMENT_INIT_ENV_ERR_MSG                 string = "Error while setup %s enrionment - "
 	PAYMENT_GENERATE_TRANSACTION_URL_ERR_MSG string = "Error while generating %s transaction URL - "
 )
+
+// File
+const (
+	FILE_GENERATE_ERR_MSG string = "Error while generating %s file - "
+
+	FILE_READ_ERR_MSG string = "Error while reading %s file - "
+)
+
+// Security
+const (
+	DATA_ENCRYPTION_ERR_MSG string = "Error while encrypting data - "
+
+	DATA_DECRYPTION_ERR_MSG string = "Error while decrypting data - "
+)
+
+// Scheduler
+const (
+	SCHEDULER_ERR_MSG string = "Error while running %s scheduler - "
+)
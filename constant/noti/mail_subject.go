@@ -9,4 +9,9 @@ const (
 
 const (
 	NOTI_PAYMENT_MAIL_SUBJECT string = "Transaction Proccess Status"
+	GIFT_CARD_MAIL_SUBJECT    string = "You Received A TourMate Gift Card"
+
+	SUBSCRIPTION_INVOICE_MAIL_SUBJECT   string = "Your TourMate Subscription Invoice"
+	SUBSCRIPTION_REMINDER_MAIL_SUBJECT  string = "Payment Reminder For Your TourMate Subscription"
+	SUBSCRIPTION_DOWNGRADE_MAIL_SUBJECT string = "Your TourMate Subscription Has Ended"
 )
@@ -27,3 +27,186 @@ const (
 
 	ITEM_OUT_OF_STOCK_WARN_MSG string = "This product is out of stock with %d items added to cart."
 )
+
+// File
+const (
+	UNSUPPORTED_FILE_FORMAT_WARN_MSG string = "Unsupported file format. Please try again."
+
+	INVALID_FILE_CONTENT_WARN_MSG string = "The file content is invalid. Please check the file and try again."
+)
+
+// Payout
+const (
+	UNSUPPORTED_BANK_WARN_MSG string = "This bank is not supported. Please try another bank."
+
+	NO_PAYABLE_REVENUE_WARN_MSG string = "There is no payable revenue for the requested tour guides."
+
+	SAME_ACTOR_APPROVAL_WARN_MSG string = "The approver must be different from the creator."
+
+	UNVERIFIED_PAYOUT_ACCOUNT_WARN_MSG string = "Tour guide %d has no verified payout account."
+
+	INVALID_PAYOUT_DAY_WARN_MSG string = "Payout day must be a weekday from 0 (Sunday) to 6 for weekly schedules or a day from 1 to 28 for monthly schedule."
+)
+
+// Ledger
+const (
+	UNSUPPORTED_LEDGER_ACCOUNT_WARN_MSG string = "This ledger account is not supported. Please try another account."
+
+	UNBALANCED_LEDGER_ENTRY_WARN_MSG string = "Total debit must be equal to total credit and each line must have either debit or credit."
+
+	INVALID_DATE_RANGE_WARN_MSG string = "The start date must be before the end date."
+)
+
+// Revenue adjustment
+const (
+	INCONSISTENT_REVENUE_ADJUSTMENT_WARN_MSG string = "Total amount must be equal to actual received plus platform commission."
+
+	NEGATIVE_REVENUE_AMOUNT_WARN_MSG string = "Adjusted revenue amounts must not be negative."
+
+	NO_REVENUE_CHANGE_WARN_MSG string = "There is no change to adjust."
+
+	REVENUE_ALREADY_REVERSED_WARN_MSG string = "This revenue is already reversed."
+
+	REVENUE_HELD_BY_PAYOUT_WARN_MSG string = "This revenue is held by a pending payout and cannot be adjusted."
+)
+
+// Fiscal period
+const (
+	CLOSED_FISCAL_PERIOD_WARN_MSG string = "The accounting period %02d/%d is closed. Please record a correction as an adjustment in the current period."
+
+	FISCAL_PERIOD_NOT_ENDED_WARN_MSG string = "An accounting period can only be closed after it ends."
+
+	REOPEN_REASON_REQUIRED_WARN_MSG string = "A reason is required to reopen an accounting period."
+)
+
+// Analytics
+const (
+	UNSUPPORTED_GRANULARITY_WARN_MSG string = "Granularity must be one of DAY, WEEK, MONTH, QUARTER or YEAR."
+
+	TOO_MANY_BUCKETS_WARN_MSG string = "The date range is too long for this granularity. Please choose a shorter range or a larger granularity."
+)
+
+// Tax
+const (
+	INVALID_TAX_CODE_WARN_MSG string = "Tax code must have 10 digits, 10 digits followed by a 3-digit branch code or 12 digits."
+
+	TAX_CODE_REQUIRED_WARN_MSG string = "A tax code is required for business taxpayers."
+)
+
+// E-invoice
+const (
+	EINVOICE_NUMBER_EXHAUSTED_WARN_MSG string = "The invoice series has run out of numbers. Please register a new series."
+
+	EINVOICE_EXISTED_WARN_MSG string = "An e-invoice has already been issued for this payment."
+
+	EINVOICE_NOT_ADJUSTABLE_WARN_MSG string = "Only an active original or replacement invoice can be adjusted or replaced."
+
+	BUYER_COMPANY_REQUIRED_WARN_MSG string = "Company name is required when a buyer tax code is given."
+
+	INVALID_EINVOICE_ADJUSTMENT_WARN_MSG string = "The adjustment must change the amount and cannot make the invoice total negative."
+)
+
+// Bank statement
+const (
+	INVOICE_ALREADY_PAID_WARN_MSG string = "This invoice has already been paid."
+
+	PENDING_INVOICE_REQUIRED_WARN_MSG string = "Please choose the pending invoice this transaction pays for."
+)
+
+// Offline payment
+const (
+	OFFLINE_PAYMENT_METHOD_WARN_MSG string = "Cash and bank transfer payments must be recorded as offline payments and confirmed by an admin."
+
+	SECOND_APPROVER_REQUIRED_WARN_MSG string = "This payment is above the approval limit and must be confirmed by another admin."
+
+	INVALID_PROOF_IMAGE_WARN_MSG string = "Proof must be a JPEG, PNG, GIF or WEBP image of at most 5 MB."
+)
+
+// Wallet
+const (
+	INSUFFICIENT_WALLET_BALANCE_WARN_MSG string = "The wallet balance is not enough for this transaction."
+
+	WALLET_AMOUNT_EXCEEDS_PRICE_WARN_MSG string = "The wallet amount cannot be greater than the price."
+
+	WALLET_TOP_UP_NOT_PAID_WARN_MSG string = "The top-up has not been paid yet. Please try again after completing the payment."
+
+	ZERO_WALLET_ADJUSTMENT_WARN_MSG string = "The adjustment amount must not be zero."
+
+	WALLET_PAYMENT_METHOD_WARN_MSG string = "Wallet payments must be made through the wallet payment endpoint."
+)
+
+// Gift card
+const (
+	GIFT_CARD_NOT_PAID_WARN_MSG string = "The gift card has not been paid yet. Please try again after completing the payment."
+
+	GIFT_CARD_EXPIRED_WARN_MSG string = "This gift card has expired."
+
+	GIFT_CARD_NOT_ACTIVE_WARN_MSG string = "This gift card cannot be used."
+
+	INSUFFICIENT_GIFT_CARD_BALANCE_WARN_MSG string = "The gift card balance is not enough for this transaction."
+
+	GIFT_CARD_PAYMENT_METHOD_WARN_MSG string = "Gift card payments must be made through the gift card payment endpoint."
+)
+
+// Loyalty
+const (
+	INSUFFICIENT_LOYALTY_POINT_WARN_MSG string = "You do not have enough loyalty points for this transaction."
+
+	LOYALTY_DISCOUNT_EXCEEDS_AMOUNT_WARN_MSG string = "The loyalty point discount must be less than the amount to pay."
+
+	LOYALTY_REDEMPTION_DETAILS_REQUIRED_WARN_MSG string = "Please provide the customer, tour guide and service of the invoice to redeem loyalty points."
+
+	LOYALTY_POINT_PAYMENT_METHOD_WARN_MSG string = "Loyalty points can only be redeemed when creating a transaction."
+
+	INVALID_LOYALTY_RULE_PERIOD_WARN_MSG string = "The rule must end after it starts."
+)
+
+// Split payment
+const (
+	SPLIT_PAYMENT_NOT_PAID_WARN_MSG string = "The rest of the invoice has not been paid yet. Please try again after completing the payment."
+
+	SPLIT_PAYMENT_PENDING_WARN_MSG string = "This invoice is waiting for an earlier payment link. Please complete or cancel it first."
+
+	INVOICE_PAYMENT_IN_PROGRESS_WARN_MSG string = "This invoice has already been paid or is being paid."
+)
+
+// Referral
+const (
+	REFERRAL_CODE_NOT_ACTIVE_WARN_MSG string = "This referral code cannot be used."
+
+	SELF_REFERRAL_WARN_MSG string = "You cannot use your own referral code."
+
+	REFERRAL_ALREADY_CLAIMED_WARN_MSG string = "A referral code has already been used for this account."
+
+	REFEREE_NOT_NEW_WARN_MSG string = "Referral codes can only be used before the first paid tour."
+
+	REFERRAL_LIMIT_REACHED_WARN_MSG string = "This referral code has reached its limit of referrals."
+)
+
+// Agency
+const (
+	INVALID_AGENCY_RATE_WARN_MSG string = "The platform commission and the agency share cannot exceed 100% together."
+
+	AGENCY_BANK_ACCOUNT_REQUIRED_WARN_MSG string = "Please provide the bank account of the agency."
+
+	NO_PAYABLE_AGENCY_REVENUE_WARN_MSG string = "There is no payable revenue for this agency."
+)
+
+// Subscription
+const (
+	SUBSCRIPTION_EXISTED_WARN_MSG string = "This tour guide already has a subscription. Please cancel it before choosing another plan."
+
+	SUBSCRIPTION_PLAN_NOT_ACTIVE_WARN_MSG string = "This plan is no longer available."
+
+	SUBSCRIPTION_INVOICE_NOT_PAID_WARN_MSG string = "The invoice has not been paid yet. Please try again after completing the payment."
+)
+
+// Gateway fee
+const (
+	UNSUPPORTED_GATEWAY_FEE_METHOD_WARN_MSG string = "Gateway fees can only be configured for payment gateways."
+)
+
+// Search
+const (
+	INVALID_AMOUNT_RANGE_WARN_MSG string = "The minimum amount must not be greater than the maximum amount."
+)
@@ -0,0 +1,8 @@
+package offlinepayment
+
+const (
+	// Used when the approval amount is not configured
+	DEFAULT_APPROVAL_AMOUNT float64 = 10000000
+	// 5 MB
+	MAX_PROOF_IMAGE_SIZE int = 5 << 20
+)
@@ -0,0 +1,6 @@
+package pagination
+
+const (
+	DEFAULT_PAGE_SIZE int = 10
+	MAX_PAGE_SIZE     int = 100
+)
@@ -5,4 +5,11 @@ const (
 	VNPAY  string = "VNPAY"
 	MOMO   string = "MOMO"
 	PAYPAL string = "PAYPAL"
+
+	BANK_TRANSFER string = "BANK_TRANSFER"
+	VIETQR        string = "VIETQR"
+	CASH          string = "CASH"
+	WALLET        string = "WALLET"
+	GIFT_CARD     string = "GIFT_CARD"
+	LOYALTY_POINT string = "LOYALTY_POINT"
 )
@@ -0,0 +1,7 @@
+package payoutschedule
+
+const (
+	WEEKLY   string = "WEEKLY"   // HÀNG TUẦN
+	BIWEEKLY string = "BIWEEKLY" // 2 TUẦN MỘT LẦN
+	MONTHLY  string = "MONTHLY"  // HÀNG THÁNG
+)
@@ -0,0 +1,26 @@
+package referral
+
+// Parties of a referral, both can refer and be referred
+const (
+	CUSTOMER   string = "CUSTOMER"   // KHÁCH HÀNG
+	TOUR_GUIDE string = "TOUR_GUIDE" // HƯỚNG DẪN VIÊN
+)
+
+// Used when the program is not configured
+const (
+	// Share of the platform commission of a tour paid to the referrer
+	DEFAULT_REWARD_RATE float64 = 0.1
+	// Number of paid tours of the referee which are rewarded
+	DEFAULT_REWARDED_TOURS int = 3
+	// Number of referees a referrer can bring
+	DEFAULT_MAX_REFERRALS int = 20
+	// Total reward a referrer can earn
+	DEFAULT_MAX_REWARD_AMOUNT float64 = 5000000
+)
+
+// Codes look like REF-XXXXXXXX
+const (
+	CODE_PREFIX       string = "REF"
+	CODE_LENGTH       int    = 8
+	MAX_CODE_ATTEMPTS int    = 5
+)
@@ -0,0 +1,6 @@
+package revenueadjustment
+
+const (
+	CORRECTION string = "CORRECTION" // ĐIỀU CHỈNH SỐ TIỀN
+	REVERSAL   string = "REVERSAL"   // ĐẢO NGƯỢC TOÀN BỘ DOANH THU
+)
@@ -0,0 +1,9 @@
+package subscription
+
+// Used when the billing is not configured
+const (
+	// Days a subscription keeps its plan after an invoice is due, it is downgraded when the invoice is still unpaid
+	DEFAULT_GRACE_DAYS int = 7
+	// Days between two payment reminders of an unpaid invoice
+	DEFAULT_REMINDER_INTERVAL_DAYS int = 2
+)
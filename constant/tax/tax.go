@@ -0,0 +1,13 @@
+package tax
+
+// Taxpayer types of tour guides
+const (
+	INDIVIDUAL_TAXPAYER string = "INDIVIDUAL" // CÁ NHÂN, BỊ KHẤU TRỪ THUẾ TNCN KHI NHẬN TIỀN
+	BUSINESS_TAXPAYER   string = "BUSINESS"   // HỘ / DOANH NGHIỆP, TỰ KÊ KHAI THUẾ
+)
+
+// Personal income tax withheld from individuals without a labour contract (Circular 111/2013/TT-BTC)
+const (
+	PIT_WITHHOLDING_RATE      float64 = 0.1
+	PIT_WITHHOLDING_THRESHOLD float64 = 2000000
+)
@@ -0,0 +1,38 @@
+package vietqr
+
+// EMVCo merchant presented QR, NAPAS VietQR specification
+const (
+	PAYLOAD_FORMAT_INDICATOR_ID string = "00"
+	POINT_OF_INITIATION_ID      string = "01"
+	MERCHANT_ACCOUNT_ID         string = "38"
+	CURRENCY_ID                 string = "53"
+	AMOUNT_ID                   string = "54"
+	COUNTRY_CODE_ID             string = "58"
+	ADDITIONAL_DATA_ID          string = "62"
+	CRC_ID                      string = "63"
+)
+
+// Sub fields of the merchant account information
+const (
+	GUID_ID             string = "00"
+	BENEFICIARY_ID      string = "01"
+	SERVICE_CODE_ID     string = "02"
+	BENEFICIARY_BIN_ID  string = "00"
+	BENEFICIARY_ACCT_ID string = "01"
+)
+
+// Sub field of the additional data, the transfer note
+const PURPOSE_OF_TRANSACTION_ID string = "08"
+
+const (
+	PAYLOAD_FORMAT_INDICATOR string = "01"
+	DYNAMIC_QR               string = "12" // QR used for a single payment with an amount
+	NAPAS_GUID               string = "A000000727"
+	ACCOUNT_TRANSFER_SERVICE string = "QRIBFTTA" // Chuyển nhanh đến tài khoản
+	VND_CURRENCY_CODE        string = "704"
+	COUNTRY_CODE             string = "VN"
+	MAX_PURPOSE_LENGTH       int    = 25
+)
+
+// Side of the QR image in pixels
+const IMAGE_SIZE int = 512
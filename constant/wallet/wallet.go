@@ -0,0 +1,19 @@
+package wallet
+
+// Wallet transaction types, credits are positive and debits negative
+const (
+	TOP_UP     string = "TOP_UP"     // NẠP TIỀN QUA CỔNG THANH TOÁN
+	PAYMENT    string = "PAYMENT"    // THANH TOÁN HÓA ĐƠN BẰNG VÍ
+	REFUND     string = "REFUND"     // HOÀN TIỀN VÀO VÍ
+	REVERSAL   string = "REVERSAL"   // TRẢ LẠI KHI THANH TOÁN BẰNG VÍ THẤT BẠI
+	ADJUSTMENT string = "ADJUSTMENT" // ĐIỀU CHỈNH BỞI QUẢN TRỊ VIÊN
+	GIFT_CARD  string = "GIFT_CARD"  // NẠP TỪ THẺ QUÀ TẶNG
+	REFERRAL   string = "REFERRAL"   // THƯỞNG GIỚI THIỆU
+)
+
+// PayOS payment link statuses
+const (
+	PAYOS_PAID_STATUS      string = "PAID"
+	PAYOS_CANCELLED_STATUS string = "CANCELLED"
+	PAYOS_EXPIRED_STATUS   string = "EXPIRED"
+)
@@ -322,79 +322,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/feedbacks": {
+        "/payment-service/api/v1/accounting/accounts": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of feedbacks with optional filters",
-                "consumes": [
+                "description": "Retrieve the chart of accounts codes used by the journal export, defaults follow Circular 200 and can be overridden with ACCOUNTING_ACCOUNT_CODES",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "accounting"
+                ],
+                "summary": "Get account mapping",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AccountMappingResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/accounting/journal/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates journal rows of payments received, commissions, guide payables, refunds, gateway fees and payouts for the accounting system, exporting the same period again gives the same file",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "feedbacks"
+                    "accounting"
                 ],
-                "summary": "Get all feedbacks",
+                "summary": "Export accounting journal",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Search keyword",
-                        "name": "keyword",
-                        "in": "query"
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Filter property",
-                        "name": "filterProp",
-                        "in": "query"
+                        "description": "To date (YYYY-MM-DD), inclusive",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Sort order (ASC or DESC)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Rating",
-                        "name": "rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The owner ID of this feedback",
-                        "name": "customerId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Tour guide ID",
-                        "name": "tourGuideId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Invoice ID",
-                        "name": "invoiceId",
-                        "in": "query"
+                        "description": "File format (csv, misa)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.PaginationDataResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -402,53 +412,45 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/payment-service/api/v1/agencies": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the agencies with their rates, account numbers are masked",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feedbacks"
-                ],
-                "summary": "Update an existing feedback",
-                "parameters": [
-                    {
-                        "description": "Update Feedback Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateFeedbackRequest"
-                        }
-                    }
+                    "agency"
                 ],
+                "summary": "Get agencies",
                 "responses": {
                     "200": {
-                        "description": "success",
-                        "schema": {
-                            "$ref": "#/definitions/response.MessageApiResponse"
-                        }
-                    },
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/entity.Feedback"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid data. Please try again.",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MessageApiResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AgencyResponse"
+                            }
                         }
                     },
                     "401": {
@@ -471,6 +473,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "Create an agency, tour guides whose company has the agency name are linked to it. Payments of its tour guides are split into the platform commission and the agency share at the agency rates, the tour guide receives the rest",
                 "consumes": [
                     "application/json"
                 ],
@@ -478,31 +481,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feedbacks"
+                    "agency"
                 ],
-                "summary": "Create a new feedback",
+                "summary": "Create agency",
                 "parameters": [
                     {
-                        "description": "Create Feedback Request",
+                        "description": "Agency Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateFeedbackRequest"
+                            "$ref": "#/definitions/request.UpsertAgencyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "success",
-                        "schema": {
-                            "$ref": "#/definitions/response.MessageApiResponse"
-                        }
-                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.Feedback"
+                            "$ref": "#/definitions/response.AgencyResponse"
                         }
                     },
                     "400": {
@@ -524,49 +521,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/payment-service/api/v1/agencies/payouts/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve an agency payout with the full account number to transfer to and the revenues it pays",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feedbacks"
+                    "agency"
                 ],
-                "summary": "Remove a feedback",
+                "summary": "Get agency payout",
                 "parameters": [
                     {
-                        "description": "Remove Feedback Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RemoveFeedbackRequest"
-                        }
+                        "type": "integer",
+                        "description": "Agency payout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MessageApiResponse"
+                            "$ref": "#/definitions/response.AgencyPayoutResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid data. Please try again.",
+                    "401": {
+                        "description": "You have no rights to access this action.",
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
-                    "401": {
-                        "description": "You have no rights to access this action.",
+                    "404": {
+                        "description": "AgencyPayout not found.",
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
@@ -580,9 +575,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/feedbacks/test-grpc/{id}": {
-            "get": {
-                "description": "Calls the gRPC feedback service to retrieve average rating and total count for a tour service",
+        "/payment-service/api/v1/agencies/payouts/{id}/cancel": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a pending payout, its revenues are paid with the next payout of the agency",
                 "consumes": [
                     "application/json"
                 ],
@@ -590,23 +590,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "test-grpc"
+                    "agency"
                 ],
-                "summary": "Test gRPC call to get payment service rating",
+                "summary": "Cancel agency payout",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tour service ID",
+                        "description": "Agency payout ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Agency Payout Process Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ProcessAgencyPayoutRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pb.TourServiceRatingResponse"
+                            "$ref": "#/definitions/response.AgencyPayoutResponse"
                         }
                     },
                     "400": {
@@ -621,6 +630,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "AgencyPayout not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -630,9 +645,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/feedbacks/tourGuide/{id}": {
-            "get": {
-                "description": "Retrieves a paginated list of feedbacks to a specific tour guide",
+        "/payment-service/api/v1/agencies/payouts/{id}/paid": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a pending payout as transferred and settle the agency payable. The confirming admin must be different from the one who created the payout",
                 "consumes": [
                     "application/json"
                 ],
@@ -640,36 +660,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feedbacks"
+                    "agency"
                 ],
-                "summary": "Get feedbacks of a tour guide",
+                "summary": "Confirm agency payout",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tour Guide ID",
+                        "description": "Agency payout ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page Index for pagination (starts from 1)",
-                        "name": "pageIndex",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                        "description": "Agency Payout Process Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ProcessAgencyPayoutRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.PaginationDataResponse"
+                            "$ref": "#/definitions/response.AgencyPayoutResponse"
                         }
                     },
                     "400": {
@@ -677,18 +693,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "AgencyPayout not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
                     }
                 }
             }
         },
-        "/payment-service/api/v1/feedbacks/user/{id}": {
-            "get": {
+        "/payment-service/api/v1/agencies/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve feedbacks filtered by customer ID",
+                "description": "Update the name, rates, bank account and status of an agency. New rates apply to payments made from now on, the bank account is kept when no account number is given",
                 "consumes": [
                     "application/json"
                 ],
@@ -696,65 +730,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feedbacks"
+                    "agency"
                 ],
-                "summary": "Get feedbacks by user",
+                "summary": "Update agency",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Customer ID",
+                        "description": "Agency ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search keyword",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter property",
-                        "name": "filterProp",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order (ASC or DESC)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Rating",
-                        "name": "rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Tour guide ID",
-                        "name": "tourGuideId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Invoice ID",
-                        "name": "invoiceId",
-                        "in": "query"
+                        "description": "Agency Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertAgencyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.PaginationDataResponse"
+                            "$ref": "#/definitions/response.AgencyResponse"
                         }
                     },
                     "400": {
@@ -762,45 +763,21 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/payment-service/api/v1/feedbacks/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "feedbacks"
-                ],
-                "summary": "Get a single feedback by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Feedback ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
                         "schema": {
-                            "$ref": "#/definitions/entity.Feedback"
+                            "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid data. Please try again.",
+                    "404": {
+                        "description": "Agency not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
@@ -808,41 +785,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/payments": {
+        "/payment-service/api/v1/agencies/{id}/payouts": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of payments",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve a paginated list of the payouts of an agency, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "agency"
                 ],
-                "summary": "Get all payments",
+                "summary": "Get agency payouts",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Agency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Payment method",
-                        "name": "method",
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Customer ID",
-                        "name": "customerId",
+                        "description": "Status (PENDING, PAID, CANCELLED)",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
@@ -872,16 +847,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/payment-service/api/v1/payments/create": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new payment",
+                "description": "Gather all unpaid revenues of the agency into a pending payout to its bank account, refund reversals are netted. The transfer is confirmed by another admin",
                 "consumes": [
                     "application/json"
                 ],
@@ -889,17 +862,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "agency"
                 ],
-                "summary": "Create a payment",
+                "summary": "Create agency payout",
                 "parameters": [
                     {
-                        "description": "Create Payment Request",
+                        "type": "integer",
+                        "description": "Agency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Agency Payout Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreatePaymentRequest"
+                            "$ref": "#/definitions/request.CreateAgencyPayoutRequest"
                         }
                     }
                 ],
@@ -907,7 +887,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.Payment"
+                            "$ref": "#/definitions/response.AgencyPayoutResponse"
                         }
                     },
                     "400": {
@@ -922,6 +902,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "Agency not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -931,35 +917,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/payments/create-embedded-payment-link": {
-            "post": {
-                "description": "Initiates a PayOS transaction with the given request body",
-                "consumes": [
-                    "application/json"
+        "/payment-service/api/v1/agencies/{id}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieve the agency share of a period with the amounts paid out and pending, broken down by tour guide. Refunds are netted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "agency"
                 ],
-                "summary": "Create a PayOS Transaction",
+                "summary": "Get agency report",
                 "parameters": [
                     {
-                        "description": "PayOS Transaction Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreatePayosTransactionRequest"
-                        }
+                        "type": "integer",
+                        "description": "Agency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UrlResponse"
+                            "$ref": "#/definitions/response.AgencyReportResponse"
                         }
                     },
                     "400": {
@@ -967,32 +965,47 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Agency not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
                     }
                 }
             }
         },
-        "/payment-service/api/v1/payments/customer/{id}": {
+        "/payment-service/api/v1/agencies/{id}/revenues": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of payments made by a specific customer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve a paginated list of the agency shares of payments, newest first. A refund adds a reversal with negative amounts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "agency"
                 ],
-                "summary": "Get payments by user ID",
+                "summary": "Get agency revenues",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Customer ID",
+                        "description": "Agency ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1004,27 +1017,21 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Search keyword",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter property (e.g. date, price)",
-                        "name": "filterProp",
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort order (ASC or DESC)",
-                        "name": "order",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Payment Method (e.g. VTP, GHTK)",
-                        "name": "method",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -1056,40 +1063,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/payments/update": {
-            "put": {
+        "/payment-service/api/v1/bank-statements/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update payment details",
+                "description": "Reads incoming transfers of an account statement, creates PAID payments for transfers matching a pending invoice by reference and amount and queues the others for review",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "bank-statements"
                 ],
-                "summary": "Update a payment record",
+                "summary": "Import bank statement",
                 "parameters": [
                     {
-                        "description": "UpdatePaymentRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdatePaymentRequest"
-                        }
+                        "type": "string",
+                        "description": "Bank layout (VCB, TCB)",
+                        "name": "bank",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actorId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Statement file (csv, xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MessageApiResponse"
+                            "$ref": "#/definitions/response.BankStatementImportResponse"
                         }
                     },
                     "400": {
@@ -1113,35 +1132,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/payments/with-service-name/{id}": {
-            "get": {
+        "/payment-service/api/v1/bank-statements/pending-invoices": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single payment record with service information by its ID",
+                "description": "Registers an invoice which the customer pays by a direct bank transfer with \"Invoice \u003cinvoiceId\u003e\" in the note, a pending registration of the same invoice is updated",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "bank-statements"
                 ],
-                "summary": "Get payment with service information by ID",
+                "summary": "Register invoice paid by bank transfer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Register Pending Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterPendingInvoiceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.PaymentWithServiceNameResponse"
+                            "$ref": "#/definitions/entity.PendingInvoice"
                         }
                     },
                     "400": {
@@ -1165,25 +1189,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/payments/{id}": {
-            "get": {
+        "/payment-service/api/v1/bank-statements/pending-invoices/{id}/cancel": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single payment record by its ID",
+                "description": "Cancels a pending invoice so that later transfers are no longer matched to it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "bank-statements"
                 ],
-                "summary": "Get payment by ID",
+                "summary": "Cancel invoice paid by bank transfer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment ID",
+                        "description": "Pending invoice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1191,9 +1215,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/entity.Payment"
+                            "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
                     "400": {
@@ -1208,6 +1232,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "PendingInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -1217,14 +1247,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/revenues": {
-            "get": {
+        "/payment-service/api/v1/bank-statements/pending-invoices/{id}/confirm": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves revenues with optional filters",
+                "description": "Creates the PAID payment of a pending invoice with its registered amount when the transfer has been seen on the account without a statement import",
                 "consumes": [
                     "application/json"
                 ],
@@ -1232,51 +1262,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "bank-statements"
                 ],
-                "summary": "Get all revenue entries",
+                "summary": "Confirm invoice paid by bank transfer",
                 "parameters": [
-                    {
-                        "maximum": 12,
-                        "type": "integer",
-                        "name": "month",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageNumber",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "name": "paymentStatus",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "name": "tourGuideId",
-                        "in": "query",
+                        "description": "Pending invoice ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "name": "year",
-                        "in": "query"
+                        "description": "Confirm Pending Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ConfirmPendingInvoiceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.RevenueResponse"
-                            }
+                            "$ref": "#/definitions/entity.Payment"
                         }
                     },
                     "400": {
@@ -1291,6 +1302,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "PendingInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -1298,40 +1315,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/payment-service/api/v1/bank-statements/pending-invoices/{id}/vietqr": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a new revenue entry",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Renders the VietQR code of a pending invoice as a PNG image",
                 "produces": [
-                    "application/json"
+                    "image/png"
                 ],
                 "tags": [
-                    "revenues"
+                    "bank-statements"
                 ],
-                "summary": "Create a revenue record",
+                "summary": "Get VietQR image",
                 "parameters": [
                     {
-                        "description": "Create Revenue Payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateRevenueRequest"
-                        }
+                        "type": "integer",
+                        "description": "Pending invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.RevenueResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1346,6 +1360,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "PendingInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -1355,56 +1375,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/revenues/growth/{id}": {
+        "/payment-service/api/v1/bank-statements/transactions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Calculates revenue growth over time",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve a paginated list of imported bank transactions, filter by REVIEW to get the manual review queue",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "bank-statements"
                 ],
-                "summary": "Get growth percentage",
+                "summary": "Get imported bank transactions",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tour Guide ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "maximum": 12,
-                        "type": "integer",
-                        "name": "month",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "name": "tourGuideId",
+                        "description": "Page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "name": "year",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Transaction status (MATCHED, REVIEW, ACCEPTED, REJECTED, UNMATCHED)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.RevenueGrowthPercentageResponse"
+                            "$ref": "#/definitions/response.PaginationDataResponse"
                         }
                     },
                     "400": {
@@ -1428,14 +1432,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/revenues/monthly/{id}": {
-            "get": {
+        "/payment-service/api/v1/bank-statements/transactions/{id}/accept": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves revenue grouped by month",
+                "description": "Confirms a transaction of the review queue, or an unmatched one, as the payment of a pending invoice and creates a PAID payment with the transferred amount",
                 "consumes": [
                     "application/json"
                 ],
@@ -1443,41 +1447,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "bank-statements"
                 ],
-                "summary": "Get revenue by month",
+                "summary": "Accept bank transaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tour Guide ID",
+                        "description": "Bank transaction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "maximum": 12,
-                        "type": "integer",
-                        "name": "month",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "name": "tourGuideId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "year",
-                        "in": "query",
-                        "required": true
+                        "description": "Accept Bank Transaction Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AcceptBankTransactionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MonthlyRevenueResponse"
+                            "$ref": "#/definitions/entity.BankTransaction"
                         }
                     },
                     "400": {
@@ -1492,6 +1487,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "BankTransaction not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -1501,14 +1502,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/revenues/stats/{id}": {
-            "get": {
+        "/payment-service/api/v1/bank-statements/transactions/{id}/reject": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves revenue stats",
+                "description": "Marks a transaction of the review queue, or an unmatched one, as not being an invoice payment",
                 "consumes": [
                     "application/json"
                 ],
@@ -1516,41 +1517,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "bank-statements"
                 ],
-                "summary": "Get revenue stats",
+                "summary": "Reject bank transaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Revenue ID",
+                        "description": "Bank transaction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "maximum": 12,
-                        "type": "integer",
-                        "name": "month",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "name": "tourGuideId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "year",
-                        "in": "query",
-                        "required": true
+                        "description": "Reject Bank Transaction Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RejectBankTransactionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.RevenueStatusResponse"
+                            "$ref": "#/definitions/entity.BankTransaction"
                         }
                     },
                     "400": {
@@ -1565,6 +1557,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.MessageApiResponse"
                         }
                     },
+                    "404": {
+                        "description": "BankTransaction not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
                     "500": {
                         "description": "There is something wrong in the system during the process. Please try again later.",
                         "schema": {
@@ -1574,14 +1572,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/revenues/{id}": {
-            "get": {
+        "/payment-service/api/v1/bank-statements/vietqr": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves revenue details by ID",
+                "description": "Registers an invoice paid by a VietQR transfer to the platform bank account and returns the EMVCo payload with its PNG QR code, the payment is created when the transfer is matched on a bank statement or confirmed by an admin",
                 "consumes": [
                     "application/json"
                 ],
@@ -1589,23 +1587,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "bank-statements"
                 ],
-                "summary": "Get a single revenue record",
+                "summary": "Create VietQR transfer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Revenue ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Register Pending Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterPendingInvoiceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.Revenue"
+                            "$ref": "#/definitions/response.VietQrResponse"
                         }
                     },
                     "400": {
@@ -1627,14 +1627,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/payment-service/api/v1/einvoices": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing revenue entry",
+                "description": "Issue a VAT e-invoice for a paid payment with the next number of the configured template and series",
                 "consumes": [
                     "application/json"
                 ],
@@ -1642,32 +1644,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "einvoices"
                 ],
-                "summary": "Update a revenue record",
+                "summary": "Issue e-invoice",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Revenue ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Revenue Payload",
+                        "description": "Issue E-Invoice Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateRevenueRequest"
+                            "$ref": "#/definitions/request.IssueEInvoiceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.RevenueResponse"
+                            "$ref": "#/definitions/entity.EInvoice"
                         }
                     },
                     "400": {
@@ -1691,28 +1686,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-service/api/v1/revenues{id}": {
-            "delete": {
+        "/payment-service/api/v1/einvoices/payment/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Removes a revenue entry by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the original, adjustment and replacement e-invoices issued for a payment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "revenues"
+                    "einvoices"
                 ],
-                "summary": "Delete a revenue record",
+                "summary": "Get e-invoices of payment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Revenue ID",
+                        "description": "Payment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1720,9 +1712,12 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MessageApiResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.EInvoice"
+                            }
                         }
                     },
                     "400": {
@@ -1745,99 +1740,9935 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Feedback": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "createdDate": {
-                    "type": "string"
-                },
-                "customerId": {
-                    "type": "integer"
-                },
-                "feedbackId": {
-                    "type": "integer"
-                },
-                "invoiceId": {
+        },
+        "/payment-service/api/v1/einvoices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve an issued VAT e-invoice by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "einvoices"
+                ],
+                "summary": "Get e-invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "E-Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.EInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "EInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/einvoices/{id}/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue an adjustment e-invoice increasing or decreasing the total of an active e-invoice",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "einvoices"
+                ],
+                "summary": "Adjust e-invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "E-Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjust E-Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AdjustEInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.EInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "EInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/einvoices/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download an e-invoice as the Decree 123 XML data file, or as a HTML or PDF copy for the buyer",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "einvoices"
+                ],
+                "summary": "Export e-invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "E-Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format (xml, html, pdf)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "EInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/einvoices/{id}/replace": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a replacement e-invoice for an active e-invoice, the replaced one is no longer valid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "einvoices"
+                ],
+                "summary": "Replace e-invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "E-Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replace E-Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReplaceEInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.EInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "EInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/feedbacks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of feedbacks with optional filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Get all feedbacks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword on the content",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "DATE",
+                            "UPDATED_DATE",
+                            "RATE",
+                            "INVOICE",
+                            "SERVICE",
+                            "CUSTOMER"
+                        ],
+                        "type": "string",
+                        "description": "Sort property",
+                        "name": "filterProp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rating",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The owner ID of this feedback",
+                        "name": "customerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Update an existing feedback",
+                "parameters": [
+                    {
+                        "description": "Update Feedback Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateFeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Feedback"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Create a new feedback",
+                "parameters": [
+                    {
+                        "description": "Create Feedback Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateFeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Feedback"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Remove a feedback",
+                "parameters": [
+                    {
+                        "description": "Remove Feedback Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RemoveFeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/feedbacks/test-grpc/{id}": {
+            "get": {
+                "description": "Calls the gRPC feedback service to retrieve average rating and total count for a tour service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-grpc"
+                ],
+                "summary": "Test gRPC call to get payment service rating",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pb.TourServiceRatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/feedbacks/tourGuide/{id}": {
+            "get": {
+                "description": "Retrieves a paginated list of feedbacks to a specific tour guide",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Get feedbacks of a tour guide",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Index for pagination (starts from 1)",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/feedbacks/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve feedbacks filtered by customer ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Get feedbacks by user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword on the content",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "DATE",
+                            "UPDATED_DATE",
+                            "RATE",
+                            "INVOICE",
+                            "SERVICE",
+                            "CUSTOMER"
+                        ],
+                        "type": "string",
+                        "description": "Sort property",
+                        "name": "filterProp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rating",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/feedbacks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Get a single feedback by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Feedback ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Feedback"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/fiscal-periods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the status of every month in a year, months which have never been closed are open",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fiscal-periods"
+                ],
+                "summary": "Get accounting periods",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FiscalPeriod"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/fiscal-periods/close": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lock payments and revenues dated in the month, later corrections are recorded as adjustments in the current period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fiscal-periods"
+                ],
+                "summary": "Close an accounting period",
+                "parameters": [
+                    {
+                        "description": "Fiscal Period Action Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FiscalPeriodActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/fiscal-periods/reopen": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlock a closed month, a reason is required and the action is audited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fiscal-periods"
+                ],
+                "summary": "Reopen an accounting period",
+                "parameters": [
+                    {
+                        "description": "Fiscal Period Action Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FiscalPeriodActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/fiscal-periods/{id}/audits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every close and reopen action of an accounting period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fiscal-periods"
+                ],
+                "summary": "Get accounting period audit trail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fiscal period ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FiscalPeriodAudit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gateway-fees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of the gateway fees of payments with their gross, surcharge and net settlement amounts, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gateway-fee"
+                ],
+                "summary": "Get payment fees",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "paymentMethod",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "CONFIGURED",
+                            "SETTLEMENT"
+                        ],
+                        "type": "string",
+                        "description": "Source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gateway-fees/methods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the gateway fee and the customer surcharge configured for each payment method, payments of methods without a configuration are recorded without a fee",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gateway-fee"
+                ],
+                "summary": "Get payment method fees",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PaymentMethodFee"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gateway-fees/methods/{method}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the gateway fee (percent of the amount collected plus a fixed fee) and the optional surcharge passed on to the customer for a payment method. It applies to the payments made afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gateway-fee"
+                ],
+                "summary": "Configure payment method fee",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PAYOS",
+                            "VNPAY",
+                            "MOMO",
+                            "PAYPAL",
+                            "BANK_TRANSFER",
+                            "VIETQR"
+                        ],
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "method",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Method Fee Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertPaymentMethodFeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PaymentMethodFee"
+                        }
+                    },
+                    "400": {
+                        "description": "Gateway fees can only be configured for payment gateways.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gateway-fees/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the gross amount collected by the gateways, the surcharges, the gateway fees and the net settlement of a period, in total and per payment method",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gateway-fee"
+                ],
+                "summary": "Get gateway fee report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GatewayFeeReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gateway-fees/settlements": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reads the settled payments of a gateway with their fees. The payment is found by the \"Invoice \u003cinvoiceId\u003e\" description, its estimated fee is replaced by the settled one and the difference is posted to the ledger. A settled payment is not settled again",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gateway-fee"
+                ],
+                "summary": "Import gateway settlement report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment method of the gateway",
+                        "name": "paymentMethod",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actorId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Settlement file (csv, xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SettlementImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gateway-fees/surcharge": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Preview the surcharge the customer pays on top of the amount with a payment method, it is zero when the method has none",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gateway-fee"
+                ],
+                "summary": "Get payment surcharge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "paymentMethod",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount to pay",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SurchargeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gift-cards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of gift cards, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Get gift cards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gift card status (PENDING, ACTIVE, REDEEMED, EXPIRED, CANCELLED)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a PayOS payment link for a gift card, the card is issued and emailed to the recipient once the purchase is confirmed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Purchase gift card",
+                "parameters": [
+                    {
+                        "description": "Purchase Gift Card Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PurchaseGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GiftCard"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gift-cards/code/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the balance, status and expiry of a gift card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Get gift card by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GiftCard"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "GiftCard not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gift-cards/liability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report the balance still owed to gift card holders with the part expiring within 30 days and totals per status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Get gift card liability",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GiftCardLiabilityResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gift-cards/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pay an invoice with a gift card. When the card balance is below the price, the card part is recorded as a paid payment and a PayOS link is returned for the rest",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Pay with gift card",
+                "parameters": [
+                    {
+                        "description": "Pay With Gift Card Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PayWithGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.GiftCardPaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "This gift card has expired.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "GiftCard not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gift-cards/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the balance of a gift card, or part of it, to the wallet of the customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Redeem gift card to wallet",
+                "parameters": [
+                    {
+                        "description": "Redeem Gift Card Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RedeemGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.GiftCardRedemptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "The gift card balance is not enough for this transaction.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "GiftCard not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/gift-cards/{id}/confirm": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the PayOS payment link of the purchase, a paid card gets its code and is emailed to the recipient once, a cancelled or expired purchase is closed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Confirm gift card purchase",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gift card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GiftCard"
+                        }
+                    },
+                    "400": {
+                        "description": "The gift card has not been paid yet. Please try again after completing the payment.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "GiftCard not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/ledger/accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the balance of every platform ledger account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Get ledger account balances",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.LedgerBalanceResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/ledger/accounts/{account}/balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the balance of a ledger account, optionally for a single owner and up to a date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Get a ledger account balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account (CUSTOMER_RECEIVABLE, GUIDE_PAYABLE, PLATFORM_COMMISSION, GATEWAY_CLEARING, REFUNDS, TAX_PAYABLE, CUSTOMER_WALLET, GIFT_CARD_LIABILITY, AGENCY_PAYABLE, SUBSCRIPTION_REVENUE, GATEWAY_FEE)",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Owner ID (customer, tour guide or agency)",
+                        "name": "ownerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Balance at the end of this date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LedgerBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/ledger/accounts/{account}/statement": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve opening balance, lines with running balance and closing balance of a ledger account, the current month is used by default",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Get a ledger account statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account (CUSTOMER_RECEIVABLE, GUIDE_PAYABLE, PLATFORM_COMMISSION, GATEWAY_CLEARING, REFUNDS, TAX_PAYABLE, CUSTOMER_WALLET, GIFT_CARD_LIABILITY, AGENCY_PAYABLE, SUBSCRIPTION_REVENUE, GATEWAY_FEE)",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Owner ID (customer, tour guide or agency)",
+                        "name": "ownerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LedgerStatementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/ledger/adjustments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Post a balanced manual adjustment entry, existing entries are never modified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Create a ledger adjustment",
+                "parameters": [
+                    {
+                        "description": "Create Ledger Adjustment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateLedgerAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/ledger/invariants": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verify that total debits equal total credits and that every journal entry is balanced",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Check ledger invariants",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LedgerInvariantResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/loyalty/customer/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the loyalty points of a customer with their discount value and the points expiring within 30 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loyalty"
+                ],
+                "summary": "Get loyalty balance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoyaltyBalanceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/loyalty/customer/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated history of the loyalty points of a customer, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loyalty"
+                ],
+                "summary": "Get loyalty transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/loyalty/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the earning rules, the highest multiplier of the active rules applying to a payment is used",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loyalty"
+                ],
+                "summary": "Get loyalty rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.LoyaltyRule"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an earning multiplier for a service, or for every service when no service is given, optionally limited to a promotion period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loyalty"
+                ],
+                "summary": "Create loyalty rule",
+                "parameters": [
+                    {
+                        "description": "Loyalty Rule Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertLoyaltyRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.LoyaltyRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/loyalty/rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change or deactivate an earning rule, points already earned are not recalculated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loyalty"
+                ],
+                "summary": "Update loyalty rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loyalty rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Loyalty Rule Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertLoyaltyRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.LoyaltyRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "LoyaltyRule not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/offline-payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of offline payments, filter by PENDING or AWAITING_APPROVAL to get the confirmation queue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offline-payments"
+                ],
+                "summary": "Get offline payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Offline payment status (PENDING, AWAITING_APPROVAL, CONFIRMED, REJECTED)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records cash or a bank transfer collected outside the payment gateways as a PENDING payment, a photo of the receipt or the transfer can be attached as proof",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offline-payments"
+                ],
+                "summary": "Record offline payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "customerId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "serviceId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Collected amount",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method (CASH, BANK_TRANSFER)",
+                        "name": "paymentMethod",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Note",
+                        "name": "note",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actorId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Proof image (jpeg, jpg, png, gif, webp)",
+                        "name": "proof",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.OfflinePayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/offline-payments/{id}/confirm": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the payment to PAID and creates the revenue of the tour guide, payments above the approval amount wait in AWAITING_APPROVAL until another admin confirms them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offline-payments"
+                ],
+                "summary": "Confirm offline payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offline payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Confirm Offline Payment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ConfirmOfflinePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.OfflinePayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OfflinePayment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/offline-payments/{id}/proof": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the proof image attached to an offline payment",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "offline-payments"
+                ],
+                "summary": "Get offline payment proof",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offline payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OfflinePaymentProof not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/offline-payments/{id}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects an offline payment waiting for confirmation, the payment is cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offline-payments"
+                ],
+                "summary": "Reject offline payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offline payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reject Offline Payment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RejectOfflinePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.OfflinePayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OfflinePayment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of payments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get all payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword on payment method or status",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "DATE",
+                            "PRICE",
+                            "AMOUNT",
+                            "STATUS",
+                            "METHOD",
+                            "INVOICE",
+                            "SERVICE",
+                            "CUSTOMER"
+                        ],
+                        "type": "string",
+                        "description": "Sort property",
+                        "name": "filterProp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "customerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Customer IDs",
+                        "name": "customerIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "serviceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum amount",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum amount",
+                        "name": "maxAmount",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "The minimum amount must not be greater than the maximum amount.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Create a payment",
+                "parameters": [
+                    {
+                        "description": "Create Payment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/create-embedded-payment-link": {
+            "post": {
+                "description": "Initiates a PayOS transaction with the given request body. Loyalty points given in redeemPoints are taken off the amount and held by a pending LOYALTY_POINT payment of the invoice until the link is paid, they are given back when the link is cancelled or expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Create a PayOS Transaction",
+                "parameters": [
+                    {
+                        "description": "PayOS Transaction Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePayosTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayosTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/customer/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of payments made by a specific customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payments by user ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "DATE",
+                            "PRICE",
+                            "AMOUNT",
+                            "STATUS",
+                            "METHOD",
+                            "INVOICE",
+                            "SERVICE",
+                            "CUSTOMER"
+                        ],
+                        "type": "string",
+                        "description": "Sort property",
+                        "name": "filterProp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment Method (e.g. VTP, GHTK)",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum amount",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum amount",
+                        "name": "maxAmount",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/customer/{id}/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the lifetime spend, number of paid tours, average ticket, last payment date and refunds of a customer with their payment history and the service names, newest first. The filters only apply to the history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get customer spending summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PAID",
+                            "REFUNDED",
+                            "PENDING",
+                            "FAILED",
+                            "CANCELLED"
+                        ],
+                        "type": "string",
+                        "description": "Payment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CustomerPaymentSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/refund": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a paid payment as refunded and post the reversal to the ledger, the amount is credited to the wallet of the customer when toWallet is set or the payment was made from the wallet or a gift card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Refund a payment",
+                "parameters": [
+                    {
+                        "description": "RefundPaymentRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefundPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/split/{id}/confirm": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the PayOS payment link of a split payment, a paid link records the held part as a paid payment once, a cancelled or expired one gives the held part back",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Confirm split payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Split payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SplitPayment"
+                        }
+                    },
+                    "400": {
+                        "description": "The rest of the invoice has not been paid yet. Please try again after completing the payment.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "SplitPayment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update payment details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Update a payment record",
+                "parameters": [
+                    {
+                        "description": "UpdatePaymentRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/with-service-name/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single payment record with service information by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment with service information by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaymentWithServiceNameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single payment record by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payout-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of tour guide payout accounts for verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Get payout accounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verification status (PENDING, VERIFIED, REJECTED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a tour guide's bank account, changed bank details must be verified again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Update a payout account",
+                "parameters": [
+                    {
+                        "description": "Update Payout Account Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePayoutAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayoutAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a bank account for a tour guide, the account must be verified by an admin before receiving payouts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Register a payout account",
+                "parameters": [
+                    {
+                        "description": "Create Payout Account Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePayoutAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayoutAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a bank account of a tour guide",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Remove a payout account",
+                "parameters": [
+                    {
+                        "description": "Remove Payout Account Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RemovePayoutAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payout-accounts/tourGuide/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the bank accounts registered by a tour guide, account numbers are masked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Get payout accounts of a tour guide",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PayoutAccountResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payout-accounts/{id}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a pending payout account with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Reject a payout account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reject Payout Account Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyPayoutAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payout-accounts/{id}/verify": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a pending payout account as verified so that it can receive payouts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout-accounts"
+                ],
+                "summary": "Verify a payout account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Verify Payout Account Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyPayoutAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of tour guide payout batches",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Get payout batches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Batch status (DRAFT, APPROVED, EXPORTED, COMPLETED, CANCELLED)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a draft payout batch from the unsettled revenues of the given tour guides",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Create a payout batch",
+                "parameters": [
+                    {
+                        "description": "Create Payout Batch Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePayoutBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayoutBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts/policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the minimum payable balance, hold period and schedule used to create payout batches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Get payout policy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PayoutPolicy"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the minimum payable balance, hold period and schedule (WEEKLY, BIWEEKLY, MONTHLY) of automatic payouts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Update payout policy",
+                "parameters": [
+                    {
+                        "description": "Update Payout Policy Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePayoutPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PayoutPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a payout batch with its transfer items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Get a payout batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayoutBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts/{id}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves a draft payout batch, the approver must be different from the creator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Approve a payout batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payout Batch Action Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PayoutBatchActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts/{id}/cancel": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a payout batch which has not been exported yet and releases its revenues",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Cancel a payout batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payout Batch Action Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PayoutBatchActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates the bulk-transfer file of an approved payout batch in the bank's layout",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Export bank bulk-transfer file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bank layout (VCB, TCB)",
+                        "name": "bank",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format (csv, xlsx)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/payouts/{id}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reads the result file returned by the bank and marks payout items as paid or failed",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payouts"
+                ],
+                "summary": "Import bank transfer result file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bank layout (VCB, TCB)",
+                        "name": "bank",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Result file (csv, xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayoutImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/referrals/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Link a new customer or tour guide to the owner of the code before the first paid tour. The referral is attributed at the first payment and the referrer earns a share of the platform commission of the following paid tours. A referee can only use one code and cannot use its own",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Claim referral code",
+                "parameters": [
+                    {
+                        "description": "Claim Referral Code Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClaimReferralCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Referral"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/referrals/codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the referral code of a customer or a tour guide, the code is created on first use",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Get referral code",
+                "parameters": [
+                    {
+                        "description": "Referral Code Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetReferralCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ReferralCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/referrals/codes/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A disabled code cannot be claimed and its referrals stop earning rewards, rewards already earned are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Enable or disable referral code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Referral code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Referral Code Status Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateReferralCodeStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ReferralCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ReferralCode not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/referrals/rewards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of referral rewards, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Get referral rewards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status (PENDING, PAID, CANCELLED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Referrer type (CUSTOMER, TOUR_GUIDE)",
+                        "name": "referrerType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Referrer ID",
+                        "name": "referrerId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/referrals/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the code, the referees and the rewards of a referrer with what is left of its caps",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Get referral summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner type (CUSTOMER, TOUR_GUIDE)",
+                        "name": "ownerType",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Owner ID",
+                        "name": "ownerId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReferralSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves revenues with optional filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get all revenue entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "filterProp",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 12,
+                        "type": "integer",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "paymentStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "tourGuideId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.RevenueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new revenue entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Create a revenue record",
+                "parameters": [
+                    {
+                        "description": "Create Revenue Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateRevenueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RevenueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/growth/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Calculates revenue growth over time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get growth percentage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 12,
+                        "type": "integer",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RevenueGrowthPercentageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/monthly/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves revenue grouped by month",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get revenue by month",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 12,
+                        "type": "integer",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MonthlyRevenueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/payout-summary/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the amount eligible for the next payout, the amount still on hold and the next payout date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get payout summary of a tour guide",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PayoutSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/platform/areas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revenue of every area in the period based on the area of each tour service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get revenue by area",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AreaRevenueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/platform/services": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revenue of every tour service in the period, service names are resolved from the tour service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get revenue by tour service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ServiceRevenueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/platform/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total gross, commission earned and net payable across all tour guides, split into pending and settled amounts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get platform revenue summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlatformRevenueSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/platform/top-guides": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tour guides with the highest gross revenue in the period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get top tour guides by revenue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tour guides, 10 by default",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TourGuideRevenueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/series/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregates total, net, commission and count per day, week, month, quarter or year with period-over-period and year-over-year growth",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get revenue time series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Granularity (DAY, WEEK, MONTH, QUARTER, YEAR)",
+                        "name": "granularity",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (yyyy-MM-dd)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (yyyy-MM-dd)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RevenueSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/statement/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates a tour guide's statement of a month, or of a whole year when month is omitted, listing revenues, payouts and the closing balance",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Export earnings statement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Month (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format (csv, pdf)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/stats/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves revenue stats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get revenue stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revenue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 12,
+                        "type": "integer",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RevenueStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves revenue details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get a single revenue record",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revenue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Revenue"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the differences to the given amounts as an adjustment of the revenue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Correct a revenue record",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revenue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Revenue Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateRevenueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RevenueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reverses a revenue entry with an adjustment, the original record is kept for statistics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Reverse a revenue record",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revenue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remove Revenue Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RemoveRevenueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/revenues/{id}/adjustments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the corrections and reversals recorded for a revenue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revenues"
+                ],
+                "summary": "Get revenue adjustments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revenue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.RevenueAdjustment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of subscriptions, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Get subscriptions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "ACTIVE",
+                            "PAST_DUE",
+                            "CANCELLED",
+                            "EXPIRED"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Subscription plan ID",
+                        "name": "subscriptionPlanId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a tour guide to a plan. The invoice of the first cycle is emailed to the billing email with its PayOS link, the plan starts once it is paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Subscribe to a plan",
+                "parameters": [
+                    {
+                        "description": "Subscription Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "This tour guide already has a subscription. Please cancel it before choosing another plan.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "SubscriptionPlan not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/billing": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run the billing job right away: invoice renewals, collect paid invoices, remind unpaid ones and downgrade subscriptions past their grace period. The job also runs every hour",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Run subscription billing",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionBillingResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/featured": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the IDs of tour guides whose subscription includes the featured listing, a plan in its grace period is still featured",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Get featured tour guides",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/invoices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of subscription invoices, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Get subscription invoices",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PAID",
+                            "VOID"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "subscriptionId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/invoices/{id}/confirm": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the PayOS payment link of the invoice, a paid invoice starts or renews its subscription once. A cancelled or expired link is replaced so that the invoice can still be paid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Confirm subscription invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SubscriptionInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "The invoice has not been paid yet. Please try again after completing the payment.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "SubscriptionInvoice not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/plans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the premium listing plans of tour guides with their price, billing cycle and commission rate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Get subscription plans",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.SubscriptionPlan"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a plan for tour guides. Subscribers pay the plan commission instead of the platform commission when it is lower, featured plans put them in the featured listing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Create subscription plan",
+                "parameters": [
+                    {
+                        "description": "Subscription Plan Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertSubscriptionPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SubscriptionPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/plans/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a plan, the new price applies from the next billing cycle. An inactive plan takes no new subscribers and is not renewed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Update subscription plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription Plan Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertSubscriptionPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SubscriptionPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "SubscriptionPlan not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/tour-guides/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current subscription of a tour guide with its plan and unpaid invoice",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Get subscription of tour guide",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/subscriptions/{id}/cancel": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel the subscription of a tour guide. An active plan is kept until the end of its paid period, an unpaid subscription ends right away and its invoice is voided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Cancel subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancel Subscription Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CancelSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/taxes/certificates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the data of the yearly personal income tax withholding certificate of a tour guide",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxes"
+                ],
+                "summary": "Get tax withholding certificate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TaxCertificateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/taxes/declaration/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates the list of individuals with income paid and personal income tax withheld for the declaration to the tax authority",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "taxes"
+                ],
+                "summary": "Export tax declaration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Month (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format (csv, xlsx)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/taxes/profiles": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update the tax profile of a tour guide, 10% personal income tax is withheld from payouts of individuals from 2,000,000đ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxes"
+                ],
+                "summary": "Save tax profile",
+                "parameters": [
+                    {
+                        "description": "Save Tax Profile Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SaveTaxProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TaxProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/taxes/profiles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the taxpayer type, tax code and legal identity of a tour guide, the identity number is masked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxes"
+                ],
+                "summary": "Get tax profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TaxProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/taxes/withholdings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve personal income tax withheld from paid payouts of a month, or of a whole year when month is omitted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxes"
+                ],
+                "summary": "Get tax withheld ledger",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tour Guide ID",
+                        "name": "tourGuideId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Month (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TaxWithholdingLedgerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/wallets/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Credit (positive amount) or debit (negative amount) the wallet of a customer, a debit cannot take the balance below zero",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Adjust wallet",
+                "parameters": [
+                    {
+                        "description": "Adjust Wallet Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AdjustWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WalletTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/wallets/customer/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the wallet balance of a customer, a customer without a wallet has a zero balance",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get wallet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Wallet"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/wallets/customer/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated history of the wallet of a customer, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get wallet transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginationDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/wallets/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pay an invoice from the wallet. When a wallet amount below the price is given, a PayOS link is returned for the rest and the wallet part is held by a pending WALLET payment until the link is paid, it is given back to the wallet when the link is cancelled or expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Pay with wallet",
+                "parameters": [
+                    {
+                        "description": "Pay With Wallet Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PayWithWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.WalletPaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "The wallet balance is not enough for this transaction.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/wallets/top-up": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a PayOS payment link to top up the wallet, the wallet is credited once the top-up is confirmed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Create wallet top-up",
+                "parameters": [
+                    {
+                        "description": "Create Wallet Top-Up Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateWalletTopUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WalletTopUp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data. Please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-service/api/v1/wallets/top-up/{id}/confirm": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the PayOS payment link of the top-up, a paid top-up is credited to the wallet once, a cancelled or expired one is closed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Confirm wallet top-up",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wallet top-up ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WalletTopUp"
+                        }
+                    },
+                    "400": {
+                        "description": "The top-up has not been paid yet. Please try again after completing the payment.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You have no rights to access this action.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "WalletTopUp not found.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "There is something wrong in the system during the process. Please try again later.",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageApiResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AgencyRevenueSummary": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "paidAmount": {
+                    "type": "number"
+                },
+                "paymentCount": {
+                    "type": "integer"
+                },
+                "pendingAmount": {
+                    "type": "number"
+                },
+                "totalAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.BankTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "bankTransactionId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "importedAt": {
+                    "type": "string"
+                },
+                "importedBy": {
+                    "type": "integer"
+                },
+                "matchNote": {
+                    "type": "string"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "pendingInvoiceId": {
+                    "type": "integer"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transactionDate": {
+                    "type": "string"
+                },
+                "transactionRef": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CustomerSpendingSummary": {
+            "type": "object",
+            "properties": {
+                "averageTicket": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "lastPaymentAt": {
+                    "type": "string"
+                },
+                "lifetimeSpend": {
+                    "description": "Paid payments, refunded ones are excluded",
+                    "type": "number"
+                },
+                "paidTours": {
+                    "type": "integer"
+                },
+                "refundCount": {
+                    "type": "integer"
+                },
+                "refundedAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.EInvoice": {
+            "type": "object",
+            "properties": {
+                "amountBeforeTax": {
+                    "type": "number"
+                },
+                "buyerAddress": {
+                    "type": "string"
+                },
+                "buyerCompanyName": {
+                    "type": "string"
+                },
+                "buyerEmail": {
+                    "type": "string"
+                },
+                "buyerName": {
+                    "type": "string"
+                },
+                "buyerTaxCode": {
+                    "type": "string"
+                },
+                "eInvoiceId": {
+                    "type": "integer"
+                },
+                "invoiceNumber": {
+                    "type": "integer"
+                },
+                "invoiceType": {
+                    "type": "string"
+                },
+                "issuedAt": {
+                    "type": "string"
+                },
+                "issuedBy": {
+                    "type": "integer"
+                },
+                "itemName": {
+                    "type": "string"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "relatedEInvoiceId": {
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "templateCode": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "number"
+                },
+                "vatAmount": {
+                    "type": "number"
+                },
+                "vatRate": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.Feedback": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "createdDate": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "feedbackId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "isDeleted": {
+                    "type": "boolean"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FiscalPeriod": {
+            "type": "object",
+            "properties": {
+                "closedAt": {
+                    "type": "string"
+                },
+                "closedBy": {
+                    "type": "integer"
+                },
+                "fiscalPeriodId": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.FiscalPeriodAudit": {
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fiscalPeriodAuditId": {
+                    "type": "integer"
+                },
+                "fiscalPeriodId": {
+                    "type": "integer"
+                },
+                "fromStatus": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "toStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GiftCard": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "checkoutUrl": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "giftCardId": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "number"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "orderCode": {
+                    "type": "integer"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "purchaserId": {
+                    "type": "integer"
+                },
+                "recipientEmail": {
+                    "type": "string"
+                },
+                "recipientName": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GiftCardStatusSummary": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "cardCount": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GiftCardTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "balanceAfter": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "giftCardId": {
+                    "type": "integer"
+                },
+                "giftCardTransactionId": {
+                    "type": "integer"
+                },
+                "referenceId": {
+                    "type": "integer"
+                },
+                "transactionType": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.LoyaltyRule": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "loyaltyRuleId": {
+                    "type": "integer"
+                },
+                "multiplier": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.OfflinePayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "approvedAt": {
+                    "type": "string"
+                },
+                "approvedBy": {
+                    "description": "Second approver of large payments",
+                    "type": "integer"
+                },
+                "confirmedAt": {
+                    "type": "string"
+                },
+                "confirmedBy": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "offlinePaymentId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "recordedAt": {
+                    "type": "string"
+                },
+                "recordedBy": {
+                    "type": "integer"
+                },
+                "rejectReason": {
+                    "type": "string"
+                },
+                "rejectedAt": {
+                    "type": "string"
+                },
+                "rejectedBy": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PaidTaxWithholding": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "grossAmount": {
+                    "type": "number"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "payoutItemId": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "taxAmount": {
+                    "type": "number"
+                },
+                "taxCode": {
+                    "type": "string"
+                },
+                "taxRate": {
+                    "type": "number"
+                },
+                "taxWithholdingId": {
+                    "type": "integer"
+                },
+                "taxpayerType": {
+                    "type": "string"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Payment": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "description": "PaymentType   string    ` + "`" + `json:\"paymentType\"` + "`" + ` // bo^' bo? theo y' m",
+                    "type": "integer"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "e.g., \"paid\", \"unpaid\", \"pending\"",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PaymentFeeSummary": {
+            "type": "object",
+            "properties": {
+                "feeAmount": {
+                    "type": "number"
+                },
+                "grossAmount": {
+                    "type": "number"
+                },
+                "netAmount": {
+                    "type": "number"
+                },
+                "paymentCount": {
+                    "type": "integer"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "surcharge": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.PaymentMethodFee": {
+            "type": "object",
+            "properties": {
+                "feeRate": {
+                    "description": "Gateway fee in percent of the amount collected",
+                    "type": "number"
+                },
+                "fixedFee": {
+                    "description": "Gateway fee per payment",
+                    "type": "number"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "surchargeFixed": {
+                    "description": "Surcharge per payment",
+                    "type": "number"
+                },
+                "surchargeRate": {
+                    "description": "Surcharge in percent of the payment price",
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.PayoutBatch": {
+            "type": "object",
+            "properties": {
+                "approvedAt": {
+                    "type": "string"
+                },
+                "approvedBy": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "itemCount": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "payoutBatchId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PayoutPolicy": {
+            "type": "object",
+            "properties": {
+                "holdDays": {
+                    "type": "integer"
+                },
+                "isAutoPayoutEnabled": {
+                    "type": "boolean"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
+                "minPayableBalance": {
+                    "type": "number"
+                },
+                "payoutDay": {
+                    "description": "Weekday (0 = Sunday) for weekly schedules, day of month otherwise",
+                    "type": "integer"
+                },
+                "payoutPolicyId": {
+                    "type": "integer"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.PendingInvoice": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "pendingInvoiceId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PlatformFeedback": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "feedbackId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Referral": {
+            "type": "object",
+            "properties": {
+                "attributedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "firstPaymentId": {
+                    "type": "integer"
+                },
+                "refereeId": {
+                    "type": "integer"
+                },
+                "refereeType": {
+                    "type": "string"
+                },
+                "referralCodeId": {
+                    "type": "integer"
+                },
+                "referralId": {
+                    "type": "integer"
+                },
+                "referrerId": {
+                    "type": "integer"
+                },
+                "referrerType": {
+                    "type": "string"
+                },
+                "rewardedTours": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReferralCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "ownerType": {
+                    "type": "string"
+                },
+                "referralCodeId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Revenue": {
+            "type": "object",
+            "properties": {
+                "actualReceived": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "paymentStatus": {
+                    "type": "boolean"
+                },
+                "platformCommission": {
+                    "type": "number"
+                },
+                "revenueId": {
+                    "type": "integer"
+                },
+                "totalAmount": {
+                    "type": "number"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.RevenueAdjustment": {
+            "type": "object",
+            "properties": {
+                "actualReceivedDelta": {
+                    "type": "number"
+                },
+                "adjustmentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "payoutItemId": {
+                    "type": "integer"
+                },
+                "platformCommissionDelta": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "revenueAdjustmentId": {
+                    "type": "integer"
+                },
+                "revenueId": {
+                    "type": "integer"
+                },
+                "settled": {
+                    "type": "boolean"
+                },
+                "totalAmountDelta": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.SplitPayment": {
+            "type": "object",
+            "properties": {
+                "checkoutUrl": {
+                    "type": "string"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "gatewayAmount": {
+                    "description": "Left to pay through the link",
+                    "type": "number"
+                },
+                "orderCode": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "description": "Payment of the held part",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "Zero for a wallet part",
+                    "type": "integer"
+                },
+                "splitPaymentId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Subscription": {
+            "type": "object",
+            "properties": {
+                "billingEmail": {
+                    "type": "string"
+                },
+                "cancelAtPeriodEnd": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentPeriodEnd": {
+                    "type": "string"
+                },
+                "currentPeriodStart": {
+                    "type": "string"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscriptionId": {
+                    "type": "integer"
+                },
+                "subscriptionPlanId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SubscriptionInvoice": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "checkoutUrl": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "lastRemindedAt": {
+                    "type": "string"
+                },
+                "orderCode": {
+                    "type": "integer"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "periodEnd": {
+                    "type": "string"
+                },
+                "periodStart": {
+                    "type": "string"
+                },
+                "reminderCount": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscriptionId": {
+                    "type": "integer"
+                },
+                "subscriptionInvoiceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.SubscriptionPlan": {
+            "type": "object",
+            "properties": {
+                "commissionRate": {
+                    "description": "Platform commission in percent of the tour price",
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "cycleMonths": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "isFeatured": {
+                    "description": "Subscribers are featured in the tour listing",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "subscriptionPlanId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Wallet": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "walletId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.WalletTopUp": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "checkoutUrl": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "orderCode": {
+                    "type": "integer"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "walletTopUpId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.WalletTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "balanceAfter": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "referenceId": {
+                    "type": "integer"
+                },
+                "transactionType": {
+                    "type": "string"
+                },
+                "walletId": {
+                    "type": "integer"
+                },
+                "walletTransactionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pb.TourServiceRatingResponse": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "number"
+                },
+                "reviewCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AcceptBankTransactionRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "bankTransactionId": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "pendingInvoiceId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AdjustEInvoiceRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "amount",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "einvoiceId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AdjustWalletRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "amount",
+                "customerId",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CancelSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "tourGuideId"
+            ],
+            "properties": {
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ClaimReferralCodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "refereeId",
+                "refereeType"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "refereeId": {
+                    "type": "integer"
+                },
+                "refereeType": {
+                    "type": "string",
+                    "enum": [
+                        "CUSTOMER",
+                        "TOUR_GUIDE"
+                    ]
+                }
+            }
+        },
+        "request.ConfirmOfflinePaymentRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "offlinePaymentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ConfirmPendingInvoiceRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "pendingInvoiceId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateAgencyPayoutRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateFeedbackRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "customerId",
+                "invoiceId",
+                "rating",
+                "serviceId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateLedgerAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "description",
+                "lines"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/request.LedgerLineRequest"
+                    }
+                },
+                "referenceId": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.CreatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "customerId",
+                "invoiceId",
+                "paymentMethod",
+                "price",
+                "serviceId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreatePayosTransactionRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "invoiceId"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "redeemPoints": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreatePayoutAccountRequest": {
+            "type": "object",
+            "required": [
+                "accountHolder",
+                "accountNumber",
+                "bankCode",
+                "tourGuideId"
+            ],
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
+                },
+                "accountNumber": {
+                    "type": "string",
+                    "maxLength": 19,
+                    "minLength": 6
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreatePayoutBatchRequest": {
+            "type": "object",
+            "required": [
+                "createdBy",
+                "tourGuideIds"
+            ],
+            "properties": {
+                "createdBy": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "tourGuideIds": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.CreatePlatformFeedbackRequest": {
+            "type": "object",
+            "required": [
+                "customerId",
+                "paymentId",
+                "rating"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5
+                }
+            }
+        },
+        "request.CreateRevenueRequest": {
+            "type": "object",
+            "required": [
+                "actualReceived",
+                "invoiceId",
+                "paymentId",
+                "paymentStatus",
+                "platformCommission",
+                "totalAmount",
+                "tourGuideId"
+            ],
+            "properties": {
+                "actualReceived": {
+                    "type": "number"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "paymentStatus": {
+                    "type": "boolean"
+                },
+                "platformCommission": {
+                    "type": "number"
+                },
+                "totalAmount": {
+                    "type": "number"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "billingEmail",
+                "subscriptionPlanId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "billingEmail": {
+                    "type": "string"
+                },
+                "subscriptionPlanId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateWalletTopUpRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "customerId"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.FiscalPeriodActionRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "month",
+                "year"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer",
+                    "maximum": 12
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.GetReferralCodeRequest": {
+            "type": "object",
+            "required": [
+                "ownerId",
+                "ownerType"
+            ],
+            "properties": {
+                "ownerId": {
+                    "type": "integer"
+                },
+                "ownerType": {
+                    "type": "string",
+                    "enum": [
+                        "CUSTOMER",
+                        "TOUR_GUIDE"
+                    ]
+                }
+            }
+        },
+        "request.IssueEInvoiceRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "paymentId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "buyerAddress": {
+                    "type": "string"
+                },
+                "buyerCompanyName": {
+                    "type": "string"
+                },
+                "buyerEmail": {
+                    "type": "string"
+                },
+                "buyerName": {
+                    "type": "string"
+                },
+                "buyerTaxCode": {
+                    "type": "string"
+                },
+                "paymentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.LedgerLineRequest": {
+            "type": "object",
+            "required": [
+                "account"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "credit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "debit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "ownerId": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.PayWithGiftCardRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "customerId",
+                "invoiceId",
+                "price",
+                "serviceId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.PayWithWalletRequest": {
+            "type": "object",
+            "required": [
+                "customerId",
+                "invoiceId",
+                "price",
+                "serviceId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "walletAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.PayoutBatchActionRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "payoutBatchId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ProcessAgencyPayoutRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PurchaseGiftCardRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "purchaserId",
+                "recipientEmail",
+                "recipientName"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "purchaserId": {
+                    "type": "integer"
+                },
+                "recipientEmail": {
+                    "type": "string"
+                },
+                "recipientName": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RedeemGiftCardRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "customerId"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.RefundPaymentRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "paymentId",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "toWallet": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.RegisterPendingInvoiceRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "customerId",
+                "invoiceId",
+                "serviceId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.RejectBankTransactionRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "note"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "bankTransactionId": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RejectOfflinePaymentRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "offlinePaymentId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RemoveFeedbackRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "feedbackId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "feedbackId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.RemovePayoutAccountRequest": {
+            "type": "object",
+            "required": [
+                "payoutAccountId",
+                "tourGuideId"
+            ],
+            "properties": {
+                "payoutAccountId": {
+                    "type": "integer"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.RemoveRevenueRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "revenueId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ReplaceEInvoiceRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "buyerAddress": {
+                    "type": "string"
+                },
+                "buyerCompanyName": {
+                    "type": "string"
+                },
+                "buyerEmail": {
+                    "type": "string"
+                },
+                "buyerName": {
+                    "type": "string"
+                },
+                "buyerTaxCode": {
+                    "type": "string"
+                },
+                "einvoiceId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.SaveTaxProfileRequest": {
+            "type": "object",
+            "required": [
+                "legalName",
+                "taxpayerType",
+                "tourGuideId"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "identityNumber": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "minLength": 9
+                },
+                "legalName": {
+                    "type": "string"
+                },
+                "taxCode": {
+                    "type": "string"
+                },
+                "taxpayerType": {
+                    "type": "string",
+                    "enum": [
+                        "INDIVIDUAL",
+                        "BUSINESS"
+                    ]
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateFeedbackRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5
+                },
+                "request": {
+                    "$ref": "#/definitions/request.RemoveFeedbackRequest"
+                }
+            }
+        },
+        "request.UpdatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "paymentId"
+            ],
+            "properties": {
+                "method": {
+                    "type": "string"
+                },
+                "paymentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdatePayoutAccountRequest": {
+            "type": "object",
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
+                },
+                "accountNumber": {
+                    "type": "string",
+                    "maxLength": 19,
+                    "minLength": 6
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "request": {
+                    "$ref": "#/definitions/request.RemovePayoutAccountRequest"
+                }
+            }
+        },
+        "request.UpdatePayoutPolicyRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "schedule"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "holdDays": {
+                    "type": "integer",
+                    "maximum": 90,
+                    "minimum": 0
+                },
+                "isAutoPayoutEnabled": {
+                    "type": "boolean"
+                },
+                "minPayableBalance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "payoutDay": {
+                    "type": "integer",
+                    "maximum": 28,
+                    "minimum": 0
+                },
+                "schedule": {
+                    "type": "string",
+                    "enum": [
+                        "WEEKLY",
+                        "BIWEEKLY",
+                        "MONTHLY"
+                    ]
+                }
+            }
+        },
+        "request.UpdatePlatformFeedbackRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "feedbackId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "feedbackId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateReferralCodeStatusRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.UpdateRevenueRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "reason"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "actualReceived": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "platformCommission": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "revenueId": {
+                    "type": "integer"
+                },
+                "totalAmount": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.UpsertAgencyRequest": {
+            "type": "object",
+            "required": [
+                "accountHolder",
+                "actorId",
+                "bankCode",
+                "name"
+            ],
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
+                },
+                "accountNumber": {
+                    "type": "string",
+                    "maxLength": 19,
+                    "minLength": 6
+                },
+                "actorId": {
+                    "type": "integer"
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "commissionRate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shareRate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "request.UpsertLoyaltyRuleRequest": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "multiplier",
+                "name"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "multiplier": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpsertPaymentMethodFeeRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "feeRate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "fixedFee": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "surchargeFixed": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "surchargeRate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "request.UpsertSubscriptionPlanRequest": {
+            "type": "object",
+            "required": [
+                "cycleMonths",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "commissionRate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "cycleMonths": {
+                    "type": "integer",
+                    "maximum": 12
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "isFeatured": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.VerifyPayoutAccountRequest": {
+            "type": "object",
+            "required": [
+                "actorId"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "payoutAccountId": {
+                    "type": "integer"
+                },
+                "rejectReason": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AccountMappingResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AgencyGuideRevenueResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "paymentCount": {
+                    "type": "integer"
+                },
+                "totalAmount": {
+                    "type": "number"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "tourGuideName": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AgencyPayoutResponse": {
+            "type": "object",
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
+                },
+                "accountNumber": {
+                    "type": "string"
+                },
+                "agencyId": {
+                    "type": "integer"
+                },
+                "agencyPayoutId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "processedAt": {
+                    "type": "string"
+                },
+                "processedBy": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "revenueIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AgencyReportResponse": {
+            "type": "object",
+            "properties": {
+                "agency": {
+                    "$ref": "#/definitions/response.AgencyResponse"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "guides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AgencyGuideRevenueResponse"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/entity.AgencyRevenueSummary"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AgencyResponse": {
+            "type": "object",
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
+                },
+                "accountNumber": {
+                    "type": "string"
+                },
+                "agencyId": {
+                    "type": "integer"
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "commissionRate": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shareRate": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AreaRevenueResponse": {
+            "type": "object",
+            "properties": {
+                "areaId": {
+                    "type": "integer"
+                },
+                "netRevenue": {
+                    "type": "number"
+                },
+                "platformFee": {
+                    "type": "number"
+                },
+                "serviceCount": {
+                    "type": "integer"
+                },
+                "totalRecords": {
+                    "type": "integer"
+                },
+                "totalRevenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.BankStatementImportResponse": {
+            "type": "object",
+            "properties": {
+                "duplicateLines": {
+                    "type": "integer"
+                },
+                "matchedLines": {
+                    "type": "integer"
+                },
+                "reviewLines": {
+                    "type": "integer"
+                },
+                "totalLines": {
                     "type": "integer"
                 },
-                "isDeleted": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BankTransaction"
+                    }
+                },
+                "unmatchedLines": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CustomerPaymentSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "$ref": "#/definitions/response.PaginationDataResponse"
+                },
+                "summary": {
+                    "$ref": "#/definitions/entity.CustomerSpendingSummary"
+                }
+            }
+        },
+        "response.GatewayFeeReportResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "methods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PaymentFeeSummary"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/entity.PaymentFeeSummary"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GiftCardLiabilityResponse": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string"
+                },
+                "expiringBalance": {
+                    "type": "number"
+                },
+                "expiringBefore": {
+                    "type": "string"
+                },
+                "expiringCount": {
+                    "type": "integer"
+                },
+                "outstandingBalance": {
+                    "type": "number"
+                },
+                "outstandingCount": {
+                    "type": "integer"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GiftCardStatusSummary"
+                    }
+                }
+            }
+        },
+        "response.GiftCardPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "checkoutUrl": {
+                    "type": "string"
+                },
+                "giftCardTransaction": {
+                    "$ref": "#/definitions/entity.GiftCardTransaction"
+                },
+                "payment": {
+                    "$ref": "#/definitions/entity.Payment"
+                },
+                "remainingAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.GiftCardRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "giftCardTransaction": {
+                    "$ref": "#/definitions/entity.GiftCardTransaction"
+                },
+                "walletTransaction": {
+                    "$ref": "#/definitions/entity.WalletTransaction"
+                }
+            }
+        },
+        "response.LedgerBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "totalCredit": {
+                    "type": "number"
+                },
+                "totalDebit": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.LedgerInvariantResponse": {
+            "type": "object",
+            "properties": {
+                "accountBalances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LedgerBalanceResponse"
+                    }
+                },
+                "difference": {
+                    "type": "number"
+                },
+                "isBalanced": {
+                    "type": "boolean"
+                },
+                "totalCredit": {
+                    "type": "number"
+                },
+                "totalDebit": {
+                    "type": "number"
+                },
+                "unbalancedEntryIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "response.LedgerStatementLineResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "credit": {
+                    "type": "number"
+                },
+                "debit": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entryType": {
+                    "type": "string"
+                },
+                "ledgerEntryId": {
+                    "type": "integer"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "referenceId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.LedgerStatementResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "closingBalance": {
+                    "type": "number"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LedgerStatementLineResponse"
+                    }
+                },
+                "openingBalance": {
+                    "type": "number"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.LoyaltyBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "balanceValue": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "expiringBefore": {
+                    "type": "string"
+                },
+                "expiringPoints": {
+                    "type": "integer"
+                },
+                "pointValue": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.MessageApiResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.MonthlyRevenueResponse": {
+            "type": "object",
+            "properties": {
+                "completedPayments": {
+                    "type": "integer"
+                },
+                "growthPercentage": {
+                    "type": "number"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "netRevenue": {
+                    "type": "number"
+                },
+                "pendingPayments": {
+                    "type": "integer"
+                },
+                "platformFee": {
+                    "type": "number"
+                },
+                "totalRecords": {
+                    "type": "integer"
+                },
+                "totalRevenue": {
+                    "type": "number"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PaginationDataResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_previous": {
+                    "type": "boolean"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PaymentWithServiceNameResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "serviceName": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PayosTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "discountAmount": {
+                    "type": "number"
+                },
+                "payment": {
+                    "$ref": "#/definitions/entity.Payment"
+                },
+                "redeemedPoints": {
+                    "type": "integer"
+                },
+                "splitPayment": {
+                    "$ref": "#/definitions/entity.SplitPayment"
+                },
+                "surcharge": {
+                    "description": "Charged by the link on top of the amount, the payment is recorded with the amount",
+                    "type": "number"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PayoutAccountResponse": {
+            "type": "object",
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
+                },
+                "accountNumber": {
+                    "type": "string"
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "isDefault": {
                     "type": "boolean"
                 },
-                "rating": {
+                "payoutAccountId": {
                     "type": "integer"
                 },
-                "serviceId": {
-                    "type": "integer"
+                "rejectReason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 },
                 "tourGuideId": {
                     "type": "integer"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "verifiedAt": {
+                    "type": "string"
+                },
+                "verifiedBy": {
+                    "type": "integer"
                 }
             }
         },
-        "entity.Payment": {
+        "response.PayoutBatchResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "batch": {
+                    "$ref": "#/definitions/entity.PayoutBatch"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PayoutItemResponse"
+                    }
+                }
+            }
+        },
+        "response.PayoutImportResponse": {
+            "type": "object",
+            "properties": {
+                "batchStatus": {
                     "type": "string"
                 },
-                "customerId": {
+                "failedItems": {
                     "type": "integer"
                 },
-                "invoiceId": {
+                "paidItems": {
                     "type": "integer"
                 },
-                "paymentId": {
-                    "description": "PaymentType   string    ` + "`" + `json:\"paymentType\"` + "`" + ` // bo^' bo? theo y' m",
-                    "type": "integer"
+                "unmatchedLines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.PayoutItemResponse": {
+            "type": "object",
+            "properties": {
+                "accountHolder": {
+                    "type": "string"
                 },
-                "paymentMethod": {
+                "accountNumber": {
                     "type": "string"
                 },
-                "price": {
+                "amount": {
                     "type": "number"
                 },
-                "serviceId": {
+                "bankCode": {
+                    "type": "string"
+                },
+                "failureReason": {
+                    "type": "string"
+                },
+                "payoutItemId": {
                     "type": "integer"
                 },
+                "processedAt": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "revenueIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rewardIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "status": {
-                    "description": "e.g., \"paid\", \"unpaid\", \"pending\"",
+                    "type": "string"
+                },
+                "taxWithheld": {
+                    "type": "number"
+                },
+                "tourGuideId": {
+                    "type": "integer"
+                },
+                "tourGuideName": {
                     "type": "string"
                 }
             }
         },
-        "entity.PlatformFeedback": {
+        "response.PayoutSummaryResponse": {
             "type": "object",
             "properties": {
-                "content": {
+                "amountEligible": {
+                    "type": "number"
+                },
+                "amountOnHold": {
+                    "type": "number"
+                },
+                "hasVerifiedAccount": {
+                    "type": "boolean"
+                },
+                "holdDays": {
+                    "type": "integer"
+                },
+                "minPayableBalance": {
+                    "type": "number"
+                },
+                "nextPayoutDate": {
                     "type": "string"
                 },
-                "createdAt": {
+                "schedule": {
                     "type": "string"
                 },
-                "customerId": {
+                "tourGuideId": {
                     "type": "integer"
+                }
+            }
+        },
+        "response.PlatformRevenueSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
                 },
-                "feedbackId": {
-                    "type": "integer"
+                "gatewayFee": {
+                    "description": "Gateway fees left after the surcharges paid by the customers",
+                    "type": "number"
                 },
-                "paymentId": {
+                "netPlatformFee": {
+                    "description": "Platform fee after the gateway fees",
+                    "type": "number"
+                },
+                "netRevenue": {
+                    "type": "number"
+                },
+                "pendingAmount": {
+                    "type": "number"
+                },
+                "platformFee": {
+                    "type": "number"
+                },
+                "settledAmount": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "totalRecords": {
                     "type": "integer"
                 },
-                "rating": {
+                "totalRevenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.ReferralSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "paidReward": {
+                    "type": "number"
+                },
+                "pendingReward": {
+                    "type": "number"
+                },
+                "referralCode": {
+                    "$ref": "#/definitions/entity.ReferralCode"
+                },
+                "referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Referral"
+                    }
+                },
+                "remainingReferrals": {
                     "type": "integer"
+                },
+                "remainingReward": {
+                    "type": "number"
                 }
             }
         },
-        "entity.Revenue": {
+        "response.RevenueGrowthPercentageResponse": {
+            "type": "object",
+            "properties": {
+                "growthPercentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.RevenueResponse": {
             "type": "object",
             "properties": {
                 "actualReceived": {
@@ -1866,367 +11697,343 @@ const docTemplate = `{
                 },
                 "tourGuideId": {
                     "type": "integer"
-                }
-            }
-        },
-        "pb.TourServiceRatingResponse": {
-            "type": "object",
-            "properties": {
-                "rating": {
-                    "type": "number"
                 },
-                "reviewCount": {
-                    "type": "integer"
+                "tourGuideName": {
+                    "type": "string"
                 }
             }
         },
-        "request.CreateFeedbackRequest": {
+        "response.RevenueSeriesBucketResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "customerId",
-                "invoiceId",
-                "rating",
-                "serviceId",
-                "tourGuideId"
-            ],
             "properties": {
-                "content": {
+                "bucketEnd": {
                     "type": "string"
                 },
-                "customerId": {
-                    "type": "integer"
+                "bucketStart": {
+                    "type": "string"
                 },
-                "invoiceId": {
+                "completedRecords": {
+                    "description": "Revenues of the bucket which have been paid out to the tour guide",
                     "type": "integer"
                 },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5
+                "netRevenue": {
+                    "type": "number"
                 },
-                "serviceId": {
-                    "type": "integer"
+                "periodGrowth": {
+                    "description": "Growth of total revenue compared to the previous bucket, null when the previous bucket is empty",
+                    "type": "number"
                 },
-                "tourGuideId": {
+                "platformFee": {
+                    "type": "number"
+                },
+                "totalRecords": {
                     "type": "integer"
+                },
+                "totalRevenue": {
+                    "type": "number"
+                },
+                "yearOverYearGrowth": {
+                    "description": "Growth of total revenue compared to the same bucket one year earlier, null when that bucket is empty",
+                    "type": "number"
                 }
             }
         },
-        "request.CreatePaymentRequest": {
+        "response.RevenueSeriesResponse": {
             "type": "object",
-            "required": [
-                "customerId",
-                "invoiceId",
-                "paymentMethod",
-                "price",
-                "serviceId",
-                "tourGuideId"
-            ],
             "properties": {
-                "customerId": {
-                    "type": "integer"
-                },
-                "invoiceId": {
-                    "type": "integer"
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RevenueSeriesBucketResponse"
+                    }
                 },
-                "paymentMethod": {
+                "from": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "granularity": {
+                    "type": "string"
                 },
-                "serviceId": {
-                    "type": "integer"
+                "to": {
+                    "type": "string"
                 },
                 "tourGuideId": {
                     "type": "integer"
                 }
             }
         },
-        "request.CreatePayosTransactionRequest": {
+        "response.RevenueStatusResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "invoiceId"
-            ],
             "properties": {
-                "amount": {
+                "completedPayments": {
+                    "type": "integer"
+                },
+                "gatewayFee": {
+                    "description": "Gateway fees left after the surcharges paid by the customers",
                     "type": "number"
                 },
-                "invoiceId": {
-                    "type": "integer"
-                }
-            }
-        },
-        "request.CreatePlatformFeedbackRequest": {
-            "type": "object",
-            "required": [
-                "customerId",
-                "paymentId",
-                "rating"
-            ],
-            "properties": {
-                "content": {
-                    "type": "string"
+                "monthlyGrowth": {
+                    "type": "number"
                 },
-                "customerId": {
+                "netPlatformFee": {
+                    "description": "Platform fee after the gateway fees",
+                    "type": "number"
+                },
+                "netRevenue": {
+                    "type": "number"
+                },
+                "pendingPayments": {
                     "type": "integer"
                 },
-                "paymentId": {
+                "platformFee": {
+                    "type": "number"
+                },
+                "revenueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RevenueResponse"
+                    }
+                },
+                "totalRecords": {
                     "type": "integer"
                 },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5
+                "totalRevenue": {
+                    "type": "number"
                 }
             }
         },
-        "request.CreateRevenueRequest": {
+        "response.ServiceRevenueResponse": {
             "type": "object",
-            "required": [
-                "actualReceived",
-                "invoiceId",
-                "paymentId",
-                "paymentStatus",
-                "platformCommission",
-                "totalAmount",
-                "tourGuideId"
-            ],
             "properties": {
-                "actualReceived": {
+                "areaId": {
+                    "type": "integer"
+                },
+                "netRevenue": {
                     "type": "number"
                 },
-                "invoiceId": {
-                    "type": "integer"
+                "platformFee": {
+                    "type": "number"
                 },
-                "paymentId": {
+                "serviceId": {
                     "type": "integer"
                 },
-                "paymentStatus": {
-                    "type": "boolean"
+                "serviceName": {
+                    "type": "string"
                 },
-                "platformCommission": {
-                    "type": "number"
+                "totalRecords": {
+                    "type": "integer"
                 },
-                "totalAmount": {
+                "totalRevenue": {
                     "type": "number"
-                },
-                "tourGuideId": {
-                    "type": "integer"
                 }
             }
         },
-        "request.RemoveFeedbackRequest": {
+        "response.SettlementImportResponse": {
             "type": "object",
-            "required": [
-                "actorId",
-                "feedbackId"
-            ],
             "properties": {
-                "actorId": {
+                "duplicateLines": {
                     "type": "integer"
                 },
-                "feedbackId": {
+                "feeDifference": {
+                    "description": "Settled fees minus the estimated fees they replace",
+                    "type": "number"
+                },
+                "settledLines": {
                     "type": "integer"
-                }
-            }
-        },
-        "request.UpdateFeedbackRequest": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
                 },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5
+                "totalLines": {
+                    "type": "integer"
                 },
-                "request": {
-                    "$ref": "#/definitions/request.RemoveFeedbackRequest"
+                "unmatched": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SettlementLine"
+                    }
+                },
+                "unmatchedLines": {
+                    "type": "integer"
                 }
             }
         },
-        "request.UpdatePaymentRequest": {
+        "response.SettlementLine": {
             "type": "object",
-            "required": [
-                "paymentId"
-            ],
             "properties": {
-                "method": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
                     "type": "string"
                 },
-                "paymentId": {
+                "feeAmount": {
+                    "type": "number"
+                },
+                "invoiceId": {
                     "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
                 }
             }
         },
-        "request.UpdatePlatformFeedbackRequest": {
+        "response.SubscriptionBillingResponse": {
             "type": "object",
-            "required": [
-                "actorId",
-                "feedbackId"
-            ],
             "properties": {
-                "actorId": {
+                "createdInvoices": {
                     "type": "integer"
                 },
-                "content": {
-                    "type": "string"
+                "downgradedGuides": {
+                    "type": "integer"
                 },
-                "feedbackId": {
+                "endedSubscriptions": {
                     "type": "integer"
                 },
-                "rating": {
+                "paidInvoices": {
+                    "type": "integer"
+                },
+                "sentReminders": {
                     "type": "integer"
                 }
             }
         },
-        "request.UpdateRevenueRequest": {
+        "response.SubscriptionResponse": {
             "type": "object",
             "properties": {
-                "actualReceived": {
-                    "type": "number"
+                "openInvoice": {
+                    "$ref": "#/definitions/entity.SubscriptionInvoice"
                 },
-                "invoiceId": {
-                    "type": "integer"
+                "plan": {
+                    "$ref": "#/definitions/entity.SubscriptionPlan"
                 },
-                "paymentId": {
-                    "type": "integer"
+                "subscription": {
+                    "$ref": "#/definitions/entity.Subscription"
+                }
+            }
+        },
+        "response.SurchargeResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "paymentStatus": {
-                    "type": "boolean"
+                "paymentMethod": {
+                    "type": "string"
                 },
-                "platformCommission": {
+                "surcharge": {
                     "type": "number"
                 },
-                "revenueId": {
-                    "type": "integer"
-                },
                 "totalAmount": {
                     "type": "number"
-                },
-                "tourGuideId": {
-                    "type": "integer"
                 }
             }
         },
-        "response.MessageApiResponse": {
+        "response.TaxCertificateResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "address": {
                     "type": "string"
-                }
-            }
-        },
-        "response.MonthlyRevenueResponse": {
-            "type": "object",
-            "properties": {
-                "completedPayments": {
-                    "type": "integer"
-                },
-                "growthPercentage": {
-                    "type": "number"
                 },
-                "month": {
+                "fromMonth": {
                     "type": "integer"
                 },
-                "netRevenue": {
-                    "type": "number"
+                "identityNumber": {
+                    "type": "string"
                 },
-                "pendingPayments": {
-                    "type": "integer"
+                "legalName": {
+                    "type": "string"
                 },
-                "platformFee": {
-                    "type": "number"
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PaidTaxWithholding"
+                    }
                 },
-                "totalRecords": {
-                    "type": "integer"
+                "taxCode": {
+                    "type": "string"
                 },
-                "totalRevenue": {
+                "taxWithheld": {
                     "type": "number"
                 },
-                "year": {
-                    "type": "integer"
-                }
-            }
-        },
-        "response.PaginationDataResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "has_next": {
-                    "type": "boolean"
+                "taxableIncome": {
+                    "type": "number"
                 },
-                "has_previous": {
-                    "type": "boolean"
+                "taxpayerType": {
+                    "type": "string"
                 },
-                "page": {
+                "toMonth": {
                     "type": "integer"
                 },
-                "per_page": {
-                    "type": "integer"
+                "totalIncome": {
+                    "type": "number"
                 },
-                "total_count": {
+                "tourGuideId": {
                     "type": "integer"
                 },
-                "total_pages": {
+                "year": {
                     "type": "integer"
                 }
             }
         },
-        "response.PaymentWithServiceNameResponse": {
+        "response.TaxProfileResponse": {
             "type": "object",
             "properties": {
+                "address": {
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
-                "paymentId": {
+                "identityNumber": {
+                    "type": "string"
+                },
+                "legalName": {
+                    "type": "string"
+                },
+                "taxCode": {
+                    "type": "string"
+                },
+                "taxProfileId": {
                     "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "taxpayerType": {
+                    "type": "string"
                 },
-                "serviceId": {
+                "tourGuideId": {
                     "type": "integer"
                 },
-                "serviceName": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "response.RevenueGrowthPercentageResponse": {
+        "response.TaxWithholdingLedgerResponse": {
             "type": "object",
             "properties": {
-                "growthPercentage": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PaidTaxWithholding"
+                    }
+                },
+                "totalGrossAmount": {
+                    "type": "number"
+                },
+                "totalTaxAmount": {
                     "type": "number"
                 }
             }
         },
-        "response.RevenueResponse": {
+        "response.TourGuideRevenueResponse": {
             "type": "object",
             "properties": {
-                "actualReceived": {
+                "netRevenue": {
                     "type": "number"
                 },
-                "createdAt": {
-                    "type": "string"
-                },
-                "invoiceId": {
-                    "type": "integer"
-                },
-                "paymentId": {
-                    "type": "integer"
-                },
-                "paymentStatus": {
-                    "type": "boolean"
-                },
-                "platformCommission": {
+                "platformFee": {
                     "type": "number"
                 },
-                "revenueId": {
+                "totalRecords": {
                     "type": "integer"
                 },
-                "totalAmount": {
+                "totalRevenue": {
                     "type": "number"
                 },
                 "tourGuideId": {
@@ -2237,43 +12044,58 @@ const docTemplate = `{
                 }
             }
         },
-        "response.RevenueStatusResponse": {
+        "response.VietQrResponse": {
             "type": "object",
             "properties": {
-                "completedPayments": {
-                    "type": "integer"
+                "accountName": {
+                    "type": "string"
                 },
-                "monthlyGrowth": {
-                    "type": "number"
+                "accountNo": {
+                    "type": "string"
                 },
-                "netRevenue": {
+                "amount": {
                     "type": "number"
                 },
-                "pendingPayments": {
+                "bankBin": {
+                    "type": "string"
+                },
+                "bankCode": {
+                    "type": "string"
+                },
+                "invoiceId": {
                     "type": "integer"
                 },
-                "platformFee": {
-                    "type": "number"
+                "note": {
+                    "type": "string"
                 },
-                "revenueList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.RevenueResponse"
-                    }
+                "payload": {
+                    "type": "string"
                 },
-                "totalRecords": {
+                "pendingInvoiceId": {
                     "type": "integer"
                 },
-                "totalRevenue": {
-                    "type": "number"
+                "qrImage": {
+                    "type": "string"
                 }
             }
         },
-        "response.UrlResponse": {
+        "response.WalletPaymentResponse": {
             "type": "object",
             "properties": {
-                "url": {
+                "checkoutUrl": {
                     "type": "string"
+                },
+                "payment": {
+                    "$ref": "#/definitions/entity.Payment"
+                },
+                "remainingAmount": {
+                    "type": "number"
+                },
+                "splitPayment": {
+                    "$ref": "#/definitions/entity.SplitPayment"
+                },
+                "walletTransaction": {
+                    "$ref": "#/definitions/entity.WalletTransaction"
                 }
             }
         }
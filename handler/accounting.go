@@ -0,0 +1,76 @@
+package handler
+
+import (
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAccountMappings godoc
+// @Summary      Get account mapping
+// @Description  Retrieve the chart of accounts codes used by the journal export, defaults follow Circular 200 and can be overridden with ACCOUNTING_ACCOUNT_CODES
+// @Tags         accounting
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} response.AccountMappingResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/accounting/accounts [get]
+func GetAccountMappings(ctx *gin.Context) {
+	service, err := business_logic.GenerateAccountingService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res := service.GetAccountMappings(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ExportJournal godoc
+// @Summary      Export accounting journal
+// @Description  Generates journal rows of payments received, commissions, guide payables, refunds, gateway fees and payouts for the accounting system, exporting the same period again gives the same file
+// @Tags         accounting
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        from   query string true "From date (YYYY-MM-DD)"
+// @Param        to     query string true "To date (YYYY-MM-DD), inclusive"
+// @Param        format query string true "File format (csv, misa)"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/accounting/journal/export [get]
+func ExportJournal(ctx *gin.Context) {
+	var request request.ExportJournalRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAccountingService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.ExportJournal(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
@@ -0,0 +1,403 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAgencies godoc
+// @Summary      Get agencies
+// @Description  Retrieve the agencies with their rates, account numbers are masked
+// @Tags         agency
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} response.AgencyResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies [get]
+func GetAgencies(ctx *gin.Context) {
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetAgencies(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateAgency godoc
+// @Summary      Create agency
+// @Description  Create an agency, tour guides whose company has the agency name are linked to it. Payments of its tour guides are split into the platform commission and the agency share at the agency rates, the tour guide receives the rest
+// @Tags         agency
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.UpsertAgencyRequest true "Agency Request"
+// @Success      201 {object} response.AgencyResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies [post]
+func CreateAgency(ctx *gin.Context) {
+	var request request.UpsertAgencyRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateAgency(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// UpdateAgency godoc
+// @Summary      Update agency
+// @Description  Update the name, rates, bank account and status of an agency. New rates apply to payments made from now on, the bank account is kept when no account number is given
+// @Tags         agency
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                         true "Agency ID"
+// @Param        request body request.UpsertAgencyRequest true "Agency Request"
+// @Success      200 {object} response.AgencyResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "Agency not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/{id} [put]
+func UpdateAgency(ctx *gin.Context) {
+	var request request.UpsertAgencyRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyId = id
+
+	res, err := service.UpdateAgency(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetAgencyReport godoc
+// @Summary      Get agency report
+// @Description  Retrieve the agency share of a period with the amounts paid out and pending, broken down by tour guide. Refunds are netted
+// @Tags         agency
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  int    true  "Agency ID"
+// @Param        from query string false "From date (yyyy-MM-dd)"
+// @Param        to   query string false "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.AgencyReportResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "Agency not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/{id}/report [get]
+func GetAgencyReport(ctx *gin.Context) {
+	var request request.GetAgencyReportRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyId = id
+
+	res, err := service.GetAgencyReport(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetAgencyRevenues godoc
+// @Summary      Get agency revenues
+// @Description  Retrieve a paginated list of the agency shares of payments, newest first. A refund adds a reversal with negative amounts
+// @Tags         agency
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path  int    true  "Agency ID"
+// @Param        page        query int    false "Page"
+// @Param        tourGuideId query int    false "Tour guide ID"
+// @Param        from        query string false "From date (yyyy-MM-dd)"
+// @Param        to          query string false "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/{id}/revenues [get]
+func GetAgencyRevenues(ctx *gin.Context) {
+	var request request.GetAgencyRevenuesRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyId = id
+
+	res, err := service.GetAgencyRevenues(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateAgencyPayout godoc
+// @Summary      Create agency payout
+// @Description  Gather all unpaid revenues of the agency into a pending payout to its bank account, refund reversals are netted. The transfer is confirmed by another admin
+// @Tags         agency
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                               true "Agency ID"
+// @Param        request body request.CreateAgencyPayoutRequest true "Agency Payout Request"
+// @Success      201 {object} response.AgencyPayoutResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "Agency not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/{id}/payouts [post]
+func CreateAgencyPayout(ctx *gin.Context) {
+	var request request.CreateAgencyPayoutRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyId = id
+
+	res, err := service.CreateAgencyPayout(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// GetAgencyPayouts godoc
+// @Summary      Get agency payouts
+// @Description  Retrieve a paginated list of the payouts of an agency, newest first
+// @Tags         agency
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path  int    true  "Agency ID"
+// @Param        page   query int    false "Page"
+// @Param        status query string false "Status (PENDING, PAID, CANCELLED)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/{id}/payouts [get]
+func GetAgencyPayouts(ctx *gin.Context) {
+	var request request.GetAgencyPayoutsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyId = id
+
+	res, err := service.GetAgencyPayouts(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetAgencyPayout godoc
+// @Summary      Get agency payout
+// @Description  Retrieve an agency payout with the full account number to transfer to and the revenues it pays
+// @Tags         agency
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Agency payout ID"
+// @Success      200 {object} response.AgencyPayoutResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 404 {object} response.MessageApiResponse "AgencyPayout not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/payouts/{id} [get]
+func GetAgencyPayout(ctx *gin.Context) {
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetAgencyPayout(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ConfirmAgencyPayout godoc
+// @Summary      Confirm agency payout
+// @Description  Mark a pending payout as transferred and settle the agency payable. The confirming admin must be different from the one who created the payout
+// @Tags         agency
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                true "Agency payout ID"
+// @Param        request body request.ProcessAgencyPayoutRequest true "Agency Payout Process Request"
+// @Success      200 {object} response.AgencyPayoutResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "AgencyPayout not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/payouts/{id}/paid [put]
+func ConfirmAgencyPayout(ctx *gin.Context) {
+	var request request.ProcessAgencyPayoutRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyPayoutId = id
+
+	res, err := service.ConfirmAgencyPayout(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CancelAgencyPayout godoc
+// @Summary      Cancel agency payout
+// @Description  Cancel a pending payout, its revenues are paid with the next payout of the agency
+// @Tags         agency
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                true "Agency payout ID"
+// @Param        request body request.ProcessAgencyPayoutRequest true "Agency Payout Process Request"
+// @Success      200 {object} response.AgencyPayoutResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "AgencyPayout not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/agencies/payouts/{id}/cancel [put]
+func CancelAgencyPayout(ctx *gin.Context) {
+	var request request.ProcessAgencyPayoutRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateAgencyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.AgencyPayoutId = id
+
+	res, err := service.CancelAgencyPayout(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
@@ -0,0 +1,373 @@
+package handler
+
+import (
+	"io"
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterPendingInvoice godoc
+// @Summary      Register invoice paid by bank transfer
+// @Description  Registers an invoice which the customer pays by a direct bank transfer with "Invoice <invoiceId>" in the note, a pending registration of the same invoice is updated
+// @Tags         bank-statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.RegisterPendingInvoiceRequest true "Register Pending Invoice Request"
+// @Success      201 {object} entity.PendingInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/pending-invoices [post]
+func RegisterPendingInvoice(ctx *gin.Context) {
+	var request request.RegisterPendingInvoiceRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.RegisterPendingInvoice(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// CancelPendingInvoice godoc
+// @Summary      Cancel invoice paid by bank transfer
+// @Description  Cancels a pending invoice so that later transfers are no longer matched to it
+// @Tags         bank-statements
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Pending invoice ID"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "PendingInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/pending-invoices/{id}/cancel [put]
+func CancelPendingInvoice(ctx *gin.Context) {
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.CancelPendingInvoice(id, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateVietQrTransaction godoc
+// @Summary      Create VietQR transfer
+// @Description  Registers an invoice paid by a VietQR transfer to the platform bank account and returns the EMVCo payload with its PNG QR code, the payment is created when the transfer is matched on a bank statement or confirmed by an admin
+// @Tags         bank-statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.RegisterPendingInvoiceRequest true "Register Pending Invoice Request"
+// @Success      201 {object} response.VietQrResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/vietqr [post]
+func CreateVietQrTransaction(ctx *gin.Context) {
+	var request request.RegisterPendingInvoiceRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateVietQrTransaction(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// GetVietQrImage godoc
+// @Summary      Get VietQR image
+// @Description  Renders the VietQR code of a pending invoice as a PNG image
+// @Tags         bank-statements
+// @Produce      png
+// @Security     BearerAuth
+// @Param        id path int true "Pending invoice ID"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "PendingInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/pending-invoices/{id}/vietqr [get]
+func GetVietQrImage(ctx *gin.Context) {
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetVietQrImage(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
+
+// ConfirmPendingInvoice godoc
+// @Summary      Confirm invoice paid by bank transfer
+// @Description  Creates the PAID payment of a pending invoice with its registered amount when the transfer has been seen on the account without a statement import
+// @Tags         bank-statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                  true "Pending invoice ID"
+// @Param        request body request.ConfirmPendingInvoiceRequest true "Confirm Pending Invoice Request"
+// @Success      200 {object} entity.Payment
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "PendingInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/pending-invoices/{id}/confirm [put]
+func ConfirmPendingInvoice(ctx *gin.Context) {
+	var request request.ConfirmPendingInvoiceRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PendingInvoiceId = id
+
+	res, err := service.ConfirmPendingInvoice(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ImportBankStatement godoc
+// @Summary      Import bank statement
+// @Description  Reads incoming transfers of an account statement, creates PAID payments for transfers matching a pending invoice by reference and amount and queues the others for review
+// @Tags         bank-statements
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        bank    formData string true "Bank layout (VCB, TCB)"
+// @Param        actorId formData int    true "Actor ID"
+// @Param        file    formData file   true "Statement file (csv, xlsx)"
+// @Success      200 {object} response.BankStatementImportResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/import [post]
+func ImportBankStatement(ctx *gin.Context) {
+	var request request.ImportBankStatementRequest
+	if ctx.ShouldBind(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	request.FileName = fileHeader.Filename
+	request.Content = content
+
+	res, err := service.ImportBankStatement(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetBankTransactions godoc
+// @Summary      Get imported bank transactions
+// @Description  Retrieve a paginated list of imported bank transactions, filter by REVIEW to get the manual review queue
+// @Tags         bank-statements
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query int    false "Page"
+// @Param        status query string false "Transaction status (MATCHED, REVIEW, ACCEPTED, REJECTED, UNMATCHED)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/transactions [get]
+func GetBankTransactions(ctx *gin.Context) {
+	var request request.GetBankTransactionsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetBankTransactions(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// AcceptBankTransaction godoc
+// @Summary      Accept bank transaction
+// @Description  Confirms a transaction of the review queue, or an unmatched one, as the payment of a pending invoice and creates a PAID payment with the transferred amount
+// @Tags         bank-statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                   true "Bank transaction ID"
+// @Param        request body request.AcceptBankTransactionRequest true "Accept Bank Transaction Request"
+// @Success      200 {object} entity.BankTransaction
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "BankTransaction not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/transactions/{id}/accept [put]
+func AcceptBankTransaction(ctx *gin.Context) {
+	var request request.AcceptBankTransactionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.BankTransactionId = id
+
+	res, err := service.AcceptBankTransaction(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// RejectBankTransaction godoc
+// @Summary      Reject bank transaction
+// @Description  Marks a transaction of the review queue, or an unmatched one, as not being an invoice payment
+// @Tags         bank-statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                   true "Bank transaction ID"
+// @Param        request body request.RejectBankTransactionRequest true "Reject Bank Transaction Request"
+// @Success      200 {object} entity.BankTransaction
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "BankTransaction not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/bank-statements/transactions/{id}/reject [put]
+func RejectBankTransaction(ctx *gin.Context) {
+	var request request.RejectBankTransactionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateBankStatementService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.BankTransactionId = id
+
+	res, err := service.RejectBankTransaction(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
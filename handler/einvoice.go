@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEInvoice godoc
+// @Summary      Get e-invoice
+// @Description  Retrieve an issued VAT e-invoice by its ID
+// @Tags         einvoices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "E-Invoice ID"
+// @Success      200 {object} entity.EInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "EInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/einvoices/{id} [get]
+func GetEInvoice(ctx *gin.Context) {
+	service, err := business_logic.GenerateEInvoiceService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetEInvoice(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetPaymentEInvoices godoc
+// @Summary      Get e-invoices of payment
+// @Description  Retrieve the original, adjustment and replacement e-invoices issued for a payment
+// @Tags         einvoices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payment ID"
+// @Success      200 {array} entity.EInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/einvoices/payment/{id} [get]
+func GetPaymentEInvoices(ctx *gin.Context) {
+	service, err := business_logic.GenerateEInvoiceService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetPaymentEInvoices(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// IssueEInvoice godoc
+// @Summary      Issue e-invoice
+// @Description  Issue a VAT e-invoice for a paid payment with the next number of the configured template and series
+// @Tags         einvoices
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.IssueEInvoiceRequest true "Issue E-Invoice Request"
+// @Success      201 {object} entity.EInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/einvoices [post]
+func IssueEInvoice(ctx *gin.Context) {
+	var request request.IssueEInvoiceRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateEInvoiceService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.IssueEInvoice(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// AdjustEInvoice godoc
+// @Summary      Adjust e-invoice
+// @Description  Issue an adjustment e-invoice increasing or decreasing the total of an active e-invoice
+// @Tags         einvoices
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                            true "E-Invoice ID"
+// @Param        request body request.AdjustEInvoiceRequest true "Adjust E-Invoice Request"
+// @Success      201 {object} entity.EInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "EInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/einvoices/{id}/adjust [post]
+func AdjustEInvoice(ctx *gin.Context) {
+	var request request.AdjustEInvoiceRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateEInvoiceService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.EInvoiceId = id
+
+	res, err := service.AdjustEInvoice(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// ReplaceEInvoice godoc
+// @Summary      Replace e-invoice
+// @Description  Issue a replacement e-invoice for an active e-invoice, the replaced one is no longer valid
+// @Tags         einvoices
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                             true "E-Invoice ID"
+// @Param        request body request.ReplaceEInvoiceRequest true "Replace E-Invoice Request"
+// @Success      201 {object} entity.EInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "EInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/einvoices/{id}/replace [post]
+func ReplaceEInvoice(ctx *gin.Context) {
+	var request request.ReplaceEInvoiceRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateEInvoiceService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.EInvoiceId = id
+
+	res, err := service.ReplaceEInvoice(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// ExportEInvoice godoc
+// @Summary      Export e-invoice
+// @Description  Download an e-invoice as the Decree 123 XML data file, or as a HTML or PDF copy for the buyer
+// @Tags         einvoices
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id     path  int    true "E-Invoice ID"
+// @Param        format query string true "File format (xml, html, pdf)"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "EInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/einvoices/{id}/export [get]
+func ExportEInvoice(ctx *gin.Context) {
+	var request request.ExportEInvoiceRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateEInvoiceService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.EInvoiceId = id
+
+	res, err := service.ExportEInvoice(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
@@ -24,8 +24,9 @@ import (
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page query int false "Page"
-// @Param        keyword     query string false "Search keyword"
-// @Param        filterProp  query string false "Filter property"
+// @Param        pageSize    query int false "Page size, at most 100"
+// @Param        keyword     query string false "Search keyword on the content"
+// @Param        filterProp  query string false "Sort property" Enums(DATE, UPDATED_DATE, RATE, INVOICE, SERVICE, CUSTOMER)
 // @Param        order       query string false "Sort order (ASC or DESC)"
 // @Param        rating      query int false "Rating"
 // @Param        customerId  query int false "The owner ID of this feedback"
@@ -67,8 +68,9 @@ func GetFeedbacks(ctx *gin.Context) {
 // @Security     BearerAuth
 // @Param        id          path int  true  "Customer ID"
 // @Param        page query int false "Page"
-// @Param        keyword     query string false "Search keyword"
-// @Param        filterProp query string false "Filter property"
+// @Param        pageSize    query int false "Page size, at most 100"
+// @Param        keyword     query string false "Search keyword on the content"
+// @Param        filterProp  query string false "Sort property" Enums(DATE, UPDATED_DATE, RATE, INVOICE, SERVICE, CUSTOMER)
 // @Param        order       query string false "Sort order (ASC or DESC)"
 // @Param        rating      query int false "Rating"
 // @Param        tourGuideId query int false "Tour guide ID"
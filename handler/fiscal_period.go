@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFiscalPeriods godoc
+// @Summary      Get accounting periods
+// @Description  Retrieve the status of every month in a year, months which have never been closed are open
+// @Tags         fiscal-periods
+// @Produce      json
+// @Security     BearerAuth
+// @Param        year query int true "Year"
+// @Success      200 {array} entity.FiscalPeriod
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/fiscal-periods [get]
+func GetFiscalPeriods(ctx *gin.Context) {
+	var request request.GetFiscalPeriodsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateFiscalPeriodService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetFiscalPeriods(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetFiscalPeriodAudits godoc
+// @Summary      Get accounting period audit trail
+// @Description  Retrieve every close and reopen action of an accounting period
+// @Tags         fiscal-periods
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Fiscal period ID"
+// @Success      200 {array} entity.FiscalPeriodAudit
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/fiscal-periods/{id}/audits [get]
+func GetFiscalPeriodAudits(ctx *gin.Context) {
+	service, err := business_logic.GenerateFiscalPeriodService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetFiscalPeriodAudits(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CloseFiscalPeriod godoc
+// @Summary      Close an accounting period
+// @Description  Lock payments and revenues dated in the month, later corrections are recorded as adjustments in the current period
+// @Tags         fiscal-periods
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.FiscalPeriodActionRequest true "Fiscal Period Action Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/fiscal-periods/close [put]
+func CloseFiscalPeriod(ctx *gin.Context) {
+	var request request.FiscalPeriodActionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateFiscalPeriodService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.CloseFiscalPeriod(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ReopenFiscalPeriod godoc
+// @Summary      Reopen an accounting period
+// @Description  Unlock a closed month, a reason is required and the action is audited
+// @Tags         fiscal-periods
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.FiscalPeriodActionRequest true "Fiscal Period Action Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/fiscal-periods/reopen [put]
+func ReopenFiscalPeriod(ctx *gin.Context) {
+	var request request.FiscalPeriodActionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateFiscalPeriodService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.ReopenFiscalPeriod(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
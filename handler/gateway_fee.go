@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"io"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPaymentMethodFees godoc
+// @Summary      Get payment method fees
+// @Description  Retrieve the gateway fee and the customer surcharge configured for each payment method, payments of methods without a configuration are recorded without a fee
+// @Tags         gateway-fee
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} entity.PaymentMethodFee
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gateway-fees/methods [get]
+func GetPaymentMethodFees(ctx *gin.Context) {
+	service, err := business_logic.GenerateGatewayFeeService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetPaymentMethodFees(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// UpsertPaymentMethodFee godoc
+// @Summary      Configure payment method fee
+// @Description  Set the gateway fee (percent of the amount collected plus a fixed fee) and the optional surcharge passed on to the customer for a payment method. It applies to the payments made afterwards
+// @Tags         gateway-fee
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        method  path string                                true "Payment method" Enums(PAYOS, VNPAY, MOMO, PAYPAL, BANK_TRANSFER, VIETQR)
+// @Param        request body request.UpsertPaymentMethodFeeRequest true "Payment Method Fee Request"
+// @Success      200 {object} entity.PaymentMethodFee
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Gateway fees can only be configured for payment gateways."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gateway-fees/methods/{method} [put]
+func UpsertPaymentMethodFee(ctx *gin.Context) {
+	var request request.UpsertPaymentMethodFeeRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGatewayFeeService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	request.PaymentMethod = ctx.Param("method")
+
+	res, err := service.UpsertPaymentMethodFee(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetPaymentFees godoc
+// @Summary      Get payment fees
+// @Description  Retrieve a paginated list of the gateway fees of payments with their gross, surcharge and net settlement amounts, newest first
+// @Tags         gateway-fee
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page          query int    false "Page"
+// @Param        paymentMethod query string false "Payment method"
+// @Param        source        query string false "Source" Enums(CONFIGURED, SETTLEMENT)
+// @Param        from          query string false "From date (yyyy-MM-dd)"
+// @Param        to            query string false "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gateway-fees [get]
+func GetPaymentFees(ctx *gin.Context) {
+	var request request.GetPaymentFeesRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGatewayFeeService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetPaymentFees(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetGatewayFeeReport godoc
+// @Summary      Get gateway fee report
+// @Description  Retrieve the gross amount collected by the gateways, the surcharges, the gateway fees and the net settlement of a period, in total and per payment method
+// @Tags         gateway-fee
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from query string false "From date (yyyy-MM-dd)"
+// @Param        to   query string false "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.GatewayFeeReportResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gateway-fees/report [get]
+func GetGatewayFeeReport(ctx *gin.Context) {
+	var request request.GetGatewayFeeReportRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGatewayFeeService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetGatewayFeeReport(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetSurcharge godoc
+// @Summary      Get payment surcharge
+// @Description  Preview the surcharge the customer pays on top of the amount with a payment method, it is zero when the method has none
+// @Tags         gateway-fee
+// @Produce      json
+// @Security     BearerAuth
+// @Param        paymentMethod query string true "Payment method"
+// @Param        amount        query number true "Amount to pay"
+// @Success      200 {object} response.SurchargeResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gateway-fees/surcharge [get]
+func GetSurcharge(ctx *gin.Context) {
+	var request request.GetSurchargeRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGatewayFeeService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetSurcharge(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ImportSettlement godoc
+// @Summary      Import gateway settlement report
+// @Description  Reads the settled payments of a gateway with their fees. The payment is found by the "Invoice <invoiceId>" description, its estimated fee is replaced by the settled one and the difference is posted to the ledger. A settled payment is not settled again
+// @Tags         gateway-fee
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        paymentMethod formData string true "Payment method of the gateway"
+// @Param        actorId       formData int    true "Actor ID"
+// @Param        file          formData file   true "Settlement file (csv, xlsx)"
+// @Success      200 {object} response.SettlementImportResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gateway-fees/settlements [post]
+func ImportSettlement(ctx *gin.Context) {
+	var request request.ImportSettlementRequest
+	if ctx.ShouldBind(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGatewayFeeService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	request.FileName = fileHeader.Filename
+	request.Content = content
+
+	res, err := service.ImportSettlement(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetGiftCards godoc
+// @Summary      Get gift cards
+// @Description  Retrieve a paginated list of gift cards, newest first
+// @Tags         gift-cards
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query int    false "Page"
+// @Param        status query string false "Gift card status (PENDING, ACTIVE, REDEEMED, EXPIRED, CANCELLED)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards [get]
+func GetGiftCards(ctx *gin.Context) {
+	var request request.GetGiftCardsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetGiftCards(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetGiftCardLiability godoc
+// @Summary      Get gift card liability
+// @Description  Report the balance still owed to gift card holders with the part expiring within 30 days and totals per status
+// @Tags         gift-cards
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.GiftCardLiabilityResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards/liability [get]
+func GetGiftCardLiability(ctx *gin.Context) {
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetGiftCardLiability(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetGiftCardByCode godoc
+// @Summary      Get gift card by code
+// @Description  Check the balance, status and expiry of a gift card
+// @Tags         gift-cards
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code path string true "Gift card code"
+// @Success      200 {object} entity.GiftCard
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 404 {object} response.MessageApiResponse "GiftCard not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards/code/{code} [get]
+func GetGiftCardByCode(ctx *gin.Context) {
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetGiftCardByCode(ctx.Param("code"), ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// PurchaseGiftCard godoc
+// @Summary      Purchase gift card
+// @Description  Create a PayOS payment link for a gift card, the card is issued and emailed to the recipient once the purchase is confirmed
+// @Tags         gift-cards
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.PurchaseGiftCardRequest true "Purchase Gift Card Request"
+// @Success      201 {object} entity.GiftCard
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards [post]
+func PurchaseGiftCard(ctx *gin.Context) {
+	var request request.PurchaseGiftCardRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.PurchaseGiftCard(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// ConfirmGiftCardPurchase godoc
+// @Summary      Confirm gift card purchase
+// @Description  Check the PayOS payment link of the purchase, a paid card gets its code and is emailed to the recipient once, a cancelled or expired purchase is closed
+// @Tags         gift-cards
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Gift card ID"
+// @Success      200 {object} entity.GiftCard
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "The gift card has not been paid yet. Please try again after completing the payment."
+// @Failure 404 {object} response.MessageApiResponse "GiftCard not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards/{id}/confirm [put]
+func ConfirmGiftCardPurchase(ctx *gin.Context) {
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.ConfirmGiftCardPurchase(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// RedeemGiftCard godoc
+// @Summary      Redeem gift card to wallet
+// @Description  Move the balance of a gift card, or part of it, to the wallet of the customer
+// @Tags         gift-cards
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.RedeemGiftCardRequest true "Redeem Gift Card Request"
+// @Success      201 {object} response.GiftCardRedemptionResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "The gift card balance is not enough for this transaction."
+// @Failure 404 {object} response.MessageApiResponse "GiftCard not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards/redeem [post]
+func RedeemGiftCard(ctx *gin.Context) {
+	var request request.RedeemGiftCardRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.RedeemGiftCard(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// PayWithGiftCard godoc
+// @Summary      Pay with gift card
+// @Description  Pay an invoice with a gift card. When the card balance is below the price, the card part is recorded as a paid payment and a PayOS link is returned for the rest
+// @Tags         gift-cards
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.PayWithGiftCardRequest true "Pay With Gift Card Request"
+// @Success      201 {object} response.GiftCardPaymentResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "This gift card has expired."
+// @Failure 404 {object} response.MessageApiResponse "GiftCard not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/gift-cards/pay [post]
+func PayWithGiftCard(ctx *gin.Context) {
+	var request request.PayWithGiftCardRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateGiftCardService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.PayWithGiftCard(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
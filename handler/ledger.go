@@ -0,0 +1,190 @@
+package handler
+
+import (
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLedgerAccountBalances godoc
+// @Summary      Get ledger account balances
+// @Description  Retrieve the balance of every platform ledger account
+// @Tags         ledger
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} response.LedgerBalanceResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/ledger/accounts [get]
+func GetLedgerAccountBalances(ctx *gin.Context) {
+	service, err := business_logic.GenerateLedgerService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetAccountBalances(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetLedgerAccountBalance godoc
+// @Summary      Get a ledger account balance
+// @Description  Retrieve the balance of a ledger account, optionally for a single owner and up to a date
+// @Tags         ledger
+// @Produce      json
+// @Security     BearerAuth
+// @Param        account path  string true  "Account (CUSTOMER_RECEIVABLE, GUIDE_PAYABLE, PLATFORM_COMMISSION, GATEWAY_CLEARING, REFUNDS, TAX_PAYABLE, CUSTOMER_WALLET, GIFT_CARD_LIABILITY, AGENCY_PAYABLE, SUBSCRIPTION_REVENUE, GATEWAY_FEE)"
+// @Param        ownerId query int    false "Owner ID (customer, tour guide or agency)"
+// @Param        to      query string false "Balance at the end of this date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.LedgerBalanceResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/ledger/accounts/{account}/balance [get]
+func GetLedgerAccountBalance(ctx *gin.Context) {
+	var request request.GetLedgerAccountRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateLedgerService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	request.Account = ctx.Param("account")
+	if request.To != nil {
+		// Include the whole day
+		var to = request.To.AddDate(0, 0, 1)
+		request.To = &to
+	}
+
+	res, err := service.GetAccountBalance(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetLedgerAccountStatement godoc
+// @Summary      Get a ledger account statement
+// @Description  Retrieve opening balance, lines with running balance and closing balance of a ledger account, the current month is used by default
+// @Tags         ledger
+// @Produce      json
+// @Security     BearerAuth
+// @Param        account path  string true  "Account (CUSTOMER_RECEIVABLE, GUIDE_PAYABLE, PLATFORM_COMMISSION, GATEWAY_CLEARING, REFUNDS, TAX_PAYABLE, CUSTOMER_WALLET, GIFT_CARD_LIABILITY, AGENCY_PAYABLE, SUBSCRIPTION_REVENUE, GATEWAY_FEE)"
+// @Param        ownerId query int    false "Owner ID (customer, tour guide or agency)"
+// @Param        from    query string false "From date (yyyy-MM-dd)"
+// @Param        to      query string false "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.LedgerStatementResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/ledger/accounts/{account}/statement [get]
+func GetLedgerAccountStatement(ctx *gin.Context) {
+	var request request.GetLedgerAccountRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateLedgerService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	request.Account = ctx.Param("account")
+
+	res, err := service.GetAccountStatement(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CheckLedgerInvariants godoc
+// @Summary      Check ledger invariants
+// @Description  Verify that total debits equal total credits and that every journal entry is balanced
+// @Tags         ledger
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.LedgerInvariantResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/ledger/invariants [get]
+func CheckLedgerInvariants(ctx *gin.Context) {
+	service, err := business_logic.GenerateLedgerService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CheckInvariants(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateLedgerAdjustment godoc
+// @Summary      Create a ledger adjustment
+// @Description  Post a balanced manual adjustment entry, existing entries are never modified
+// @Tags         ledger
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.CreateLedgerAdjustmentRequest true "Create Ledger Adjustment Request"
+// @Success      201 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/ledger/adjustments [post]
+func CreateLedgerAdjustment(ctx *gin.Context) {
+	var request request.CreateLedgerAdjustmentRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateLedgerService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateAdjustment(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
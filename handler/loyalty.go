@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLoyaltyBalance godoc
+// @Summary      Get loyalty balance
+// @Description  Retrieve the loyalty points of a customer with their discount value and the points expiring within 30 days
+// @Tags         loyalty
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Customer ID"
+// @Success      200 {object} response.LoyaltyBalanceResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/loyalty/customer/{id} [get]
+func GetLoyaltyBalance(ctx *gin.Context) {
+	service, err := business_logic.GenerateLoyaltyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetLoyaltyBalance(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetLoyaltyTransactions godoc
+// @Summary      Get loyalty transactions
+// @Description  Retrieve a paginated history of the loyalty points of a customer, newest first
+// @Tags         loyalty
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  int true  "Customer ID"
+// @Param        page query int false "Page"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/loyalty/customer/{id}/transactions [get]
+func GetLoyaltyTransactions(ctx *gin.Context) {
+	var request request.GetLoyaltyTransactionsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateLoyaltyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.CustomerId = id
+
+	res, err := service.GetLoyaltyTransactions(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetLoyaltyRules godoc
+// @Summary      Get loyalty rules
+// @Description  Retrieve the earning rules, the highest multiplier of the active rules applying to a payment is used
+// @Tags         loyalty
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} entity.LoyaltyRule
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/loyalty/rules [get]
+func GetLoyaltyRules(ctx *gin.Context) {
+	service, err := business_logic.GenerateLoyaltyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetLoyaltyRules(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateLoyaltyRule godoc
+// @Summary      Create loyalty rule
+// @Description  Create an earning multiplier for a service, or for every service when no service is given, optionally limited to a promotion period
+// @Tags         loyalty
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.UpsertLoyaltyRuleRequest true "Loyalty Rule Request"
+// @Success      201 {object} entity.LoyaltyRule
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/loyalty/rules [post]
+func CreateLoyaltyRule(ctx *gin.Context) {
+	var request request.UpsertLoyaltyRuleRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateLoyaltyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateLoyaltyRule(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// UpdateLoyaltyRule godoc
+// @Summary      Update loyalty rule
+// @Description  Change or deactivate an earning rule, points already earned are not recalculated
+// @Tags         loyalty
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                              true "Loyalty rule ID"
+// @Param        request body request.UpsertLoyaltyRuleRequest true "Loyalty Rule Request"
+// @Success      200 {object} entity.LoyaltyRule
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "LoyaltyRule not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/loyalty/rules/{id} [put]
+func UpdateLoyaltyRule(ctx *gin.Context) {
+	var request request.UpsertLoyaltyRuleRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateLoyaltyService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.LoyaltyRuleId = id
+
+	res, err := service.UpdateLoyaltyRule(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"io"
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateOfflinePayment godoc
+// @Summary      Record offline payment
+// @Description  Records cash or a bank transfer collected outside the payment gateways as a PENDING payment, a photo of the receipt or the transfer can be attached as proof
+// @Tags         offline-payments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        customerId    formData int    true  "Customer ID"
+// @Param        tourGuideId   formData int    true  "Tour guide ID"
+// @Param        invoiceId     formData int    true  "Invoice ID"
+// @Param        serviceId     formData int    true  "Service ID"
+// @Param        price         formData number true  "Collected amount"
+// @Param        paymentMethod formData string true  "Payment method (CASH, BANK_TRANSFER)"
+// @Param        note          formData string false "Note"
+// @Param        actorId       formData int    true  "Actor ID"
+// @Param        proof         formData file   false "Proof image (jpeg, jpg, png, gif, webp)"
+// @Success      201 {object} entity.OfflinePayment
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/offline-payments [post]
+func CreateOfflinePayment(ctx *gin.Context) {
+	var request request.CreateOfflinePaymentRequest
+	if ctx.ShouldBind(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	// The proof is optional
+	if fileHeader, err := ctx.FormFile("proof"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+			return
+		}
+
+		request.ProofFileName = fileHeader.Filename
+		request.ProofContent = content
+	}
+
+	service, err := business_logic.GenerateOfflinePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateOfflinePayment(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// GetOfflinePayments godoc
+// @Summary      Get offline payments
+// @Description  Retrieve a paginated list of offline payments, filter by PENDING or AWAITING_APPROVAL to get the confirmation queue
+// @Tags         offline-payments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query int    false "Page"
+// @Param        status query string false "Offline payment status (PENDING, AWAITING_APPROVAL, CONFIRMED, REJECTED)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/offline-payments [get]
+func GetOfflinePayments(ctx *gin.Context) {
+	var request request.GetOfflinePaymentsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateOfflinePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetOfflinePayments(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetOfflinePaymentProof godoc
+// @Summary      Get offline payment proof
+// @Description  Download the proof image attached to an offline payment
+// @Tags         offline-payments
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id path int true "Offline payment ID"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 404 {object} response.MessageApiResponse "OfflinePaymentProof not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/offline-payments/{id}/proof [get]
+func GetOfflinePaymentProof(ctx *gin.Context) {
+	service, err := business_logic.GenerateOfflinePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetOfflinePaymentProof(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
+
+// ConfirmOfflinePayment godoc
+// @Summary      Confirm offline payment
+// @Description  Moves the payment to PAID and creates the revenue of the tour guide, payments above the approval amount wait in AWAITING_APPROVAL until another admin confirms them
+// @Tags         offline-payments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                  true "Offline payment ID"
+// @Param        request body request.ConfirmOfflinePaymentRequest true "Confirm Offline Payment Request"
+// @Success      200 {object} entity.OfflinePayment
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "OfflinePayment not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/offline-payments/{id}/confirm [put]
+func ConfirmOfflinePayment(ctx *gin.Context) {
+	var request request.ConfirmOfflinePaymentRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateOfflinePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.OfflinePaymentId = id
+
+	res, err := service.ConfirmOfflinePayment(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// RejectOfflinePayment godoc
+// @Summary      Reject offline payment
+// @Description  Rejects an offline payment waiting for confirmation, the payment is cancelled
+// @Tags         offline-payments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                 true "Offline payment ID"
+// @Param        request body request.RejectOfflinePaymentRequest true "Reject Offline Payment Request"
+// @Success      200 {object} entity.OfflinePayment
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "OfflinePayment not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/offline-payments/{id}/reject [put]
+func RejectOfflinePayment(ctx *gin.Context) {
+	var request request.RejectOfflinePaymentRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateOfflinePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.OfflinePaymentId = id
+
+	res, err := service.RejectOfflinePayment(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
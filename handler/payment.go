@@ -18,12 +18,24 @@ import (
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param        page query int false "Page number"
-// @Param        method query string false "Payment method"
-// @Param        customerId query string false "Customer ID"
+// @Param        page        query int    false "Page number"
+// @Param        pageSize    query int    false "Page size, at most 100"
+// @Param        keyword     query string false "Search keyword on payment method or status"
+// @Param        filterProp  query string false "Sort property" Enums(DATE, PRICE, AMOUNT, STATUS, METHOD, INVOICE, SERVICE, CUSTOMER)
+// @Param        order       query string false "Sort order (ASC or DESC)"
+// @Param        method      query string false "Payment method"
+// @Param        status      query string false "Payment status"
+// @Param        customerId  query int    false "Customer ID"
+// @Param        customerIds query []int  false "Customer IDs" collectionFormat(multi)
+// @Param        serviceId   query int    false "Service ID"
+// @Param        invoiceId   query int    false "Invoice ID"
+// @Param        from        query string false "From date (yyyy-MM-dd)"
+// @Param        to          query string false "To date, inclusive (yyyy-MM-dd)"
+// @Param        minAmount   query number false "Minimum amount"
+// @Param        maxAmount   query number false "Maximum amount"
 // @Success 200 {object} response.PaginationDataResponse
 // @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
-// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 400 {object} response.MessageApiResponse "The minimum amount must not be greater than the maximum amount."
 // @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
 // @Router /payment-service/api/v1/payments [get]
 func GetAllPayments(ctx *gin.Context) {
@@ -92,9 +104,15 @@ func GetPaymentById(ctx *gin.Context) {
 // @Param        id path int true "Customer ID"
 // @Param        page query int false "Page"
 // @Param        keyword     query string false "Search keyword"
-// @Param        filterProp  query string false "Filter property (e.g. date, price)"
+// @Param        pageSize    query int false "Page size, at most 100"
+// @Param        filterProp  query string false "Sort property" Enums(DATE, PRICE, AMOUNT, STATUS, METHOD, INVOICE, SERVICE, CUSTOMER)
 // @Param        order       query string false "Sort order (ASC or DESC)"
 // @Param        method       query string false "Payment Method (e.g. VTP, GHTK)"
+// @Param        status      query string false "Payment status"
+// @Param        from        query string false "From date (yyyy-MM-dd)"
+// @Param        to          query string false "To date, inclusive (yyyy-MM-dd)"
+// @Param        minAmount   query number false "Minimum amount"
+// @Param        maxAmount   query number false "Maximum amount"
 // @Success 200 {object} response.PaginationDataResponse
 // @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
 // @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
@@ -128,6 +146,49 @@ func GetPaymentsByUser(ctx *gin.Context) {
 	})
 }
 
+// GetCustomerPaymentSummary godoc
+// @Summary Get customer spending summary
+// @Description Retrieve the lifetime spend, number of paid tours, average ticket, last payment date and refunds of a customer with their payment history and the service names, newest first. The filters only apply to the history
+// @Tags payments
+// @Produce json
+// @Security BearerAuth
+// @Param        id     path  int    true  "Customer ID"
+// @Param        page   query int    false "Page"
+// @Param        status query string false "Payment status" Enums(PAID, REFUNDED, PENDING, FAILED, CANCELLED)
+// @Param        from   query string false "From date (yyyy-MM-dd)"
+// @Param        to     query string false "To date, inclusive (yyyy-MM-dd)"
+// @Success 200 {object} response.CustomerPaymentSummaryResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router /payment-service/api/v1/payments/customer/{id}/summary [get]
+func GetCustomerPaymentSummary(ctx *gin.Context) {
+	var request request.GetCustomerPaymentSummaryRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.CustomerId = id
+
+	res, err := service.GetCustomerPaymentSummary(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
 // UpdatePayment godoc
 // @Summary Update a payment record
 // @Description Update payment details
@@ -161,6 +222,39 @@ func UpdatePayment(ctx *gin.Context) {
 	})
 }
 
+// RefundPayment godoc
+// @Summary Refund a payment
+// @Description Mark a paid payment as refunded and post the reversal to the ledger, the amount is credited to the wallet of the customer when toWallet is set or the payment was made from the wallet or a gift card
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.RefundPaymentRequest true "RefundPaymentRequest"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router /payment-service/api/v1/payments/refund [put]
+func RefundPayment(ctx *gin.Context) {
+	var request request.RefundPaymentRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.RefundPayment(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
 // CreatePayment godoc
 // @Summary      Create a payment
 // @Description  Creates a new payment
@@ -200,12 +294,12 @@ func CreatePayment(ctx *gin.Context) {
 
 // CreatePayosTransaction godoc
 // @Summary      Create a PayOS Transaction
-// @Description  Initiates a PayOS transaction with the given request body
+// @Description  Initiates a PayOS transaction with the given request body. Loyalty points given in redeemPoints are taken off the amount and held by a pending LOYALTY_POINT payment of the invoice until the link is paid, they are given back when the link is cancelled or expires
 // @Tags         payments
 // @Accept       json
 // @Produce      json
 // @Param        request body request.CreatePayosTransactionRequest true "PayOS Transaction Request"
-// @Success      200 {object} response.UrlResponse
+// @Success      200 {object} response.PayosTransactionResponse
 // @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
 // @Router       /payment-service/api/v1/payments/create-embedded-payment-link [post]
 func CreatePayosTransaction(ctx *gin.Context) {
@@ -232,6 +326,39 @@ func CreatePayosTransaction(ctx *gin.Context) {
 	})
 }
 
+// ConfirmSplitPayment godoc
+// @Summary      Confirm split payment
+// @Description  Check the PayOS payment link of a split payment, a paid link records the held part as a paid payment once, a cancelled or expired one gives the held part back
+// @Tags         payments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Split payment ID"
+// @Success      200 {object} entity.SplitPayment
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "The rest of the invoice has not been paid yet. Please try again after completing the payment."
+// @Failure 404 {object} response.MessageApiResponse "SplitPayment not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payments/split/{id}/confirm [put]
+func ConfirmSplitPayment(ctx *gin.Context) {
+	service, err := business_logic.GeneratePaymentService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.ConfirmSplitPayment(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
 // GetPaymentWithService godoc
 // @Summary Get payment with service information by ID
 // @Description Retrieve a single payment record with service information by its ID
@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"io"
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPayoutBatches godoc
+// @Summary      Get payout batches
+// @Description  Retrieve a paginated list of tour guide payout batches
+// @Tags         payouts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query int    false "Page"
+// @Param        status query string false "Batch status (DRAFT, APPROVED, EXPORTED, COMPLETED, CANCELLED)"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts [get]
+func GetPayoutBatches(ctx *gin.Context) {
+	var request request.GetPayoutBatchesRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetPayoutBatches(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetPayoutBatch godoc
+// @Summary      Get a payout batch
+// @Description  Retrieve a payout batch with its transfer items
+// @Tags         payouts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payout batch ID"
+// @Success      200 {object} response.PayoutBatchResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/{id} [get]
+func GetPayoutBatch(ctx *gin.Context) {
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetPayoutBatch(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreatePayoutBatch godoc
+// @Summary      Create a payout batch
+// @Description  Creates a draft payout batch from the unsettled revenues of the given tour guides
+// @Tags         payouts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.CreatePayoutBatchRequest true "Create Payout Batch Request"
+// @Success      201 {object} response.PayoutBatchResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts [post]
+func CreatePayoutBatch(ctx *gin.Context) {
+	var request request.CreatePayoutBatchRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreatePayoutBatch(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// ApprovePayoutBatch godoc
+// @Summary      Approve a payout batch
+// @Description  Approves a draft payout batch, the approver must be different from the creator
+// @Tags         payouts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payout batch ID"
+// @Param        request body request.PayoutBatchActionRequest true "Payout Batch Action Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/{id}/approve [put]
+func ApprovePayoutBatch(ctx *gin.Context) {
+	var request request.PayoutBatchActionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PayoutBatchId = id
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.ApprovePayoutBatch(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CancelPayoutBatch godoc
+// @Summary      Cancel a payout batch
+// @Description  Cancels a payout batch which has not been exported yet and releases its revenues
+// @Tags         payouts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payout batch ID"
+// @Param        request body request.PayoutBatchActionRequest true "Payout Batch Action Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/{id}/cancel [put]
+func CancelPayoutBatch(ctx *gin.Context) {
+	var request request.PayoutBatchActionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PayoutBatchId = id
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.CancelPayoutBatch(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ExportPayoutBatch godoc
+// @Summary      Export bank bulk-transfer file
+// @Description  Generates the bulk-transfer file of an approved payout batch in the bank's layout
+// @Tags         payouts
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id     path  int    true "Payout batch ID"
+// @Param        bank   query string true "Bank layout (VCB, TCB)"
+// @Param        format query string true "File format (csv, xlsx)"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/{id}/export [get]
+func ExportPayoutBatch(ctx *gin.Context) {
+	var request request.ExportPayoutBatchRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PayoutBatchId = id
+
+	res, err := service.ExportPayoutBatch(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
+
+// ImportPayoutResult godoc
+// @Summary      Import bank transfer result file
+// @Description  Reads the result file returned by the bank and marks payout items as paid or failed
+// @Tags         payouts
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path     int    true "Payout batch ID"
+// @Param        bank formData string true "Bank layout (VCB, TCB)"
+// @Param        file formData file   true "Result file (csv, xlsx)"
+// @Success      200 {object} response.PayoutImportResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/{id}/import [post]
+func ImportPayoutResult(ctx *gin.Context) {
+	var request request.ImportPayoutResultRequest
+	if ctx.ShouldBind(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PayoutBatchId = id
+	request.FileName = fileHeader.Filename
+	request.Content = content
+
+	res, err := service.ImportPayoutResult(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetPayoutPolicy godoc
+// @Summary      Get payout policy
+// @Description  Retrieve the minimum payable balance, hold period and schedule used to create payout batches
+// @Tags         payouts
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} entity.PayoutPolicy
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/policy [get]
+func GetPayoutPolicy(ctx *gin.Context) {
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetPayoutPolicy(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// UpdatePayoutPolicy godoc
+// @Summary      Update payout policy
+// @Description  Updates the minimum payable balance, hold period and schedule (WEEKLY, BIWEEKLY, MONTHLY) of automatic payouts
+// @Tags         payouts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.UpdatePayoutPolicyRequest true "Update Payout Policy Request"
+// @Success      200 {object} entity.PayoutPolicy
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payouts/policy [put]
+func UpdatePayoutPolicy(ctx *gin.Context) {
+	var request request.UpdatePayoutPolicyRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.UpdatePayoutPolicy(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPayoutAccounts godoc
+// @Summary      Get payout accounts
+// @Description  Retrieve a paginated list of tour guide payout accounts for verification
+// @Tags         payout-accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query int    false "Page"
+// @Param        status      query string false "Verification status (PENDING, VERIFIED, REJECTED)"
+// @Param        tourGuideId query int    false "Tour guide ID"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts [get]
+func GetPayoutAccounts(ctx *gin.Context) {
+	var request request.GetPayoutAccountsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetPayoutAccounts(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetTourGuidePayoutAccounts godoc
+// @Summary      Get payout accounts of a tour guide
+// @Description  Retrieve the bank accounts registered by a tour guide, account numbers are masked
+// @Tags         payout-accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Tour guide ID"
+// @Success      200 {array} response.PayoutAccountResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts/tourGuide/{id} [get]
+func GetTourGuidePayoutAccounts(ctx *gin.Context) {
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetPayoutAccountsByTourGuide(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreatePayoutAccount godoc
+// @Summary      Register a payout account
+// @Description  Registers a bank account for a tour guide, the account must be verified by an admin before receiving payouts
+// @Tags         payout-accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.CreatePayoutAccountRequest true "Create Payout Account Request"
+// @Success      201 {object} response.PayoutAccountResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts [post]
+func CreatePayoutAccount(ctx *gin.Context) {
+	var request request.CreatePayoutAccountRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreatePayoutAccount(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// UpdatePayoutAccount godoc
+// @Summary      Update a payout account
+// @Description  Updates a tour guide's bank account, changed bank details must be verified again
+// @Tags         payout-accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.UpdatePayoutAccountRequest true "Update Payout Account Request"
+// @Success      200 {object} response.PayoutAccountResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts [put]
+func UpdatePayoutAccount(ctx *gin.Context) {
+	var request request.UpdatePayoutAccountRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.UpdatePayoutAccount(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// RemovePayoutAccount godoc
+// @Summary      Remove a payout account
+// @Description  Removes a bank account of a tour guide
+// @Tags         payout-accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.RemovePayoutAccountRequest true "Remove Payout Account Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts [delete]
+func RemovePayoutAccount(ctx *gin.Context) {
+	var request request.RemovePayoutAccountRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.RemovePayoutAccount(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// VerifyPayoutAccount godoc
+// @Summary      Verify a payout account
+// @Description  Marks a pending payout account as verified so that it can receive payouts
+// @Tags         payout-accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payout account ID"
+// @Param        request body request.VerifyPayoutAccountRequest true "Verify Payout Account Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts/{id}/verify [put]
+func VerifyPayoutAccount(ctx *gin.Context) {
+	var request request.VerifyPayoutAccountRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PayoutAccountId = id
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.VerifyPayoutAccount(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// RejectPayoutAccount godoc
+// @Summary      Reject a payout account
+// @Description  Rejects a pending payout account with a reason
+// @Tags         payout-accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Payout account ID"
+// @Param        request body request.VerifyPayoutAccountRequest true "Reject Payout Account Request"
+// @Success 200 {object} response.MessageApiResponse "Success"
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/payout-accounts/{id}/reject [put]
+func RejectPayoutAccount(ctx *gin.Context) {
+	var request request.VerifyPayoutAccountRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GeneratePayoutAccountService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.PayoutAccountId = id
+
+	utils.ProcessResponse(response.ApiResponse{
+		ErrMsg:   service.RejectPayoutAccount(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
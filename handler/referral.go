@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetReferralCode godoc
+// @Summary      Get referral code
+// @Description  Retrieve the referral code of a customer or a tour guide, the code is created on first use
+// @Tags         referral
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.GetReferralCodeRequest true "Referral Code Request"
+// @Success      200 {object} entity.ReferralCode
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/referrals/codes [post]
+func GetReferralCode(ctx *gin.Context) {
+	var request request.GetReferralCodeRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateReferralService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetReferralCode(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// UpdateReferralCodeStatus godoc
+// @Summary      Enable or disable referral code
+// @Description  A disabled code cannot be claimed and its referrals stop earning rewards, rewards already earned are kept
+// @Tags         referral
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                     true "Referral code ID"
+// @Param        request body request.UpdateReferralCodeStatusRequest true "Referral Code Status Request"
+// @Success      200 {object} entity.ReferralCode
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "ReferralCode not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/referrals/codes/{id}/status [put]
+func UpdateReferralCodeStatus(ctx *gin.Context) {
+	var request request.UpdateReferralCodeStatusRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateReferralService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.ReferralCodeId = id
+
+	res, err := service.UpdateReferralCodeStatus(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ClaimReferralCode godoc
+// @Summary      Claim referral code
+// @Description  Link a new customer or tour guide to the owner of the code before the first paid tour. The referral is attributed at the first payment and the referrer earns a share of the platform commission of the following paid tours. A referee can only use one code and cannot use its own
+// @Tags         referral
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.ClaimReferralCodeRequest true "Claim Referral Code Request"
+// @Success      201 {object} entity.Referral
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/referrals/claim [post]
+func ClaimReferralCode(ctx *gin.Context) {
+	var request request.ClaimReferralCodeRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateReferralService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.ClaimReferralCode(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// GetReferralSummary godoc
+// @Summary      Get referral summary
+// @Description  Retrieve the code, the referees and the rewards of a referrer with what is left of its caps
+// @Tags         referral
+// @Produce      json
+// @Security     BearerAuth
+// @Param        ownerType query string true "Owner type (CUSTOMER, TOUR_GUIDE)"
+// @Param        ownerId   query int    true "Owner ID"
+// @Success      200 {object} response.ReferralSummaryResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/referrals/summary [get]
+func GetReferralSummary(ctx *gin.Context) {
+	var request request.GetReferralCodeRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateReferralService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetReferralSummary(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetReferralRewards godoc
+// @Summary      Get referral rewards
+// @Description  Retrieve a paginated list of referral rewards, newest first
+// @Tags         referral
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page         query int    false "Page"
+// @Param        status       query string false "Status (PENDING, PAID, CANCELLED)"
+// @Param        referrerType query string false "Referrer type (CUSTOMER, TOUR_GUIDE)"
+// @Param        referrerId   query int    false "Referrer ID"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/referrals/rewards [get]
+func GetReferralRewards(ctx *gin.Context) {
+	var request request.GetReferralRewardsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateReferralService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetReferralRewards(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
@@ -130,6 +130,48 @@ func GetGrowthPercentage(ctx *gin.Context) {
 	})
 }
 
+// GetRevenueSeries godoc
+// @Summary      Get revenue time series
+// @Description  Aggregates total, net, commission and count per day, week, month, quarter or year with period-over-period and year-over-year growth
+// @Tags         revenues
+// @Produce      json
+// @Param        id          path  int    true "Tour Guide ID"
+// @Param        granularity query string true "Granularity (DAY, WEEK, MONTH, QUARTER, YEAR)"
+// @Param        from        query string true "From date (yyyy-MM-dd)"
+// @Param        to          query string true "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.RevenueSeriesResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/series/{id} [get]
+// @Security     BearerAuth
+func GetRevenueSeries(ctx *gin.Context) {
+	var request request.GetRevenueSeriesRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.TourGuideId = id
+
+	res, err := service.GetRevenueSeries(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
 // GetRevenue godoc
 // @Summary      Get a single revenue record
 // @Description  Retrieves revenue details by ID
@@ -201,8 +243,8 @@ func CreateRevenue(ctx *gin.Context) {
 }
 
 // UpdateRevenue godoc
-// @Summary      Update a revenue record
-// @Description  Updates an existing revenue entry
+// @Summary      Correct a revenue record
+// @Description  Records the differences to the given amounts as an adjustment of the revenue
 // @Tags         revenues
 // @Accept       json
 // @Produce      json
@@ -243,19 +285,26 @@ func UpdateRevenue(ctx *gin.Context) {
 }
 
 // RemoveRevenue godoc
-// @Summary      Delete a revenue record
-// @Description  Removes a revenue entry by ID
+// @Summary      Reverse a revenue record
+// @Description  Reverses a revenue entry with an adjustment, the original record is kept for statistics
 // @Tags         revenues
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "Revenue ID"
+// @Param        request body request.RemoveRevenueRequest true "Remove Revenue Payload"
 // @Success 200 {object} response.MessageApiResponse "Success"
 // @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
 // @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
 // @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
-// @Router       /payment-service/api/v1/revenues{id} [delete]
+// @Router       /payment-service/api/v1/revenues/{id} [delete]
 // @Security     BearerAuth
 func RemoveRevenue(ctx *gin.Context) {
+	var request request.RemoveRevenueRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
 	service, err := business_logic.GenerateRevenueService()
 	if err != nil {
 		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
@@ -264,8 +313,42 @@ func RemoveRevenue(ctx *gin.Context) {
 
 	id, _ := strconv.Atoi(ctx.Param("id"))
 
+	request.RevenueId = id
+
 	utils.ProcessResponse(response.ApiResponse{
-		ErrMsg:   service.RemoveRevenue(id, ctx),
+		ErrMsg:   service.RemoveRevenue(request, ctx),
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetRevenueAdjustments godoc
+// @Summary      Get revenue adjustments
+// @Description  Retrieves the corrections and reversals recorded for a revenue
+// @Tags         revenues
+// @Produce      json
+// @Param        id path int true "Revenue ID"
+// @Success      200 {array} entity.RevenueAdjustment
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/{id}/adjustments [get]
+// @Security     BearerAuth
+func GetRevenueAdjustments(ctx *gin.Context) {
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetRevenueAdjustments(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
 		Context:  ctx,
 		PostType: action_type.NON_POST,
 	})
@@ -312,3 +395,226 @@ func GetRevenueStats(ctx *gin.Context) {
 		PostType: action_type.NON_POST,
 	})
 }
+
+// GetEarningsStatement godoc
+// @Summary      Export earnings statement
+// @Description  Generates a tour guide's statement of a month, or of a whole year when month is omitted, listing revenues, payouts and the closing balance
+// @Tags         revenues
+// @Produce      octet-stream
+// @Param        id     path  int    true  "Tour Guide ID"
+// @Param        year   query int    true  "Year"
+// @Param        month  query int    false "Month (1-12)"
+// @Param        format query string true  "File format (csv, pdf)"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/statement/{id} [get]
+// @Security     BearerAuth
+func GetEarningsStatement(ctx *gin.Context) {
+	var request request.GetEarningsStatementRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.TourGuideId = id
+
+	res, err := service.GetEarningsStatement(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
+
+// GetPayoutSummary godoc
+// @Summary      Get payout summary of a tour guide
+// @Description  Retrieves the amount eligible for the next payout, the amount still on hold and the next payout date
+// @Tags         revenues
+// @Produce      json
+// @Param        id path int true "Tour guide ID"
+// @Success      200 {object} response.PayoutSummaryResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/payout-summary/{id} [get]
+// @Security     BearerAuth
+func GetPayoutSummary(ctx *gin.Context) {
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetPayoutSummary(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetPlatformRevenueSummary godoc
+// @Summary      Get platform revenue summary
+// @Description  Total gross, commission earned and net payable across all tour guides, split into pending and settled amounts
+// @Tags         revenues
+// @Produce      json
+// @Param        from query string true  "From date (yyyy-MM-dd)"
+// @Param        to   query string true  "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {object} response.PlatformRevenueSummaryResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/platform/summary [get]
+// @Security     BearerAuth
+func GetPlatformRevenueSummary(ctx *gin.Context) {
+	var request request.GetPlatformRevenueRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetPlatformRevenueSummary(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetTopTourGuideRevenues godoc
+// @Summary      Get top tour guides by revenue
+// @Description  Tour guides with the highest gross revenue in the period
+// @Tags         revenues
+// @Produce      json
+// @Param        from query string true  "From date (yyyy-MM-dd)"
+// @Param        to   query string true  "To date, inclusive (yyyy-MM-dd)"
+// @Param        top  query int    false "Number of tour guides, 10 by default"
+// @Success      200 {array} response.TourGuideRevenueResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/platform/top-guides [get]
+// @Security     BearerAuth
+func GetTopTourGuideRevenues(ctx *gin.Context) {
+	var request request.GetPlatformRevenueRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetTopTourGuideRevenues(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetServiceRevenues godoc
+// @Summary      Get revenue by tour service
+// @Description  Revenue of every tour service in the period, service names are resolved from the tour service
+// @Tags         revenues
+// @Produce      json
+// @Param        from query string true  "From date (yyyy-MM-dd)"
+// @Param        to   query string true  "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {array} response.ServiceRevenueResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/platform/services [get]
+// @Security     BearerAuth
+func GetServiceRevenues(ctx *gin.Context) {
+	var request request.GetPlatformRevenueRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetServiceRevenues(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetAreaRevenues godoc
+// @Summary      Get revenue by area
+// @Description  Revenue of every area in the period based on the area of each tour service
+// @Tags         revenues
+// @Produce      json
+// @Param        from query string true  "From date (yyyy-MM-dd)"
+// @Param        to   query string true  "To date, inclusive (yyyy-MM-dd)"
+// @Success      200 {array} response.AreaRevenueResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/revenues/platform/areas [get]
+// @Security     BearerAuth
+func GetAreaRevenues(ctx *gin.Context) {
+	var request request.GetPlatformRevenueRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateRevenueService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetAreaRevenues(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSubscriptionPlans godoc
+// @Summary      Get subscription plans
+// @Description  Retrieve the premium listing plans of tour guides with their price, billing cycle and commission rate
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} entity.SubscriptionPlan
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/plans [get]
+func GetSubscriptionPlans(ctx *gin.Context) {
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetSubscriptionPlans(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateSubscriptionPlan godoc
+// @Summary      Create subscription plan
+// @Description  Create a plan for tour guides. Subscribers pay the plan commission instead of the platform commission when it is lower, featured plans put them in the featured listing
+// @Tags         subscription
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.UpsertSubscriptionPlanRequest true "Subscription Plan Request"
+// @Success      201 {object} entity.SubscriptionPlan
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/plans [post]
+func CreateSubscriptionPlan(ctx *gin.Context) {
+	var request request.UpsertSubscriptionPlanRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateSubscriptionPlan(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// UpdateSubscriptionPlan godoc
+// @Summary      Update subscription plan
+// @Description  Update a plan, the new price applies from the next billing cycle. An inactive plan takes no new subscribers and is not renewed
+// @Tags         subscription
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                   true "Subscription plan ID"
+// @Param        request body request.UpsertSubscriptionPlanRequest true "Subscription Plan Request"
+// @Success      200 {object} entity.SubscriptionPlan
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "SubscriptionPlan not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/plans/{id} [put]
+func UpdateSubscriptionPlan(ctx *gin.Context) {
+	var request request.UpsertSubscriptionPlanRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.SubscriptionPlanId = id
+
+	res, err := service.UpdateSubscriptionPlan(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateSubscription godoc
+// @Summary      Subscribe to a plan
+// @Description  Subscribe a tour guide to a plan. The invoice of the first cycle is emailed to the billing email with its PayOS link, the plan starts once it is paid
+// @Tags         subscription
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.CreateSubscriptionRequest true "Subscription Request"
+// @Success      201 {object} response.SubscriptionResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "This tour guide already has a subscription. Please cancel it before choosing another plan."
+// @Failure 404 {object} response.MessageApiResponse "SubscriptionPlan not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions [post]
+func CreateSubscription(ctx *gin.Context) {
+	var request request.CreateSubscriptionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateSubscription(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// GetSubscriptions godoc
+// @Summary      Get subscriptions
+// @Description  Retrieve a paginated list of subscriptions, newest first
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page               query int    false "Page"
+// @Param        status             query string false "Status" Enums(PENDING, ACTIVE, PAST_DUE, CANCELLED, EXPIRED)
+// @Param        tourGuideId        query int    false "Tour guide ID"
+// @Param        subscriptionPlanId query int    false "Subscription plan ID"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions [get]
+func GetSubscriptions(ctx *gin.Context) {
+	var request request.GetSubscriptionsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetSubscriptions(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetTourGuideSubscription godoc
+// @Summary      Get subscription of tour guide
+// @Description  Retrieve the current subscription of a tour guide with its plan and unpaid invoice
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Tour guide ID"
+// @Success      200 {object} response.SubscriptionResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 404 {object} response.MessageApiResponse "Subscription not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/tour-guides/{id} [get]
+func GetTourGuideSubscription(ctx *gin.Context) {
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetTourGuideSubscription(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CancelSubscription godoc
+// @Summary      Cancel subscription
+// @Description  Cancel the subscription of a tour guide. An active plan is kept until the end of its paid period, an unpaid subscription ends right away and its invoice is voided
+// @Tags         subscription
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                               true "Subscription ID"
+// @Param        request body request.CancelSubscriptionRequest true "Cancel Subscription Request"
+// @Success      200 {object} response.SubscriptionResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 404 {object} response.MessageApiResponse "Subscription not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/{id}/cancel [put]
+func CancelSubscription(ctx *gin.Context) {
+	var request request.CancelSubscriptionRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.SubscriptionId = id
+
+	res, err := service.CancelSubscription(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetSubscriptionInvoices godoc
+// @Summary      Get subscription invoices
+// @Description  Retrieve a paginated list of subscription invoices, newest first
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page           query int    false "Page"
+// @Param        status         query string false "Status" Enums(PENDING, PAID, VOID)
+// @Param        tourGuideId    query int    false "Tour guide ID"
+// @Param        subscriptionId query int    false "Subscription ID"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/invoices [get]
+func GetSubscriptionInvoices(ctx *gin.Context) {
+	var request request.GetSubscriptionInvoicesRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetSubscriptionInvoices(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ConfirmSubscriptionInvoice godoc
+// @Summary      Confirm subscription invoice
+// @Description  Check the PayOS payment link of the invoice, a paid invoice starts or renews its subscription once. A cancelled or expired link is replaced so that the invoice can still be paid
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Subscription invoice ID"
+// @Success      200 {object} entity.SubscriptionInvoice
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "The invoice has not been paid yet. Please try again after completing the payment."
+// @Failure 404 {object} response.MessageApiResponse "SubscriptionInvoice not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/invoices/{id}/confirm [put]
+func ConfirmSubscriptionInvoice(ctx *gin.Context) {
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.ConfirmSubscriptionInvoice(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetFeaturedTourGuideIds godoc
+// @Summary      Get featured tour guides
+// @Description  Retrieve the IDs of tour guides whose subscription includes the featured listing, a plan in its grace period is still featured
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} int
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/featured [get]
+func GetFeaturedTourGuideIds(ctx *gin.Context) {
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetFeaturedTourGuideIds(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ProcessSubscriptions godoc
+// @Summary      Run subscription billing
+// @Description  Run the billing job right away: invoice renewals, collect paid invoices, remind unpaid ones and downgrade subscriptions past their grace period. The job also runs every hour
+// @Tags         subscription
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.SubscriptionBillingResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/subscriptions/billing [post]
+func ProcessSubscriptions(ctx *gin.Context) {
+	service, err := business_logic.GenerateSubscriptionService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.ProcessSubscriptions(ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
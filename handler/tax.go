@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTaxProfile godoc
+// @Summary      Get tax profile
+// @Description  Retrieve the taxpayer type, tax code and legal identity of a tour guide, the identity number is masked
+// @Tags         taxes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Tour Guide ID"
+// @Success      200 {object} response.TaxProfileResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/taxes/profiles/{id} [get]
+func GetTaxProfile(ctx *gin.Context) {
+	service, err := business_logic.GenerateTaxService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetTaxProfile(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// SaveTaxProfile godoc
+// @Summary      Save tax profile
+// @Description  Create or update the tax profile of a tour guide, 10% personal income tax is withheld from payouts of individuals from 2,000,000đ
+// @Tags         taxes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.SaveTaxProfileRequest true "Save Tax Profile Request"
+// @Success      200 {object} response.TaxProfileResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/taxes/profiles [put]
+func SaveTaxProfile(ctx *gin.Context) {
+	var request request.SaveTaxProfileRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateTaxService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.SaveTaxProfile(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetTaxWithholdings godoc
+// @Summary      Get tax withheld ledger
+// @Description  Retrieve personal income tax withheld from paid payouts of a month, or of a whole year when month is omitted
+// @Tags         taxes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tourGuideId query int false "Tour Guide ID"
+// @Param        year        query int true  "Year"
+// @Param        month       query int false "Month (1-12)"
+// @Success      200 {object} response.TaxWithholdingLedgerResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/taxes/withholdings [get]
+func GetTaxWithholdings(ctx *gin.Context) {
+	var request request.GetTaxWithholdingsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateTaxService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.GetTaxWithholdings(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetTaxCertificate godoc
+// @Summary      Get tax withholding certificate
+// @Description  Retrieve the data of the yearly personal income tax withholding certificate of a tour guide
+// @Tags         taxes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  int true "Tour Guide ID"
+// @Param        year query int true "Year"
+// @Success      200 {object} response.TaxCertificateResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/taxes/certificates/{id} [get]
+func GetTaxCertificate(ctx *gin.Context) {
+	var request request.GetTaxCertificateRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateTaxService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.TourGuideId = id
+
+	res, err := service.GetTaxCertificate(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// ExportTaxDeclaration godoc
+// @Summary      Export tax declaration
+// @Description  Generates the list of individuals with income paid and personal income tax withheld for the declaration to the tax authority
+// @Tags         taxes
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        year   query int    true  "Year"
+// @Param        month  query int    false "Month (1-12)"
+// @Param        format query string true  "File format (csv, xlsx)"
+// @Success      200 {file} file
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/taxes/declaration/export [get]
+func ExportTaxDeclaration(ctx *gin.Context) {
+	var request request.ExportTaxDeclarationRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateTaxService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.ExportTaxDeclaration(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.FILE_DOWNLOAD,
+	})
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"strconv"
+	business_logic "tourmate/payment-service/business_logic"
+	action_type "tourmate/payment-service/constant/action_type"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWallet godoc
+// @Summary      Get wallet
+// @Description  Retrieve the wallet balance of a customer, a customer without a wallet has a zero balance
+// @Tags         wallets
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Customer ID"
+// @Success      200 {object} entity.Wallet
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/wallets/customer/{id} [get]
+func GetWallet(ctx *gin.Context) {
+	service, err := business_logic.GenerateWalletService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.GetWallet(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// GetWalletTransactions godoc
+// @Summary      Get wallet transactions
+// @Description  Retrieve a paginated history of the wallet of a customer, newest first
+// @Tags         wallets
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  int true  "Customer ID"
+// @Param        page query int false "Page"
+// @Success      200 {object} response.PaginationDataResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/wallets/customer/{id}/transactions [get]
+func GetWalletTransactions(ctx *gin.Context) {
+	var request request.GetWalletTransactionsRequest
+	if ctx.ShouldBindQuery(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateWalletService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	request.CustomerId = id
+
+	res, err := service.GetWalletTransactions(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// CreateWalletTopUp godoc
+// @Summary      Create wallet top-up
+// @Description  Create a PayOS payment link to top up the wallet, the wallet is credited once the top-up is confirmed
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.CreateWalletTopUpRequest true "Create Wallet Top-Up Request"
+// @Success      201 {object} entity.WalletTopUp
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/wallets/top-up [post]
+func CreateWalletTopUp(ctx *gin.Context) {
+	var request request.CreateWalletTopUpRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateWalletService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.CreateWalletTopUp(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// ConfirmWalletTopUp godoc
+// @Summary      Confirm wallet top-up
+// @Description  Check the PayOS payment link of the top-up, a paid top-up is credited to the wallet once, a cancelled or expired one is closed
+// @Tags         wallets
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Wallet top-up ID"
+// @Success      200 {object} entity.WalletTopUp
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "The top-up has not been paid yet. Please try again after completing the payment."
+// @Failure 404 {object} response.MessageApiResponse "WalletTopUp not found."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/wallets/top-up/{id}/confirm [put]
+func ConfirmWalletTopUp(ctx *gin.Context) {
+	service, err := business_logic.GenerateWalletService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	res, err := service.ConfirmWalletTopUp(id, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.NON_POST,
+	})
+}
+
+// PayWithWallet godoc
+// @Summary      Pay with wallet
+// @Description  Pay an invoice from the wallet. When a wallet amount below the price is given, a PayOS link is returned for the rest and the wallet part is held by a pending WALLET payment until the link is paid, it is given back to the wallet when the link is cancelled or expires
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.PayWithWalletRequest true "Pay With Wallet Request"
+// @Success      201 {object} response.WalletPaymentResponse
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "The wallet balance is not enough for this transaction."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/wallets/pay [post]
+func PayWithWallet(ctx *gin.Context) {
+	var request request.PayWithWalletRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateWalletService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.PayWithWallet(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
+
+// AdjustWallet godoc
+// @Summary      Adjust wallet
+// @Description  Credit (positive amount) or debit (negative amount) the wallet of a customer, a debit cannot take the balance below zero
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body request.AdjustWalletRequest true "Adjust Wallet Request"
+// @Success      201 {object} entity.WalletTransaction
+// @Failure 401 {object} response.MessageApiResponse "You have no rights to access this action."
+// @Failure 400 {object} response.MessageApiResponse "Invalid data. Please try again."
+// @Failure 500 {object} response.MessageApiResponse "There is something wrong in the system during the process. Please try again later."
+// @Router       /payment-service/api/v1/wallets/adjust [post]
+func AdjustWallet(ctx *gin.Context) {
+	var request request.AdjustWalletRequest
+	if ctx.ShouldBindJSON(&request) != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, nil))
+		return
+	}
+
+	service, err := business_logic.GenerateWalletService()
+	if err != nil {
+		utils.ProcessResponse(utils.GenerateInvalidRequestAndSystemProblemModel(ctx, err))
+		return
+	}
+
+	res, err := service.AdjustWallet(request, ctx)
+
+	utils.ProcessResponse(response.ApiResponse{
+		Data1:    res,
+		Data2:    res,
+		ErrMsg:   err,
+		Context:  ctx,
+		PostType: action_type.CREATE_ACTION,
+	})
+}
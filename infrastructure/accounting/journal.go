@@ -0,0 +1,120 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"tourmate/payment-service/constant/accounting"
+	"tourmate/payment-service/utils"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// A journal row posting the amount from the credit account to the debit account, accounts hold the account codes
+type JournalLine struct {
+	Date          time.Time
+	VoucherNo     string
+	VoucherMemo   string
+	Description   string
+	DebitAccount  string
+	CreditAccount string
+	Amount        float64
+	DebitObject   string
+	CreditObject  string
+}
+
+var csvHeaders []string = []string{
+	"Ngày hạch toán", "Số chứng từ", "Diễn giải", "Diễn giải hạch toán", "TK Nợ", "TK Có", "Số tiền", "Đối tượng Nợ", "Đối tượng Có",
+}
+
+var misaHeaders []string = []string{
+	"Ngày hạch toán (*)", "Ngày chứng từ (*)", "Số chứng từ (*)", "Diễn giải", "Diễn giải (hạch toán)",
+	"TK Nợ (*)", "TK Có (*)", "Số tiền", "Đối tượng Nợ", "Đối tượng Có",
+}
+
+// Order lines by date then voucher, lines of a voucher keep the order they were added in
+func SortJournalLines(lines []JournalLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		if !lines[i].Date.Equal(lines[j].Date) {
+			return lines[i].Date.Before(lines[j].Date)
+		}
+
+		return lines[i].VoucherNo < lines[j].VoucherNo
+	})
+}
+
+func GenerateVoucherNo(prefix string, id int) string {
+	return fmt.Sprintf("%s%08d", prefix, id)
+}
+
+func GenerateObjectCode(prefix string, id int) string {
+	return fmt.Sprintf("%s%d", prefix, id)
+}
+
+func GenerateJournalCsv(lines []JournalLine) ([]byte, error) {
+	var rows [][]string
+	for _, line := range lines {
+		rows = append(rows, []string{
+			line.Date.Format(utils.DATE_FORMAT),
+			line.VoucherNo,
+			line.VoucherMemo,
+			line.Description,
+			line.DebitAccount,
+			line.CreditAccount,
+			fmt.Sprintf("%.0f", line.Amount),
+			line.DebitObject,
+			line.CreditObject,
+		})
+	}
+
+	return utils.GenerateCsvFile(csvHeaders, rows)
+}
+
+// Generate the MISA import file of other vouchers, amounts are written as numbers so that MISA reads them without conversion
+func GenerateJournalMisaXlsx(lines []JournalLine) ([]byte, error) {
+	var file = excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), accounting.MISA_SHEET); err != nil {
+		return nil, err
+	}
+
+	var headers []interface{}
+	for _, header := range misaHeaders {
+		headers = append(headers, header)
+	}
+
+	if err := file.SetSheetRow(accounting.MISA_SHEET, "A1", &headers); err != nil {
+		return nil, err
+	}
+
+	for i, line := range lines {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, err
+		}
+
+		var date string = line.Date.Format(accounting.MISA_DATE_FORMAT)
+		if err := file.SetSheetRow(accounting.MISA_SHEET, cell, &[]interface{}{
+			date,
+			date,
+			line.VoucherNo,
+			line.VoucherMemo,
+			line.Description,
+			line.DebitAccount,
+			line.CreditAccount,
+			line.Amount,
+			line.DebitObject,
+			line.CreditObject,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,52 @@
+package accounting
+
+import (
+	"os"
+	"strings"
+	"tourmate/payment-service/constant/accounting"
+	"tourmate/payment-service/constant/env"
+	"tourmate/payment-service/constant/ledger"
+)
+
+// Journal accounts in the order they are listed
+var journalAccounts []string = []string{
+	ledger.GATEWAY_CLEARING,
+	ledger.CUSTOMER_RECEIVABLE,
+	ledger.GUIDE_PAYABLE,
+	ledger.PLATFORM_COMMISSION,
+	ledger.TAX_PAYABLE,
+	ledger.AGENCY_PAYABLE,
+	ledger.SUBSCRIPTION_REVENUE,
+	ledger.GATEWAY_FEE,
+}
+
+func GetJournalAccounts() []string {
+	return journalAccounts
+}
+
+// Get account codes of the chart of accounts, the defaults are overridden by the configured mapping
+func GetAccountCodes() map[string]string {
+	var res map[string]string = map[string]string{
+		ledger.GATEWAY_CLEARING:    accounting.DEFAULT_GATEWAY_CLEARING_CODE,
+		ledger.CUSTOMER_RECEIVABLE: accounting.DEFAULT_CUSTOMER_RECEIVABLE_CODE,
+		ledger.GUIDE_PAYABLE:       accounting.DEFAULT_GUIDE_PAYABLE_CODE,
+		ledger.PLATFORM_COMMISSION: accounting.DEFAULT_PLATFORM_COMMISSION_CODE,
+		ledger.TAX_PAYABLE:         accounting.DEFAULT_TAX_PAYABLE_CODE,
+		ledger.AGENCY_PAYABLE:      accounting.DEFAULT_AGENCY_PAYABLE_CODE,
+		ledger.GATEWAY_FEE:         accounting.DEFAULT_GATEWAY_FEE_CODE,
+
+		ledger.SUBSCRIPTION_REVENUE: accounting.DEFAULT_SUBSCRIPTION_REVENUE_CODE,
+	}
+
+	for _, pair := range strings.Split(os.Getenv(env.ACCOUNTING_ACCOUNT_CODES), ",") {
+		account, code, ok := strings.Cut(pair, "=")
+		account, code = strings.ToUpper(strings.TrimSpace(account)), strings.TrimSpace(code)
+
+		// Unknown accounts are ignored
+		if _, isExisted := res[account]; ok && isExisted && code != "" {
+			res[account] = code
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,128 @@
+package bank
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"tourmate/payment-service/constant/bank"
+	"tourmate/payment-service/constant/noti"
+	business_logic "tourmate/payment-service/interface/business_logic"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+)
+
+// Payout reference placed in the transfer note, see utils.GeneratePayoutReference
+var payoutReferencePattern = regexp.MustCompile(`TM\d+G\d+`)
+
+func GenerateBankTransferFile(bankCode string) (business_logic.IBankTransferFile, error) {
+	switch strings.ToUpper(bankCode) {
+	case bank.VIETCOMBANK:
+		return &vietcombankFile{}, nil
+	case bank.TECHCOMBANK:
+		return &techcombankFile{}, nil
+	default:
+		return nil, errors.New(noti.UNSUPPORTED_BANK_WARN_MSG)
+	}
+}
+
+// Column names of a result file, each field accepts several header spellings
+type resultColumns struct {
+	note       []string
+	status     []string
+	message    []string
+	successKey []string
+}
+
+// Locate the header row then read a result for every line carrying a payout reference
+func parseResultRows(rows [][]string, columns resultColumns) ([]response.BankTransferResult, error) {
+	var headerIndex int = -1
+	var noteIndex, statusIndex, messageIndex int
+	for i, row := range rows {
+		noteIndex = utils.FindColumnIndex(row, columns.note...)
+		statusIndex = utils.FindColumnIndex(row, columns.status...)
+		if noteIndex >= 0 && statusIndex >= 0 {
+			headerIndex = i
+			messageIndex = utils.FindColumnIndex(row, columns.message...)
+			break
+		}
+	}
+
+	if headerIndex < 0 {
+		return nil, errors.New(noti.INVALID_FILE_CONTENT_WARN_MSG)
+	}
+
+	var res []response.BankTransferResult
+	for _, row := range rows[headerIndex+1:] {
+		var reference string = payoutReferencePattern.FindString(utils.GetCellValue(row, noteIndex))
+		if reference == "" {
+			continue
+		}
+
+		var status string = utils.ToNormalizedString(utils.GetCellValue(row, statusIndex))
+		var isSuccess bool
+		for _, key := range columns.successKey {
+			if status == utils.ToNormalizedString(key) {
+				isSuccess = true
+				break
+			}
+		}
+
+		res = append(res, response.BankTransferResult{
+			Reference: reference,
+			IsSuccess: isSuccess,
+			Message:   utils.GetCellValue(row, messageIndex),
+		})
+	}
+
+	return res, nil
+}
+
+// Column names of an account statement, each field accepts several header spellings
+type statementColumns struct {
+	date        []string
+	reference   []string
+	credit      []string
+	description []string
+}
+
+// Locate the header row then read every line with a credit amount, debit lines and summary rows are skipped
+func parseStatementRows(rows [][]string, columns statementColumns) ([]response.BankStatementTransaction, error) {
+	var headerIndex int = -1
+	var dateIndex, referenceIndex, creditIndex, descriptionIndex int
+	for i, row := range rows {
+		creditIndex = utils.FindColumnIndex(row, columns.credit...)
+		descriptionIndex = utils.FindColumnIndex(row, columns.description...)
+		dateIndex = utils.FindColumnIndex(row, columns.date...)
+		if creditIndex >= 0 && descriptionIndex >= 0 && dateIndex >= 0 {
+			headerIndex = i
+			referenceIndex = utils.FindColumnIndex(row, columns.reference...)
+			break
+		}
+	}
+
+	if headerIndex < 0 {
+		return nil, errors.New(noti.INVALID_FILE_CONTENT_WARN_MSG)
+	}
+
+	var res []response.BankStatementTransaction
+	for _, row := range rows[headerIndex+1:] {
+		amount, isAmount := utils.ParseMoney(utils.GetCellValue(row, creditIndex))
+		if !isAmount || amount <= 0 {
+			continue
+		}
+
+		date, isDate := utils.ParseStatementDate(utils.GetCellValue(row, dateIndex))
+		if !isDate {
+			continue
+		}
+
+		res = append(res, response.BankStatementTransaction{
+			Reference:       utils.GetCellValue(row, referenceIndex),
+			TransactionDate: date,
+			Amount:          amount,
+			Description:     utils.GetCellValue(row, descriptionIndex),
+		})
+	}
+
+	return res, nil
+}
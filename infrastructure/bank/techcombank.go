@@ -0,0 +1,56 @@
+package bank
+
+import (
+	"fmt"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+// Techcombank Business bulk-transfer layout
+type techcombankFile struct{}
+
+// GenerateTransferRows implements businesslogic.IBankTransferFile.
+func (t *techcombankFile) GenerateTransferRows(items []request.BankTransferItem) ([]string, [][]string) {
+	var headers []string = []string{
+		"No.",
+		"Beneficiary Account No.",
+		"Beneficiary Name",
+		"Beneficiary Bank Code",
+		"Amount",
+		"Payment Details",
+	}
+
+	var rows [][]string
+	for i, item := range items {
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1),
+			item.AccountNumber,
+			item.AccountHolder,
+			item.BankCode,
+			fmt.Sprintf("%.0f", item.Amount),
+			item.Note,
+		})
+	}
+
+	return headers, rows
+}
+
+// ParseResultRows implements businesslogic.IBankTransferFile.
+func (t *techcombankFile) ParseResultRows(rows [][]string) ([]response.BankTransferResult, error) {
+	return parseResultRows(rows, resultColumns{
+		note:       []string{"Payment Details", "Remark"},
+		status:     []string{"Status", "Transaction Status"},
+		message:    []string{"Reason", "Error Description"},
+		successKey: []string{"SUCCESS", "SUCCESSFUL", "COMPLETED"},
+	})
+}
+
+// ParseStatementRows implements businesslogic.IBankTransferFile.
+func (t *techcombankFile) ParseStatementRows(rows [][]string) ([]response.BankStatementTransaction, error) {
+	return parseStatementRows(rows, statementColumns{
+		date:        []string{"Transaction Date", "Value Date", "Ngày giao dịch"},
+		reference:   []string{"Reference", "Transaction No.", "Số tham chiếu"},
+		credit:      []string{"Credit", "Credit Amount", "Ghi có"},
+		description: []string{"Description", "Transaction Details", "Remark", "Diễn giải"},
+	})
+}
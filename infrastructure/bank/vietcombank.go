@@ -0,0 +1,56 @@
+package bank
+
+import (
+	"fmt"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+// Vietcombank VCB DigiBiz bulk-transfer layout
+type vietcombankFile struct{}
+
+// GenerateTransferRows implements businesslogic.IBankTransferFile.
+func (v *vietcombankFile) GenerateTransferRows(items []request.BankTransferItem) ([]string, [][]string) {
+	var headers []string = []string{
+		"STT",
+		"So tai khoan thu huong",
+		"Ten nguoi thu huong",
+		"Ma ngan hang thu huong",
+		"So tien",
+		"Noi dung chuyen khoan",
+	}
+
+	var rows [][]string
+	for i, item := range items {
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1),
+			item.AccountNumber,
+			item.AccountHolder,
+			item.BankCode,
+			fmt.Sprintf("%.0f", item.Amount),
+			item.Note,
+		})
+	}
+
+	return headers, rows
+}
+
+// ParseResultRows implements businesslogic.IBankTransferFile.
+func (v *vietcombankFile) ParseResultRows(rows [][]string) ([]response.BankTransferResult, error) {
+	return parseResultRows(rows, resultColumns{
+		note:       []string{"Noi dung chuyen khoan", "Nội dung chuyển khoản"},
+		status:     []string{"Trang thai", "Trạng thái"},
+		message:    []string{"Ghi chu", "Ghi chú", "Mo ta loi", "Mô tả lỗi"},
+		successKey: []string{"Thanh cong", "Thành công"},
+	})
+}
+
+// ParseStatementRows implements businesslogic.IBankTransferFile.
+func (v *vietcombankFile) ParseStatementRows(rows [][]string) ([]response.BankStatementTransaction, error) {
+	return parseStatementRows(rows, statementColumns{
+		date:        []string{"Ngay giao dich", "Ngày giao dịch", "Ngay GD", "Ngày GD"},
+		reference:   []string{"So tham chieu", "Số tham chiếu", "So CT", "Số CT"},
+		credit:      []string{"So tien ghi co", "Số tiền ghi có", "Ghi co", "Ghi có"},
+		description: []string{"Mo ta", "Mô tả", "Noi dung", "Nội dung", "Dien giai", "Diễn giải"},
+	})
+}
@@ -0,0 +1,112 @@
+package einvoice
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"tourmate/payment-service/constant/einvoice"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/utils"
+)
+
+// Values printed on the human readable invoice, amounts are already formatted
+type invoiceView struct {
+	Title           string
+	TemplateCode    string
+	Series          string
+	InvoiceNumber   string
+	IssuedDate      string
+	Seller          Seller
+	BuyerName       string
+	BuyerCompany    string
+	BuyerTaxCode    string
+	BuyerAddress    string
+	BuyerEmail      string
+	RelatedNote     string
+	ItemName        string
+	VatRate         string
+	AmountBeforeTax string
+	VatAmount       string
+	TotalAmount     string
+	TotalInWords    string
+}
+
+// Generate the HTML rendering of the invoice from the invoice template
+func GenerateHtml(invoice entity.EInvoice, related *entity.EInvoice, seller Seller) ([]byte, error) {
+	tmpl, err := template.ParseFiles(einvoice.HTML_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, generateInvoiceView(invoice, related, seller)); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Generate the PDF rendering of the invoice
+func GeneratePdf(invoice entity.EInvoice, related *entity.EInvoice, seller Seller) ([]byte, error) {
+	var view invoiceView = generateInvoiceView(invoice, related, seller)
+
+	var descriptions []string = []string{
+		fmt.Sprintf("Ký hiệu mẫu số: %s - Ký hiệu: %s - Số: %s - Ngày: %s", view.TemplateCode, view.Series, view.InvoiceNumber, view.IssuedDate),
+		fmt.Sprintf("Đơn vị bán hàng: %s - MST: %s", seller.Name, seller.TaxCode),
+		"Địa chỉ: " + seller.Address,
+		"Họ tên người mua hàng: " + view.BuyerName,
+		fmt.Sprintf("Tên đơn vị: %s - MST: %s", view.BuyerCompany, view.BuyerTaxCode),
+		"Địa chỉ: " + view.BuyerAddress,
+	}
+
+	if view.RelatedNote != "" {
+		descriptions = append(descriptions, view.RelatedNote)
+	}
+
+	return utils.GeneratePdfFile(view.Title, descriptions,
+		[]string{"STT", "Tên hàng hóa, dịch vụ", "Đơn vị tính", "Số lượng", "Đơn giá", "Thành tiền"},
+		[][]string{{"1", view.ItemName, "Tour", "1", view.AmountBeforeTax, view.AmountBeforeTax}},
+		[][]string{
+			{"Cộng tiền hàng", view.AmountBeforeTax},
+			{"Thuế suất GTGT", view.VatRate},
+			{"Tiền thuế GTGT", view.VatAmount},
+			{"Tổng tiền thanh toán", view.TotalAmount},
+			{"Số tiền viết bằng chữ", view.TotalInWords},
+		})
+}
+
+func generateInvoiceView(invoice entity.EInvoice, related *entity.EInvoice, seller Seller) invoiceView {
+	var res invoiceView = invoiceView{
+		Title:           "HÓA ĐƠN GIÁ TRỊ GIA TĂNG",
+		TemplateCode:    invoice.TemplateCode,
+		Series:          invoice.Series,
+		InvoiceNumber:   fmt.Sprintf("%08d", invoice.InvoiceNumber),
+		IssuedDate:      invoice.IssuedAt.Format("02/01/2006"),
+		Seller:          seller,
+		BuyerName:       invoice.BuyerName,
+		BuyerCompany:    invoice.BuyerCompanyName,
+		BuyerTaxCode:    invoice.BuyerTaxCode,
+		BuyerAddress:    invoice.BuyerAddress,
+		BuyerEmail:      invoice.BuyerEmail,
+		ItemName:        invoice.ItemName,
+		VatRate:         fmt.Sprintf("%g%%", invoice.VatRate),
+		AmountBeforeTax: utils.FormatMoney(invoice.AmountBeforeTax),
+		VatAmount:       utils.FormatMoney(invoice.VatAmount),
+		TotalAmount:     utils.FormatMoney(invoice.TotalAmount),
+		TotalInWords:    utils.AmountToVietnameseWords(invoice.TotalAmount),
+	}
+
+	if related != nil {
+		var action string = "Điều chỉnh"
+		if invoice.InvoiceType == einvoice.REPLACEMENT {
+			action = "Thay thế"
+		}
+
+		res.Title += " (" + strings.ToUpper(action) + ")"
+		res.RelatedNote = fmt.Sprintf("%s cho hóa đơn mẫu số %s ký hiệu %s số %08d ngày %s. Lý do: %s", action,
+			related.TemplateCode, related.Series, related.InvoiceNumber, related.IssuedAt.Format("02/01/2006"), invoice.Reason)
+	}
+
+	return res
+}
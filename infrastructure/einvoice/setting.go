@@ -0,0 +1,52 @@
+package einvoice
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+	"tourmate/payment-service/constant/einvoice"
+	"tourmate/payment-service/constant/env"
+)
+
+// Seller information printed on every invoice
+type Seller struct {
+	Name    string
+	TaxCode string
+	Address string
+}
+
+func GetSeller() Seller {
+	return Seller{
+		Name:    os.Getenv(env.EINVOICE_SELLER_NAME),
+		TaxCode: os.Getenv(env.EINVOICE_SELLER_TAX_CODE),
+		Address: os.Getenv(env.EINVOICE_SELLER_ADDRESS),
+	}
+}
+
+func GetTemplateCode() string {
+	if code := os.Getenv(env.EINVOICE_TEMPLATE_CODE); code != "" {
+		return code
+	}
+
+	return einvoice.DEFAULT_TEMPLATE_CODE
+}
+
+// Series of invoices issued in the year, e.g. C25TTM where C means the invoice has a tax authority code
+func GetSeries(issuedAt time.Time) string {
+	var suffix string = os.Getenv(env.EINVOICE_SERIES_SUFFIX)
+	if suffix == "" {
+		suffix = einvoice.DEFAULT_SERIES_SUFFIX
+	}
+
+	return fmt.Sprintf("C%02d%s", issuedAt.Year()%100, suffix)
+}
+
+// VAT rate in percent
+func GetVatRate() float64 {
+	if rate, err := strconv.ParseFloat(os.Getenv(env.EINVOICE_VAT_RATE), 64); err == nil && rate >= 0 {
+		return rate
+	}
+
+	return einvoice.DEFAULT_VAT_RATE
+}
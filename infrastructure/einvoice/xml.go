@@ -0,0 +1,194 @@
+package einvoice
+
+import (
+	"encoding/xml"
+	"fmt"
+	"tourmate/payment-service/constant/einvoice"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/utils"
+)
+
+// Invoice data structure of Decree 123/2020/ND-CP following the XML format published by the General Department of Taxation
+type invoiceXml struct {
+	XMLName   xml.Name      `xml:"HDon"`
+	Data      invoiceData   `xml:"DLHDon"`
+	TaxCode   string        `xml:"MCCQT"` // Assigned by the tax authority after the invoice is sent
+	Signature signatureList `xml:"DSCKS"`
+}
+
+type invoiceData struct {
+	Id      string         `xml:"Id,attr"`
+	General generalInfo    `xml:"TTChung"`
+	Content invoiceContent `xml:"NDHDon"`
+}
+
+type generalInfo struct {
+	Version       string       `xml:"PBan"`
+	InvoiceName   string       `xml:"THDon"`
+	TemplateCode  string       `xml:"KHMSHDon"`
+	Series        string       `xml:"KHHDon"`
+	InvoiceNumber int          `xml:"SHDon"`
+	IssuedDate    string       `xml:"NLap"`
+	Currency      string       `xml:"DVTTe"`
+	ExchangeRate  int          `xml:"TGia"`
+	PaymentMethod string       `xml:"HTTToan"`
+	Related       *relatedInfo `xml:"TTHDLQuan,omitempty"`
+}
+
+// Invoice which is adjusted or replaced by this one
+type relatedInfo struct {
+	Nature        int    `xml:"TCHDon"`    // 1 is replacement, 2 is adjustment
+	InvoiceKind   int    `xml:"LHDCLQuan"` // 1 is an e-invoice under Decree 123
+	TemplateCode  string `xml:"KHMSHDCLQuan"`
+	Series        string `xml:"KHHDCLQuan"`
+	InvoiceNumber int    `xml:"SHDCLQuan"`
+	IssuedDate    string `xml:"NLHDCLQuan"`
+	Note          string `xml:"GChu,omitempty"`
+}
+
+type invoiceContent struct {
+	Seller sellerInfo   `xml:"NBan"`
+	Buyer  buyerInfo    `xml:"NMua"`
+	Items  []lineItem   `xml:"DSHHDVu>HHDVu"`
+	Total  paymentTotal `xml:"TToan"`
+}
+
+type sellerInfo struct {
+	Name    string `xml:"Ten"`
+	TaxCode string `xml:"MST"`
+	Address string `xml:"DChi"`
+}
+
+type buyerInfo struct {
+	Name      string `xml:"Ten"`
+	TaxCode   string `xml:"MST,omitempty"`
+	Address   string `xml:"DChi,omitempty"`
+	BuyerName string `xml:"HVTNMHang,omitempty"`
+	Email     string `xml:"DCTDTu,omitempty"`
+}
+
+type lineItem struct {
+	Nature    int    `xml:"TChat"` // 1 is goods or services
+	Order     int    `xml:"STT"`
+	Name      string `xml:"THHDVu"`
+	Unit      string `xml:"DVTinh"`
+	Quantity  int    `xml:"SLuong"`
+	UnitPrice string `xml:"DGia"`
+	Amount    string `xml:"ThTien"`
+	VatRate   string `xml:"TSuat"`
+}
+
+type paymentTotal struct {
+	VatRates        []vatRateTotal `xml:"THTTLTSuat>LTSuat"`
+	AmountBeforeTax string         `xml:"TgTCThue"`
+	VatAmount       string         `xml:"TgTThue"`
+	TotalAmount     string         `xml:"TgTTTBSo"`
+	TotalInWords    string         `xml:"TgTTTBChu"`
+}
+
+type vatRateTotal struct {
+	VatRate         string `xml:"TSuat"`
+	AmountBeforeTax string `xml:"ThTien"`
+	VatAmount       string `xml:"TThue"`
+}
+
+// Digital signatures are added by the signing provider
+type signatureList struct{}
+
+// Generate the invoice XML, related is the invoice adjusted or replaced by an adjustment or replacement invoice
+func GenerateXml(invoice entity.EInvoice, related *entity.EInvoice, seller Seller) ([]byte, error) {
+	var vatRate string = fmt.Sprintf("%g%%", invoice.VatRate)
+
+	var data invoiceXml = invoiceXml{
+		Data: invoiceData{
+			Id: fmt.Sprintf("%s-%s-%08d", invoice.TemplateCode, invoice.Series, invoice.InvoiceNumber),
+			General: generalInfo{
+				Version:       einvoice.XML_VERSION,
+				InvoiceName:   einvoice.INVOICE_NAME,
+				TemplateCode:  invoice.TemplateCode,
+				Series:        invoice.Series,
+				InvoiceNumber: invoice.InvoiceNumber,
+				IssuedDate:    invoice.IssuedAt.Format(utils.DATE_FORMAT),
+				Currency:      einvoice.CURRENCY_CODE,
+				ExchangeRate:  1,
+				PaymentMethod: einvoice.PAYMENT_METHOD,
+			},
+			Content: invoiceContent{
+				Seller: sellerInfo{
+					Name:    seller.Name,
+					TaxCode: seller.TaxCode,
+					Address: seller.Address,
+				},
+				Buyer: buyerInfo{
+					Name:      GetBuyerDisplayName(invoice),
+					TaxCode:   invoice.BuyerTaxCode,
+					Address:   invoice.BuyerAddress,
+					BuyerName: invoice.BuyerName,
+					Email:     invoice.BuyerEmail,
+				},
+				Items: []lineItem{
+					{
+						Nature:    1,
+						Order:     1,
+						Name:      invoice.ItemName,
+						Unit:      "Tour",
+						Quantity:  1,
+						UnitPrice: formatXmlAmount(invoice.AmountBeforeTax),
+						Amount:    formatXmlAmount(invoice.AmountBeforeTax),
+						VatRate:   vatRate,
+					},
+				},
+				Total: paymentTotal{
+					VatRates: []vatRateTotal{
+						{
+							VatRate:         vatRate,
+							AmountBeforeTax: formatXmlAmount(invoice.AmountBeforeTax),
+							VatAmount:       formatXmlAmount(invoice.VatAmount),
+						},
+					},
+					AmountBeforeTax: formatXmlAmount(invoice.AmountBeforeTax),
+					VatAmount:       formatXmlAmount(invoice.VatAmount),
+					TotalAmount:     formatXmlAmount(invoice.TotalAmount),
+					TotalInWords:    utils.AmountToVietnameseWords(invoice.TotalAmount),
+				},
+			},
+		},
+	}
+
+	if related != nil {
+		var nature int = 2
+		if invoice.InvoiceType == einvoice.REPLACEMENT {
+			nature = 1
+		}
+
+		data.Data.General.Related = &relatedInfo{
+			Nature:        nature,
+			InvoiceKind:   1,
+			TemplateCode:  related.TemplateCode,
+			Series:        related.Series,
+			InvoiceNumber: related.InvoiceNumber,
+			IssuedDate:    related.IssuedAt.Format(utils.DATE_FORMAT),
+			Note:          invoice.Reason,
+		}
+	}
+
+	content, err := xml.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
+
+// Company buyers are named by the company, the person is kept as the purchaser
+func GetBuyerDisplayName(invoice entity.EInvoice) string {
+	if invoice.BuyerCompanyName != "" {
+		return invoice.BuyerCompanyName
+	}
+
+	return invoice.BuyerName
+}
+
+func formatXmlAmount(amount float64) string {
+	return fmt.Sprintf("%.0f", amount)
+}
@@ -0,0 +1,66 @@
+package settlement
+
+import (
+	"errors"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/utils"
+)
+
+// Column names of a gateway settlement report, each field accepts several header spellings
+var (
+	referenceColumns   []string = []string{"Ma giao dich", "Mã giao dịch", "Reference", "Transaction ID", "Order code", "Mã đơn hàng"}
+	descriptionColumns []string = []string{"Noi dung", "Nội dung", "Mo ta", "Mô tả", "Description"}
+	amountColumns      []string = []string{"So tien", "Số tiền", "Amount", "So tien giao dich", "Số tiền giao dịch"}
+	feeColumns         []string = []string{"Phi", "Phí", "Phi giao dich", "Phí giao dịch", "Fee", "Transaction fee"}
+)
+
+// Locate the header row then read every line with a collected amount and its fee, the invoice is read from
+// the "Invoice <invoiceId>" description of the payment link. Summary rows without an amount are skipped
+func ParseSettlementRows(rows [][]string) ([]response.SettlementLine, error) {
+	var headerIndex int = -1
+	var referenceIndex, descriptionIndex, amountIndex, feeIndex int
+	for i, row := range rows {
+		descriptionIndex = utils.FindColumnIndex(row, descriptionColumns...)
+		amountIndex = utils.FindColumnIndex(row, amountColumns...)
+		feeIndex = utils.FindColumnIndex(row, feeColumns...)
+		if descriptionIndex >= 0 && amountIndex >= 0 && feeIndex >= 0 {
+			headerIndex = i
+			referenceIndex = utils.FindColumnIndex(row, referenceColumns...)
+			break
+		}
+	}
+
+	if headerIndex < 0 {
+		return nil, errors.New(noti.INVALID_FILE_CONTENT_WARN_MSG)
+	}
+
+	var res []response.SettlementLine
+	for _, row := range rows[headerIndex+1:] {
+		amount, isAmount := utils.ParseMoney(utils.GetCellValue(row, amountIndex))
+		if !isAmount || amount <= 0 {
+			continue
+		}
+
+		fee, isFee := utils.ParseMoney(utils.GetCellValue(row, feeIndex))
+		if !isFee || fee < 0 {
+			fee = 0
+		}
+
+		var line response.SettlementLine = response.SettlementLine{
+			Reference:   utils.GetCellValue(row, referenceIndex),
+			Description: utils.GetCellValue(row, descriptionIndex),
+			Amount:      amount,
+			FeeAmount:   fee,
+		}
+
+		// A payment link pays a single invoice
+		if invoiceIds := utils.GetNoteInvoiceIds(line.Description); len(invoiceIds) == 1 {
+			line.InvoiceId = invoiceIds[0]
+		}
+
+		res = append(res, line)
+	}
+
+	return res, nil
+}
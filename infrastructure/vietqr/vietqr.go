@@ -0,0 +1,129 @@
+package vietqr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"tourmate/payment-service/constant/bank"
+	payment_env "tourmate/payment-service/constant/env/payment"
+	"tourmate/payment-service/constant/vietqr"
+	"tourmate/payment-service/utils"
+	"unicode/utf8"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Bank identification numbers of the supported bank codes
+var bankBins map[string]string = map[string]string{
+	bank.VIETCOMBANK: bank.VIETCOMBANK_BIN,
+	bank.TECHCOMBANK: bank.TECHCOMBANK_BIN,
+	bank.BIDV:        bank.BIDV_BIN,
+	bank.VIETINBANK:  bank.VIETINBANK_BIN,
+	bank.AGRIBANK:    bank.AGRIBANK_BIN,
+	bank.ACB:         bank.ACB_BIN,
+	bank.MB_BANK:     bank.MB_BANK_BIN,
+	bank.VP_BANK:     bank.VP_BANK_BIN,
+	bank.TP_BANK:     bank.TP_BANK_BIN,
+	bank.SACOMBANK:   bank.SACOMBANK_BIN,
+	bank.SHB:         bank.SHB_BIN,
+	bank.HD_BANK:     bank.HD_BANK_BIN,
+	bank.VIB:         bank.VIB_BIN,
+	bank.OCB:         bank.OCB_BIN,
+	bank.MSB:         bank.MSB_BIN,
+}
+
+// Platform bank account receiving the transfers
+type Account struct {
+	BankCode    string
+	BankBin     string
+	AccountNo   string
+	AccountName string
+}
+
+func GetAccount() (Account, error) {
+	var res Account = Account{
+		BankCode:    strings.ToUpper(strings.TrimSpace(os.Getenv(payment_env.VIETQR_BANK_CODE))),
+		AccountNo:   strings.TrimSpace(os.Getenv(payment_env.VIETQR_ACCOUNT_NO)),
+		AccountName: strings.TrimSpace(os.Getenv(payment_env.VIETQR_ACCOUNT_NAME)),
+	}
+
+	bin, isExisted := bankBins[res.BankCode]
+	if !isExisted {
+		return Account{}, fmt.Errorf("unsupported bank code %q", res.BankCode)
+	}
+
+	if res.AccountNo == "" {
+		return Account{}, errors.New("account number is not configured")
+	}
+
+	res.BankBin = bin
+	return res, nil
+}
+
+// Generate the EMVCo payload of a transfer of the amount to the account, the note is what the bank statement shows
+func GeneratePayload(account Account, amount float64, note string) string {
+	note = toAsciiNote(note)
+	if len(note) > vietqr.MAX_PURPOSE_LENGTH {
+		note = note[:vietqr.MAX_PURPOSE_LENGTH]
+	}
+
+	var beneficiary string = generateField(vietqr.BENEFICIARY_BIN_ID, account.BankBin) +
+		generateField(vietqr.BENEFICIARY_ACCT_ID, account.AccountNo)
+
+	var merchantAccount string = generateField(vietqr.GUID_ID, vietqr.NAPAS_GUID) +
+		generateField(vietqr.BENEFICIARY_ID, beneficiary) +
+		generateField(vietqr.SERVICE_CODE_ID, vietqr.ACCOUNT_TRANSFER_SERVICE)
+
+	var res string = generateField(vietqr.PAYLOAD_FORMAT_INDICATOR_ID, vietqr.PAYLOAD_FORMAT_INDICATOR) +
+		generateField(vietqr.POINT_OF_INITIATION_ID, vietqr.DYNAMIC_QR) +
+		generateField(vietqr.MERCHANT_ACCOUNT_ID, merchantAccount) +
+		generateField(vietqr.CURRENCY_ID, vietqr.VND_CURRENCY_CODE) +
+		generateField(vietqr.AMOUNT_ID, fmt.Sprintf("%.0f", math.Round(amount))) +
+		generateField(vietqr.COUNTRY_CODE_ID, vietqr.COUNTRY_CODE) +
+		generateField(vietqr.ADDITIONAL_DATA_ID, generateField(vietqr.PURPOSE_OF_TRANSACTION_ID, note))
+
+	// The checksum covers the payload including the id and length of the checksum field
+	res += vietqr.CRC_ID + "04"
+	return res + fmt.Sprintf("%04X", calculateCrc(res))
+}
+
+// Render the payload as a PNG QR code
+func GeneratePng(payload string) ([]byte, error) {
+	return qrcode.Encode(payload, qrcode.Medium, vietqr.IMAGE_SIZE)
+}
+
+// Field of id, 2-digit length and value, the length counts characters
+func generateField(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, utf8.RuneCountInString(value), value)
+}
+
+// Banking apps only read ASCII notes, so the diacritics are removed and other characters are dropped
+func toAsciiNote(note string) string {
+	var builder strings.Builder
+	for _, r := range utils.RemoveVietnameseAccents(note) {
+		if r >= ' ' && r <= '~' {
+			builder.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// CRC-16/CCITT-FALSE
+func calculateCrc(data string) uint16 {
+	var res uint16 = 0xFFFF
+	for i := 0; i < len(data); i++ {
+		res ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if res&0x8000 != 0 {
+				res = res<<1 ^ 0x1021
+			} else {
+				res <<= 1
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,13 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+type IAccountingService interface {
+	GetAccountMappings(ctx context.Context) []response.AccountMappingResponse
+	// Export journal rows of payments, commissions, guide payables, refunds, gateway fees and payouts in the date range
+	ExportJournal(req request.ExportJournalRequest, ctx context.Context) (response.FileResponse, error)
+}
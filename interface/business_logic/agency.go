@@ -0,0 +1,24 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+type IAgencyService interface {
+	GetAgencies(ctx context.Context) ([]response.AgencyResponse, error)
+	CreateAgency(req request.UpsertAgencyRequest, ctx context.Context) (*response.AgencyResponse, error)
+	UpdateAgency(req request.UpsertAgencyRequest, ctx context.Context) (*response.AgencyResponse, error)
+	// Totals of the agency share with a breakdown by tour guide
+	GetAgencyReport(req request.GetAgencyReportRequest, ctx context.Context) (*response.AgencyReportResponse, error)
+	GetAgencyRevenues(req request.GetAgencyRevenuesRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	// Pay all unpaid revenues of the agency in a single bank transfer
+	CreateAgencyPayout(req request.CreateAgencyPayoutRequest, ctx context.Context) (*response.AgencyPayoutResponse, error)
+	GetAgencyPayouts(req request.GetAgencyPayoutsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	GetAgencyPayout(id int, ctx context.Context) (*response.AgencyPayoutResponse, error)
+	// Confirm that the transfer was made, the confirming admin must be different from the creator
+	ConfirmAgencyPayout(req request.ProcessAgencyPayoutRequest, ctx context.Context) (*response.AgencyPayoutResponse, error)
+	// Cancel a pending payout, its revenues are paid with the next payout
+	CancelAgencyPayout(req request.ProcessAgencyPayoutRequest, ctx context.Context) (*response.AgencyPayoutResponse, error)
+}
@@ -0,0 +1,15 @@
+package businesslogic
+
+import (
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+type IBankTransferFile interface {
+	// Header and rows of the bulk-transfer file accepted by the bank
+	GenerateTransferRows(items []request.BankTransferItem) ([]string, [][]string)
+	// Transfer results from the rows of the result file returned by the bank
+	ParseResultRows(rows [][]string) ([]response.BankTransferResult, error)
+	// Incoming transactions from the rows of an account statement exported from the bank
+	ParseStatementRows(rows [][]string) ([]response.BankStatementTransaction, error)
+}
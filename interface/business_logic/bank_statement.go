@@ -0,0 +1,24 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IBankStatementService interface {
+	// Register an invoice which the customer pays by a direct bank transfer, a pending one is updated
+	RegisterPendingInvoice(req request.RegisterPendingInvoiceRequest, ctx context.Context) (*entity.PendingInvoice, error)
+	CancelPendingInvoice(id int, ctx context.Context) error
+	// Register the invoice for a VietQR transfer to the platform account and render the QR code
+	CreateVietQrTransaction(req request.RegisterPendingInvoiceRequest, ctx context.Context) (*response.VietQrResponse, error)
+	GetVietQrImage(pendingInvoiceId int, ctx context.Context) (response.FileResponse, error)
+	// Pay a pending invoice when an accountant has seen the transfer without a statement import
+	ConfirmPendingInvoice(req request.ConfirmPendingInvoiceRequest, ctx context.Context) (*entity.Payment, error)
+	// Match incoming transactions to pending invoices, confident matches are paid and the others wait for review
+	ImportBankStatement(req request.ImportBankStatementRequest, ctx context.Context) (*response.BankStatementImportResponse, error)
+	GetBankTransactions(req request.GetBankTransactionsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	AcceptBankTransaction(req request.AcceptBankTransactionRequest, ctx context.Context) (*entity.BankTransaction, error)
+	RejectBankTransaction(req request.RejectBankTransactionRequest, ctx context.Context) (*entity.BankTransaction, error)
+}
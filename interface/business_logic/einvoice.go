@@ -0,0 +1,19 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IEInvoiceService interface {
+	GetEInvoice(id int, ctx context.Context) (*entity.EInvoice, error)
+	// Original invoice of the payment followed by its adjustment and replacement invoices
+	GetPaymentEInvoices(paymentId int, ctx context.Context) (*[]entity.EInvoice, error)
+	IssueEInvoice(req request.IssueEInvoiceRequest, ctx context.Context) (*entity.EInvoice, error)
+	AdjustEInvoice(req request.AdjustEInvoiceRequest, ctx context.Context) (*entity.EInvoice, error)
+	ReplaceEInvoice(req request.ReplaceEInvoiceRequest, ctx context.Context) (*entity.EInvoice, error)
+	// Render the invoice as the Decree 123 XML, HTML or PDF
+	ExportEInvoice(req request.ExportEInvoiceRequest, ctx context.Context) (response.FileResponse, error)
+}
@@ -0,0 +1,15 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IFiscalPeriodService interface {
+	// Every month of the year, months which have never been closed are returned as open
+	GetFiscalPeriods(req request.GetFiscalPeriodsRequest, ctx context.Context) ([]entity.FiscalPeriod, error)
+	GetFiscalPeriodAudits(id int, ctx context.Context) (*[]entity.FiscalPeriodAudit, error)
+	CloseFiscalPeriod(req request.FiscalPeriodActionRequest, ctx context.Context) error
+	ReopenFiscalPeriod(req request.FiscalPeriodActionRequest, ctx context.Context) error
+}
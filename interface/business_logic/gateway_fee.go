@@ -0,0 +1,21 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IGatewayFeeService interface {
+	GetPaymentMethodFees(ctx context.Context) (*[]entity.PaymentMethodFee, error)
+	// Configure the gateway fee and the customer surcharge of a payment method, later payments are charged with it
+	UpsertPaymentMethodFee(req request.UpsertPaymentMethodFeeRequest, ctx context.Context) (*entity.PaymentMethodFee, error)
+	GetPaymentFees(req request.GetPaymentFeesRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	// Gross collected, surcharges, gateway fees and net settled amounts per payment method
+	GetGatewayFeeReport(req request.GetGatewayFeeReportRequest, ctx context.Context) (*response.GatewayFeeReportResponse, error)
+	// Surcharge the customer pays on top of the price with the payment method
+	GetSurcharge(req request.GetSurchargeRequest, ctx context.Context) (*response.SurchargeResponse, error)
+	// Replace the estimated fees of the payments in a gateway settlement report by the settled ones
+	ImportSettlement(req request.ImportSettlementRequest, ctx context.Context) (*response.SettlementImportResponse, error)
+}
@@ -0,0 +1,23 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IGiftCardService interface {
+	GetGiftCards(req request.GetGiftCardsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	GetGiftCardByCode(code string, ctx context.Context) (*entity.GiftCard, error)
+	PurchaseGiftCard(req request.PurchaseGiftCardRequest, ctx context.Context) (*entity.GiftCard, error)
+	// Check the PayOS link of the purchase, a paid card is issued once and its code is emailed to the recipient
+	ConfirmGiftCardPurchase(id int, ctx context.Context) (*entity.GiftCard, error)
+	// Move gift card balance to the wallet of the customer
+	RedeemGiftCard(req request.RedeemGiftCardRequest, ctx context.Context) (*response.GiftCardRedemptionResponse, error)
+	// Pay an invoice with a gift card, the part above the card balance is paid through PayOS
+	PayWithGiftCard(req request.PayWithGiftCardRequest, ctx context.Context) (*response.GiftCardPaymentResponse, error)
+	GetGiftCardLiability(ctx context.Context) (*response.GiftCardLiabilityResponse, error)
+	// Forfeit the balance of cards past their expiry, the number of expired cards is returned
+	ExpireGiftCards(ctx context.Context) (int, error)
+}
@@ -0,0 +1,16 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+type ILedgerService interface {
+	GetAccountBalances(ctx context.Context) ([]response.LedgerBalanceResponse, error)
+	GetAccountBalance(req request.GetLedgerAccountRequest, ctx context.Context) (*response.LedgerBalanceResponse, error)
+	GetAccountStatement(req request.GetLedgerAccountRequest, ctx context.Context) (*response.LedgerStatementResponse, error)
+	// Verify that debits equal credits for the whole ledger and for every entry
+	CheckInvariants(ctx context.Context) (*response.LedgerInvariantResponse, error)
+	CreateAdjustment(req request.CreateLedgerAdjustmentRequest, ctx context.Context) (int, error)
+}
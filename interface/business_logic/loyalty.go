@@ -0,0 +1,18 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type ILoyaltyService interface {
+	GetLoyaltyBalance(customerId int, ctx context.Context) (*response.LoyaltyBalanceResponse, error)
+	GetLoyaltyTransactions(req request.GetLoyaltyTransactionsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	GetLoyaltyRules(ctx context.Context) (*[]entity.LoyaltyRule, error)
+	CreateLoyaltyRule(req request.UpsertLoyaltyRuleRequest, ctx context.Context) (*entity.LoyaltyRule, error)
+	UpdateLoyaltyRule(req request.UpsertLoyaltyRuleRequest, ctx context.Context) (*entity.LoyaltyRule, error)
+	// Take the remaining points of credits past their expiry, the number of expired points is returned
+	ExpireLoyaltyPoints(ctx context.Context) (int, error)
+}
@@ -0,0 +1,18 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IOfflinePaymentService interface {
+	// Record cash or a bank transfer collected outside the gateways, the payment stays PENDING until it is confirmed
+	CreateOfflinePayment(req request.CreateOfflinePaymentRequest, ctx context.Context) (*entity.OfflinePayment, error)
+	GetOfflinePayments(req request.GetOfflinePaymentsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	GetOfflinePaymentProof(id int, ctx context.Context) (response.FileResponse, error)
+	// Move the payment to PAID and create the revenue, payments above the approval amount need a second admin
+	ConfirmOfflinePayment(req request.ConfirmOfflinePaymentRequest, ctx context.Context) (*entity.OfflinePayment, error)
+	RejectOfflinePayment(req request.RejectOfflinePaymentRequest, ctx context.Context) (*entity.OfflinePayment, error)
+}
@@ -12,9 +12,17 @@ type IPaymentService interface {
 	GetPayments(req request.GetPaymentsRequest, ctx context.Context) (response.PaginationDataResponse, error)
 	GetPaymentById(id int, ctx context.Context) (*entity.Payment, error)
 	GetPaymentWithService(id int, ctx context.Context) (*response.PaymentWithServiceNameResponse, error)
+	// Lifetime spend, paid tours, average ticket, last payment and refunds of a customer with their payment history
+	GetCustomerPaymentSummary(req request.GetCustomerPaymentSummaryRequest, ctx context.Context) (*response.CustomerPaymentSummaryResponse, error)
 	UpdatePayment(req request.UpdatePaymentRequest, ctx context.Context) error
+	// Refund a paid payment, the revenue is reversed so that it is excluded from later payouts
+	RefundPayment(req request.RefundPaymentRequest, ctx context.Context) error
 	CreatePayment(req request.CreatePaymentRequest, ctx context.Context) (*entity.Payment, error)
-	CreatePayosTransaction(req request.CreatePayosTransactionRequest, ctx context.Context) (response.UrlResponse, error)
+	CreatePayosTransaction(req request.CreatePayosTransactionRequest, ctx context.Context) (response.PayosTransactionResponse, error)
+	// Check the payment link of a split payment, a paid link records the held part as paid, a cancelled or expired one gives it back
+	ConfirmSplitPayment(id int, ctx context.Context) (*entity.SplitPayment, error)
+	// Give back the held part of split payments whose link was cancelled or expired, the number of released ones is returned
+	ReleaseExpiredSplitPayments(ctx context.Context) (int, error)
 	// Callback function
 	// CallbackPaymentSuccess(component response.PaymentCallbackComponent, ctx context.Context) (string, error)
 	// CallbackPaymentCancel(component response.PaymentCallbackComponent, ctx context.Context) (string, error)
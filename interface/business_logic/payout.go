@@ -0,0 +1,22 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IPayoutService interface {
+	GetPayoutBatches(req request.GetPayoutBatchesRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	GetPayoutBatch(id int, ctx context.Context) (*response.PayoutBatchResponse, error)
+	CreatePayoutBatch(req request.CreatePayoutBatchRequest, ctx context.Context) (*response.PayoutBatchResponse, error)
+	ApprovePayoutBatch(req request.PayoutBatchActionRequest, ctx context.Context) error
+	CancelPayoutBatch(req request.PayoutBatchActionRequest, ctx context.Context) error
+	ExportPayoutBatch(req request.ExportPayoutBatchRequest, ctx context.Context) (response.FileResponse, error)
+	ImportPayoutResult(req request.ImportPayoutResultRequest, ctx context.Context) (*response.PayoutImportResponse, error)
+	// Create a draft batch when the policy schedule is due, nil when nothing has been created
+	CreateScheduledPayoutBatch(ctx context.Context) (*response.PayoutBatchResponse, error)
+	GetPayoutPolicy(ctx context.Context) (*entity.PayoutPolicy, error)
+	UpdatePayoutPolicy(req request.UpdatePayoutPolicyRequest, ctx context.Context) (*entity.PayoutPolicy, error)
+}
@@ -0,0 +1,17 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+type IPayoutAccountService interface {
+	GetPayoutAccounts(req request.GetPayoutAccountsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	GetPayoutAccountsByTourGuide(tourGuideId int, ctx context.Context) (*[]response.PayoutAccountResponse, error)
+	CreatePayoutAccount(req request.CreatePayoutAccountRequest, ctx context.Context) (*response.PayoutAccountResponse, error)
+	UpdatePayoutAccount(req request.UpdatePayoutAccountRequest, ctx context.Context) (*response.PayoutAccountResponse, error)
+	RemovePayoutAccount(req request.RemovePayoutAccountRequest, ctx context.Context) error
+	VerifyPayoutAccount(req request.VerifyPayoutAccountRequest, ctx context.Context) error
+	RejectPayoutAccount(req request.VerifyPayoutAccountRequest, ctx context.Context) error
+}
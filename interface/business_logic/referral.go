@@ -0,0 +1,18 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IReferralService interface {
+	// Get the referral code of the owner, it is created on first use
+	GetReferralCode(req request.GetReferralCodeRequest, ctx context.Context) (*entity.ReferralCode, error)
+	UpdateReferralCodeStatus(req request.UpdateReferralCodeStatusRequest, ctx context.Context) (*entity.ReferralCode, error)
+	// Link the referee to the owner of the code, the referral is attributed at the first paid tour of the referee
+	ClaimReferralCode(req request.ClaimReferralCodeRequest, ctx context.Context) (*entity.Referral, error)
+	GetReferralSummary(req request.GetReferralCodeRequest, ctx context.Context) (*response.ReferralSummaryResponse, error)
+	GetReferralRewards(req request.GetReferralRewardsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+}
@@ -12,8 +12,22 @@ type IRevenueService interface {
 	GetMonthlyRevenue(req request.GetMonthlyRevenueRequest, ctx context.Context) (*response.MonthlyRevenueResponse, error)
 	GetRevenueStats(req request.GetMonthlyRevenueRequest, ctx context.Context) (*response.RevenueStatusResponse, error)
 	GetGrowthPercentage(req request.GetMonthlyRevenueRequest, ctx context.Context) (response.RevenueGrowthPercentageResponse, error)
+	// Totals per day, week, month, quarter or year with period-over-period and year-over-year growth
+	GetRevenueSeries(req request.GetRevenueSeriesRequest, ctx context.Context) (*response.RevenueSeriesResponse, error)
+	// Amount eligible for the next payout and its date according to the payout policy
+	GetPayoutSummary(tourGuideId int, ctx context.Context) (*response.PayoutSummaryResponse, error)
+	// Platform-wide totals of all tour guides, settled amounts have been paid out to the guides
+	GetPlatformRevenueSummary(req request.GetPlatformRevenueRequest, ctx context.Context) (*response.PlatformRevenueSummaryResponse, error)
+	GetTopTourGuideRevenues(req request.GetPlatformRevenueRequest, ctx context.Context) ([]response.TourGuideRevenueResponse, error)
+	GetServiceRevenues(req request.GetPlatformRevenueRequest, ctx context.Context) ([]response.ServiceRevenueResponse, error)
+	GetAreaRevenues(req request.GetPlatformRevenueRequest, ctx context.Context) ([]response.AreaRevenueResponse, error)
+	// Printable statement of a month or a year with revenues, payouts and the closing balance
+	GetEarningsStatement(req request.GetEarningsStatementRequest, ctx context.Context) (response.FileResponse, error)
 	GetRevenue(id int, ctx context.Context) (*entity.Revenue, error)
 	CreateRevenue(req request.CreateRevenueRequest, ctx context.Context) (*response.RevenueResponse, error)
+	// Record a correction of the amounts as an adjustment, the original revenue is kept
 	UpdateRevenue(req request.UpdateRevenueRequest, ctx context.Context) (*response.RevenueResponse, error)
-	RemoveRevenue(id int, ctx context.Context) error
+	// Reverse the revenue with an adjustment instead of deleting it
+	RemoveRevenue(req request.RemoveRevenueRequest, ctx context.Context) error
+	GetRevenueAdjustments(id int, ctx context.Context) (*[]entity.RevenueAdjustment, error)
 }
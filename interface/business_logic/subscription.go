@@ -0,0 +1,26 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type ISubscriptionService interface {
+	GetSubscriptionPlans(ctx context.Context) (*[]entity.SubscriptionPlan, error)
+	CreateSubscriptionPlan(req request.UpsertSubscriptionPlanRequest, ctx context.Context) (*entity.SubscriptionPlan, error)
+	UpdateSubscriptionPlan(req request.UpsertSubscriptionPlanRequest, ctx context.Context) (*entity.SubscriptionPlan, error)
+	// Subscribe a tour guide to a plan, the invoice of the first cycle is emailed with its PayOS link
+	CreateSubscription(req request.CreateSubscriptionRequest, ctx context.Context) (*response.SubscriptionResponse, error)
+	GetSubscriptions(req request.GetSubscriptionsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	// Get the subscription of the tour guide which has not ended yet with its plan and unpaid invoice
+	GetTourGuideSubscription(tourGuideId int, ctx context.Context) (*response.SubscriptionResponse, error)
+	CancelSubscription(req request.CancelSubscriptionRequest, ctx context.Context) (*response.SubscriptionResponse, error)
+	GetSubscriptionInvoices(req request.GetSubscriptionInvoicesRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	// Check the PayOS link of the invoice, a paid invoice starts or renews its subscription once
+	ConfirmSubscriptionInvoice(id int, ctx context.Context) (*entity.SubscriptionInvoice, error)
+	GetFeaturedTourGuideIds(ctx context.Context) ([]int, error)
+	// Invoice renewals, collect paid invoices, remind unpaid ones and downgrade subscriptions past their grace period
+	ProcessSubscriptions(ctx context.Context) (*response.SubscriptionBillingResponse, error)
+}
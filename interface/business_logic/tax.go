@@ -0,0 +1,17 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+)
+
+type ITaxService interface {
+	GetTaxProfile(tourGuideId int, ctx context.Context) (*response.TaxProfileResponse, error)
+	SaveTaxProfile(req request.SaveTaxProfileRequest, ctx context.Context) (*response.TaxProfileResponse, error)
+	// Tax withheld from paid payouts, it is the detail of the TAX_PAYABLE ledger account
+	GetTaxWithholdings(req request.GetTaxWithholdingsRequest, ctx context.Context) (*response.TaxWithholdingLedgerResponse, error)
+	GetTaxCertificate(req request.GetTaxCertificateRequest, ctx context.Context) (*response.TaxCertificateResponse, error)
+	// Income paid to individuals and tax withheld per tour guide for the tax declaration
+	ExportTaxDeclaration(req request.ExportTaxDeclarationRequest, ctx context.Context) (response.FileResponse, error)
+}
@@ -0,0 +1,20 @@
+package businesslogic
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/dto/response"
+	"tourmate/payment-service/model/entity"
+)
+
+type IWalletService interface {
+	// A customer without a wallet has a zero balance
+	GetWallet(customerId int, ctx context.Context) (*entity.Wallet, error)
+	GetWalletTransactions(req request.GetWalletTransactionsRequest, ctx context.Context) (response.PaginationDataResponse, error)
+	CreateWalletTopUp(req request.CreateWalletTopUpRequest, ctx context.Context) (*entity.WalletTopUp, error)
+	// Check the PayOS link of the top-up and credit the wallet once it is paid
+	ConfirmWalletTopUp(id int, ctx context.Context) (*entity.WalletTopUp, error)
+	// Pay an invoice from the wallet, fully or partly with the rest paid through PayOS
+	PayWithWallet(req request.PayWithWalletRequest, ctx context.Context) (*response.WalletPaymentResponse, error)
+	AdjustWallet(req request.AdjustWalletRequest, ctx context.Context) (*entity.WalletTransaction, error)
+}
@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IAgencyRepo interface {
+	GetAgencies(ctx context.Context) (*[]entity.Agency, error)
+	GetAgencyById(id int, ctx context.Context) (*entity.Agency, error)
+	GetAgencyByName(name string, ctx context.Context) (*entity.Agency, error)
+	CreateAgency(agency entity.Agency, ctx context.Context) (int, error)
+	UpdateAgency(agency entity.Agency, ctx context.Context) error
+	CreateAgencyRevenue(revenue entity.AgencyRevenue, ctx context.Context) (int, error)
+	// Get the share of the payment with its reversals
+	GetAgencyRevenuesByPaymentId(paymentId int, ctx context.Context) (*[]entity.AgencyRevenue, error)
+	// Revenues in [from, to), a missing bound is not applied
+	GetAgencyRevenues(req request.GetAgencyRevenuesRequest, from, to *time.Time, ctx context.Context) (*[]entity.AgencyRevenue, int, int, error)
+	GetAgencyRevenueSummary(agencyId int, from, to *time.Time, ctx context.Context) (*entity.AgencyRevenueSummary, error)
+	GetAgencyGuideRevenues(agencyId int, from, to *time.Time, ctx context.Context) (*[]entity.AgencyGuideRevenue, error)
+	// Revenues which are not in a pending or paid payout
+	GetPayableAgencyRevenues(agencyId int, ctx context.Context) (*[]entity.AgencyRevenue, error)
+	// Create the payout and link the revenues to it, it fails when a revenue is already in another payout
+	CreateAgencyPayout(payout entity.AgencyPayout, revenueIds []int, ctx context.Context) (int, error)
+	GetAgencyPayoutById(id int, ctx context.Context) (*entity.AgencyPayout, error)
+	GetAgencyPayouts(req request.GetAgencyPayoutsRequest, ctx context.Context) (*[]entity.AgencyPayout, int, int, error)
+	GetAgencyRevenueIdsByPayoutId(payoutId int, ctx context.Context) ([]int, error)
+	// Update the payout only if it is still in the current status, the revenues of a cancelled payout are released
+	UpdateAgencyPayout(payout entity.AgencyPayout, currentStatus string, ctx context.Context) error
+}
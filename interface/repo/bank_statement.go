@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IBankStatementRepo interface {
+	GetPendingInvoiceById(id int, ctx context.Context) (*entity.PendingInvoice, error)
+	// Latest pending invoice registered for the invoice, whatever its status
+	GetPendingInvoiceByInvoiceId(invoiceId int, ctx context.Context) (*entity.PendingInvoice, error)
+	CreatePendingInvoice(invoice entity.PendingInvoice, ctx context.Context) (int, error)
+	// Update only when the invoice still has the given status so that an invoice cannot be paid twice
+	UpdatePendingInvoice(invoice entity.PendingInvoice, currentStatus string, ctx context.Context) error
+	// A transaction is identified by its bank reference, or by date, amount and description when the bank gives none
+	IsBankTransactionImported(transaction entity.BankTransaction, ctx context.Context) (bool, error)
+	CreateBankTransaction(transaction entity.BankTransaction, ctx context.Context) (int, error)
+	GetBankTransactions(req request.GetBankTransactionsRequest, ctx context.Context) (*[]entity.BankTransaction, int, int, error)
+	GetBankTransactionById(id int, ctx context.Context) (*entity.BankTransaction, error)
+	// Update only when the transaction still has the given status so that a review is decided once
+	UpdateBankTransaction(transaction entity.BankTransaction, currentStatus string, ctx context.Context) error
+}
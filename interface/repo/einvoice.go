@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/entity"
+)
+
+type IEInvoiceRepo interface {
+	GetEInvoiceById(id int, ctx context.Context) (*entity.EInvoice, error)
+	GetEInvoicesByPaymentId(paymentId int, ctx context.Context) (*[]entity.EInvoice, error)
+	// Take the next number of the invoice template and series then create the invoice in a single transaction,
+	// the related invoice is moved to relatedStatus when the new invoice adjusts or replaces it
+	CreateEInvoice(invoice entity.EInvoice, relatedStatus string, ctx context.Context) (*entity.EInvoice, error)
+}
@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/entity"
+)
+
+type IFiscalPeriodRepo interface {
+	GetFiscalPeriodsByYear(year int, ctx context.Context) (*[]entity.FiscalPeriod, error)
+	GetFiscalPeriod(year, month int, ctx context.Context) (*entity.FiscalPeriod, error)
+	GetFiscalPeriodById(id int, ctx context.Context) (*entity.FiscalPeriod, error)
+	// Create or update the period together with its audit record
+	SaveFiscalPeriod(period entity.FiscalPeriod, audit entity.FiscalPeriodAudit, ctx context.Context) (int, error)
+	GetFiscalPeriodAudits(periodId int, ctx context.Context) (*[]entity.FiscalPeriodAudit, error)
+}
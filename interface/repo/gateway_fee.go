@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IGatewayFeeRepo interface {
+	GetPaymentMethodFees(ctx context.Context) (*[]entity.PaymentMethodFee, error)
+	GetPaymentMethodFee(paymentMethod string, ctx context.Context) (*entity.PaymentMethodFee, error)
+	// Create the fee of the payment method or replace the existing one
+	UpsertPaymentMethodFee(fee entity.PaymentMethodFee, ctx context.Context) error
+	// Create the fee unless the payment already has one, a payment is charged a single time
+	CreatePaymentFee(fee entity.PaymentFee, ctx context.Context) (int, error)
+	GetPaymentFeeByPaymentId(paymentId int, ctx context.Context) (*entity.PaymentFee, error)
+	GetPaymentFeesByPaymentIds(paymentIds []int, ctx context.Context) (*[]entity.PaymentFee, error)
+	GetPaymentFees(req request.GetPaymentFeesRequest, ctx context.Context) (*[]entity.PaymentFee, int, int, error)
+	// Replace an estimated fee by the settled one, a fee is settled once
+	SettlePaymentFee(fee entity.PaymentFee, ctx context.Context) error
+	// Fees of the payments made in [from, to) grouped by payment method
+	GetPaymentFeeSummaries(from, to *time.Time, ctx context.Context) (*[]entity.PaymentFeeSummary, error)
+}
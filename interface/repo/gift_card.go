@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IGiftCardRepo interface {
+	GetGiftCards(req request.GetGiftCardsRequest, ctx context.Context) (*[]entity.GiftCard, int, int, error)
+	GetGiftCardById(id int, ctx context.Context) (*entity.GiftCard, error)
+	GetGiftCardByCode(code string, ctx context.Context) (*entity.GiftCard, error)
+	CreateGiftCard(card entity.GiftCard, ctx context.Context) (int, error)
+	// Update only when the card still has the given status so that a card is issued once
+	UpdateGiftCard(card entity.GiftCard, currentStatus string, ctx context.Context) error
+	// Apply the transaction amount to the balance and record the transaction in a single database transaction.
+	// Debits need an active card which has not expired and enough balance
+	ChangeGiftCardBalance(transaction entity.GiftCardTransaction, ctx context.Context) (*entity.GiftCardTransaction, error)
+	GetExpiredGiftCardIds(curTime time.Time, ctx context.Context) ([]int, error)
+	// Move an active card past its expiry to EXPIRED and record the forfeited balance, nil when it is not due
+	ExpireGiftCard(id int, curTime time.Time, ctx context.Context) (*entity.GiftCardTransaction, error)
+	GetGiftCardStatusSummaries(ctx context.Context) (*[]entity.GiftCardStatusSummary, error)
+	// Balance and count of active cards expiring before the given time
+	GetExpiringGiftCardBalance(curTime, expiringBefore time.Time, ctx context.Context) (float64, int, error)
+}
@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+// Source data of the accounting journal, every method returns records in [from, to) in a stable order
+type IJournalRepo interface {
+	GetJournalPayments(from, to time.Time, ctx context.Context) (*[]entity.JournalPayment, error)
+	GetJournalRefunds(from, to time.Time, ctx context.Context) (*[]entity.JournalRefund, error)
+	GetJournalAdjustments(from, to time.Time, ctx context.Context) (*[]entity.JournalAdjustment, error)
+	GetJournalPayouts(from, to time.Time, ctx context.Context) (*[]entity.JournalPayout, error)
+	GetJournalAgencyReversals(from, to time.Time, ctx context.Context) (*[]entity.JournalAgencyReversal, error)
+	GetJournalAgencyPayouts(from, to time.Time, ctx context.Context) (*[]entity.AgencyPayout, error)
+	GetJournalSubscriptionInvoices(from, to time.Time, ctx context.Context) (*[]entity.SubscriptionInvoice, error)
+}
@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+// Ledger is append-only, there is no update or remove method on purpose
+type ILedgerRepo interface {
+	// Create entry with its lines in a single transaction
+	CreateLedgerEntry(entry entity.LedgerEntry, lines []entity.LedgerLine, ctx context.Context) (int, error)
+	GetLedgerEntryByReference(entryType string, referenceId int, ctx context.Context) (*entity.LedgerEntry, error)
+	GetLedgerEntriesByIds(ids []int, ctx context.Context) (*[]entity.LedgerEntry, error)
+	// Response data: total debit, total credit, error. Owner and time are optional filters, time is exclusive
+	GetAccountTotals(account string, ownerId *int, before *time.Time, ctx context.Context) (float64, float64, error)
+	GetAccountLines(account string, ownerId *int, from, to time.Time, ctx context.Context) (*[]entity.LedgerLine, error)
+	// Response data: total debit, total credit, error
+	GetLedgerTotals(ctx context.Context) (float64, float64, error)
+	GetUnbalancedEntryIds(ctx context.Context) ([]int, error)
+}
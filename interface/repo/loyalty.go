@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type ILoyaltyRepo interface {
+	GetLoyaltyAccount(customerId int, ctx context.Context) (*entity.LoyaltyAccount, error)
+	GetLoyaltyTransactions(req request.GetLoyaltyTransactionsRequest, ctx context.Context) (*[]entity.LoyaltyTransaction, int, int, error)
+	// Latest transaction of the type with the reference
+	GetLoyaltyTransactionByReference(transactionType string, referenceId int, ctx context.Context) (*entity.LoyaltyTransaction, error)
+	// Apply the transaction points to the balance and record the transaction in a single database transaction.
+	// The account is created on the first credit. A debit takes the remaining points of the preferred credit first, then the
+	// oldest ones. A debit larger than the balance is refused unless partial debits are allowed, nil is returned when
+	// there is nothing to take
+	ChangeLoyaltyPoints(transaction entity.LoyaltyTransaction, preferredCreditId *int, allowPartial bool, ctx context.Context) (*entity.LoyaltyTransaction, error)
+	GetExpiredLoyaltyPoints(curTime time.Time, ctx context.Context) (*[]entity.ExpiredLoyaltyPoint, error)
+	// Take the remaining points of a credit past its expiry, nil when there is nothing left
+	ExpireLoyaltyPoints(creditId int, curTime time.Time, ctx context.Context) (*entity.LoyaltyTransaction, error)
+	// Remaining points of the customer expiring before the given time
+	GetExpiringLoyaltyPoints(customerId int, curTime, expiringBefore time.Time, ctx context.Context) (int, error)
+	GetLoyaltyRules(ctx context.Context) (*[]entity.LoyaltyRule, error)
+	GetLoyaltyRuleById(id int, ctx context.Context) (*entity.LoyaltyRule, error)
+	// Highest multiplier of the active rules applying to the service at the time, nil when no rule applies
+	GetLoyaltyMultiplier(serviceId int, curTime time.Time, ctx context.Context) (*float64, error)
+	CreateLoyaltyRule(rule entity.LoyaltyRule, ctx context.Context) (int, error)
+	UpdateLoyaltyRule(rule entity.LoyaltyRule, ctx context.Context) error
+}
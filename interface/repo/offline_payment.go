@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IOfflinePaymentRepo interface {
+	GetOfflinePayments(req request.GetOfflinePaymentsRequest, ctx context.Context) (*[]entity.OfflinePayment, int, int, error)
+	GetOfflinePaymentById(id int, ctx context.Context) (*entity.OfflinePayment, error)
+	CreateOfflinePayment(payment entity.OfflinePayment, ctx context.Context) (int, error)
+	// Update only when the payment still has the given status so that a payment is confirmed once
+	UpdateOfflinePayment(payment entity.OfflinePayment, currentStatus string, ctx context.Context) error
+	GetOfflinePaymentProof(offlinePaymentId int, ctx context.Context) (*entity.OfflinePaymentProof, error)
+	CreateOfflinePaymentProof(proof entity.OfflinePaymentProof, ctx context.Context) error
+}
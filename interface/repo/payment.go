@@ -9,7 +9,15 @@ import (
 type IPaymentRepo interface {
 	GetPayments(req request.GetPaymentsRequest, ctx context.Context) (*[]entity.Payment, int, int, error)
 	GetPaymentById(id int, ctx context.Context) (*entity.Payment, error)
+	// Latest PAID payment of the invoice, nil when the invoice has not been paid
+	GetPaidPaymentByInvoiceId(invoiceId int, ctx context.Context) (*entity.Payment, error)
+	// Spend, paid tours and refunds of the customer aggregated over all their payments
+	GetCustomerSpendingSummary(customerId int, ctx context.Context) (*entity.CustomerSpendingSummary, error)
+	GetCustomerPaymentHistory(req request.GetCustomerPaymentSummaryRequest, ctx context.Context) (*[]entity.Payment, int, int, error)
 	CreatePayment(payment entity.Payment, ctx context.Context) (*entity.Payment, error)
+	// Create the payment only when the invoice has no paid or pending payment, the check and the insert run under one lock
+	// so that two requests cannot both claim the invoice
+	ClaimInvoicePayment(payment entity.Payment, ctx context.Context) (*entity.Payment, error)
 	CreatePaymentWithScopeId(payment entity.Payment, ctx context.Context) (int, error)
 	UpdatePayment(payment entity.Payment, ctx context.Context) error
 }
@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IPayoutRepo interface {
+	// Response data: data, total pages, total records, error
+	GetPayoutBatches(req request.GetPayoutBatchesRequest, ctx context.Context) (*[]entity.PayoutBatch, int, int, error)
+	GetPayoutBatchById(id int, ctx context.Context) (*entity.PayoutBatch, error)
+	GetPayoutItems(batchId int, ctx context.Context) (*[]entity.PayoutItem, error)
+	// Payout items of the tour guide which were paid in [from, to)
+	GetPaidPayoutItems(tourGuideId int, from, to time.Time, ctx context.Context) (*[]entity.PayoutItem, error)
+	GetPayoutItemRevenueIds(itemId int, ctx context.Context) ([]int, error)
+	// Create batch with its items, revenueIds[i], adjustmentIds[i] and rewardIds[i] are the revenues, revenue adjustments and
+	// referral rewards settled by items[i] and withholdings[i] is the tax of items[i]
+	CreatePayoutBatch(batch entity.PayoutBatch, items []entity.PayoutItem, revenueIds, adjustmentIds, rewardIds [][]int, withholdings []entity.TaxWithholding, ctx context.Context) (int, error)
+	UpdatePayoutBatch(batch entity.PayoutBatch, ctx context.Context) error
+	UpdatePayoutItem(item entity.PayoutItem, ctx context.Context) error
+	// Save the bank result of a pending item, a paid item settles its revenues, revenue adjustments and referral rewards in the same transaction
+	ProcessPayoutItem(item entity.PayoutItem, ctx context.Context) error
+}
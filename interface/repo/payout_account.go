@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IPayoutAccountRepo interface {
+	// Response data: data, total pages, total records, error
+	GetPayoutAccounts(req request.GetPayoutAccountsRequest, ctx context.Context) (*[]entity.PayoutAccount, int, int, error)
+	GetPayoutAccountsByTourGuide(tourGuideId int, ctx context.Context) (*[]entity.PayoutAccount, error)
+	GetPayoutAccountById(id int, ctx context.Context) (*entity.PayoutAccount, error)
+	// Default verified account first, then the latest verified one
+	GetVerifiedPayoutAccount(tourGuideId int, ctx context.Context) (*entity.PayoutAccount, error)
+	CreatePayoutAccount(account entity.PayoutAccount, ctx context.Context) (int, error)
+	UpdatePayoutAccount(account entity.PayoutAccount, ctx context.Context) error
+	RemovePayoutAccount(id, tourGuideId int, ctx context.Context) error
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/entity"
+)
+
+type IPayoutPolicyRepo interface {
+	// Get the current policy, nil if it has never been configured
+	GetPayoutPolicy(ctx context.Context) (*entity.PayoutPolicy, error)
+	CreatePayoutPolicy(policy entity.PayoutPolicy, ctx context.Context) (int, error)
+	UpdatePayoutPolicy(policy entity.PayoutPolicy, ctx context.Context) error
+}
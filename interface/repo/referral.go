@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IReferralRepo interface {
+	GetReferralCodeById(id int, ctx context.Context) (*entity.ReferralCode, error)
+	GetReferralCodeByCode(code string, ctx context.Context) (*entity.ReferralCode, error)
+	GetReferralCodeByOwner(ownerType string, ownerId int, ctx context.Context) (*entity.ReferralCode, error)
+	CreateReferralCode(code entity.ReferralCode, ctx context.Context) (int, error)
+	UpdateReferralCode(code entity.ReferralCode, ctx context.Context) error
+	// Check that the party has never paid for nor led a paid tour
+	IsNewReferee(refereeType string, refereeId int, ctx context.Context) (bool, error)
+	// Create the referral unless the referee already has one or the code has reached the given number of referrals
+	CreateReferral(referral entity.Referral, maxReferrals int, ctx context.Context) (int, error)
+	GetReferralByReferee(refereeType string, refereeId int, ctx context.Context) (*entity.Referral, error)
+	GetReferralsByCodeId(codeId int, ctx context.Context) (*[]entity.Referral, error)
+	// Attribute a pending referral to the first payment of the referee, nothing happens when it is already attributed
+	AttributeReferral(id, paymentId int, attributedAt time.Time, ctx context.Context) error
+	// Record the reward and count its tour in a single database transaction. A new tour is not counted once the referral
+	// has rewarded maxTours tours and the amount is reduced to what is left of maxRewardAmount for the referrer.
+	// Nil when nothing is left to reward
+	CreateReferralReward(reward entity.ReferralReward, maxTours int, maxRewardAmount float64, ctx context.Context) (*entity.ReferralReward, error)
+	GetReferralRewards(req request.GetReferralRewardsRequest, ctx context.Context) (*[]entity.ReferralReward, int, int, error)
+	GetReferralRewardsByPaymentId(paymentId int, ctx context.Context) (*[]entity.ReferralReward, error)
+	// Update only when the reward still has the given status
+	UpdateReferralReward(reward entity.ReferralReward, currentStatus string, ctx context.Context) error
+	// Cancel a pending reward which is not held by a payout item waiting for the bank result or paid
+	CancelReferralReward(id int, ctx context.Context) error
+	// Sum of paid and pending rewards of the referrer
+	GetReferralRewardTotals(referrerType string, referrerId int, ctx context.Context) (float64, float64, error)
+	// Pending rewards of the tour guide created before the given time which are not held by any payout item
+	GetPayableReferralRewards(tourGuideId int, createdBefore time.Time, ctx context.Context) (*[]entity.ReferralReward, error)
+	GetPayableReferrerIds(createdBefore time.Time, ctx context.Context) ([]int, error)
+	GetReferralRewardIdsByPayoutItemId(itemId int, ctx context.Context) ([]int, error)
+}
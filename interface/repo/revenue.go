@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"time"
 	"tourmate/payment-service/model/dto/request"
 	"tourmate/payment-service/model/entity"
 
@@ -10,10 +11,25 @@ import (
 type IRevenueRepo interface {
 	GetRevenues(req request.GetRevenuesRequest, ctx context.Context) (*[]entity.Revenue, error)
 	GetRevenuesByMonth(tourGuideId, year, month int, ctx context.Context) (*[]entity.Revenue, error)
-	GetRevenueTotalAmountByMonth(tourGuideId, year, month int, ctx context.Context) (float64, error)
 	GetCountTotalRevenue(req request.GetRevenuesRequest, ctx context.Context) (int, error)
 	GetRevenue(id int, ctx context.Context) (*entity.Revenue, error)
+	GetRevenueByPaymentId(paymentId int, ctx context.Context) (*entity.Revenue, error)
 	CreateRevenue(revenue entity.Revenue, ctx context.Context) (int, error)
-	UpdateRevenue(revenue entity.Revenue, ctx context.Context) error
-	RemoveRevenue(id int, ctx context.Context) error
+	// Revenues of the tour guide created in [from, to) and the adjustments made in it, with the tour service of their payments
+	GetRevenueDetails(tourGuideId int, from, to time.Time, ctx context.Context) (*[]entity.RevenueDetail, error)
+	GetRevenueAdjustments(revenueId int, ctx context.Context) (*[]entity.RevenueAdjustment, error)
+	CreateRevenueAdjustment(adjustment entity.RevenueAdjustment, ctx context.Context) (int, error)
+	// Check whether the revenue is part of a payout item waiting for the bank result
+	IsRevenueHeldByPayout(id int, ctx context.Context) (bool, error)
+	// Unsettled revenues created before the given time which are not held by any payout item
+	GetPayableRevenues(tourGuideId int, createdBefore time.Time, ctx context.Context) (*[]entity.Revenue, error)
+	// Unsettled adjustments of the tour guide whose revenue was created before the given time and which are not held by any payout item
+	GetPayableRevenueAdjustments(tourGuideId int, createdBefore time.Time, ctx context.Context) (*[]entity.RevenueAdjustment, error)
+	GetPayableTourGuideIds(createdBefore time.Time, ctx context.Context) ([]int, error)
+	// Aggregates below count each adjustment in the period it was made, not in the period of its revenue
+	GetRevenueSummary(from, to time.Time, ctx context.Context) (*entity.RevenueSummary, error)
+	GetTopTourGuideRevenues(from, to time.Time, limit int, ctx context.Context) (*[]entity.RevenueGroup, error)
+	GetServiceRevenues(from, to time.Time, ctx context.Context) (*[]entity.RevenueGroup, error)
+	// Sum revenues and adjustments per bucket in [from, to), all tour guides when tourGuideId is nil
+	GetRevenueSeries(tourGuideId *int, granularity string, from, to time.Time, ctx context.Context) (*[]entity.RevenueBucket, error)
 }
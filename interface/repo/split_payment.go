@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/entity"
+)
+
+type ISplitPaymentRepo interface {
+	GetSplitPaymentById(id int, ctx context.Context) (*entity.SplitPayment, error)
+	// Split payment of the invoice still waiting for its payment link, nil when there is none
+	GetPendingSplitPaymentByInvoiceId(invoiceId int, ctx context.Context) (*entity.SplitPayment, error)
+	GetPendingSplitPayments(ctx context.Context) (*[]entity.SplitPayment, error)
+	CreateSplitPayment(payment entity.SplitPayment, ctx context.Context) (int, error)
+	// Update only when the split payment still has the given status so that the held part is settled once
+	UpdateSplitPayment(payment entity.SplitPayment, currentStatus string, ctx context.Context) error
+}
@@ -0,0 +1,36 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type ISubscriptionRepo interface {
+	GetSubscriptionPlans(ctx context.Context) (*[]entity.SubscriptionPlan, error)
+	GetSubscriptionPlanById(id int, ctx context.Context) (*entity.SubscriptionPlan, error)
+	CreateSubscriptionPlan(plan entity.SubscriptionPlan, ctx context.Context) (int, error)
+	UpdateSubscriptionPlan(plan entity.SubscriptionPlan, ctx context.Context) error
+	GetSubscriptionById(id int, ctx context.Context) (*entity.Subscription, error)
+	// Get the subscription of the tour guide which has not ended yet
+	GetOpenSubscription(tourGuideId int, ctx context.Context) (*entity.Subscription, error)
+	// Create the subscription with its first invoice unless the tour guide already has a subscription which has not ended
+	CreateSubscription(subscription entity.Subscription, invoice entity.SubscriptionInvoice, ctx context.Context) (int, int, error)
+	// Update the subscription only if it is still in the current status
+	UpdateSubscription(subscription entity.Subscription, currentStatus string, ctx context.Context) error
+	GetSubscriptions(req request.GetSubscriptionsRequest, ctx context.Context) (*[]entity.Subscription, int, int, error)
+	// Active subscriptions whose current period ends before the given time
+	GetRenewableSubscriptions(before time.Time, ctx context.Context) (*[]entity.Subscription, error)
+	// Create the invoice of a cycle, it fails when the cycle is already invoiced
+	CreateSubscriptionInvoice(invoice entity.SubscriptionInvoice, ctx context.Context) (int, error)
+	GetSubscriptionInvoiceById(id int, ctx context.Context) (*entity.SubscriptionInvoice, error)
+	GetPendingSubscriptionInvoice(subscriptionId int, ctx context.Context) (*entity.SubscriptionInvoice, error)
+	GetPendingSubscriptionInvoices(ctx context.Context) (*[]entity.SubscriptionInvoice, error)
+	GetSubscriptionInvoices(req request.GetSubscriptionInvoicesRequest, ctx context.Context) (*[]entity.SubscriptionInvoice, int, int, error)
+	// Update the invoice only if it is still in the current status
+	UpdateSubscriptionInvoice(invoice entity.SubscriptionInvoice, currentStatus string, ctx context.Context) error
+	// Get the plan of the tour guide while the subscription is active or in its grace period
+	GetActiveSubscriptionPlan(tourGuideId int, ctx context.Context) (*entity.SubscriptionPlan, error)
+	GetFeaturedTourGuideIds(ctx context.Context) ([]int, error)
+}
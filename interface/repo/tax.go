@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+type ITaxRepo interface {
+	GetTaxProfile(tourGuideId int, ctx context.Context) (*entity.TaxProfile, error)
+	// Create the profile when its id is 0, otherwise update it
+	SaveTaxProfile(profile entity.TaxProfile, ctx context.Context) (int, error)
+	GetTaxWithholdingByPayoutItemId(payoutItemId int, ctx context.Context) (*entity.TaxWithholding, error)
+	GetTaxWithholdingsByPayoutBatchId(payoutBatchId int, ctx context.Context) (*[]entity.TaxWithholding, error)
+	// Withholdings of payout items paid in [from, to), all tour guides when tourGuideId is nil
+	GetPaidTaxWithholdings(tourGuideId *int, from, to time.Time, ctx context.Context) (*[]entity.PaidTaxWithholding, error)
+	// Income paid to individual taxpayers in [from, to) grouped by tour guide
+	GetIndividualIncomeSummaries(from, to time.Time, ctx context.Context) (*[]entity.TaxIncomeSummary, error)
+}
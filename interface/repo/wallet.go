@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type IWalletRepo interface {
+	GetWalletByCustomerId(customerId int, ctx context.Context) (*entity.Wallet, error)
+	// Apply the transaction amount to the balance and record the transaction in a single database transaction.
+	// The wallet is created on the first credit, a debit larger than the balance is refused
+	ChangeWalletBalance(transaction entity.WalletTransaction, ctx context.Context) (*entity.WalletTransaction, error)
+	// Latest transaction of the type with the reference
+	GetWalletTransactionByReference(transactionType string, referenceId int, ctx context.Context) (*entity.WalletTransaction, error)
+	GetWalletTransactions(req request.GetWalletTransactionsRequest, ctx context.Context) (*[]entity.WalletTransaction, int, int, error)
+	GetWalletTopUpById(id int, ctx context.Context) (*entity.WalletTopUp, error)
+	CreateWalletTopUp(topUp entity.WalletTopUp, ctx context.Context) (int, error)
+	// Update only when the top-up still has the given status so that a top-up is credited once
+	UpdateWalletTopUp(topUp entity.WalletTopUp, currentStatus string, ctx context.Context) error
+}
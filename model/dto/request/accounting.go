@@ -0,0 +1,9 @@
+package request
+
+import "time"
+
+type ExportJournalRequest struct {
+	From   time.Time `json:"from" form:"from" binding:"required" time_format:"2006-01-02"`
+	To     time.Time `json:"to" form:"to" binding:"required" time_format:"2006-01-02"`
+	Format string    `json:"format" form:"format" binding:"required,oneof=csv misa"`
+}
@@ -0,0 +1,52 @@
+package request
+
+import "time"
+
+// The bank account is kept when no account number is provided on update
+type UpsertAgencyRequest struct {
+	AgencyId       int     `json:"-"`
+	Name           string  `json:"name" binding:"required"`
+	CommissionRate float64 `json:"commissionRate" binding:"gte=0,lte=100"`
+	ShareRate      float64 `json:"shareRate" binding:"gte=0,lte=100"`
+	BankCode       string  `json:"bankCode" binding:"required"`
+	AccountNumber  string  `json:"accountNumber" binding:"omitempty,numeric,min=6,max=19"`
+	AccountHolder  string  `json:"accountHolder" binding:"required"`
+	IsActive       bool    `json:"isActive"`
+	ActorId        int     `json:"actorId" binding:"required,gt=0"`
+}
+
+// The whole history is reported when no dates are provided, the end date is inclusive
+type GetAgencyReportRequest struct {
+	AgencyId int        `json:"-"`
+	From     *time.Time `json:"from" form:"from" time_format:"2006-01-02"`
+	To       *time.Time `json:"to" form:"to" time_format:"2006-01-02"`
+}
+
+type GetAgencyRevenuesRequest struct {
+	Request     SearchPaginationRequest `json:"request"`
+	AgencyId    int                     `json:"-"`
+	TourGuideId int                     `json:"tourGuideId" form:"tourGuideId"`
+	From        *time.Time              `json:"from" form:"from" time_format:"2006-01-02"`
+	To          *time.Time              `json:"to" form:"to" time_format:"2006-01-02"`
+	PageSize    int
+}
+
+// All unpaid revenues of the agency are paid
+type CreateAgencyPayoutRequest struct {
+	AgencyId int    `json:"-"`
+	Note     string `json:"note"`
+	ActorId  int    `json:"actorId" binding:"required,gt=0"`
+}
+
+type GetAgencyPayoutsRequest struct {
+	Request  SearchPaginationRequest `json:"request"`
+	AgencyId int                     `json:"-"`
+	Status   string                  `json:"status" form:"status"`
+	PageSize int
+}
+
+type ProcessAgencyPayoutRequest struct {
+	AgencyPayoutId int    `json:"-"`
+	Note           string `json:"note"`
+	ActorId        int    `json:"actorId" binding:"required,gt=0"`
+}
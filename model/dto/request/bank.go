@@ -0,0 +1,11 @@
+package request
+
+// A single transfer line of a bank bulk-transfer file
+type BankTransferItem struct {
+	Reference     string
+	BankCode      string
+	AccountNumber string
+	AccountHolder string
+	Amount        float64
+	Note          string
+}
@@ -0,0 +1,42 @@
+package request
+
+type RegisterPendingInvoiceRequest struct {
+	InvoiceId   int     `json:"invoiceId" binding:"required,gt=0"`
+	CustomerId  int     `json:"customerId" binding:"required,gt=0"`
+	TourGuideId int     `json:"tourGuideId" binding:"required,gt=0"`
+	ServiceId   int     `json:"serviceId" binding:"required,gt=0"`
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+}
+
+type ConfirmPendingInvoiceRequest struct {
+	PendingInvoiceId int
+	ActorId          int    `json:"actorId" binding:"required,gt=0"`
+	Note             string `json:"note"`
+}
+
+type ImportBankStatementRequest struct {
+	Bank     string `form:"bank" binding:"required"`
+	ActorId  int    `form:"actorId" binding:"required,gt=0"`
+	FileName string
+	Content  []byte
+}
+
+type GetBankTransactionsRequest struct {
+	Request  SearchPaginationRequest `json:"request"`
+	Status   string                  `json:"status" form:"status"`
+	PageSize int
+}
+
+// Accepting may point the transaction to another pending invoice than the suggested one
+type AcceptBankTransactionRequest struct {
+	BankTransactionId int
+	ActorId           int    `json:"actorId" binding:"required,gt=0"`
+	PendingInvoiceId  *int   `json:"pendingInvoiceId" binding:"omitempty,gt=0"`
+	Note              string `json:"note"`
+}
+
+type RejectBankTransactionRequest struct {
+	BankTransactionId int
+	ActorId           int    `json:"actorId" binding:"required,gt=0"`
+	Note              string `json:"note" binding:"required"`
+}
@@ -0,0 +1,38 @@
+package request
+
+// Buyer details default to the customer's name and email, a company buyer must give its tax code and name
+type IssueEInvoiceRequest struct {
+	PaymentId        int    `json:"paymentId" binding:"required,gt=0"`
+	ActorId          int    `json:"actorId" binding:"required,gt=0"`
+	BuyerName        string `json:"buyerName"`
+	BuyerCompanyName string `json:"buyerCompanyName"`
+	BuyerTaxCode     string `json:"buyerTaxCode"`
+	BuyerAddress     string `json:"buyerAddress"`
+	BuyerEmail       string `json:"buyerEmail" binding:"omitempty,email"`
+}
+
+// Amount is the change of the total including VAT, a negative amount decreases the invoice
+type AdjustEInvoiceRequest struct {
+	EInvoiceId int
+	ActorId    int     `json:"actorId" binding:"required,gt=0"`
+	Amount     float64 `json:"amount" binding:"required"`
+	Reason     string  `json:"reason" binding:"required"`
+}
+
+// Empty fields keep the values of the replaced invoice
+type ReplaceEInvoiceRequest struct {
+	EInvoiceId       int
+	ActorId          int      `json:"actorId" binding:"required,gt=0"`
+	Reason           string   `json:"reason" binding:"required"`
+	TotalAmount      *float64 `json:"totalAmount" binding:"omitempty,gte=0"`
+	BuyerName        string   `json:"buyerName"`
+	BuyerCompanyName string   `json:"buyerCompanyName"`
+	BuyerTaxCode     string   `json:"buyerTaxCode"`
+	BuyerAddress     string   `json:"buyerAddress"`
+	BuyerEmail       string   `json:"buyerEmail" binding:"omitempty,email"`
+}
+
+type ExportEInvoiceRequest struct {
+	EInvoiceId int
+	Format     string `json:"format" form:"format" binding:"required,oneof=xml html pdf"`
+}
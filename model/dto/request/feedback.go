@@ -8,7 +8,7 @@ type GetFeedbacksRequest struct {
 	InvoiceId   *int                    `json:"invoiceId" form:"invoiceId" binding:"omitempty,gt=0"`
 	Rating      *int                    `json:"rating" form:"rating" binding:"omitempty,gt=0"`
 	IsDeleted   *bool                   `json:"isDeleted" form:"isDeleted" binding:"omitempty"`
-	PageSize    int
+	PageSize    int                     `json:"pageSize" form:"pageSize" binding:"omitempty,gt=0"`
 }
 
 type GetTourGuideFeedbacksRequest struct {
@@ -0,0 +1,12 @@
+package request
+
+type GetFiscalPeriodsRequest struct {
+	Year int `json:"year" form:"year" binding:"required,gt=2020"`
+}
+
+type FiscalPeriodActionRequest struct {
+	Year    int    `json:"year" binding:"required,gt=2020"`
+	Month   int    `json:"month" binding:"required,gt=0,max=12"`
+	ActorId int    `json:"actorId" binding:"required,gt=0"`
+	Reason  string `json:"reason"`
+}
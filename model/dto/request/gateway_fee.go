@@ -0,0 +1,41 @@
+package request
+
+import "time"
+
+type UpsertPaymentMethodFeeRequest struct {
+	PaymentMethod  string  `json:"-"`
+	FeeRate        float64 `json:"feeRate" binding:"gte=0,lte=100"`
+	FixedFee       float64 `json:"fixedFee" binding:"gte=0"`
+	SurchargeRate  float64 `json:"surchargeRate" binding:"gte=0,lte=100"`
+	SurchargeFixed float64 `json:"surchargeFixed" binding:"gte=0"`
+	ActorId        int     `json:"actorId" binding:"required,gt=0"`
+}
+
+type GetPaymentFeesRequest struct {
+	Request       SearchPaginationRequest `json:"request"`
+	PaymentMethod string                  `json:"paymentMethod" form:"paymentMethod"`
+	Source        string                  `json:"source" form:"source"`
+	From          *time.Time              `json:"from" form:"from" time_format:"2006-01-02"`
+	To            *time.Time              `json:"to" form:"to" time_format:"2006-01-02"`
+	PageSize      int
+}
+
+// The whole history is reported when no dates are provided, the end date is inclusive
+type GetGatewayFeeReportRequest struct {
+	From *time.Time `json:"from" form:"from" time_format:"2006-01-02"`
+	To   *time.Time `json:"to" form:"to" time_format:"2006-01-02"`
+}
+
+// Preview of the surcharge added to a payment of the given price
+type GetSurchargeRequest struct {
+	PaymentMethod string  `json:"paymentMethod" form:"paymentMethod" binding:"required"`
+	Amount        float64 `json:"amount" form:"amount" binding:"required,gt=0"`
+}
+
+// Settlement report of a gateway, its fees replace the fees estimated from the payment method configuration
+type ImportSettlementRequest struct {
+	PaymentMethod string `form:"paymentMethod" binding:"required"`
+	ActorId       int    `form:"actorId" binding:"required,gt=0"`
+	FileName      string
+	Content       []byte
+}
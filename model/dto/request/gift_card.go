@@ -0,0 +1,32 @@
+package request
+
+type GetGiftCardsRequest struct {
+	Request  SearchPaginationRequest `json:"request"`
+	Status   string                  `json:"status" form:"status"`
+	PageSize int
+}
+
+type PurchaseGiftCardRequest struct {
+	PurchaserId    int     `json:"purchaserId" binding:"required,gt=0"`
+	Amount         float64 `json:"amount" binding:"required,gt=0"`
+	RecipientName  string  `json:"recipientName" binding:"required"`
+	RecipientEmail string  `json:"recipientEmail" binding:"required,email"`
+	Message        string  `json:"message"`
+}
+
+// The whole balance is moved to the wallet unless a smaller amount is given
+type RedeemGiftCardRequest struct {
+	Code       string   `json:"code" binding:"required"`
+	CustomerId int      `json:"customerId" binding:"required,gt=0"`
+	Amount     *float64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+// The gift card pays as much of the price as its balance allows, the rest is then paid through PayOS
+type PayWithGiftCardRequest struct {
+	Code        string  `json:"code" binding:"required"`
+	CustomerId  int     `json:"customerId" binding:"required,gt=0"`
+	TourGuideId int     `json:"tourGuideId" binding:"required,gt=0"`
+	InvoiceId   int     `json:"invoiceId" binding:"required,gt=0"`
+	ServiceId   int     `json:"serviceId" binding:"required,gt=0"`
+	Price       float64 `json:"price" binding:"required,gt=0"`
+}
@@ -0,0 +1,24 @@
+package request
+
+import "time"
+
+type GetLedgerAccountRequest struct {
+	Account string
+	OwnerId *int       `json:"ownerId" form:"ownerId" binding:"omitempty,gte=0"`
+	From    *time.Time `json:"from" form:"from" time_format:"2006-01-02"`
+	To      *time.Time `json:"to" form:"to" time_format:"2006-01-02"`
+}
+
+type LedgerLineRequest struct {
+	Account string  `json:"account" binding:"required"`
+	OwnerId int     `json:"ownerId" binding:"gte=0"`
+	Debit   float64 `json:"debit" binding:"gte=0"`
+	Credit  float64 `json:"credit" binding:"gte=0"`
+}
+
+type CreateLedgerAdjustmentRequest struct {
+	ActorId     int                 `json:"actorId" binding:"required,gt=0"`
+	ReferenceId int                 `json:"referenceId" binding:"gte=0"`
+	Description string              `json:"description" binding:"required"`
+	Lines       []LedgerLineRequest `json:"lines" binding:"required,min=2,dive"`
+}
@@ -0,0 +1,20 @@
+package request
+
+import "time"
+
+type GetLoyaltyTransactionsRequest struct {
+	Request    SearchPaginationRequest `json:"request"`
+	CustomerId int
+	PageSize   int
+}
+
+type UpsertLoyaltyRuleRequest struct {
+	LoyaltyRuleId int        `json:"-"`
+	Name          string     `json:"name" binding:"required"`
+	ServiceId     *int       `json:"serviceId" binding:"omitempty,gt=0"`
+	Multiplier    float64    `json:"multiplier" binding:"required,gt=0"`
+	StartsAt      *time.Time `json:"startsAt"`
+	EndsAt        *time.Time `json:"endsAt"`
+	IsActive      bool       `json:"isActive"`
+	ActorId       int        `json:"actorId" binding:"required,gt=0"`
+}
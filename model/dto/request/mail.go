@@ -7,6 +7,26 @@ type MailBody struct {
 	Subject       string
 	Username      string
 	TransactionId int
+	GiftCard      GiftCardMailBody
+	Subscription  SubscriptionMailBody
+}
+
+type GiftCardMailBody struct {
+	Code       string
+	Amount     string
+	ExpiresAt  string
+	SenderName string
+	Message    string
+}
+
+type SubscriptionMailBody struct {
+	PlanName    string
+	Amount      string
+	PeriodStart string
+	PeriodEnd   string
+	DueAt       string
+	CheckoutUrl string
+	IsReminder  bool
 }
 
 type SendMailRequest struct {
@@ -0,0 +1,31 @@
+package request
+
+type CreateOfflinePaymentRequest struct {
+	CustomerId    int     `form:"customerId" binding:"required,gt=0"`
+	TourGuideId   int     `form:"tourGuideId" binding:"required,gt=0"`
+	InvoiceId     int     `form:"invoiceId" binding:"required,gt=0"`
+	ServiceId     int     `form:"serviceId" binding:"required,gt=0"`
+	Price         float64 `form:"price" binding:"required,gt=0"`
+	PaymentMethod string  `form:"paymentMethod" binding:"required,oneof=CASH BANK_TRANSFER"`
+	Note          string  `form:"note"`
+	ActorId       int     `form:"actorId" binding:"required,gt=0"`
+	ProofFileName string
+	ProofContent  []byte
+}
+
+type GetOfflinePaymentsRequest struct {
+	Request  SearchPaginationRequest `json:"request"`
+	Status   string                  `json:"status" form:"status"`
+	PageSize int
+}
+
+type ConfirmOfflinePaymentRequest struct {
+	OfflinePaymentId int
+	ActorId          int `json:"actorId" binding:"required,gt=0"`
+}
+
+type RejectOfflinePaymentRequest struct {
+	OfflinePaymentId int
+	ActorId          int    `json:"actorId" binding:"required,gt=0"`
+	Reason           string `json:"reason" binding:"required"`
+}
@@ -1,9 +1,30 @@
 package request
 
+import "time"
+
+// The keyword matches the payment method or status, customer IDs are sent as repeated customerIds params
 type GetPaymentsRequest struct {
+	Request     SearchPaginationRequest `json:"request"`
+	Method      string                  `json:"method" form:"method"`
+	CustomerId  *int                    `json:"customerId" form:"customerId" binding:"omitempty,gt=0"`
+	CustomerIds []int                   `json:"customerIds" form:"customerIds" binding:"omitempty,dive,gt=0"`
+	Status      string                  `json:"status" form:"status"`
+	ServiceId   *int                    `json:"serviceId" form:"serviceId" binding:"omitempty,gt=0"`
+	InvoiceId   *int                    `json:"invoiceId" form:"invoiceId" binding:"omitempty,gt=0"`
+	From        *time.Time              `json:"from" form:"from" time_format:"2006-01-02"`
+	To          *time.Time              `json:"to" form:"to" time_format:"2006-01-02"`
+	MinAmount   *float64                `json:"minAmount" form:"minAmount" binding:"omitempty,gte=0"`
+	MaxAmount   *float64                `json:"maxAmount" form:"maxAmount" binding:"omitempty,gte=0"`
+	PageSize    int                     `json:"pageSize" form:"pageSize" binding:"omitempty,gt=0"`
+}
+
+// The history is filtered by status and date range, the summary always covers every payment of the customer
+type GetCustomerPaymentSummaryRequest struct {
 	Request    SearchPaginationRequest `json:"request"`
-	Method     string                  `json:"method" form:"method"`
-	CustomerId *int                    `json:"customerId" form:"customerId" binding:"omitempty,gt=0"`
+	CustomerId int                     `json:"-"`
+	Status     string                  `json:"status" form:"status"`
+	From       *time.Time              `json:"from" form:"from" time_format:"2006-01-02"`
+	To         *time.Time              `json:"to" form:"to" time_format:"2006-01-02"`
 	PageSize   int
 }
 
@@ -21,7 +42,20 @@ type UpdatePaymentRequest struct {
 	Method    string `json:"method"`
 }
 
+// Loyalty points can be redeemed as a discount, the customer, tour guide and service are then needed to record it
 type CreatePayosTransactionRequest struct {
-	Amount    float64 `json:"amount" binding:"required,gt=0"`
-	InvoiceId int     `json:"invoiceId" binding:"required,gt=0"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	InvoiceId    int     `json:"invoiceId" binding:"required,gt=0"`
+	CustomerId   int     `json:"customerId" binding:"omitempty,gt=0"`
+	TourGuideId  int     `json:"tourGuideId" binding:"omitempty,gt=0"`
+	ServiceId    int     `json:"serviceId" binding:"omitempty,gt=0"`
+	RedeemPoints int     `json:"redeemPoints" binding:"omitempty,gt=0"`
+}
+
+// Payments made from the wallet or a gift card are always refunded to the wallet, loyalty point discounts go back as points
+type RefundPaymentRequest struct {
+	PaymentId int    `json:"paymentId" binding:"required,gt=0"`
+	ActorId   int    `json:"actorId" binding:"required,gt=0"`
+	Reason    string `json:"reason" binding:"required"`
+	ToWallet  bool   `json:"toWallet"`
 }
@@ -0,0 +1,40 @@
+package request
+
+type GetPayoutBatchesRequest struct {
+	Request  SearchPaginationRequest `json:"request"`
+	Status   string                  `json:"status" form:"status"`
+	PageSize int
+}
+
+type CreatePayoutBatchRequest struct {
+	CreatedBy    int    `json:"createdBy" binding:"required,gt=0"`
+	Note         string `json:"note"`
+	TourGuideIds []int  `json:"tourGuideIds" binding:"required,min=1,dive,gt=0"`
+}
+
+type PayoutBatchActionRequest struct {
+	PayoutBatchId int
+	ActorId       int `json:"actorId" binding:"required,gt=0"`
+}
+
+type ExportPayoutBatchRequest struct {
+	PayoutBatchId int
+	Bank          string `json:"bank" form:"bank" binding:"required"`
+	Format        string `json:"format" form:"format" binding:"required,oneof=csv xlsx"`
+}
+
+type ImportPayoutResultRequest struct {
+	PayoutBatchId int
+	Bank          string `form:"bank" binding:"required"`
+	FileName      string
+	Content       []byte
+}
+
+type UpdatePayoutPolicyRequest struct {
+	ActorId             int     `json:"actorId" binding:"required,gt=0"`
+	MinPayableBalance   float64 `json:"minPayableBalance" binding:"gte=0"`
+	HoldDays            int     `json:"holdDays" binding:"gte=0,max=90"`
+	Schedule            string  `json:"schedule" binding:"required,oneof=WEEKLY BIWEEKLY MONTHLY"`
+	PayoutDay           int     `json:"payoutDay" binding:"gte=0,max=28"`
+	IsAutoPayoutEnabled bool    `json:"isAutoPayoutEnabled"`
+}
@@ -0,0 +1,35 @@
+package request
+
+type GetPayoutAccountsRequest struct {
+	Request     SearchPaginationRequest `json:"request"`
+	Status      string                  `json:"status" form:"status"`
+	TourGuideId *int                    `json:"tourGuideId" form:"tourGuideId" binding:"omitempty,gt=0"`
+	PageSize    int
+}
+
+type CreatePayoutAccountRequest struct {
+	TourGuideId   int    `json:"tourGuideId" binding:"required,gt=0"`
+	BankCode      string `json:"bankCode" binding:"required"`
+	AccountNumber string `json:"accountNumber" binding:"required,numeric,min=6,max=19"`
+	AccountHolder string `json:"accountHolder" binding:"required"`
+	IsDefault     bool   `json:"isDefault"`
+}
+
+type UpdatePayoutAccountRequest struct {
+	Request       RemovePayoutAccountRequest `json:"request"`
+	BankCode      string                     `json:"bankCode"`
+	AccountNumber string                     `json:"accountNumber" binding:"omitempty,numeric,min=6,max=19"`
+	AccountHolder string                     `json:"accountHolder"`
+	IsDefault     *bool                      `json:"isDefault"`
+}
+
+type RemovePayoutAccountRequest struct {
+	PayoutAccountId int `json:"payoutAccountId" binding:"required,gt=0"`
+	TourGuideId     int `json:"tourGuideId" binding:"required,gt=0"`
+}
+
+type VerifyPayoutAccountRequest struct {
+	PayoutAccountId int
+	ActorId         int    `json:"actorId" binding:"required,gt=0"`
+	RejectReason    string `json:"rejectReason"`
+}
@@ -0,0 +1,27 @@
+package request
+
+type GetReferralCodeRequest struct {
+	OwnerType string `json:"ownerType" form:"ownerType" binding:"required,oneof=CUSTOMER TOUR_GUIDE"`
+	OwnerId   int    `json:"ownerId" form:"ownerId" binding:"required,gt=0"`
+}
+
+type UpdateReferralCodeStatusRequest struct {
+	ReferralCodeId int  `json:"-"`
+	IsActive       bool `json:"isActive"`
+	ActorId        int  `json:"actorId" binding:"required,gt=0"`
+}
+
+// The code is used before the first paid tour of the referee
+type ClaimReferralCodeRequest struct {
+	Code        string `json:"code" binding:"required"`
+	RefereeType string `json:"refereeType" binding:"required,oneof=CUSTOMER TOUR_GUIDE"`
+	RefereeId   int    `json:"refereeId" binding:"required,gt=0"`
+}
+
+type GetReferralRewardsRequest struct {
+	Request      SearchPaginationRequest `json:"request"`
+	Status       string                  `json:"status" form:"status"`
+	ReferrerType string                  `json:"referrerType" form:"referrerType"`
+	ReferrerId   int                     `json:"referrerId" form:"referrerId"`
+	PageSize     int
+}
@@ -1,14 +1,18 @@
 package request
 
+import "time"
+
 // TourGuideId int `json:"tourGuideId" form:"tourGuideId" binding:"required,gt=0"`
 
 type GetRevenuesRequest struct {
-	TourGuideId   int   `json:"tourGuideId" form:"tourGuideId" binding:"required,gt=0"`
-	Year          *int  `json:"year" form:"year" binding:"omitempty,gt=2020"`
-	Month         *int  `json:"month" form:"month" binding:"omitempty,gt=0,max=12"`
-	PaymentStatus *bool `json:"paymentStatus" form:"paymentStatus"`
-	PageNumber    *int  `json:"pageNumber" form:"pageNumber" binding:"omitempty,gt=0"`
-	PageSize      *int  `json:"pageSize" form:"pageSize" binding:"omitempty,gt=0"`
+	TourGuideId   int    `json:"tourGuideId" form:"tourGuideId" binding:"required,gt=0"`
+	Year          *int   `json:"year" form:"year" binding:"omitempty,gt=2020"`
+	Month         *int   `json:"month" form:"month" binding:"omitempty,gt=0,max=12"`
+	PaymentStatus *bool  `json:"paymentStatus" form:"paymentStatus"`
+	PageNumber    *int   `json:"pageNumber" form:"pageNumber" binding:"omitempty,gt=0"`
+	PageSize      *int   `json:"pageSize" form:"pageSize" binding:"omitempty,gt=0"`
+	FilterProp    string `json:"filterProp" form:"filterProp"`
+	Order         string `json:"order" form:"order"`
 }
 
 type GetMonthlyRevenueRequest struct {
@@ -27,13 +31,39 @@ type CreateRevenueRequest struct {
 	PaymentStatus      bool    `json:"paymentStatus" binding:"required"`
 }
 
+// Amounts are the expected effective values, the differences are stored as an adjustment
 type UpdateRevenueRequest struct {
-	RevenueId          int      `json:"revenueId"`
-	PaymentId          *int     `json:"paymentId" binding:"omitempty,gt=0"`
-	TourGuideId        *int     `json:"tourGuideId" binding:"omitempty,gt=0"`
-	InvoiceId          *int     `json:"invoiceId" binding:"omitempty,gt=0"`
-	TotalAmount        *float64 `json:"totalAmount" binding:"omitempty,gt=0"`
-	ActualReceived     *float64 `json:"actualReceived" binding:"omitempty,gt=0"`
-	PlatformCommission *float64 `json:"platformCommission" binding:"omitempty,gt=0"`
-	PaymentStatus      *bool    `json:"paymentStatus" binding:"omitempty"`
+	RevenueId          int
+	ActorId            int      `json:"actorId" binding:"required,gt=0"`
+	Reason             string   `json:"reason" binding:"required"`
+	TotalAmount        *float64 `json:"totalAmount" binding:"omitempty,gte=0"`
+	ActualReceived     *float64 `json:"actualReceived" binding:"omitempty,gte=0"`
+	PlatformCommission *float64 `json:"platformCommission" binding:"omitempty,gte=0"`
+}
+
+type RemoveRevenueRequest struct {
+	RevenueId int
+	ActorId   int    `json:"actorId" binding:"required,gt=0"`
+	Reason    string `json:"reason" binding:"required"`
+}
+
+type GetRevenueSeriesRequest struct {
+	TourGuideId int
+	Granularity string    `json:"granularity" form:"granularity" binding:"required"`
+	From        time.Time `json:"from" form:"from" binding:"required" time_format:"2006-01-02"`
+	To          time.Time `json:"to" form:"to" binding:"required" time_format:"2006-01-02"`
+}
+
+type GetPlatformRevenueRequest struct {
+	From time.Time `json:"from" form:"from" binding:"required" time_format:"2006-01-02"`
+	To   time.Time `json:"to" form:"to" binding:"required" time_format:"2006-01-02"`
+	Top  int       `json:"top" form:"top" binding:"omitempty,gt=0,max=100"`
+}
+
+// Statement of a whole year when month is omitted
+type GetEarningsStatementRequest struct {
+	TourGuideId int
+	Year        int    `json:"year" form:"year" binding:"required,min=2000"`
+	Month       int    `json:"month" form:"month" binding:"omitempty,min=1,max=12"`
+	Format      string `json:"format" form:"format" binding:"required,oneof=csv pdf"`
 }
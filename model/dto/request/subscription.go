@@ -0,0 +1,41 @@
+package request
+
+type UpsertSubscriptionPlanRequest struct {
+	SubscriptionPlanId int     `json:"-"`
+	Name               string  `json:"name" binding:"required"`
+	Description        string  `json:"description"`
+	Price              float64 `json:"price" binding:"required,gt=0"`
+	CycleMonths        int     `json:"cycleMonths" binding:"required,gt=0,lte=12"`
+	CommissionRate     float64 `json:"commissionRate" binding:"gte=0,lte=100"`
+	IsFeatured         bool    `json:"isFeatured"`
+	IsActive           bool    `json:"isActive"`
+}
+
+// The plan starts when the first invoice is paid, the invoices and reminders are sent to the billing email
+type CreateSubscriptionRequest struct {
+	TourGuideId        int    `json:"tourGuideId" binding:"required,gt=0"`
+	SubscriptionPlanId int    `json:"subscriptionPlanId" binding:"required,gt=0"`
+	BillingEmail       string `json:"billingEmail" binding:"required,email"`
+}
+
+// An active subscription ends with its current period, other subscriptions end right away
+type CancelSubscriptionRequest struct {
+	SubscriptionId int `json:"-"`
+	TourGuideId    int `json:"tourGuideId" binding:"required,gt=0"`
+}
+
+type GetSubscriptionsRequest struct {
+	Request            SearchPaginationRequest `json:"request"`
+	Status             string                  `json:"status" form:"status"`
+	TourGuideId        int                     `json:"tourGuideId" form:"tourGuideId"`
+	SubscriptionPlanId int                     `json:"subscriptionPlanId" form:"subscriptionPlanId"`
+	PageSize           int
+}
+
+type GetSubscriptionInvoicesRequest struct {
+	Request        SearchPaginationRequest `json:"request"`
+	Status         string                  `json:"status" form:"status"`
+	TourGuideId    int                     `json:"tourGuideId" form:"tourGuideId"`
+	SubscriptionId int                     `json:"subscriptionId" form:"subscriptionId"`
+	PageSize       int
+}
@@ -0,0 +1,29 @@
+package request
+
+type SaveTaxProfileRequest struct {
+	TourGuideId    int    `json:"tourGuideId" binding:"required,gt=0"`
+	TaxpayerType   string `json:"taxpayerType" binding:"required,oneof=INDIVIDUAL BUSINESS"`
+	TaxCode        string `json:"taxCode"`
+	LegalName      string `json:"legalName" binding:"required"`
+	IdentityNumber string `json:"identityNumber" binding:"omitempty,numeric,min=9,max=12"`
+	Address        string `json:"address"`
+}
+
+// Withholdings of a month, or of a whole year when month is omitted
+type GetTaxWithholdingsRequest struct {
+	TourGuideId *int `json:"tourGuideId" form:"tourGuideId" binding:"omitempty,gt=0"`
+	Year        int  `json:"year" form:"year" binding:"required,min=2000"`
+	Month       int  `json:"month" form:"month" binding:"omitempty,min=1,max=12"`
+}
+
+type GetTaxCertificateRequest struct {
+	TourGuideId int
+	Year        int `json:"year" form:"year" binding:"required,min=2000"`
+}
+
+// Declaration of a month, or of a whole year when month is omitted
+type ExportTaxDeclarationRequest struct {
+	Year   int    `json:"year" form:"year" binding:"required,min=2000"`
+	Month  int    `json:"month" form:"month" binding:"omitempty,min=1,max=12"`
+	Format string `json:"format" form:"format" binding:"required,oneof=csv xlsx"`
+}
@@ -0,0 +1,30 @@
+package request
+
+type GetWalletTransactionsRequest struct {
+	Request    SearchPaginationRequest `json:"request"`
+	CustomerId int
+	PageSize   int
+}
+
+type CreateWalletTopUpRequest struct {
+	CustomerId int     `json:"customerId" binding:"required,gt=0"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// The whole price is paid from the wallet unless a smaller wallet amount is given, the rest is then paid through PayOS
+type PayWithWalletRequest struct {
+	CustomerId   int      `json:"customerId" binding:"required,gt=0"`
+	TourGuideId  int      `json:"tourGuideId" binding:"required,gt=0"`
+	InvoiceId    int      `json:"invoiceId" binding:"required,gt=0"`
+	ServiceId    int      `json:"serviceId" binding:"required,gt=0"`
+	Price        float64  `json:"price" binding:"required,gt=0"`
+	WalletAmount *float64 `json:"walletAmount" binding:"omitempty,gt=0"`
+}
+
+// A positive amount credits the wallet, a negative one debits it
+type AdjustWalletRequest struct {
+	CustomerId int     `json:"customerId" binding:"required,gt=0"`
+	Amount     float64 `json:"amount" binding:"required"`
+	Reason     string  `json:"reason" binding:"required"`
+	ActorId    int     `json:"actorId" binding:"required,gt=0"`
+}
@@ -0,0 +1,6 @@
+package response
+
+type AccountMappingResponse struct {
+	Account string `json:"account"`
+	Code    string `json:"code"`
+}
@@ -0,0 +1,51 @@
+package response
+
+import (
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+type AgencyResponse struct {
+	AgencyId       int       `json:"agencyId"`
+	Name           string    `json:"name"`
+	CommissionRate float64   `json:"commissionRate"`
+	ShareRate      float64   `json:"shareRate"`
+	BankCode       string    `json:"bankCode"`
+	AccountNumber  string    `json:"accountNumber"`
+	AccountHolder  string    `json:"accountHolder"`
+	IsActive       bool      `json:"isActive"`
+	CreatedBy      int       `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type AgencyGuideRevenueResponse struct {
+	entity.AgencyGuideRevenue
+	TourGuideName string `json:"tourGuideName"`
+}
+
+type AgencyReportResponse struct {
+	Agency  AgencyResponse               `json:"agency"`
+	From    *time.Time                   `json:"from"`
+	To      *time.Time                   `json:"to"`
+	Summary entity.AgencyRevenueSummary  `json:"summary"`
+	Guides  []AgencyGuideRevenueResponse `json:"guides"`
+}
+
+// The full account number is given so that the transfer can be made
+type AgencyPayoutResponse struct {
+	AgencyPayoutId int        `json:"agencyPayoutId"`
+	AgencyId       int        `json:"agencyId"`
+	Amount         float64    `json:"amount"`
+	BankCode       string     `json:"bankCode"`
+	AccountNumber  string     `json:"accountNumber"`
+	AccountHolder  string     `json:"accountHolder"`
+	Reference      string     `json:"reference"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note"`
+	CreatedBy      int        `json:"createdBy"`
+	ProcessedBy    *int       `json:"processedBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ProcessedAt    *time.Time `json:"processedAt"`
+	RevenueIds     []int      `json:"revenueIds"`
+}
@@ -27,3 +27,9 @@ type PaginationDataResponse struct {
 	HasNext     bool        `json:"has_next"`
 	HasPrevious bool        `json:"has_previous"`
 }
+
+type FileResponse struct {
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Content     []byte `json:"content"`
+}
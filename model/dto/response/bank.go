@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+// A single line of a bank bulk-transfer result file
+type BankTransferResult struct {
+	Reference string `json:"reference"`
+	IsSuccess bool   `json:"isSuccess"`
+	Message   string `json:"message"`
+}
+
+// A credit line of a bank account statement
+type BankStatementTransaction struct {
+	Reference       string    `json:"reference"`
+	TransactionDate time.Time `json:"transactionDate"`
+	Amount          float64   `json:"amount"`
+	Description     string    `json:"description"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import "tourmate/payment-service/model/entity"
+
+type BankStatementImportResponse struct {
+	TotalLines     int                      `json:"totalLines"`
+	MatchedLines   int                      `json:"matchedLines"`
+	ReviewLines    int                      `json:"reviewLines"`
+	UnmatchedLines int                      `json:"unmatchedLines"`
+	DuplicateLines int                      `json:"duplicateLines"`
+	Transactions   []entity.BankTransaction `json:"transactions"`
+}
+
+// Transfer details of a VietQR payment, the QR image is a base64 PNG data URL
+type VietQrResponse struct {
+	PendingInvoiceId int     `json:"pendingInvoiceId"`
+	InvoiceId        int     `json:"invoiceId"`
+	BankCode         string  `json:"bankCode"`
+	BankBin          string  `json:"bankBin"`
+	AccountNo        string  `json:"accountNo"`
+	AccountName      string  `json:"accountName"`
+	Amount           float64 `json:"amount"`
+	Note             string  `json:"note"`
+	Payload          string  `json:"payload"`
+	QrImage          string  `json:"qrImage"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+// Line of a gateway settlement report, the invoice is read from the payment description
+type SettlementLine struct {
+	Reference   string  `json:"reference"`
+	Description string  `json:"description"`
+	InvoiceId   int     `json:"invoiceId"`
+	Amount      float64 `json:"amount"`
+	FeeAmount   float64 `json:"feeAmount"`
+}
+
+type SettlementImportResponse struct {
+	TotalLines     int              `json:"totalLines"`
+	SettledLines   int              `json:"settledLines"`
+	DuplicateLines int              `json:"duplicateLines"`
+	UnmatchedLines int              `json:"unmatchedLines"`
+	FeeDifference  float64          `json:"feeDifference"` // Settled fees minus the estimated fees they replace
+	Unmatched      []SettlementLine `json:"unmatched"`
+}
+
+type SurchargeResponse struct {
+	PaymentMethod string  `json:"paymentMethod"`
+	Amount        float64 `json:"amount"`
+	Surcharge     float64 `json:"surcharge"`
+	TotalAmount   float64 `json:"totalAmount"`
+}
+
+// Gross is collected by the gateways, net is settled to the platform after the gateway fees
+type GatewayFeeReportResponse struct {
+	From    *time.Time                 `json:"from"`
+	To      *time.Time                 `json:"to"`
+	Summary entity.PaymentFeeSummary   `json:"summary"`
+	Methods []entity.PaymentFeeSummary `json:"methods"`
+}
@@ -0,0 +1,30 @@
+package response
+
+import (
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+// The checkout URL is given when part of the price is left to pay through PayOS
+type GiftCardPaymentResponse struct {
+	Payment             *entity.Payment             `json:"payment"`
+	GiftCardTransaction *entity.GiftCardTransaction `json:"giftCardTransaction"`
+	RemainingAmount     float64                     `json:"remainingAmount"`
+	CheckoutUrl         string                      `json:"checkoutUrl"`
+}
+
+type GiftCardRedemptionResponse struct {
+	GiftCardTransaction *entity.GiftCardTransaction `json:"giftCardTransaction"`
+	WalletTransaction   *entity.WalletTransaction   `json:"walletTransaction"`
+}
+
+// Outstanding balance is what the platform still owes to gift card holders
+type GiftCardLiabilityResponse struct {
+	AsOf               time.Time                      `json:"asOf"`
+	OutstandingBalance float64                        `json:"outstandingBalance"`
+	OutstandingCount   int                            `json:"outstandingCount"`
+	ExpiringBefore     time.Time                      `json:"expiringBefore"`
+	ExpiringBalance    float64                        `json:"expiringBalance"`
+	ExpiringCount      int                            `json:"expiringCount"`
+	Statuses           []entity.GiftCardStatusSummary `json:"statuses"`
+}
@@ -0,0 +1,42 @@
+package response
+
+import "time"
+
+type LedgerBalanceResponse struct {
+	Account     string  `json:"account"`
+	OwnerId     *int    `json:"ownerId"`
+	TotalDebit  float64 `json:"totalDebit"`
+	TotalCredit float64 `json:"totalCredit"`
+	Balance     float64 `json:"balance"`
+}
+
+type LedgerStatementLineResponse struct {
+	LedgerEntryId int       `json:"ledgerEntryId"`
+	EntryType     string    `json:"entryType"`
+	ReferenceId   int       `json:"referenceId"`
+	Description   string    `json:"description"`
+	OwnerId       int       `json:"ownerId"`
+	Debit         float64   `json:"debit"`
+	Credit        float64   `json:"credit"`
+	Balance       float64   `json:"balance"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type LedgerStatementResponse struct {
+	Account        string                        `json:"account"`
+	OwnerId        *int                          `json:"ownerId"`
+	From           time.Time                     `json:"from"`
+	To             time.Time                     `json:"to"`
+	OpeningBalance float64                       `json:"openingBalance"`
+	ClosingBalance float64                       `json:"closingBalance"`
+	Lines          []LedgerStatementLineResponse `json:"lines"`
+}
+
+type LedgerInvariantResponse struct {
+	TotalDebit         float64                 `json:"totalDebit"`
+	TotalCredit        float64                 `json:"totalCredit"`
+	Difference         float64                 `json:"difference"`
+	IsBalanced         bool                    `json:"isBalanced"`
+	UnbalancedEntryIds []int                   `json:"unbalancedEntryIds"`
+	AccountBalances    []LedgerBalanceResponse `json:"accountBalances"`
+}
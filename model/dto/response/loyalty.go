@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// The value is the discount the balance can be redeemed for
+type LoyaltyBalanceResponse struct {
+	CustomerId     int       `json:"customerId"`
+	Balance        int       `json:"balance"`
+	PointValue     float64   `json:"pointValue"`
+	BalanceValue   float64   `json:"balanceValue"`
+	ExpiringPoints int       `json:"expiringPoints"`
+	ExpiringBefore time.Time `json:"expiringBefore"`
+}
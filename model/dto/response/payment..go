@@ -1,6 +1,9 @@
 package response
 
-import "time"
+import (
+	"time"
+	"tourmate/payment-service/model/entity"
+)
 
 type PaymentCallbackComponent struct {
 	//PaymentType   string  `json:"paymentType" form:"paymentType"` // bo^' bo? theo y' m
@@ -18,3 +21,26 @@ type PaymentWithServiceNameResponse struct {
 	ServiceName string    `json:"serviceName"`
 	CreatedAt   time.Time `json:"createdAt"`
 }
+
+type PaymentHistoryResponse struct {
+	entity.Payment
+	ServiceName string `json:"serviceName"`
+}
+
+// The history is a page of PaymentHistoryResponse
+type CustomerPaymentSummaryResponse struct {
+	Summary entity.CustomerSpendingSummary `json:"summary"`
+	History PaginationDataResponse         `json:"history"`
+}
+
+// The url pays the amount left after the loyalty point discount. The points are held by a pending payment of the split
+// payment, which is paid once the url is paid
+type PayosTransactionResponse struct {
+	Url            string               `json:"url"`
+	Amount         float64              `json:"amount"`
+	RedeemedPoints int                  `json:"redeemedPoints"`
+	DiscountAmount float64              `json:"discountAmount"`
+	Surcharge      float64              `json:"surcharge"` // Charged by the link on top of the amount, the payment is recorded with the amount
+	Payment        *entity.Payment      `json:"payment"`
+	SplitPayment   *entity.SplitPayment `json:"splitPayment"`
+}
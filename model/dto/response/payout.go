@@ -0,0 +1,46 @@
+package response
+
+import (
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+type PayoutItemResponse struct {
+	PayoutItemId  int        `json:"payoutItemId"`
+	TourGuideId   int        `json:"tourGuideId"`
+	TourGuideName string     `json:"tourGuideName"`
+	Amount        float64    `json:"amount"`
+	TaxWithheld   float64    `json:"taxWithheld"`
+	BankCode      string     `json:"bankCode"`
+	AccountNumber string     `json:"accountNumber"`
+	AccountHolder string     `json:"accountHolder"`
+	Reference     string     `json:"reference"`
+	Status        string     `json:"status"`
+	FailureReason string     `json:"failureReason"`
+	ProcessedAt   *time.Time `json:"processedAt"`
+	RevenueIds    []int      `json:"revenueIds"`
+	RewardIds     []int      `json:"rewardIds"`
+}
+
+type PayoutBatchResponse struct {
+	Batch entity.PayoutBatch   `json:"batch"`
+	Items []PayoutItemResponse `json:"items"`
+}
+
+type PayoutImportResponse struct {
+	PaidItems      int      `json:"paidItems"`
+	FailedItems    int      `json:"failedItems"`
+	UnmatchedLines []string `json:"unmatchedLines"`
+	BatchStatus    string   `json:"batchStatus"`
+}
+
+type PayoutSummaryResponse struct {
+	TourGuideId        int        `json:"tourGuideId"`
+	AmountEligible     float64    `json:"amountEligible"`
+	AmountOnHold       float64    `json:"amountOnHold"`
+	MinPayableBalance  float64    `json:"minPayableBalance"`
+	HoldDays           int        `json:"holdDays"`
+	Schedule           string     `json:"schedule"`
+	NextPayoutDate     *time.Time `json:"nextPayoutDate"`
+	HasVerifiedAccount bool       `json:"hasVerifiedAccount"`
+}
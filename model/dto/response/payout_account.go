@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+type PayoutAccountResponse struct {
+	PayoutAccountId int        `json:"payoutAccountId"`
+	TourGuideId     int        `json:"tourGuideId"`
+	BankCode        string     `json:"bankCode"`
+	AccountNumber   string     `json:"accountNumber"`
+	AccountHolder   string     `json:"accountHolder"`
+	Status          string     `json:"status"`
+	RejectReason    string     `json:"rejectReason"`
+	IsDefault       bool       `json:"isDefault"`
+	VerifiedBy      *int       `json:"verifiedBy"`
+	VerifiedAt      *time.Time `json:"verifiedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import "tourmate/payment-service/model/entity"
+
+// Rewards are pending while they wait for the payout of a tour guide
+type ReferralSummaryResponse struct {
+	ReferralCode       *entity.ReferralCode `json:"referralCode"`
+	Referrals          []entity.Referral    `json:"referrals"`
+	RemainingReferrals int                  `json:"remainingReferrals"`
+	PaidReward         float64              `json:"paidReward"`
+	PendingReward      float64              `json:"pendingReward"`
+	RemainingReward    float64              `json:"remainingReward"`
+}
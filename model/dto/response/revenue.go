@@ -34,6 +34,8 @@ type RevenueGrowthPercentageResponse struct {
 type RevenueStatusResponse struct {
 	TotalRevenue      float64           `json:"totalRevenue"`
 	PlatformFee       float64           `json:"platformFee"`
+	GatewayFee        float64           `json:"gatewayFee"`     // Gateway fees left after the surcharges paid by the customers
+	NetPlatformFee    float64           `json:"netPlatformFee"` // Platform fee after the gateway fees
 	NetRevenue        float64           `json:"netRevenue"`
 	TotalRecords      int               `json:"totalRecords"`
 	CompletedPayments int               `json:"completedPayments"`
@@ -41,3 +43,67 @@ type RevenueStatusResponse struct {
 	MonthlyGrowth     float64           `json:"monthlyGrowth"`
 	RevenueList       []RevenueResponse `json:"revenueList"`
 }
+
+type RevenueSeriesBucketResponse struct {
+	BucketStart  time.Time `json:"bucketStart"`
+	BucketEnd    time.Time `json:"bucketEnd"`
+	TotalRevenue float64   `json:"totalRevenue"`
+	PlatformFee  float64   `json:"platformFee"`
+	NetRevenue   float64   `json:"netRevenue"`
+	TotalRecords int       `json:"totalRecords"`
+	// Revenues of the bucket which have been paid out to the tour guide
+	CompletedRecords int `json:"completedRecords"`
+	// Growth of total revenue compared to the previous bucket, null when the previous bucket is empty
+	PeriodGrowth *float64 `json:"periodGrowth"`
+	// Growth of total revenue compared to the same bucket one year earlier, null when that bucket is empty
+	YearOverYearGrowth *float64 `json:"yearOverYearGrowth"`
+}
+
+type RevenueSeriesResponse struct {
+	TourGuideId int                           `json:"tourGuideId"`
+	Granularity string                        `json:"granularity"`
+	From        time.Time                     `json:"from"`
+	To          time.Time                     `json:"to"`
+	Buckets     []RevenueSeriesBucketResponse `json:"buckets"`
+}
+
+type PlatformRevenueSummaryResponse struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	TotalRevenue   float64   `json:"totalRevenue"`
+	PlatformFee    float64   `json:"platformFee"`
+	GatewayFee     float64   `json:"gatewayFee"`     // Gateway fees left after the surcharges paid by the customers
+	NetPlatformFee float64   `json:"netPlatformFee"` // Platform fee after the gateway fees
+	NetRevenue     float64   `json:"netRevenue"`
+	TotalRecords   int       `json:"totalRecords"`
+	SettledAmount  float64   `json:"settledAmount"`
+	PendingAmount  float64   `json:"pendingAmount"`
+}
+
+type TourGuideRevenueResponse struct {
+	TourGuideId   int     `json:"tourGuideId"`
+	TourGuideName string  `json:"tourGuideName"`
+	TotalRevenue  float64 `json:"totalRevenue"`
+	PlatformFee   float64 `json:"platformFee"`
+	NetRevenue    float64 `json:"netRevenue"`
+	TotalRecords  int     `json:"totalRecords"`
+}
+
+type ServiceRevenueResponse struct {
+	ServiceId    int     `json:"serviceId"`
+	ServiceName  string  `json:"serviceName"`
+	AreaId       int     `json:"areaId"`
+	TotalRevenue float64 `json:"totalRevenue"`
+	PlatformFee  float64 `json:"platformFee"`
+	NetRevenue   float64 `json:"netRevenue"`
+	TotalRecords int     `json:"totalRecords"`
+}
+
+type AreaRevenueResponse struct {
+	AreaId       int     `json:"areaId"`
+	TotalRevenue float64 `json:"totalRevenue"`
+	PlatformFee  float64 `json:"platformFee"`
+	NetRevenue   float64 `json:"netRevenue"`
+	TotalRecords int     `json:"totalRecords"`
+	ServiceCount int     `json:"serviceCount"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import "tourmate/payment-service/model/entity"
+
+type SubscriptionResponse struct {
+	Subscription entity.Subscription         `json:"subscription"`
+	Plan         *entity.SubscriptionPlan    `json:"plan"`
+	OpenInvoice  *entity.SubscriptionInvoice `json:"openInvoice"`
+}
+
+// Result of a billing run
+type SubscriptionBillingResponse struct {
+	CreatedInvoices    int `json:"createdInvoices"`
+	PaidInvoices       int `json:"paidInvoices"`
+	SentReminders      int `json:"sentReminders"`
+	DowngradedGuides   int `json:"downgradedGuides"`
+	EndedSubscriptions int `json:"endedSubscriptions"`
+}
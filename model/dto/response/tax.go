@@ -0,0 +1,41 @@
+package response
+
+import (
+	"time"
+	"tourmate/payment-service/model/entity"
+)
+
+type TaxProfileResponse struct {
+	TaxProfileId   int       `json:"taxProfileId"`
+	TourGuideId    int       `json:"tourGuideId"`
+	TaxpayerType   string    `json:"taxpayerType"`
+	TaxCode        string    `json:"taxCode"`
+	LegalName      string    `json:"legalName"`
+	IdentityNumber string    `json:"identityNumber"`
+	Address        string    `json:"address"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type TaxWithholdingLedgerResponse struct {
+	TotalGrossAmount float64                     `json:"totalGrossAmount"`
+	TotalTaxAmount   float64                     `json:"totalTaxAmount"`
+	Items            []entity.PaidTaxWithholding `json:"items"`
+}
+
+// Data of the yearly personal income tax withholding certificate
+type TaxCertificateResponse struct {
+	Year           int                         `json:"year"`
+	TourGuideId    int                         `json:"tourGuideId"`
+	TaxpayerType   string                      `json:"taxpayerType"`
+	TaxCode        string                      `json:"taxCode"`
+	LegalName      string                      `json:"legalName"`
+	IdentityNumber string                      `json:"identityNumber"`
+	Address        string                      `json:"address"`
+	FromMonth      int                         `json:"fromMonth"`
+	ToMonth        int                         `json:"toMonth"`
+	TotalIncome    float64                     `json:"totalIncome"`
+	TaxableIncome  float64                     `json:"taxableIncome"`
+	TaxWithheld    float64                     `json:"taxWithheld"`
+	Payments       []entity.PaidTaxWithholding `json:"payments"`
+}
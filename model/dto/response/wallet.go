@@ -0,0 +1,13 @@
+package response
+
+import "tourmate/payment-service/model/entity"
+
+// The checkout URL is given when part of the price is left to pay through PayOS, the wallet part is then held by a pending
+// payment of the split payment until the link is paid
+type WalletPaymentResponse struct {
+	Payment           *entity.Payment           `json:"payment"`
+	WalletTransaction *entity.WalletTransaction `json:"walletTransaction"`
+	RemainingAmount   float64                   `json:"remainingAmount"`
+	CheckoutUrl       string                    `json:"checkoutUrl"`
+	SplitPayment      *entity.SplitPayment      `json:"splitPayment"`
+}
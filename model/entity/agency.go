@@ -0,0 +1,87 @@
+package entity
+
+import "time"
+
+// Company which tour guides work under, a guide belongs to the active agency named as the company of the guide
+type Agency struct {
+	AgencyId       int       `json:"agencyId"`
+	Name           string    `json:"name"`
+	CommissionRate float64   `json:"commissionRate"` // Platform commission in percent of the tour price
+	ShareRate      float64   `json:"shareRate"`      // Agency share in percent of the tour price
+	BankCode       string    `json:"bankCode"`
+	AccountNumber  string    `json:"-"` // Encrypted at rest
+	AccountHolder  string    `json:"accountHolder"`
+	IsActive       bool      `json:"isActive"`
+	CreatedBy      int       `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func (a Agency) GetAgencyTable() string {
+	return "Agency"
+}
+
+// Share of a payment earned by the agency of the tour guide. A refund adds a reversal with negative amounts
+type AgencyRevenue struct {
+	AgencyRevenueId int       `json:"agencyRevenueId"`
+	AgencyId        int       `json:"agencyId"`
+	RevenueId       int       `json:"revenueId"`
+	PaymentId       int       `json:"paymentId"`
+	TourGuideId     int       `json:"tourGuideId"`
+	InvoiceId       int       `json:"invoiceId"`
+	TotalAmount     float64   `json:"totalAmount"`
+	ShareRate       float64   `json:"shareRate"`
+	Amount          float64   `json:"amount"`
+	AgencyPayoutId  *int      `json:"agencyPayoutId"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+func (a AgencyRevenue) GetAgencyRevenueTable() string {
+	return "AgencyRevenue"
+}
+
+func (a AgencyRevenue) GetAgencyRevenueLimitRecords() int {
+	return 20
+}
+
+// Bank transfer of the unpaid agency revenues, the account is copied from the agency when the payout is created
+type AgencyPayout struct {
+	AgencyPayoutId int        `json:"agencyPayoutId"`
+	AgencyId       int        `json:"agencyId"`
+	Amount         float64    `json:"amount"`
+	BankCode       string     `json:"bankCode"`
+	AccountNumber  string     `json:"-"` // Encrypted at rest
+	AccountHolder  string     `json:"accountHolder"`
+	Reference      string     `json:"reference"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note"`
+	CreatedBy      int        `json:"createdBy"`
+	ProcessedBy    *int       `json:"processedBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ProcessedAt    *time.Time `json:"processedAt"`
+}
+
+func (a AgencyPayout) GetAgencyPayoutTable() string {
+	return "AgencyPayout"
+}
+
+func (a AgencyPayout) GetAgencyPayoutLimitRecords() int {
+	return 10
+}
+
+// Agency revenues of a period, reversals are netted
+type AgencyRevenueSummary struct {
+	TotalAmount   float64 `json:"totalAmount"`
+	Amount        float64 `json:"amount"`
+	PaidAmount    float64 `json:"paidAmount"`
+	PendingAmount float64 `json:"pendingAmount"`
+	PaymentCount  int     `json:"paymentCount"`
+}
+
+// Agency revenues of a period brought by a tour guide
+type AgencyGuideRevenue struct {
+	TourGuideId  int     `json:"tourGuideId"`
+	TotalAmount  float64 `json:"totalAmount"`
+	Amount       float64 `json:"amount"`
+	PaymentCount int     `json:"paymentCount"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Invoice which the customer pays by a direct bank transfer or a VietQR transfer with "Invoice <invoiceId>" in the note
+type PendingInvoice struct {
+	PendingInvoiceId int       `json:"pendingInvoiceId"`
+	InvoiceId        int       `json:"invoiceId"`
+	CustomerId       int       `json:"customerId"`
+	TourGuideId      int       `json:"tourGuideId"`
+	ServiceId        int       `json:"serviceId"`
+	Amount           float64   `json:"amount"`
+	PaymentMethod    string    `json:"paymentMethod"`
+	Status           string    `json:"status"`
+	PaymentId        *int      `json:"paymentId"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+func (p PendingInvoice) GetPendingInvoiceTable() string {
+	return "PendingInvoice"
+}
+
+// Incoming transaction of an imported bank statement, the pending invoice is the match or the review candidate
+type BankTransaction struct {
+	BankTransactionId int        `json:"bankTransactionId"`
+	BankCode          string     `json:"bankCode"`
+	TransactionRef    string     `json:"transactionRef"`
+	TransactionDate   time.Time  `json:"transactionDate"`
+	Amount            float64    `json:"amount"`
+	Description       string     `json:"description"`
+	Status            string     `json:"status"`
+	MatchNote         string     `json:"matchNote"`
+	PendingInvoiceId  *int       `json:"pendingInvoiceId"`
+	PaymentId         *int       `json:"paymentId"`
+	ImportedBy        int        `json:"importedBy"`
+	ImportedAt        time.Time  `json:"importedAt"`
+	ReviewedBy        *int       `json:"reviewedBy"`
+	ReviewedAt        *time.Time `json:"reviewedAt"`
+}
+
+func (b BankTransaction) GetBankTransactionTable() string {
+	return "BankTransaction"
+}
+
+func (b BankTransaction) GetBankTransactionLimitRecords() int {
+	return 20
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// VAT e-invoice of a payment, amounts of adjustment invoices are the changes made to the related invoice
+type EInvoice struct {
+	EInvoiceId        int       `json:"eInvoiceId"`
+	PaymentId         int       `json:"paymentId"`
+	InvoiceType       string    `json:"invoiceType"`
+	TemplateCode      string    `json:"templateCode"`
+	Series            string    `json:"series"`
+	InvoiceNumber     int       `json:"invoiceNumber"`
+	Status            string    `json:"status"`
+	BuyerName         string    `json:"buyerName"`
+	BuyerCompanyName  string    `json:"buyerCompanyName"`
+	BuyerTaxCode      string    `json:"buyerTaxCode"`
+	BuyerAddress      string    `json:"buyerAddress"`
+	BuyerEmail        string    `json:"buyerEmail"`
+	ItemName          string    `json:"itemName"`
+	AmountBeforeTax   float64   `json:"amountBeforeTax"`
+	VatRate           float64   `json:"vatRate"`
+	VatAmount         float64   `json:"vatAmount"`
+	TotalAmount       float64   `json:"totalAmount"`
+	RelatedEInvoiceId *int      `json:"relatedEInvoiceId"`
+	Reason            string    `json:"reason"`
+	IssuedBy          int       `json:"issuedBy"`
+	IssuedAt          time.Time `json:"issuedAt"`
+}
+
+func (e EInvoice) GetEInvoiceTable() string {
+	return "EInvoice"
+}
+
+// Last invoice number used by a template and series
+type EInvoiceSequence struct {
+	TemplateCode string `json:"templateCode"`
+	Series       string `json:"series"`
+	LastNumber   int    `json:"lastNumber"`
+}
+
+func (e EInvoiceSequence) GetEInvoiceSequenceTable() string {
+	return "EInvoiceSequence"
+}
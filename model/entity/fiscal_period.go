@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// A month without a record is considered open
+type FiscalPeriod struct {
+	FiscalPeriodId int        `json:"fiscalPeriodId"`
+	Year           int        `json:"year"`
+	Month          int        `json:"month"`
+	Status         string     `json:"status"`
+	ClosedBy       *int       `json:"closedBy"`
+	ClosedAt       *time.Time `json:"closedAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func (f FiscalPeriod) GetFiscalPeriodTable() string {
+	return "FiscalPeriod"
+}
+
+// Every close and reopen of a period is recorded
+type FiscalPeriodAudit struct {
+	FiscalPeriodAuditId int       `json:"fiscalPeriodAuditId"`
+	FiscalPeriodId      int       `json:"fiscalPeriodId"`
+	FromStatus          string    `json:"fromStatus"`
+	ToStatus            string    `json:"toStatus"`
+	Reason              string    `json:"reason"`
+	ActorId             int       `json:"actorId"`
+	CreatedAt           time.Time `json:"createdAt"`
+}
+
+func (f FiscalPeriodAudit) GetFiscalPeriodAuditTable() string {
+	return "FiscalPeriodAudit"
+}
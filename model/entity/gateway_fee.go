@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+// Fee charged by the gateway of a payment method, the surcharge is passed on to the customer on top of the price
+type PaymentMethodFee struct {
+	PaymentMethod  string    `json:"paymentMethod"`
+	FeeRate        float64   `json:"feeRate"`        // Gateway fee in percent of the amount collected
+	FixedFee       float64   `json:"fixedFee"`       // Gateway fee per payment
+	SurchargeRate  float64   `json:"surchargeRate"`  // Surcharge in percent of the payment price
+	SurchargeFixed float64   `json:"surchargeFixed"` // Surcharge per payment
+	UpdatedBy      int       `json:"updatedBy"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func (p PaymentMethodFee) GetPaymentMethodFeeTable() string {
+	return "PaymentMethodFee"
+}
+
+// Gateway fee of a payment, the gross amount is the price plus the surcharge collected by the gateway and the net amount
+// is what is settled to the platform
+type PaymentFee struct {
+	PaymentFeeId        int       `json:"paymentFeeId"`
+	PaymentId           int       `json:"paymentId"`
+	PaymentMethod       string    `json:"paymentMethod"`
+	GrossAmount         float64   `json:"grossAmount"`
+	Surcharge           float64   `json:"surcharge"`
+	FeeAmount           float64   `json:"feeAmount"`
+	NetAmount           float64   `json:"netAmount"`
+	Source              string    `json:"source"`
+	SettlementReference string    `json:"settlementReference"`
+	CreatedAt           time.Time `json:"createdAt"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
+
+func (p PaymentFee) GetPaymentFeeTable() string {
+	return "PaymentFee"
+}
+
+func (p PaymentFee) GetPaymentFeeLimitRecords() int {
+	return 20
+}
+
+// Gateway fees aggregated over a payment method
+type PaymentFeeSummary struct {
+	PaymentMethod string  `json:"paymentMethod"`
+	GrossAmount   float64 `json:"grossAmount"`
+	Surcharge     float64 `json:"surcharge"`
+	FeeAmount     float64 `json:"feeAmount"`
+	NetAmount     float64 `json:"netAmount"`
+	PaymentCount  int     `json:"paymentCount"`
+}
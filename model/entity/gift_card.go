@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// Prepaid card bought for a recipient, the code is issued once the purchase is paid
+type GiftCard struct {
+	GiftCardId     int        `json:"giftCardId"`
+	Code           *string    `json:"code"`
+	PurchaserId    int        `json:"purchaserId"`
+	RecipientName  string     `json:"recipientName"`
+	RecipientEmail string     `json:"recipientEmail"`
+	Message        string     `json:"message"`
+	InitialAmount  float64    `json:"initialAmount"`
+	Balance        float64    `json:"balance"`
+	OrderCode      int64      `json:"orderCode"`
+	CheckoutUrl    string     `json:"checkoutUrl"`
+	Status         string     `json:"status"`
+	ExpiresAt      *time.Time `json:"expiresAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	PaidAt         *time.Time `json:"paidAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func (g GiftCard) GetGiftCardTable() string {
+	return "GiftCard"
+}
+
+func (g GiftCard) GetGiftCardLimitRecords() int {
+	return 20
+}
+
+// Balance change of a gift card, the reference is the invoice of invoice redemptions and restores
+type GiftCardTransaction struct {
+	GiftCardTransactionId int       `json:"giftCardTransactionId"`
+	GiftCardId            int       `json:"giftCardId"`
+	TransactionType       string    `json:"transactionType"`
+	Amount                float64   `json:"amount"`
+	BalanceAfter          float64   `json:"balanceAfter"`
+	CustomerId            *int      `json:"customerId"`
+	ReferenceId           *int      `json:"referenceId"`
+	CreatedBy             int       `json:"createdBy"`
+	CreatedAt             time.Time `json:"createdAt"`
+}
+
+func (g GiftCardTransaction) GetGiftCardTransactionTable() string {
+	return "GiftCardTransaction"
+}
+
+type GiftCardStatusSummary struct {
+	Status        string  `json:"status"`
+	CardCount     int     `json:"cardCount"`
+	InitialAmount float64 `json:"initialAmount"`
+	Balance       float64 `json:"balance"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// Payment with the revenue recorded for it, used for accounting journals
+type JournalPayment struct {
+	Payment
+	TourGuideId        int     `json:"tourGuideId"`
+	ActualReceived     float64 `json:"actualReceived"`
+	PlatformCommission float64 `json:"platformCommission"`
+	AgencyId           *int    `json:"agencyId"`
+	AgencyAmount       float64 `json:"agencyAmount"`
+	FeeAmount          float64 `json:"feeAmount"` // Gateway fee, the settled one once the settlement is imported
+	Surcharge          float64 `json:"surcharge"`
+}
+
+// Refunded payment with the time its refund was recorded
+type JournalRefund struct {
+	Payment
+	RefundedAt time.Time `json:"refundedAt"`
+}
+
+// Revenue adjustment with the payment and tour guide of its revenue
+type JournalAdjustment struct {
+	RevenueAdjustment
+	InvoiceId   int `json:"invoiceId"`
+	CustomerId  int `json:"customerId"`
+	TourGuideId int `json:"tourGuideId"`
+}
+
+// Paid payout item with the personal income tax withheld from it
+type JournalPayout struct {
+	PayoutItem
+	TaxAmount float64 `json:"taxAmount"`
+}
+
+// Reversal of an agency share with the customer of its payment
+type JournalAgencyReversal struct {
+	AgencyRevenue
+	CustomerId int `json:"customerId"`
+}
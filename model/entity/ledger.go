@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Journal entries are append-only, corrections are posted as new entries
+type LedgerEntry struct {
+	LedgerEntryId int       `json:"ledgerEntryId"`
+	EntryType     string    `json:"entryType"`
+	ReferenceId   int       `json:"referenceId"`
+	Description   string    `json:"description"`
+	CreatedBy     int       `json:"createdBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func (l LedgerEntry) GetLedgerEntryTable() string {
+	return "LedgerEntry"
+}
+
+type LedgerLine struct {
+	LedgerLineId  int       `json:"ledgerLineId"`
+	LedgerEntryId int       `json:"ledgerEntryId"`
+	Account       string    `json:"account"`
+	OwnerId       int       `json:"ownerId"`
+	Debit         float64   `json:"debit"`
+	Credit        float64   `json:"credit"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func (l LedgerLine) GetLedgerLineTable() string {
+	return "LedgerLine"
+}
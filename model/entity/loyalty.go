@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+// Loyalty point balance of a customer, the balance only changes together with a loyalty transaction
+type LoyaltyAccount struct {
+	CustomerId int       `json:"customerId"`
+	Balance    int       `json:"balance"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (l LoyaltyAccount) GetLoyaltyAccountTable() string {
+	return "LoyaltyAccount"
+}
+
+// Point change of a customer. Credits keep their remaining points until they are spent or expire, debits take the
+// oldest remaining points first. The reference is the payment of earnings and revocations and the invoice of
+// redemptions and restores, the amount is the discount of redeemed and restored points
+type LoyaltyTransaction struct {
+	LoyaltyTransactionId int        `json:"loyaltyTransactionId"`
+	CustomerId           int        `json:"customerId"`
+	TransactionType      string     `json:"transactionType"`
+	Points               int        `json:"points"`
+	RemainingPoints      int        `json:"remainingPoints"`
+	BalanceAfter         int        `json:"balanceAfter"`
+	Multiplier           float64    `json:"multiplier"`
+	Amount               float64    `json:"amount"`
+	ReferenceId          *int       `json:"referenceId"`
+	Description          string     `json:"description"`
+	ExpiresAt            *time.Time `json:"expiresAt"`
+	CreatedBy            int        `json:"createdBy"`
+	CreatedAt            time.Time  `json:"createdAt"`
+}
+
+func (l LoyaltyTransaction) GetLoyaltyTransactionTable() string {
+	return "LoyaltyTransaction"
+}
+
+func (l LoyaltyTransaction) GetLoyaltyTransactionLimitRecords() int {
+	return 20
+}
+
+// Earning multiplier of a service, or of every service when no service is set, within an optional period.
+// The highest multiplier of the rules applying to a payment is used
+type LoyaltyRule struct {
+	LoyaltyRuleId int        `json:"loyaltyRuleId"`
+	Name          string     `json:"name"`
+	ServiceId     *int       `json:"serviceId"`
+	Multiplier    float64    `json:"multiplier"`
+	StartsAt      *time.Time `json:"startsAt"`
+	EndsAt        *time.Time `json:"endsAt"`
+	IsActive      bool       `json:"isActive"`
+	CreatedBy     int        `json:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+func (l LoyaltyRule) GetLoyaltyRuleTable() string {
+	return "LoyaltyRule"
+}
+
+// Remaining points of a credit past its expiry
+type ExpiredLoyaltyPoint struct {
+	LoyaltyTransactionId int `json:"loyaltyTransactionId"`
+	CustomerId           int `json:"customerId"`
+	RemainingPoints      int `json:"remainingPoints"`
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// Cash or bank transfer collected outside the payment gateways, the payment stays PENDING until an admin confirms it
+type OfflinePayment struct {
+	OfflinePaymentId int        `json:"offlinePaymentId"`
+	PaymentId        int        `json:"paymentId"`
+	TourGuideId      int        `json:"tourGuideId"`
+	Amount           float64    `json:"amount"`
+	PaymentMethod    string     `json:"paymentMethod"`
+	Note             string     `json:"note"`
+	Status           string     `json:"status"`
+	RecordedBy       int        `json:"recordedBy"`
+	RecordedAt       time.Time  `json:"recordedAt"`
+	ConfirmedBy      *int       `json:"confirmedBy"`
+	ConfirmedAt      *time.Time `json:"confirmedAt"`
+	ApprovedBy       *int       `json:"approvedBy"` // Second approver of large payments
+	ApprovedAt       *time.Time `json:"approvedAt"`
+	RejectedBy       *int       `json:"rejectedBy"`
+	RejectedAt       *time.Time `json:"rejectedAt"`
+	RejectReason     string     `json:"rejectReason"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+func (o OfflinePayment) GetOfflinePaymentTable() string {
+	return "OfflinePayment"
+}
+
+func (o OfflinePayment) GetOfflinePaymentLimitRecords() int {
+	return 20
+}
+
+// Photo of the receipt or the transfer, kept apart so that listing payments does not load the images
+type OfflinePaymentProof struct {
+	OfflinePaymentId int       `json:"offlinePaymentId"`
+	FileName         string    `json:"fileName"`
+	Image            string    `json:"image"` // Base64 data URL
+	UploadedAt       time.Time `json:"uploadedAt"`
+}
+
+func (o OfflinePaymentProof) GetOfflinePaymentProofTable() string {
+	return "OfflinePaymentProof"
+}
@@ -17,3 +17,14 @@ type Payment struct {
 func (p Payment) GetPaymentTable() string {
 	return "Payment"
 }
+
+// Lifetime payments of a customer, a tour is counted once however many payments its invoice took
+type CustomerSpendingSummary struct {
+	CustomerId     int        `json:"customerId"`
+	LifetimeSpend  float64    `json:"lifetimeSpend"` // Paid payments, refunded ones are excluded
+	PaidTours      int        `json:"paidTours"`
+	AverageTicket  float64    `json:"averageTicket"`
+	LastPaymentAt  *time.Time `json:"lastPaymentAt"`
+	RefundedAmount float64    `json:"refundedAmount"`
+	RefundCount    int        `json:"refundCount"`
+}
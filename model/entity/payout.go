@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+type PayoutBatch struct {
+	PayoutBatchId int        `json:"payoutBatchId"`
+	Status        string     `json:"status"`
+	TotalAmount   float64    `json:"totalAmount"`
+	ItemCount     int        `json:"itemCount"`
+	Note          string     `json:"note"`
+	CreatedBy     int        `json:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ApprovedBy    *int       `json:"approvedBy"`
+	ApprovedAt    *time.Time `json:"approvedAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+func (p PayoutBatch) GetPayoutBatchTable() string {
+	return "PayoutBatch"
+}
+
+func (p PayoutBatch) GetPayoutBatchLimitRecords() int {
+	return 10
+}
+
+type PayoutItem struct {
+	PayoutItemId  int        `json:"payoutItemId"`
+	PayoutBatchId int        `json:"payoutBatchId"`
+	TourGuideId   int        `json:"tourGuideId"`
+	Amount        float64    `json:"amount"`
+	BankCode      string     `json:"bankCode"`
+	AccountNumber string     `json:"accountNumber"`
+	AccountHolder string     `json:"accountHolder"`
+	Reference     string     `json:"reference"`
+	Status        string     `json:"status"`
+	FailureReason string     `json:"failureReason"`
+	ProcessedAt   *time.Time `json:"processedAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+func (p PayoutItem) GetPayoutItemTable() string {
+	return "PayoutItem"
+}
+
+// Revenues settled by a payout item
+type PayoutItemRevenue struct {
+	PayoutItemId int `json:"payoutItemId"`
+	RevenueId    int `json:"revenueId"`
+}
+
+func (p PayoutItemRevenue) GetPayoutItemRevenueTable() string {
+	return "PayoutItemRevenue"
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type PayoutAccount struct {
+	PayoutAccountId int        `json:"payoutAccountId"`
+	TourGuideId     int        `json:"tourGuideId"`
+	BankCode        string     `json:"bankCode"`
+	AccountNumber   string     `json:"-"` // Encrypted at rest
+	AccountHolder   string     `json:"accountHolder"`
+	Status          string     `json:"status"`
+	RejectReason    string     `json:"rejectReason"`
+	IsDefault       bool       `json:"isDefault"`
+	VerifiedBy      *int       `json:"verifiedBy"`
+	VerifiedAt      *time.Time `json:"verifiedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+func (p PayoutAccount) GetPayoutAccountTable() string {
+	return "PayoutAccount"
+}
+
+func (p PayoutAccount) GetPayoutAccountLimitRecords() int {
+	return 10
+}
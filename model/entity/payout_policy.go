@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type PayoutPolicy struct {
+	PayoutPolicyId      int        `json:"payoutPolicyId"`
+	MinPayableBalance   float64    `json:"minPayableBalance"`
+	HoldDays            int        `json:"holdDays"`
+	Schedule            string     `json:"schedule"`
+	PayoutDay           int        `json:"payoutDay"` // Weekday (0 = Sunday) for weekly schedules, day of month otherwise
+	IsAutoPayoutEnabled bool       `json:"isAutoPayoutEnabled"`
+	LastRunAt           *time.Time `json:"lastRunAt"`
+	UpdatedBy           int        `json:"updatedBy"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+func (p PayoutPolicy) GetPayoutPolicyTable() string {
+	return "PayoutPolicy"
+}
@@ -0,0 +1,65 @@
+package entity
+
+import "time"
+
+// Code shared by a customer or a tour guide, every owner has a single code
+type ReferralCode struct {
+	ReferralCodeId int       `json:"referralCodeId"`
+	Code           string    `json:"code"`
+	OwnerType      string    `json:"ownerType"`
+	OwnerId        int       `json:"ownerId"`
+	IsActive       bool      `json:"isActive"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func (r ReferralCode) GetReferralCodeTable() string {
+	return "ReferralCode"
+}
+
+// Referee brought by a referrer, it is attributed at the first paid tour of the referee
+type Referral struct {
+	ReferralId     int        `json:"referralId"`
+	ReferralCodeId int        `json:"referralCodeId"`
+	ReferrerType   string     `json:"referrerType"`
+	ReferrerId     int        `json:"referrerId"`
+	RefereeType    string     `json:"refereeType"`
+	RefereeId      int        `json:"refereeId"`
+	Status         string     `json:"status"`
+	RewardedTours  int        `json:"rewardedTours"`
+	FirstPaymentId *int       `json:"firstPaymentId"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	AttributedAt   *time.Time `json:"attributedAt"`
+}
+
+func (r Referral) GetReferralTable() string {
+	return "Referral"
+}
+
+// Share of the platform commission of a paid tour earned by the referrer. Customers are credited to their wallet,
+// tour guides are paid with their next payout
+type ReferralReward struct {
+	ReferralRewardId    int        `json:"referralRewardId"`
+	ReferralId          int        `json:"referralId"`
+	ReferrerType        string     `json:"referrerType"`
+	ReferrerId          int        `json:"referrerId"`
+	PaymentId           int        `json:"paymentId"`
+	InvoiceId           int        `json:"invoiceId"`
+	RevenueId           int        `json:"revenueId"`
+	CommissionAmount    float64    `json:"commissionAmount"`
+	RewardRate          float64    `json:"rewardRate"`
+	Amount              float64    `json:"amount"`
+	Status              string     `json:"status"`
+	WalletTransactionId *int       `json:"walletTransactionId"`
+	PayoutItemId        *int       `json:"payoutItemId"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	PaidAt              *time.Time `json:"paidAt"`
+}
+
+func (r ReferralReward) GetReferralRewardTable() string {
+	return "ReferralReward"
+}
+
+func (r ReferralReward) GetReferralRewardLimitRecords() int {
+	return 20
+}
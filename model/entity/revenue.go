@@ -17,3 +17,38 @@ type Revenue struct {
 func (r Revenue) GetRevenueTable() string {
 	return "Revenue"
 }
+
+// Revenue aggregated over a time bucket, adjustments are counted in the bucket they were recorded in
+type RevenueBucket struct {
+	BucketStart        time.Time `json:"bucketStart"`
+	TotalAmount        float64   `json:"totalAmount"`
+	ActualReceived     float64   `json:"actualReceived"`
+	PlatformCommission float64   `json:"platformCommission"`
+	RevenueCount       int       `json:"revenueCount"`
+	SettledCount       int       `json:"settledCount"`
+}
+
+// Revenue aggregated over a group such as a tour guide or a tour service
+type RevenueGroup struct {
+	GroupId            int     `json:"groupId"`
+	TotalAmount        float64 `json:"totalAmount"`
+	ActualReceived     float64 `json:"actualReceived"`
+	PlatformCommission float64 `json:"platformCommission"`
+	RevenueCount       int     `json:"revenueCount"`
+}
+
+// Platform-wide revenue totals, settled means the guide share has been paid out
+type RevenueSummary struct {
+	TotalAmount        float64 `json:"totalAmount"`
+	ActualReceived     float64 `json:"actualReceived"`
+	PlatformCommission float64 `json:"platformCommission"`
+	RevenueCount       int     `json:"revenueCount"`
+	SettledAmount      float64 `json:"settledAmount"`
+	PendingAmount      float64 `json:"pendingAmount"`
+}
+
+// Revenue with the tour service of its payment, used for earnings statements
+type RevenueDetail struct {
+	Revenue
+	ServiceId int `json:"serviceId"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Corrections of a revenue, the effective amount is the original amount plus all deltas. The delta of actual received
+// is settled with the tour guide by the payout item which claims it, independently of the revenue itself
+type RevenueAdjustment struct {
+	RevenueAdjustmentId     int       `json:"revenueAdjustmentId"`
+	RevenueId               int       `json:"revenueId"`
+	AdjustmentType          string    `json:"adjustmentType"`
+	TotalAmountDelta        float64   `json:"totalAmountDelta"`
+	ActualReceivedDelta     float64   `json:"actualReceivedDelta"`
+	PlatformCommissionDelta float64   `json:"platformCommissionDelta"`
+	Reason                  string    `json:"reason"`
+	CreatedBy               int       `json:"createdBy"`
+	CreatedAt               time.Time `json:"createdAt"`
+	PayoutItemId            *int      `json:"payoutItemId"`
+	Settled                 bool      `json:"settled"`
+}
+
+func (r RevenueAdjustment) GetRevenueAdjustmentTable() string {
+	return "RevenueAdjustment"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Part of an invoice paid with loyalty points or the wallet while the rest is paid through a PayOS payment link. The part
+// is held and its payment stays PENDING until the link is paid, the part is given back when the link is cancelled or expires
+type SplitPayment struct {
+	SplitPaymentId int        `json:"splitPaymentId"`
+	PaymentId      int        `json:"paymentId"` // Payment of the held part
+	TourGuideId    int        `json:"tourGuideId"`
+	Points         int        `json:"points"`        // Zero for a wallet part
+	GatewayAmount  float64    `json:"gatewayAmount"` // Left to pay through the link
+	OrderCode      int64      `json:"orderCode"`
+	CheckoutUrl    string     `json:"checkoutUrl"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	CompletedAt    *time.Time `json:"completedAt"`
+}
+
+func (s SplitPayment) GetSplitPaymentTable() string {
+	return "SplitPayment"
+}
@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// Paid plan of tour guides, a subscriber pays the plan commission instead of the platform commission
+type SubscriptionPlan struct {
+	SubscriptionPlanId int       `json:"subscriptionPlanId"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description"`
+	Price              float64   `json:"price"`
+	CycleMonths        int       `json:"cycleMonths"`
+	CommissionRate     float64   `json:"commissionRate"` // Platform commission in percent of the tour price
+	IsFeatured         bool      `json:"isFeatured"`     // Subscribers are featured in the tour listing
+	IsActive           bool      `json:"isActive"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+func (s SubscriptionPlan) GetSubscriptionPlanTable() string {
+	return "SubscriptionPlan"
+}
+
+// Plan of a tour guide, it starts when the first invoice is paid and renews every cycle
+type Subscription struct {
+	SubscriptionId     int        `json:"subscriptionId"`
+	TourGuideId        int        `json:"tourGuideId"`
+	SubscriptionPlanId int        `json:"subscriptionPlanId"`
+	BillingEmail       string     `json:"billingEmail"`
+	Status             string     `json:"status"`
+	CurrentPeriodStart *time.Time `json:"currentPeriodStart"`
+	CurrentPeriodEnd   *time.Time `json:"currentPeriodEnd"`
+	CancelAtPeriodEnd  bool       `json:"cancelAtPeriodEnd"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+	EndedAt            *time.Time `json:"endedAt"`
+}
+
+func (s Subscription) GetSubscriptionTable() string {
+	return "Subscription"
+}
+
+func (s Subscription) GetSubscriptionLimitRecords() int {
+	return 20
+}
+
+// Invoice of a billing cycle paid through a PayOS link, a new link is created when the previous one is cancelled or expired
+type SubscriptionInvoice struct {
+	SubscriptionInvoiceId int        `json:"subscriptionInvoiceId"`
+	SubscriptionId        int        `json:"subscriptionId"`
+	TourGuideId           int        `json:"tourGuideId"`
+	Amount                float64    `json:"amount"`
+	PeriodStart           time.Time  `json:"periodStart"`
+	PeriodEnd             time.Time  `json:"periodEnd"`
+	OrderCode             int64      `json:"orderCode"`
+	CheckoutUrl           string     `json:"checkoutUrl"`
+	Status                string     `json:"status"`
+	DueAt                 time.Time  `json:"dueAt"`
+	ReminderCount         int        `json:"reminderCount"`
+	LastRemindedAt        *time.Time `json:"lastRemindedAt"`
+	PaidAt                *time.Time `json:"paidAt"`
+	CreatedAt             time.Time  `json:"createdAt"`
+}
+
+func (s SubscriptionInvoice) GetSubscriptionInvoiceTable() string {
+	return "SubscriptionInvoice"
+}
+
+func (s SubscriptionInvoice) GetSubscriptionInvoiceLimitRecords() int {
+	return 20
+}
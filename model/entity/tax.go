@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type TaxProfile struct {
+	TaxProfileId   int       `json:"taxProfileId"`
+	TourGuideId    int       `json:"tourGuideId"`
+	TaxpayerType   string    `json:"taxpayerType"`
+	TaxCode        string    `json:"taxCode"`
+	LegalName      string    `json:"legalName"`
+	IdentityNumber string    `json:"-"` // Encrypted at rest
+	Address        string    `json:"address"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func (t TaxProfile) GetTaxProfileTable() string {
+	return "TaxProfile"
+}
+
+// Tax calculated for a payout item, it only counts as withheld once the item is paid
+type TaxWithholding struct {
+	TaxWithholdingId int       `json:"taxWithholdingId"`
+	PayoutItemId     int       `json:"payoutItemId"`
+	TourGuideId      int       `json:"tourGuideId"`
+	TaxpayerType     string    `json:"taxpayerType"`
+	TaxCode          string    `json:"taxCode"`
+	GrossAmount      float64   `json:"grossAmount"`
+	TaxRate          float64   `json:"taxRate"`
+	TaxAmount        float64   `json:"taxAmount"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+func (t TaxWithholding) GetTaxWithholdingTable() string {
+	return "TaxWithholding"
+}
+
+// Withholding of a paid payout item with the transfer reference and date
+type PaidTaxWithholding struct {
+	TaxWithholding
+	Reference string    `json:"reference"`
+	PaidAt    time.Time `json:"paidAt"`
+}
+
+// Income paid to a tour guide over a period, taxable income is the part of payments which were withheld
+type TaxIncomeSummary struct {
+	TourGuideId   int     `json:"tourGuideId"`
+	TotalIncome   float64 `json:"totalIncome"`
+	TaxableIncome float64 `json:"taxableIncome"`
+	TaxAmount     float64 `json:"taxAmount"`
+	PaymentCount  int     `json:"paymentCount"`
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// Stored credit of a customer, the balance only changes together with a wallet transaction
+type Wallet struct {
+	WalletId   int       `json:"walletId"`
+	CustomerId int       `json:"customerId"`
+	Balance    float64   `json:"balance"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (w Wallet) GetWalletTable() string {
+	return "Wallet"
+}
+
+// Balance change of a wallet, the reference is the invoice of payments and refunds or the top-up of top-ups
+type WalletTransaction struct {
+	WalletTransactionId int       `json:"walletTransactionId"`
+	WalletId            int       `json:"walletId"`
+	CustomerId          int       `json:"customerId"`
+	TransactionType     string    `json:"transactionType"`
+	Amount              float64   `json:"amount"`
+	BalanceAfter        float64   `json:"balanceAfter"`
+	ReferenceId         *int      `json:"referenceId"`
+	Description         string    `json:"description"`
+	CreatedBy           int       `json:"createdBy"`
+	CreatedAt           time.Time `json:"createdAt"`
+}
+
+func (w WalletTransaction) GetWalletTransactionTable() string {
+	return "WalletTransaction"
+}
+
+func (w WalletTransaction) GetWalletTransactionLimitRecords() int {
+	return 20
+}
+
+// Top-up paid through a PayOS payment link, the wallet is credited once the link is paid
+type WalletTopUp struct {
+	WalletTopUpId int        `json:"walletTopUpId"`
+	CustomerId    int        `json:"customerId"`
+	Amount        float64    `json:"amount"`
+	OrderCode     int64      `json:"orderCode"`
+	CheckoutUrl   string     `json:"checkoutUrl"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	PaidAt        *time.Time `json:"paidAt"`
+}
+
+func (w WalletTopUp) GetWalletTopUpTable() string {
+	return "WalletTopUp"
+}
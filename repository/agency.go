@@ -0,0 +1,519 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/utils"
+)
+
+type agencyRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeAgencyRepo(db *sql.DB, logger *log.Logger) repo.IAgencyRepo {
+	return &agencyRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAgencies implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencies(ctx context.Context) (*[]entity.Agency, error) {
+	var table string = entity.Agency{}.GetAgencyTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencies - "
+	var query string = "SELECT * FROM " + table + " ORDER BY name ASC"
+
+	rows, err := a.db.QueryContext(ctx, query)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.Agency
+	for rows.Next() {
+		var x entity.Agency
+		if err := rows.Scan(
+			&x.AgencyId, &x.Name, &x.CommissionRate, &x.ShareRate, &x.BankCode, &x.AccountNumber, &x.AccountHolder,
+			&x.IsActive, &x.CreatedBy, &x.CreatedAt, &x.UpdatedAt); err != nil {
+
+			a.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetAgencyById implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyById(id int, ctx context.Context) (*entity.Agency, error) {
+	return a.getAgency("agencyId = @p1", "GetAgencyById - ", ctx, id)
+}
+
+// GetAgencyByName implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyByName(name string, ctx context.Context) (*entity.Agency, error) {
+	return a.getAgency("name = @p1", "GetAgencyByName - ", ctx, name)
+}
+
+func (a *agencyRepo) getAgency(condition string, method string, ctx context.Context, args ...interface{}) (*entity.Agency, error) {
+	var res entity.Agency
+	var query string = "SELECT * FROM " + res.GetAgencyTable() + " WHERE " + condition
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetAgencyTable()) + method
+
+	if err := a.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.AgencyId, &res.Name, &res.CommissionRate, &res.ShareRate, &res.BankCode, &res.AccountNumber, &res.AccountHolder,
+		&res.IsActive, &res.CreatedBy, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateAgency implements repo.IAgencyRepo.
+func (a *agencyRepo) CreateAgency(agency entity.Agency, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + agency.GetAgencyTable() +
+		" (name, commissionRate, shareRate, bankCode, accountNumber, accountHolder, isActive, createdBy, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.agencyId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, agency.GetAgencyTable()) + "CreateAgency - "
+
+	var res int
+	if err := a.db.QueryRowContext(ctx, query, agency.Name, agency.CommissionRate, agency.ShareRate, agency.BankCode,
+		agency.AccountNumber, agency.AccountHolder, agency.IsActive, agency.CreatedBy, agency.CreatedAt, agency.UpdatedAt).Scan(&res); err != nil {
+
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateAgency implements repo.IAgencyRepo.
+func (a *agencyRepo) UpdateAgency(agency entity.Agency, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, agency.GetAgencyTable()) + "UpdateAgency - "
+	var query string = "UPDATE " + agency.GetAgencyTable() + " SET name = @p1, commissionRate = @p2, shareRate = @p3, " +
+		"bankCode = @p4, accountNumber = @p5, accountHolder = @p6, isActive = @p7, updatedAt = @p8 WHERE agencyId = @p9"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := a.db.ExecContext(ctx, query, agency.Name, agency.CommissionRate, agency.ShareRate, agency.BankCode,
+		agency.AccountNumber, agency.AccountHolder, agency.IsActive, agency.UpdatedAt, agency.AgencyId)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, agency.GetAgencyTable()))
+	}
+
+	return nil
+}
+
+// CreateAgencyRevenue implements repo.IAgencyRepo.
+func (a *agencyRepo) CreateAgencyRevenue(revenue entity.AgencyRevenue, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + revenue.GetAgencyRevenueTable() +
+		" (agencyId, revenueId, paymentId, tourGuideId, invoiceId, totalAmount, shareRate, amount, agencyPayoutId, createdAt) " +
+		"OUTPUT INSERTED.agencyRevenueId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, revenue.GetAgencyRevenueTable()) + "CreateAgencyRevenue - "
+
+	var res int
+	if err := a.db.QueryRowContext(ctx, query, revenue.AgencyId, revenue.RevenueId, revenue.PaymentId, revenue.TourGuideId,
+		revenue.InvoiceId, revenue.TotalAmount, revenue.ShareRate, revenue.Amount, revenue.AgencyPayoutId, revenue.CreatedAt).Scan(&res); err != nil {
+
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// GetAgencyRevenuesByPaymentId implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyRevenuesByPaymentId(paymentId int, ctx context.Context) (*[]entity.AgencyRevenue, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencyRevenuesByPaymentId - "
+	var query string = "SELECT * FROM " + table + " WHERE paymentId = @p1 ORDER BY agencyRevenueId ASC"
+
+	rows, err := a.db.QueryContext(ctx, query, paymentId)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanAgencyRevenues(rows)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetAgencyRevenues implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyRevenues(req request.GetAgencyRevenuesRequest, from, to *time.Time, ctx context.Context) (*[]entity.AgencyRevenue, int, int, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencyRevenues - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = generateAgencyRevenueQuery(req.AgencyId, from, to)
+	if req.TourGuideId > 0 {
+		builder.where("tourGuideId = ?", req.TourGuideId)
+	}
+
+	builder.orderBy("agencyRevenueId", order.DESCENDING_ORDER)
+
+	rows, err := a.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanAgencyRevenues(rows)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	a.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetAgencyRevenueSummary implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyRevenueSummary(agencyId int, from, to *time.Time, ctx context.Context) (*entity.AgencyRevenueSummary, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencyRevenueSummary - "
+
+	var builder *queryBuilder = generateAgencyRevenueQuery(agencyId, from, to)
+
+	// Reversals are counted with their payment, so only the original shares are counted as payments
+	var query string = "SELECT ISNULL(SUM(totalAmount), 0), ISNULL(SUM(amount), 0), " +
+		"ISNULL(SUM(CASE WHEN agencyPayoutId IN (SELECT agencyPayoutId FROM " + entity.AgencyPayout{}.GetAgencyPayoutTable() +
+		" WHERE status = " + builder.param(domain_status.AGENCY_PAYOUT_PAID) + ") THEN amount ELSE 0 END), 0), " +
+		"COUNT(CASE WHEN amount > 0 THEN 1 END) FROM " + table + " " + builder.condition()
+
+	var res entity.AgencyRevenueSummary
+	if err := a.db.QueryRowContext(ctx, query, builder.args...).Scan(&res.TotalAmount, &res.Amount, &res.PaidAmount, &res.PaymentCount); err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	res.TotalAmount = utils.RoundMoney(res.TotalAmount)
+	res.Amount = utils.RoundMoney(res.Amount)
+	res.PaidAmount = utils.RoundMoney(res.PaidAmount)
+	res.PendingAmount = utils.RoundMoney(res.Amount - res.PaidAmount)
+
+	return &res, nil
+}
+
+// GetAgencyGuideRevenues implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyGuideRevenues(agencyId int, from, to *time.Time, ctx context.Context) (*[]entity.AgencyGuideRevenue, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencyGuideRevenues - "
+
+	var builder *queryBuilder = generateAgencyRevenueQuery(agencyId, from, to)
+	var query string = "SELECT tourGuideId, SUM(totalAmount), SUM(amount), COUNT(CASE WHEN amount > 0 THEN 1 END) FROM " + table + " " +
+		builder.condition() + " GROUP BY tourGuideId ORDER BY SUM(amount) DESC, tourGuideId ASC"
+
+	rows, err := a.db.QueryContext(ctx, query, builder.args...)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.AgencyGuideRevenue
+	for rows.Next() {
+		var x entity.AgencyGuideRevenue
+		if err := rows.Scan(&x.TourGuideId, &x.TotalAmount, &x.Amount, &x.PaymentCount); err != nil {
+			a.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		x.TotalAmount = utils.RoundMoney(x.TotalAmount)
+		x.Amount = utils.RoundMoney(x.Amount)
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPayableAgencyRevenues implements repo.IAgencyRepo.
+func (a *agencyRepo) GetPayableAgencyRevenues(agencyId int, ctx context.Context) (*[]entity.AgencyRevenue, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayableAgencyRevenues - "
+	var query string = "SELECT * FROM " + table + " WHERE agencyId = @p1 AND agencyPayoutId IS NULL ORDER BY agencyRevenueId ASC"
+
+	rows, err := a.db.QueryContext(ctx, query, agencyId)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanAgencyRevenues(rows)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateAgencyPayout implements repo.IAgencyRepo.
+func (a *agencyRepo) CreateAgencyPayout(payout entity.AgencyPayout, revenueIds []int, ctx context.Context) (int, error) {
+	var table string = payout.GetAgencyPayoutTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "CreateAgencyPayout - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var createQuery string = "INSERT INTO " + table +
+		" (agencyId, amount, bankCode, accountNumber, accountHolder, reference, status, note, createdBy, processedBy, createdAt, processedAt) " +
+		"OUTPUT INSERTED.agencyPayoutId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12)"
+	var referenceQuery string = "UPDATE " + table + " SET reference = @p1 WHERE agencyPayoutId = @p2"
+
+	// Revenues taken by a concurrent payout are not linked again
+	var revenueQuery string = "UPDATE " + entity.AgencyRevenue{}.GetAgencyRevenueTable() + " SET agencyPayoutId = @p1 " +
+		"WHERE agencyPayoutId IS NULL AND agencyRevenueId IN (" + generateInParams(2, len(revenueIds)) + ")"
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+	defer tx.Rollback()
+
+	var payoutId int
+	if err := tx.QueryRowContext(ctx, createQuery, payout.AgencyId, payout.Amount, payout.BankCode, payout.AccountNumber,
+		payout.AccountHolder, payout.Reference, payout.Status, payout.Note, payout.CreatedBy, payout.ProcessedBy, payout.CreatedAt,
+		payout.ProcessedAt).Scan(&payoutId); err != nil {
+
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if _, err := tx.ExecContext(ctx, referenceQuery, utils.GenerateAgencyPayoutReference(payoutId, payout.AgencyId), payoutId); err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	var args []interface{} = []interface{}{payoutId}
+	for _, id := range revenueIds {
+		args = append(args, id)
+	}
+
+	res, err := tx.ExecContext(ctx, revenueQuery, args...)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if int(rowsAffected) != len(revenueIds) {
+		return 0, errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	if err := tx.Commit(); err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	return payoutId, nil
+}
+
+// GetAgencyPayoutById implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyPayoutById(id int, ctx context.Context) (*entity.AgencyPayout, error) {
+	var res entity.AgencyPayout
+	var query string = "SELECT * FROM " + res.GetAgencyPayoutTable() + " WHERE agencyPayoutId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetAgencyPayoutTable()) + "GetAgencyPayoutById - "
+
+	if err := a.db.QueryRowContext(ctx, query, id).Scan(
+		&res.AgencyPayoutId, &res.AgencyId, &res.Amount, &res.BankCode, &res.AccountNumber, &res.AccountHolder, &res.Reference,
+		&res.Status, &res.Note, &res.CreatedBy, &res.ProcessedBy, &res.CreatedAt, &res.ProcessedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetAgencyPayouts implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyPayouts(req request.GetAgencyPayoutsRequest, ctx context.Context) (*[]entity.AgencyPayout, int, int, error) {
+	var table string = entity.AgencyPayout{}.GetAgencyPayoutTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencyPayouts - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder().where("agencyId = ?", req.AgencyId)
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	builder.orderBy("agencyPayoutId", order.DESCENDING_ORDER)
+
+	rows, err := a.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.AgencyPayout
+	for rows.Next() {
+		var x entity.AgencyPayout
+		if err := rows.Scan(
+			&x.AgencyPayoutId, &x.AgencyId, &x.Amount, &x.BankCode, &x.AccountNumber, &x.AccountHolder, &x.Reference,
+			&x.Status, &x.Note, &x.CreatedBy, &x.ProcessedBy, &x.CreatedAt, &x.ProcessedAt); err != nil {
+
+			a.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	a.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetAgencyRevenueIdsByPayoutId implements repo.IAgencyRepo.
+func (a *agencyRepo) GetAgencyRevenueIdsByPayoutId(payoutId int, ctx context.Context) ([]int, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAgencyRevenueIdsByPayoutId - "
+	var query string = "SELECT agencyRevenueId FROM " + table + " WHERE agencyPayoutId = @p1 ORDER BY agencyRevenueId ASC"
+
+	rows, err := a.db.QueryContext(ctx, query, payoutId)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			a.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+// UpdateAgencyPayout implements repo.IAgencyRepo.
+func (a *agencyRepo) UpdateAgencyPayout(payout entity.AgencyPayout, currentStatus string, ctx context.Context) error {
+	var table string = payout.GetAgencyPayoutTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "UpdateAgencyPayout - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var query string = "UPDATE " + table + " SET status = @p1, note = @p2, processedBy = @p3, processedAt = @p4 " +
+		"WHERE agencyPayoutId = @p5 AND status = @p6"
+	var releaseQuery string = "UPDATE " + entity.AgencyRevenue{}.GetAgencyRevenueTable() + " SET agencyPayoutId = NULL WHERE agencyPayoutId = @p1"
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, query, payout.Status, payout.Note, payout.ProcessedBy, payout.ProcessedAt, payout.AgencyPayoutId, currentStatus)
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	if payout.Status == domain_status.AGENCY_PAYOUT_CANCELLED {
+		if _, err := tx.ExecContext(ctx, releaseQuery, payout.AgencyPayoutId); err != nil {
+			a.logger.Println(errLogMsg + err.Error())
+			return internalErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		a.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	return nil
+}
+
+func scanAgencyRevenues(rows *sql.Rows) ([]entity.AgencyRevenue, error) {
+	var res []entity.AgencyRevenue
+	for rows.Next() {
+		var x entity.AgencyRevenue
+		if err := rows.Scan(
+			&x.AgencyRevenueId, &x.AgencyId, &x.RevenueId, &x.PaymentId, &x.TourGuideId, &x.InvoiceId, &x.TotalAmount,
+			&x.ShareRate, &x.Amount, &x.AgencyPayoutId, &x.CreatedAt); err != nil {
+
+			return nil, err
+		}
+
+		res = append(res, x)
+	}
+
+	return res, nil
+}
+
+// Revenues of the agency in [from, to)
+func generateAgencyRevenueQuery(agencyId int, from, to *time.Time) *queryBuilder {
+	var res *queryBuilder = newQueryBuilder().where("agencyId = ?", agencyId)
+	if from != nil {
+		res.where("createdAt >= ?", *from)
+	}
+
+	if to != nil {
+		res.where("createdAt < ?", *to)
+	}
+
+	return res
+}
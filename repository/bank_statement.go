@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type bankStatementRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeBankStatementRepo(db *sql.DB, logger *log.Logger) repo.IBankStatementRepo {
+	return &bankStatementRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPendingInvoiceById implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) GetPendingInvoiceById(id int, ctx context.Context) (*entity.PendingInvoice, error) {
+	var res entity.PendingInvoice
+	var query string = "SELECT * FROM " + res.GetPendingInvoiceTable() + " WHERE pendingInvoiceId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPendingInvoiceTable()) + "GetPendingInvoiceById - "
+
+	if err := b.db.QueryRowContext(ctx, query, id).Scan(
+		&res.PendingInvoiceId, &res.InvoiceId, &res.CustomerId, &res.TourGuideId, &res.ServiceId,
+		&res.Amount, &res.PaymentMethod, &res.Status, &res.PaymentId, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		b.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetPendingInvoiceByInvoiceId implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) GetPendingInvoiceByInvoiceId(invoiceId int, ctx context.Context) (*entity.PendingInvoice, error) {
+	var res entity.PendingInvoice
+	var query string = "SELECT TOP 1 * FROM " + res.GetPendingInvoiceTable() + " WHERE invoiceId = @p1 ORDER BY pendingInvoiceId DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPendingInvoiceTable()) + "GetPendingInvoiceByInvoiceId - "
+
+	if err := b.db.QueryRowContext(ctx, query, invoiceId).Scan(
+		&res.PendingInvoiceId, &res.InvoiceId, &res.CustomerId, &res.TourGuideId, &res.ServiceId,
+		&res.Amount, &res.PaymentMethod, &res.Status, &res.PaymentId, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		b.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreatePendingInvoice implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) CreatePendingInvoice(invoice entity.PendingInvoice, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + invoice.GetPendingInvoiceTable() +
+		" (invoiceId, customerId, tourGuideId, serviceId, amount, paymentMethod, status, paymentId, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.pendingInvoiceId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, invoice.GetPendingInvoiceTable()) + "CreatePendingInvoice - "
+
+	var res int
+	if err := b.db.QueryRowContext(ctx, query, invoice.InvoiceId, invoice.CustomerId, invoice.TourGuideId, invoice.ServiceId,
+		invoice.Amount, invoice.PaymentMethod, invoice.Status, invoice.PaymentId, invoice.CreatedAt, invoice.UpdatedAt).Scan(&res); err != nil {
+
+		b.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdatePendingInvoice implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) UpdatePendingInvoice(invoice entity.PendingInvoice, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, invoice.GetPendingInvoiceTable()) + "UpdatePendingInvoice - "
+	var query string = "UPDATE " + invoice.GetPendingInvoiceTable() + " SET customerId = @p1, tourGuideId = @p2, serviceId = @p3, " +
+		"amount = @p4, paymentMethod = @p5, status = @p6, paymentId = @p7, updatedAt = @p8 WHERE pendingInvoiceId = @p9 AND status = @p10"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := b.db.ExecContext(ctx, query, invoice.CustomerId, invoice.TourGuideId, invoice.ServiceId, invoice.Amount,
+		invoice.PaymentMethod, invoice.Status, invoice.PaymentId, invoice.UpdatedAt, invoice.PendingInvoiceId, currentStatus)
+	if err != nil {
+		b.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		b.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// IsBankTransactionImported implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) IsBankTransactionImported(transaction entity.BankTransaction, ctx context.Context) (bool, error) {
+	var table string = transaction.GetBankTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "IsBankTransactionImported - "
+
+	var query string
+	var args []interface{}
+	if transaction.TransactionRef != "" {
+		query = "SELECT COUNT(*) FROM " + table + " WHERE bankCode = @p1 AND transactionRef = @p2"
+		args = []interface{}{transaction.BankCode, transaction.TransactionRef}
+	} else {
+		query = "SELECT COUNT(*) FROM " + table + " WHERE bankCode = @p1 AND transactionDate = @p2 AND amount = @p3 AND description = @p4"
+		args = []interface{}{transaction.BankCode, transaction.TransactionDate, transaction.Amount, transaction.Description}
+	}
+
+	var count int
+	if err := b.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		b.logger.Println(errLogMsg + err.Error())
+		return false, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return count > 0, nil
+}
+
+// CreateBankTransaction implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) CreateBankTransaction(transaction entity.BankTransaction, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + transaction.GetBankTransactionTable() +
+		" (bankCode, transactionRef, transactionDate, amount, description, status, matchNote, pendingInvoiceId, paymentId, " +
+		"importedBy, importedAt, reviewedBy, reviewedAt) " +
+		"OUTPUT INSERTED.bankTransactionId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, transaction.GetBankTransactionTable()) + "CreateBankTransaction - "
+
+	var res int
+	if err := b.db.QueryRowContext(ctx, query, transaction.BankCode, transaction.TransactionRef, transaction.TransactionDate,
+		transaction.Amount, transaction.Description, transaction.Status, transaction.MatchNote, transaction.PendingInvoiceId,
+		transaction.PaymentId, transaction.ImportedBy, transaction.ImportedAt, transaction.ReviewedBy, transaction.ReviewedAt).Scan(&res); err != nil {
+
+		b.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// GetBankTransactions implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) GetBankTransactions(req request.GetBankTransactionsRequest, ctx context.Context) (*[]entity.BankTransaction, int, int, error) {
+	var table string = entity.BankTransaction{}.GetBankTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetBankTransactions - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	builder.orderBy("transactionDate", order.DESCENDING_ORDER)
+
+	rows, err := b.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		b.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.BankTransaction
+	for rows.Next() {
+		var x entity.BankTransaction
+		if err := rows.Scan(
+			&x.BankTransactionId, &x.BankCode, &x.TransactionRef, &x.TransactionDate, &x.Amount, &x.Description,
+			&x.Status, &x.MatchNote, &x.PendingInvoiceId, &x.PaymentId, &x.ImportedBy, &x.ImportedAt,
+			&x.ReviewedBy, &x.ReviewedAt); err != nil {
+
+			b.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	b.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetBankTransactionById implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) GetBankTransactionById(id int, ctx context.Context) (*entity.BankTransaction, error) {
+	var res entity.BankTransaction
+	var query string = "SELECT * FROM " + res.GetBankTransactionTable() + " WHERE bankTransactionId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetBankTransactionTable()) + "GetBankTransactionById - "
+
+	if err := b.db.QueryRowContext(ctx, query, id).Scan(
+		&res.BankTransactionId, &res.BankCode, &res.TransactionRef, &res.TransactionDate, &res.Amount, &res.Description,
+		&res.Status, &res.MatchNote, &res.PendingInvoiceId, &res.PaymentId, &res.ImportedBy, &res.ImportedAt,
+		&res.ReviewedBy, &res.ReviewedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		b.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// UpdateBankTransaction implements repo.IBankStatementRepo.
+func (b *bankStatementRepo) UpdateBankTransaction(transaction entity.BankTransaction, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, transaction.GetBankTransactionTable()) + "UpdateBankTransaction - "
+	var query string = "UPDATE " + transaction.GetBankTransactionTable() + " SET status = @p1, matchNote = @p2, pendingInvoiceId = @p3, " +
+		"paymentId = @p4, reviewedBy = @p5, reviewedAt = @p6 WHERE bankTransactionId = @p7 AND status = @p8"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := b.db.ExecContext(ctx, query, transaction.Status, transaction.MatchNote, transaction.PendingInvoiceId,
+		transaction.PaymentId, transaction.ReviewedBy, transaction.ReviewedAt, transaction.BankTransactionId, currentStatus)
+	if err != nil {
+		b.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		b.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
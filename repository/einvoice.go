@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"tourmate/payment-service/constant/einvoice"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type eInvoiceRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeEInvoiceRepo(db *sql.DB, logger *log.Logger) repo.IEInvoiceRepo {
+	return &eInvoiceRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetEInvoiceById implements repo.IEInvoiceRepo.
+func (e *eInvoiceRepo) GetEInvoiceById(id int, ctx context.Context) (*entity.EInvoice, error) {
+	var res entity.EInvoice
+	var query string = "SELECT * FROM " + res.GetEInvoiceTable() + " WHERE eInvoiceId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetEInvoiceTable()) + "GetEInvoiceById - "
+
+	if err := e.db.QueryRowContext(ctx, query, id).Scan(
+		&res.EInvoiceId, &res.PaymentId, &res.InvoiceType, &res.TemplateCode, &res.Series, &res.InvoiceNumber, &res.Status,
+		&res.BuyerName, &res.BuyerCompanyName, &res.BuyerTaxCode, &res.BuyerAddress, &res.BuyerEmail, &res.ItemName,
+		&res.AmountBeforeTax, &res.VatRate, &res.VatAmount, &res.TotalAmount, &res.RelatedEInvoiceId, &res.Reason, &res.IssuedBy, &res.IssuedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		e.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetEInvoicesByPaymentId implements repo.IEInvoiceRepo.
+func (e *eInvoiceRepo) GetEInvoicesByPaymentId(paymentId int, ctx context.Context) (*[]entity.EInvoice, error) {
+	var table string = entity.EInvoice{}.GetEInvoiceTable()
+	var query string = "SELECT * FROM " + table + " WHERE paymentId = @p1 ORDER BY issuedAt ASC, eInvoiceId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetEInvoicesByPaymentId - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := e.db.QueryContext(ctx, query, paymentId)
+	if err != nil {
+		e.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.EInvoice
+	for rows.Next() {
+		var x entity.EInvoice
+		if err := rows.Scan(
+			&x.EInvoiceId, &x.PaymentId, &x.InvoiceType, &x.TemplateCode, &x.Series, &x.InvoiceNumber, &x.Status,
+			&x.BuyerName, &x.BuyerCompanyName, &x.BuyerTaxCode, &x.BuyerAddress, &x.BuyerEmail, &x.ItemName,
+			&x.AmountBeforeTax, &x.VatRate, &x.VatAmount, &x.TotalAmount, &x.RelatedEInvoiceId, &x.Reason, &x.IssuedBy, &x.IssuedAt); err != nil {
+
+			e.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// CreateEInvoice implements repo.IEInvoiceRepo.
+func (e *eInvoiceRepo) CreateEInvoice(invoice entity.EInvoice, relatedStatus string, ctx context.Context) (*entity.EInvoice, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, invoice.GetEInvoiceTable()) + "CreateEInvoice - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var sequenceTable string = entity.EInvoiceSequence{}.GetEInvoiceSequenceTable()
+	// The lock keeps numbers of a series gapless when invoices are issued at the same time
+	var nextNumberQuery string = "UPDATE " + sequenceTable + " WITH (UPDLOCK, HOLDLOCK) SET lastNumber = lastNumber + 1 " +
+		"OUTPUT INSERTED.lastNumber WHERE templateCode = @p1 AND series = @p2"
+	var createSequenceQuery string = "INSERT INTO " + sequenceTable + " (templateCode, series, lastNumber) values (@p1, @p2, 1)"
+	var createQuery string = "INSERT INTO " + invoice.GetEInvoiceTable() +
+		" (paymentId, invoiceType, templateCode, series, invoiceNumber, status, buyerName, buyerCompanyName, buyerTaxCode, " +
+		"buyerAddress, buyerEmail, itemName, amountBeforeTax, vatRate, vatAmount, totalAmount, relatedEInvoiceId, reason, issuedBy, issuedAt) " +
+		"OUTPUT INSERTED.eInvoiceId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13, @p14, @p15, @p16, @p17, @p18, @p19, @p20)"
+	var updateRelatedQuery string = "UPDATE " + invoice.GetEInvoiceTable() + " SET status = @p1 WHERE eInvoiceId = @p2"
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		e.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, nextNumberQuery, invoice.TemplateCode, invoice.Series).Scan(&invoice.InvoiceNumber); err != nil {
+		if err != sql.ErrNoRows {
+			e.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		// First invoice of the series
+		if _, err := tx.ExecContext(ctx, createSequenceQuery, invoice.TemplateCode, invoice.Series); err != nil {
+			e.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		invoice.InvoiceNumber = 1
+	}
+
+	if invoice.InvoiceNumber > einvoice.MAX_INVOICE_NUMBER {
+		return nil, errors.New(noti.EINVOICE_NUMBER_EXHAUSTED_WARN_MSG)
+	}
+
+	if err := tx.QueryRowContext(ctx, createQuery, invoice.PaymentId, invoice.InvoiceType, invoice.TemplateCode, invoice.Series,
+		invoice.InvoiceNumber, invoice.Status, invoice.BuyerName, invoice.BuyerCompanyName, invoice.BuyerTaxCode, invoice.BuyerAddress,
+		invoice.BuyerEmail, invoice.ItemName, invoice.AmountBeforeTax, invoice.VatRate, invoice.VatAmount, invoice.TotalAmount,
+		invoice.RelatedEInvoiceId, invoice.Reason, invoice.IssuedBy, invoice.IssuedAt).Scan(&invoice.EInvoiceId); err != nil {
+
+		e.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if invoice.RelatedEInvoiceId != nil {
+		if _, err := tx.ExecContext(ctx, updateRelatedQuery, relatedStatus, *invoice.RelatedEInvoiceId); err != nil {
+			e.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		e.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	return &invoice, nil
+}
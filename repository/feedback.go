@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	filter_property "tourmate/payment-service/constant/filter_property"
 	"tourmate/payment-service/constant/noti"
 	"tourmate/payment-service/interface/repo"
 	"tourmate/payment-service/model/dto/request"
@@ -101,67 +102,40 @@ func (f *feedbackRepo) GetFeedbacks(req request.GetFeedbacksRequest, ctx context
 	var limitRecords int = req.PageSize
 
 	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetFeedbacks - "
-	var queryCondition string = "WHERE "
-	var isHavePreviousCond bool = false
+	var builder *queryBuilder = newQueryBuilder().whereLike(req.Request.Keyword, "content")
 
 	if req.ServiceId != nil {
-		queryCondition += fmt.Sprintf("serviceId = %d", *req.ServiceId)
-		isHavePreviousCond = true
+		builder.where("serviceId = ?", *req.ServiceId)
 	}
 
 	if req.TourGuideId != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
-		}
-		queryCondition += fmt.Sprintf("tourGuideId = %d", *req.TourGuideId)
-		isHavePreviousCond = true
+		builder.where("tourGuideId = ?", *req.TourGuideId)
 	}
 
 	if req.CustomerId != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
-		}
-		queryCondition += fmt.Sprintf("customerId = %d", *req.CustomerId)
-		isHavePreviousCond = true
+		builder.where("customerId = ?", *req.CustomerId)
 	}
 
 	if req.InvoiceId != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
-		}
-		queryCondition += fmt.Sprintf("invoiceId = %d", *req.InvoiceId)
-		isHavePreviousCond = true
+		builder.where("invoiceId = ?", *req.InvoiceId)
 	}
+
 	if req.IsDeleted != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
-		}
-		queryCondition += fmt.Sprintf("isDeleted = %t", *req.IsDeleted)
-		isHavePreviousCond = true
+		builder.where("isDeleted = ?", *req.IsDeleted)
 	}
 
 	if req.Rating != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
-		}
-		queryCondition += fmt.Sprintf("rating = %d", *req.Rating)
-		isHavePreviousCond = true
+		builder.where("rating = ?", *req.Rating)
 	}
 
-	if queryCondition == "WHERE " {
-		queryCondition = ""
-	}
-
-	var orderCondition string = generateOrderCondition(req.Request.FilterProp, req.Request.Order)
-	var query string = generateRetrieveQuery(table, queryCondition+orderCondition, limitRecords, req.Request.Page, false)
-
-	f.logger.Println("Query: ", query)
+	builder.sortBy(req.Request.FilterProp, req.Request.Order, filter_property.FEEDBACK_SORT_COLUMNS, "createdDate")
 
-	rows, err := f.db.Query(query)
+	rows, err := f.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
 	if err != nil {
 		f.logger.Println(errLogMsg + err.Error())
 		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
 	}
+	defer rows.Close()
 
 	var res []entity.Feedback
 	for rows.Next() {
@@ -180,7 +154,10 @@ func (f *feedbackRepo) GetFeedbacks(req request.GetFeedbacksRequest, ctx context
 
 	// Track total records in table
 	var totalRecords int
-	f.db.QueryRow(generateRetrieveQuery(table, queryCondition, limitRecords, req.Request.Page, true)).Scan(&totalRecords)
+	if err := f.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords); err != nil {
+		f.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
 
 	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
 }
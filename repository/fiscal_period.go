@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type fiscalPeriodRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeFiscalPeriodRepo(db *sql.DB, logger *log.Logger) repo.IFiscalPeriodRepo {
+	return &fiscalPeriodRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetFiscalPeriodsByYear implements repo.IFiscalPeriodRepo.
+func (f *fiscalPeriodRepo) GetFiscalPeriodsByYear(year int, ctx context.Context) (*[]entity.FiscalPeriod, error) {
+	var table string = entity.FiscalPeriod{}.GetFiscalPeriodTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetFiscalPeriodsByYear - "
+	var query string = "SELECT * FROM " + table + " WHERE year = @p1 ORDER BY month ASC"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := f.db.QueryContext(ctx, query, year)
+	if err != nil {
+		f.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.FiscalPeriod
+	for rows.Next() {
+		var x entity.FiscalPeriod
+		if err := rows.Scan(&x.FiscalPeriodId, &x.Year, &x.Month, &x.Status, &x.ClosedBy, &x.ClosedAt, &x.UpdatedAt); err != nil {
+			f.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetFiscalPeriod implements repo.IFiscalPeriodRepo.
+func (f *fiscalPeriodRepo) GetFiscalPeriod(year int, month int, ctx context.Context) (*entity.FiscalPeriod, error) {
+	var res entity.FiscalPeriod
+	var query string = "SELECT * FROM " + res.GetFiscalPeriodTable() + " WHERE year = @p1 AND month = @p2"
+
+	return f.getFiscalPeriod(query, "GetFiscalPeriod - ", ctx, year, month)
+}
+
+// GetFiscalPeriodById implements repo.IFiscalPeriodRepo.
+func (f *fiscalPeriodRepo) GetFiscalPeriodById(id int, ctx context.Context) (*entity.FiscalPeriod, error) {
+	var res entity.FiscalPeriod
+	var query string = "SELECT * FROM " + res.GetFiscalPeriodTable() + " WHERE fiscalPeriodId = @p1"
+
+	return f.getFiscalPeriod(query, "GetFiscalPeriodById - ", ctx, id)
+}
+
+func (f *fiscalPeriodRepo) getFiscalPeriod(query, method string, ctx context.Context, args ...interface{}) (*entity.FiscalPeriod, error) {
+	var res entity.FiscalPeriod
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetFiscalPeriodTable()) + method
+
+	if err := f.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.FiscalPeriodId, &res.Year, &res.Month, &res.Status, &res.ClosedBy, &res.ClosedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		f.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// SaveFiscalPeriod implements repo.IFiscalPeriodRepo.
+func (f *fiscalPeriodRepo) SaveFiscalPeriod(period entity.FiscalPeriod, audit entity.FiscalPeriodAudit, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, period.GetFiscalPeriodTable()) + "SaveFiscalPeriod - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var createQuery string = "INSERT INTO " + period.GetFiscalPeriodTable() +
+		" (year, month, status, closedBy, closedAt, updatedAt) " +
+		"OUTPUT INSERTED.fiscalPeriodId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6)"
+	var updateQuery string = "UPDATE " + period.GetFiscalPeriodTable() +
+		" SET status = @p1, closedBy = @p2, closedAt = @p3, updatedAt = @p4 WHERE fiscalPeriodId = @p5"
+	var auditQuery string = "INSERT INTO " + audit.GetFiscalPeriodAuditTable() +
+		" (fiscalPeriodId, fromStatus, toStatus, reason, actorId, createdAt) " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6)"
+
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		f.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+	defer tx.Rollback()
+
+	var id int = period.FiscalPeriodId
+	if id == 0 {
+		if err := tx.QueryRowContext(ctx, createQuery, period.Year, period.Month, period.Status,
+			period.ClosedBy, period.ClosedAt, period.UpdatedAt).Scan(&id); err != nil {
+
+			f.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+	} else {
+		res, err := tx.ExecContext(ctx, updateQuery, period.Status, period.ClosedBy, period.ClosedAt, period.UpdatedAt, id)
+		if err != nil {
+			f.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			f.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+
+		if rowsAffected == 0 {
+			return 0, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, period.GetFiscalPeriodTable()))
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, auditQuery, id, audit.FromStatus, audit.ToStatus, audit.Reason, audit.ActorId, audit.CreatedAt); err != nil {
+		f.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		f.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	return id, nil
+}
+
+// GetFiscalPeriodAudits implements repo.IFiscalPeriodRepo.
+func (f *fiscalPeriodRepo) GetFiscalPeriodAudits(periodId int, ctx context.Context) (*[]entity.FiscalPeriodAudit, error) {
+	var table string = entity.FiscalPeriodAudit{}.GetFiscalPeriodAuditTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetFiscalPeriodAudits - "
+	var query string = "SELECT * FROM " + table + " WHERE fiscalPeriodId = @p1 ORDER BY createdAt DESC"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := f.db.QueryContext(ctx, query, periodId)
+	if err != nil {
+		f.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.FiscalPeriodAudit
+	for rows.Next() {
+		var x entity.FiscalPeriodAudit
+		if err := rows.Scan(&x.FiscalPeriodAuditId, &x.FiscalPeriodId, &x.FromStatus, &x.ToStatus, &x.Reason, &x.ActorId, &x.CreatedAt); err != nil {
+			f.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	gateway_fee "tourmate/payment-service/constant/gateway_fee"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/utils"
+)
+
+type gatewayFeeRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeGatewayFeeRepo(db *sql.DB, logger *log.Logger) repo.IGatewayFeeRepo {
+	return &gatewayFeeRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPaymentMethodFees implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) GetPaymentMethodFees(ctx context.Context) (*[]entity.PaymentMethodFee, error) {
+	var table string = entity.PaymentMethodFee{}.GetPaymentMethodFeeTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaymentMethodFees - "
+	var query string = "SELECT * FROM " + table + " ORDER BY paymentMethod ASC"
+
+	rows, err := g.db.QueryContext(ctx, query)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.PaymentMethodFee
+	for rows.Next() {
+		var x entity.PaymentMethodFee
+		if err := rows.Scan(&x.PaymentMethod, &x.FeeRate, &x.FixedFee, &x.SurchargeRate, &x.SurchargeFixed,
+			&x.UpdatedBy, &x.UpdatedAt); err != nil {
+
+			g.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPaymentMethodFee implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) GetPaymentMethodFee(paymentMethod string, ctx context.Context) (*entity.PaymentMethodFee, error) {
+	var res entity.PaymentMethodFee
+	var query string = "SELECT * FROM " + res.GetPaymentMethodFeeTable() + " WHERE paymentMethod = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPaymentMethodFeeTable()) + "GetPaymentMethodFee - "
+
+	if err := g.db.QueryRowContext(ctx, query, paymentMethod).Scan(&res.PaymentMethod, &res.FeeRate, &res.FixedFee,
+		&res.SurchargeRate, &res.SurchargeFixed, &res.UpdatedBy, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// UpsertPaymentMethodFee implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) UpsertPaymentMethodFee(fee entity.PaymentMethodFee, ctx context.Context) error {
+	var table string = fee.GetPaymentMethodFeeTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "UpsertPaymentMethodFee - "
+	var query string = "UPDATE " + table + " WITH (UPDLOCK, HOLDLOCK) SET feeRate = @p2, fixedFee = @p3, surchargeRate = @p4, " +
+		"surchargeFixed = @p5, updatedBy = @p6, updatedAt = @p7 WHERE paymentMethod = @p1; " +
+		"IF @@ROWCOUNT = 0 INSERT INTO " + table +
+		" (paymentMethod, feeRate, fixedFee, surchargeRate, surchargeFixed, updatedBy, updatedAt) " +
+		"VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7)"
+
+	if _, err := g.db.ExecContext(ctx, query, fee.PaymentMethod, fee.FeeRate, fee.FixedFee, fee.SurchargeRate,
+		fee.SurchargeFixed, fee.UpdatedBy, fee.UpdatedAt); err != nil {
+
+		g.logger.Println(errLogMsg + err.Error())
+		return errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return nil
+}
+
+// CreatePaymentFee implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) CreatePaymentFee(fee entity.PaymentFee, ctx context.Context) (int, error) {
+	var table string = fee.GetPaymentFeeTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "CreatePaymentFee - "
+	var query string = "INSERT INTO " + table +
+		" (paymentId, paymentMethod, grossAmount, surcharge, feeAmount, netAmount, source, settlementReference, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.paymentFeeId " +
+		"SELECT @p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10 " +
+		"WHERE NOT EXISTS (SELECT 1 FROM " + table + " WITH (UPDLOCK, HOLDLOCK) WHERE paymentId = @p1)"
+
+	var res int
+	if err := g.db.QueryRowContext(ctx, query, fee.PaymentId, fee.PaymentMethod, fee.GrossAmount, fee.Surcharge, fee.FeeAmount,
+		fee.NetAmount, fee.Source, fee.SettlementReference, fee.CreatedAt, fee.UpdatedAt).Scan(&res); err != nil {
+
+		if err == sql.ErrNoRows {
+			return 0, errors.New(noti.INVALID_STATUS_WARN_MSG)
+		}
+
+		g.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// GetPaymentFeeByPaymentId implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) GetPaymentFeeByPaymentId(paymentId int, ctx context.Context) (*entity.PaymentFee, error) {
+	var res entity.PaymentFee
+	var query string = "SELECT * FROM " + res.GetPaymentFeeTable() + " WHERE paymentId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPaymentFeeTable()) + "GetPaymentFeeByPaymentId - "
+
+	if err := g.db.QueryRowContext(ctx, query, paymentId).Scan(&res.PaymentFeeId, &res.PaymentId, &res.PaymentMethod,
+		&res.GrossAmount, &res.Surcharge, &res.FeeAmount, &res.NetAmount, &res.Source, &res.SettlementReference,
+		&res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetPaymentFeesByPaymentIds implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) GetPaymentFeesByPaymentIds(paymentIds []int, ctx context.Context) (*[]entity.PaymentFee, error) {
+	var res []entity.PaymentFee
+	if len(paymentIds) == 0 {
+		return &res, nil
+	}
+
+	var table string = entity.PaymentFee{}.GetPaymentFeeTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaymentFeesByPaymentIds - "
+	var query string = "SELECT * FROM " + table + " WHERE paymentId IN (" + generateInParams(1, len(paymentIds)) + ")"
+
+	var args []interface{}
+	for _, id := range paymentIds {
+		args = append(args, id)
+	}
+
+	rows, err := g.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err = scanPaymentFees(rows)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetPaymentFees implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) GetPaymentFees(req request.GetPaymentFeesRequest, ctx context.Context) (*[]entity.PaymentFee, int, int, error) {
+	var table string = entity.PaymentFee{}.GetPaymentFeeTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaymentFees - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.PaymentMethod != "" {
+		builder.where("paymentMethod = ?", req.PaymentMethod)
+	}
+
+	if req.Source != "" {
+		builder.where("source = ?", req.Source)
+	}
+
+	if req.From != nil {
+		builder.where("createdAt >= ?", *req.From)
+	}
+
+	if req.To != nil {
+		builder.where("createdAt < ?", *req.To)
+	}
+
+	builder.orderBy("paymentFeeId", order.DESCENDING_ORDER)
+
+	rows, err := g.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanPaymentFees(rows)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	g.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// SettlePaymentFee implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) SettlePaymentFee(fee entity.PaymentFee, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, fee.GetPaymentFeeTable()) + "SettlePaymentFee - "
+	var query string = "UPDATE " + fee.GetPaymentFeeTable() + " SET feeAmount = @p1, netAmount = @p2, source = @p3, " +
+		"settlementReference = @p4, updatedAt = @p5 WHERE paymentFeeId = @p6 AND source = @p7"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := g.db.ExecContext(ctx, query, fee.FeeAmount, fee.NetAmount, gateway_fee.SETTLEMENT_SOURCE, fee.SettlementReference,
+		fee.UpdatedAt, fee.PaymentFeeId, gateway_fee.CONFIGURED_SOURCE)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// GetPaymentFeeSummaries implements repo.IGatewayFeeRepo.
+func (g *gatewayFeeRepo) GetPaymentFeeSummaries(from, to *time.Time, ctx context.Context) (*[]entity.PaymentFeeSummary, error) {
+	var table string = entity.PaymentFee{}.GetPaymentFeeTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaymentFeeSummaries - "
+
+	var builder *queryBuilder = newQueryBuilder()
+	if from != nil {
+		builder.where("createdAt >= ?", *from)
+	}
+
+	if to != nil {
+		builder.where("createdAt < ?", *to)
+	}
+
+	var query string = "SELECT paymentMethod, SUM(grossAmount), SUM(surcharge), SUM(feeAmount), SUM(netAmount), COUNT(*) " +
+		"FROM " + table + " " + builder.condition() + " GROUP BY paymentMethod ORDER BY paymentMethod ASC"
+
+	rows, err := g.db.QueryContext(ctx, query, builder.args...)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.PaymentFeeSummary
+	for rows.Next() {
+		var x entity.PaymentFeeSummary
+		if err := rows.Scan(&x.PaymentMethod, &x.GrossAmount, &x.Surcharge, &x.FeeAmount, &x.NetAmount, &x.PaymentCount); err != nil {
+			g.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		x.GrossAmount = utils.RoundMoney(x.GrossAmount)
+		x.Surcharge = utils.RoundMoney(x.Surcharge)
+		x.FeeAmount = utils.RoundMoney(x.FeeAmount)
+		x.NetAmount = utils.RoundMoney(x.NetAmount)
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+func scanPaymentFees(rows *sql.Rows) ([]entity.PaymentFee, error) {
+	var res []entity.PaymentFee
+	for rows.Next() {
+		var x entity.PaymentFee
+		if err := rows.Scan(&x.PaymentFeeId, &x.PaymentId, &x.PaymentMethod, &x.GrossAmount, &x.Surcharge, &x.FeeAmount,
+			&x.NetAmount, &x.Source, &x.SettlementReference, &x.CreatedAt, &x.UpdatedAt); err != nil {
+
+			return nil, err
+		}
+
+		res = append(res, x)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	gift_card "tourmate/payment-service/constant/gift_card"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type giftCardRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeGiftCardRepo(db *sql.DB, logger *log.Logger) repo.IGiftCardRepo {
+	return &giftCardRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetGiftCards implements repo.IGiftCardRepo.
+func (g *giftCardRepo) GetGiftCards(req request.GetGiftCardsRequest, ctx context.Context) (*[]entity.GiftCard, int, int, error) {
+	var table string = entity.GiftCard{}.GetGiftCardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetGiftCards - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	builder.orderBy("giftCardId", order.DESCENDING_ORDER)
+
+	rows, err := g.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.GiftCard
+	for rows.Next() {
+		var x entity.GiftCard
+		if err := rows.Scan(
+			&x.GiftCardId, &x.Code, &x.PurchaserId, &x.RecipientName, &x.RecipientEmail, &x.Message, &x.InitialAmount,
+			&x.Balance, &x.OrderCode, &x.CheckoutUrl, &x.Status, &x.ExpiresAt, &x.CreatedAt, &x.PaidAt, &x.UpdatedAt); err != nil {
+
+			g.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	g.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetGiftCardById implements repo.IGiftCardRepo.
+func (g *giftCardRepo) GetGiftCardById(id int, ctx context.Context) (*entity.GiftCard, error) {
+	return g.getGiftCard("giftCardId", id, "GetGiftCardById - ", ctx)
+}
+
+// GetGiftCardByCode implements repo.IGiftCardRepo.
+func (g *giftCardRepo) GetGiftCardByCode(code string, ctx context.Context) (*entity.GiftCard, error) {
+	return g.getGiftCard("code", code, "GetGiftCardByCode - ", ctx)
+}
+
+func (g *giftCardRepo) getGiftCard(column string, value interface{}, method string, ctx context.Context) (*entity.GiftCard, error) {
+	var res entity.GiftCard
+	var query string = "SELECT * FROM " + res.GetGiftCardTable() + " WHERE " + column + " = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetGiftCardTable()) + method
+
+	if err := g.db.QueryRowContext(ctx, query, value).Scan(
+		&res.GiftCardId, &res.Code, &res.PurchaserId, &res.RecipientName, &res.RecipientEmail, &res.Message, &res.InitialAmount,
+		&res.Balance, &res.OrderCode, &res.CheckoutUrl, &res.Status, &res.ExpiresAt, &res.CreatedAt, &res.PaidAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateGiftCard implements repo.IGiftCardRepo.
+func (g *giftCardRepo) CreateGiftCard(card entity.GiftCard, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + card.GetGiftCardTable() +
+		" (code, purchaserId, recipientName, recipientEmail, message, initialAmount, balance, orderCode, checkoutUrl, status, " +
+		"expiresAt, createdAt, paidAt, updatedAt) " +
+		"OUTPUT INSERTED.giftCardId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13, @p14)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, card.GetGiftCardTable()) + "CreateGiftCard - "
+
+	var res int
+	if err := g.db.QueryRowContext(ctx, query, card.Code, card.PurchaserId, card.RecipientName, card.RecipientEmail, card.Message,
+		card.InitialAmount, card.Balance, card.OrderCode, card.CheckoutUrl, card.Status, card.ExpiresAt, card.CreatedAt,
+		card.PaidAt, card.UpdatedAt).Scan(&res); err != nil {
+
+		g.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateGiftCard implements repo.IGiftCardRepo.
+func (g *giftCardRepo) UpdateGiftCard(card entity.GiftCard, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, card.GetGiftCardTable()) + "UpdateGiftCard - "
+	var query string = "UPDATE " + card.GetGiftCardTable() + " SET code = @p1, status = @p2, expiresAt = @p3, paidAt = @p4, " +
+		"updatedAt = @p5 WHERE giftCardId = @p6 AND status = @p7"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := g.db.ExecContext(ctx, query, card.Code, card.Status, card.ExpiresAt, card.PaidAt, card.UpdatedAt,
+		card.GiftCardId, currentStatus)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// ChangeGiftCardBalance implements repo.IGiftCardRepo.
+func (g *giftCardRepo) ChangeGiftCardBalance(transaction entity.GiftCardTransaction, ctx context.Context) (*entity.GiftCardTransaction, error) {
+	var table string = entity.GiftCard{}.GetGiftCardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "ChangeGiftCardBalance - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	// The conditions are checked by the update itself so that concurrent redemptions cannot both pass
+	var updateQuery string = "UPDATE " + table + " SET balance = balance + @p1, " +
+		"status = CASE WHEN balance + @p1 = 0 THEN @p2 ELSE @p3 END, updatedAt = @p4 " +
+		"OUTPUT INSERTED.balance WHERE giftCardId = @p5 AND "
+	var args []interface{} = []interface{}{transaction.Amount, domain_status.GIFT_CARD_REDEEMED, domain_status.GIFT_CARD_ACTIVE,
+		transaction.CreatedAt, transaction.GiftCardId}
+
+	if transaction.Amount < 0 {
+		updateQuery += "status = @p3 AND expiresAt > @p4 AND balance + @p1 >= 0"
+	} else {
+		// A used up card takes restored money back
+		updateQuery += "status IN (@p2, @p3)"
+	}
+
+	var transactionQuery string = "INSERT INTO " + transaction.GetGiftCardTransactionTable() +
+		" (giftCardId, transactionType, amount, balanceAfter, customerId, referenceId, createdBy, createdAt) " +
+		"OUTPUT INSERTED.giftCardTransactionId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8)"
+
+	tx, err := g.db.BeginTx(ctx, nil)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, updateQuery, args...).Scan(&transaction.BalanceAfter); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(noti.INSUFFICIENT_GIFT_CARD_BALANCE_WARN_MSG)
+		}
+
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := g.createGiftCardTransaction(tx, transactionQuery, &transaction, ctx); err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	return &transaction, nil
+}
+
+// GetExpiredGiftCardIds implements repo.IGiftCardRepo.
+func (g *giftCardRepo) GetExpiredGiftCardIds(curTime time.Time, ctx context.Context) ([]int, error) {
+	var table string = entity.GiftCard{}.GetGiftCardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetExpiredGiftCardIds - "
+	var query string = "SELECT giftCardId FROM " + table + " WHERE status = @p1 AND expiresAt <= @p2 ORDER BY giftCardId ASC"
+
+	rows, err := g.db.QueryContext(ctx, query, domain_status.GIFT_CARD_ACTIVE, curTime)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			g.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+// ExpireGiftCard implements repo.IGiftCardRepo.
+func (g *giftCardRepo) ExpireGiftCard(id int, curTime time.Time, ctx context.Context) (*entity.GiftCardTransaction, error) {
+	var table string = entity.GiftCard{}.GetGiftCardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "ExpireGiftCard - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	var updateQuery string = "UPDATE " + table + " SET balance = 0, status = @p1, updatedAt = @p2 OUTPUT DELETED.balance " +
+		"WHERE giftCardId = @p3 AND status = @p4 AND expiresAt <= @p2"
+	var transactionQuery string = "INSERT INTO " + entity.GiftCardTransaction{}.GetGiftCardTransactionTable() +
+		" (giftCardId, transactionType, amount, balanceAfter, customerId, referenceId, createdBy, createdAt) " +
+		"OUTPUT INSERTED.giftCardTransactionId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8)"
+
+	tx, err := g.db.BeginTx(ctx, nil)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	var balance float64
+	if err := tx.QueryRowContext(ctx, updateQuery, domain_status.GIFT_CARD_EXPIRED, curTime, id, domain_status.GIFT_CARD_ACTIVE).
+		Scan(&balance); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	var res entity.GiftCardTransaction = entity.GiftCardTransaction{
+		GiftCardId:      id,
+		TransactionType: gift_card.EXPIRY,
+		Amount:          -balance,
+		CreatedAt:       curTime,
+	}
+
+	if err := g.createGiftCardTransaction(tx, transactionQuery, &res, ctx); err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	return &res, nil
+}
+
+func (g *giftCardRepo) createGiftCardTransaction(tx *sql.Tx, query string, transaction *entity.GiftCardTransaction, ctx context.Context) error {
+	return tx.QueryRowContext(ctx, query, transaction.GiftCardId, transaction.TransactionType, transaction.Amount,
+		transaction.BalanceAfter, transaction.CustomerId, transaction.ReferenceId, transaction.CreatedBy, transaction.CreatedAt).
+		Scan(&transaction.GiftCardTransactionId)
+}
+
+// GetGiftCardStatusSummaries implements repo.IGiftCardRepo.
+func (g *giftCardRepo) GetGiftCardStatusSummaries(ctx context.Context) (*[]entity.GiftCardStatusSummary, error) {
+	var table string = entity.GiftCard{}.GetGiftCardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetGiftCardStatusSummaries - "
+	var query string = "SELECT status, COUNT(*), COALESCE(SUM(initialAmount), 0), COALESCE(SUM(balance), 0) FROM " + table +
+		" GROUP BY status ORDER BY status ASC"
+
+	rows, err := g.db.QueryContext(ctx, query)
+	if err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.GiftCardStatusSummary
+	for rows.Next() {
+		var x entity.GiftCardStatusSummary
+		if err := rows.Scan(&x.Status, &x.CardCount, &x.InitialAmount, &x.Balance); err != nil {
+			g.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetExpiringGiftCardBalance implements repo.IGiftCardRepo.
+func (g *giftCardRepo) GetExpiringGiftCardBalance(curTime, expiringBefore time.Time, ctx context.Context) (float64, int, error) {
+	var table string = entity.GiftCard{}.GetGiftCardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetExpiringGiftCardBalance - "
+	var query string = "SELECT COALESCE(SUM(balance), 0), COUNT(*) FROM " + table +
+		" WHERE status = @p1 AND expiresAt > @p2 AND expiresAt <= @p3"
+
+	var balance float64
+	var count int
+	if err := g.db.QueryRowContext(ctx, query, domain_status.GIFT_CARD_ACTIVE, curTime, expiringBefore).Scan(&balance, &count); err != nil {
+		g.logger.Println(errLogMsg + err.Error())
+		return 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return balance, count, nil
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/ledger"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type journalRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeJournalRepo(db *sql.DB, logger *log.Logger) repo.IJournalRepo {
+	return &journalRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetJournalPayments implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalPayments(from time.Time, to time.Time, ctx context.Context) (*[]entity.JournalPayment, error) {
+	var table string = entity.Payment{}.GetPaymentTable()
+	var query string = "SELECT p.*, r.tourGuideId, r.actualReceived, r.platformCommission, ar.agencyId, ISNULL(ar.amount, 0), " +
+		"ISNULL(pf.feeAmount, 0), ISNULL(pf.surcharge, 0) FROM " + table + " p " +
+		"JOIN " + entity.Revenue{}.GetRevenueTable() + " r ON r.paymentId = p.paymentId " +
+		"LEFT JOIN " + entity.AgencyRevenue{}.GetAgencyRevenueTable() + " ar ON ar.revenueId = r.revenueId AND ar.amount > 0 " +
+		"LEFT JOIN " + entity.PaymentFee{}.GetPaymentFeeTable() + " pf ON pf.paymentId = p.paymentId " +
+		"WHERE p.createdAt >= @p1 AND p.createdAt < @p2 " +
+		"ORDER BY p.createdAt ASC, p.paymentId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalPayments - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := j.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.JournalPayment
+	for rows.Next() {
+		var x entity.JournalPayment
+		if err := rows.Scan(
+			&x.PaymentId, &x.Price, &x.CreatedAt, &x.PaymentMethod, &x.InvoiceId, &x.CustomerId, &x.ServiceId, &x.Status,
+			&x.TourGuideId, &x.ActualReceived, &x.PlatformCommission, &x.AgencyId, &x.AgencyAmount, &x.FeeAmount, &x.Surcharge); err != nil {
+
+			j.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetJournalRefunds implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalRefunds(from time.Time, to time.Time, ctx context.Context) (*[]entity.JournalRefund, error) {
+	var table string = entity.Payment{}.GetPaymentTable()
+	// The refund time is taken from its journal entry, which is posted once per refunded payment
+	var query string = "SELECT p.*, le.createdAt FROM " + table + " p " +
+		"JOIN " + entity.LedgerEntry{}.GetLedgerEntryTable() + " le ON le.entryType = @p1 AND le.referenceId = p.paymentId " +
+		"WHERE p.status = @p2 AND le.createdAt >= @p3 AND le.createdAt < @p4 " +
+		"ORDER BY le.createdAt ASC, p.paymentId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalRefunds - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := j.db.QueryContext(ctx, query, ledger.REFUND_ENTRY, domain_status.PAYMENT_REFUNDED, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.JournalRefund
+	for rows.Next() {
+		var x entity.JournalRefund
+		if err := rows.Scan(
+			&x.PaymentId, &x.Price, &x.CreatedAt, &x.PaymentMethod, &x.InvoiceId, &x.CustomerId, &x.ServiceId, &x.Status,
+			&x.RefundedAt); err != nil {
+
+			j.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetJournalAdjustments implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalAdjustments(from time.Time, to time.Time, ctx context.Context) (*[]entity.JournalAdjustment, error) {
+	var table string = entity.RevenueAdjustment{}.GetRevenueAdjustmentTable()
+	var query string = "SELECT ra.*, p.invoiceId, p.customerId, r.tourGuideId FROM " + table + " ra " +
+		"JOIN " + entity.Revenue{}.GetRevenueTable() + " r ON r.revenueId = ra.revenueId " +
+		"JOIN " + entity.Payment{}.GetPaymentTable() + " p ON p.paymentId = r.paymentId " +
+		"WHERE ra.createdAt >= @p1 AND ra.createdAt < @p2 " +
+		"ORDER BY ra.createdAt ASC, ra.revenueAdjustmentId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalAdjustments - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := j.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.JournalAdjustment
+	for rows.Next() {
+		var x entity.JournalAdjustment
+		if err := rows.Scan(
+			&x.RevenueAdjustmentId, &x.RevenueId, &x.AdjustmentType, &x.TotalAmountDelta, &x.ActualReceivedDelta,
+			&x.PlatformCommissionDelta, &x.Reason, &x.CreatedBy, &x.CreatedAt, &x.PayoutItemId, &x.Settled,
+			&x.InvoiceId, &x.CustomerId, &x.TourGuideId); err != nil {
+
+			j.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetJournalPayouts implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalPayouts(from time.Time, to time.Time, ctx context.Context) (*[]entity.JournalPayout, error) {
+	var table string = entity.PayoutItem{}.GetPayoutItemTable()
+	var query string = "SELECT pi.*, ISNULL(tw.taxAmount, 0) FROM " + table + " pi " +
+		"LEFT JOIN " + entity.TaxWithholding{}.GetTaxWithholdingTable() + " tw ON tw.payoutItemId = pi.payoutItemId " +
+		"WHERE pi.status = @p1 AND pi.processedAt >= @p2 AND pi.processedAt < @p3 " +
+		"ORDER BY pi.processedAt ASC, pi.payoutItemId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalPayouts - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := j.db.QueryContext(ctx, query, domain_status.PAYOUT_ITEM_PAID, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.JournalPayout
+	for rows.Next() {
+		var x entity.JournalPayout
+		if err := rows.Scan(
+			&x.PayoutItemId, &x.PayoutBatchId, &x.TourGuideId, &x.Amount, &x.BankCode, &x.AccountNumber,
+			&x.AccountHolder, &x.Reference, &x.Status, &x.FailureReason, &x.ProcessedAt, &x.CreatedAt,
+			&x.TaxAmount); err != nil {
+
+			j.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetJournalAgencyReversals implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalAgencyReversals(from time.Time, to time.Time, ctx context.Context) (*[]entity.JournalAgencyReversal, error) {
+	var table string = entity.AgencyRevenue{}.GetAgencyRevenueTable()
+	var query string = "SELECT ar.*, p.customerId FROM " + table + " ar " +
+		"JOIN " + entity.Payment{}.GetPaymentTable() + " p ON p.paymentId = ar.paymentId " +
+		"WHERE ar.amount < 0 AND ar.createdAt >= @p1 AND ar.createdAt < @p2 " +
+		"ORDER BY ar.createdAt ASC, ar.agencyRevenueId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalAgencyReversals - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := j.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.JournalAgencyReversal
+	for rows.Next() {
+		var x entity.JournalAgencyReversal
+		if err := rows.Scan(
+			&x.AgencyRevenueId, &x.AgencyId, &x.RevenueId, &x.PaymentId, &x.TourGuideId, &x.InvoiceId, &x.TotalAmount,
+			&x.ShareRate, &x.Amount, &x.AgencyPayoutId, &x.CreatedAt,
+			&x.CustomerId); err != nil {
+
+			j.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetJournalAgencyPayouts implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalAgencyPayouts(from time.Time, to time.Time, ctx context.Context) (*[]entity.AgencyPayout, error) {
+	var table string = entity.AgencyPayout{}.GetAgencyPayoutTable()
+	var query string = "SELECT * FROM " + table + " " +
+		"WHERE status = @p1 AND processedAt >= @p2 AND processedAt < @p3 " +
+		"ORDER BY processedAt ASC, agencyPayoutId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalAgencyPayouts - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := j.db.QueryContext(ctx, query, domain_status.AGENCY_PAYOUT_PAID, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.AgencyPayout
+	for rows.Next() {
+		var x entity.AgencyPayout
+		if err := rows.Scan(
+			&x.AgencyPayoutId, &x.AgencyId, &x.Amount, &x.BankCode, &x.AccountNumber, &x.AccountHolder, &x.Reference,
+			&x.Status, &x.Note, &x.CreatedBy, &x.ProcessedBy, &x.CreatedAt, &x.ProcessedAt); err != nil {
+
+			j.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetJournalSubscriptionInvoices implements repo.IJournalRepo.
+func (j *journalRepo) GetJournalSubscriptionInvoices(from time.Time, to time.Time, ctx context.Context) (*[]entity.SubscriptionInvoice, error) {
+	var table string = entity.SubscriptionInvoice{}.GetSubscriptionInvoiceTable()
+	var query string = "SELECT * FROM " + table + " " +
+		"WHERE status = @p1 AND paidAt >= @p2 AND paidAt < @p3 " +
+		"ORDER BY paidAt ASC, subscriptionInvoiceId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetJournalSubscriptionInvoices - "
+
+	rows, err := j.db.QueryContext(ctx, query, domain_status.SUBSCRIPTION_INVOICE_PAID, from, to)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanSubscriptionInvoices(rows)
+	if err != nil {
+		j.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type ledgerRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeLedgerRepo(db *sql.DB, logger *log.Logger) repo.ILedgerRepo {
+	return &ledgerRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateLedgerEntry implements repo.ILedgerRepo.
+func (l *ledgerRepo) CreateLedgerEntry(entry entity.LedgerEntry, lines []entity.LedgerLine, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, entry.GetLedgerEntryTable()) + "CreateLedgerEntry - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var entryQuery string = "INSERT INTO " + entry.GetLedgerEntryTable() +
+		" (entryType, referenceId, description, createdBy, createdAt) " +
+		"OUTPUT INSERTED.ledgerEntryId " +
+		"values (@p1, @p2, @p3, @p4, @p5)"
+	var lineQuery string = "INSERT INTO " + entity.LedgerLine{}.GetLedgerLineTable() +
+		" (ledgerEntryId, account, ownerId, debit, credit, createdAt) " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6)"
+
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+	defer tx.Rollback()
+
+	var id int
+	if err := tx.QueryRowContext(ctx, entryQuery, entry.EntryType, entry.ReferenceId, entry.Description,
+		entry.CreatedBy, entry.CreatedAt).Scan(&id); err != nil {
+
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, lineQuery, id, line.Account, line.OwnerId, line.Debit, line.Credit, entry.CreatedAt); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	return id, nil
+}
+
+// GetLedgerEntryByReference implements repo.ILedgerRepo.
+func (l *ledgerRepo) GetLedgerEntryByReference(entryType string, referenceId int, ctx context.Context) (*entity.LedgerEntry, error) {
+	var res entity.LedgerEntry
+	var query string = "SELECT TOP 1 * FROM " + res.GetLedgerEntryTable() + " WHERE entryType = @p1 AND referenceId = @p2"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetLedgerEntryTable()) + "GetLedgerEntryByReference - "
+
+	if err := l.db.QueryRowContext(ctx, query, entryType, referenceId).Scan(
+		&res.LedgerEntryId, &res.EntryType, &res.ReferenceId, &res.Description, &res.CreatedBy, &res.CreatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetLedgerEntriesByIds implements repo.ILedgerRepo.
+func (l *ledgerRepo) GetLedgerEntriesByIds(ids []int, ctx context.Context) (*[]entity.LedgerEntry, error) {
+	var res []entity.LedgerEntry
+	if len(ids) == 0 {
+		return &res, nil
+	}
+
+	var table string = entity.LedgerEntry{}.GetLedgerEntryTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetLedgerEntriesByIds - "
+	var query string = "SELECT * FROM " + table + " WHERE ledgerEntryId IN (" + generateInParams(1, len(ids)) + ")"
+
+	var args []interface{}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var x entity.LedgerEntry
+		if err := rows.Scan(&x.LedgerEntryId, &x.EntryType, &x.ReferenceId, &x.Description, &x.CreatedBy, &x.CreatedAt); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetAccountTotals implements repo.ILedgerRepo.
+func (l *ledgerRepo) GetAccountTotals(account string, ownerId *int, before *time.Time, ctx context.Context) (float64, float64, error) {
+	var table string = entity.LedgerLine{}.GetLedgerLineTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAccountTotals - "
+	var builder *queryBuilder = newQueryBuilder().where("account = ?", account)
+	if ownerId != nil {
+		builder.where("ownerId = ?", *ownerId)
+	}
+
+	if before != nil {
+		builder.where("createdAt < ?", *before)
+	}
+
+	var query string = "SELECT COALESCE(SUM(debit), 0), COALESCE(SUM(credit), 0) FROM " + table + " " + builder.condition()
+
+	var debit, credit float64
+	if err := l.db.QueryRowContext(ctx, query, builder.args...).Scan(&debit, &credit); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return debit, credit, nil
+}
+
+// GetAccountLines implements repo.ILedgerRepo.
+func (l *ledgerRepo) GetAccountLines(account string, ownerId *int, from, to time.Time, ctx context.Context) (*[]entity.LedgerLine, error) {
+	var table string = entity.LedgerLine{}.GetLedgerLineTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetAccountLines - "
+	var builder *queryBuilder = newQueryBuilder().where("account = ? AND createdAt >= ? AND createdAt < ?", account, from, to)
+	if ownerId != nil {
+		builder.where("ownerId = ?", *ownerId)
+	}
+
+	var query string = "SELECT * FROM " + table + " " + builder.condition() + " ORDER BY createdAt ASC, ledgerLineId ASC"
+
+	rows, err := l.db.QueryContext(ctx, query, builder.args...)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.LedgerLine
+	for rows.Next() {
+		var x entity.LedgerLine
+		if err := rows.Scan(&x.LedgerLineId, &x.LedgerEntryId, &x.Account, &x.OwnerId, &x.Debit, &x.Credit, &x.CreatedAt); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetLedgerTotals implements repo.ILedgerRepo.
+func (l *ledgerRepo) GetLedgerTotals(ctx context.Context) (float64, float64, error) {
+	var table string = entity.LedgerLine{}.GetLedgerLineTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetLedgerTotals - "
+	var query string = "SELECT COALESCE(SUM(debit), 0), COALESCE(SUM(credit), 0) FROM " + table
+
+	var debit, credit float64
+	if err := l.db.QueryRowContext(ctx, query).Scan(&debit, &credit); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return debit, credit, nil
+}
+
+// GetUnbalancedEntryIds implements repo.ILedgerRepo.
+func (l *ledgerRepo) GetUnbalancedEntryIds(ctx context.Context) ([]int, error) {
+	var table string = entity.LedgerLine{}.GetLedgerLineTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetUnbalancedEntryIds - "
+	var query string = "SELECT ledgerEntryId FROM " + table +
+		" GROUP BY ledgerEntryId HAVING ABS(SUM(debit) - SUM(credit)) >= 0.01 ORDER BY ledgerEntryId ASC"
+
+	rows, err := l.db.QueryContext(ctx, query)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"tourmate/payment-service/constant/loyalty"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type loyaltyRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeLoyaltyRepo(db *sql.DB, logger *log.Logger) repo.ILoyaltyRepo {
+	return &loyaltyRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetLoyaltyAccount implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetLoyaltyAccount(customerId int, ctx context.Context) (*entity.LoyaltyAccount, error) {
+	var res entity.LoyaltyAccount
+	var query string = "SELECT * FROM " + res.GetLoyaltyAccountTable() + " WHERE customerId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetLoyaltyAccountTable()) + "GetLoyaltyAccount - "
+
+	if err := l.db.QueryRowContext(ctx, query, customerId).Scan(&res.CustomerId, &res.Balance, &res.CreatedAt, &res.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetLoyaltyTransactions implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetLoyaltyTransactions(req request.GetLoyaltyTransactionsRequest, ctx context.Context) (*[]entity.LoyaltyTransaction, int, int, error) {
+	var table string = entity.LoyaltyTransaction{}.GetLoyaltyTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetLoyaltyTransactions - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder().where("customerId = ?", req.CustomerId)
+
+	builder.orderBy("loyaltyTransactionId", order.DESCENDING_ORDER)
+
+	rows, err := l.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.LoyaltyTransaction
+	for rows.Next() {
+		var x entity.LoyaltyTransaction
+		if err := scanLoyaltyTransaction(rows, &x); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	l.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetLoyaltyTransactionByReference implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetLoyaltyTransactionByReference(transactionType string, referenceId int, ctx context.Context) (*entity.LoyaltyTransaction, error) {
+	var table string = entity.LoyaltyTransaction{}.GetLoyaltyTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetLoyaltyTransactionByReference - "
+	var query string = "SELECT TOP 1 * FROM " + table + " WHERE transactionType = @p1 AND referenceId = @p2 ORDER BY loyaltyTransactionId DESC"
+
+	var res entity.LoyaltyTransaction
+	if err := scanLoyaltyTransaction(l.db.QueryRowContext(ctx, query, transactionType, referenceId), &res); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// ChangeLoyaltyPoints implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) ChangeLoyaltyPoints(transaction entity.LoyaltyTransaction, preferredCreditId *int, allowPartial bool, ctx context.Context) (*entity.LoyaltyTransaction, error) {
+	var accountTable string = entity.LoyaltyAccount{}.GetLoyaltyAccountTable()
+	var transactionTable string = transaction.GetLoyaltyTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, accountTable) + "ChangeLoyaltyPoints - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var insufficientErr error = errors.New(noti.INSUFFICIENT_LOYALTY_POINT_WARN_MSG)
+
+	// The account row is locked for the whole transaction so that concurrent debits are applied one after another
+	var accountQuery string = "SELECT balance FROM " + accountTable + " WITH (UPDLOCK, HOLDLOCK) WHERE customerId = @p1"
+	var createAccountQuery string = "INSERT INTO " + accountTable + " (customerId, balance, createdAt, updatedAt) VALUES (@p1, 0, @p2, @p2)"
+	var creditQuery string = "SELECT loyaltyTransactionId, remainingPoints FROM " + transactionTable + " WITH (UPDLOCK) " +
+		"WHERE customerId = @p1 AND remainingPoints > 0 " +
+		"ORDER BY CASE WHEN loyaltyTransactionId = @p2 THEN 0 ELSE 1 END, expiresAt ASC, loyaltyTransactionId ASC"
+	var takeCreditQuery string = "UPDATE " + transactionTable + " SET remainingPoints = remainingPoints - @p1 WHERE loyaltyTransactionId = @p2"
+	var balanceQuery string = "UPDATE " + accountTable + " SET balance = balance + @p1, updatedAt = @p2 OUTPUT INSERTED.balance WHERE customerId = @p3"
+
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	var balance int
+	if err := tx.QueryRowContext(ctx, accountQuery, transaction.CustomerId).Scan(&balance); err != nil {
+		if err != sql.ErrNoRows {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		if _, err := tx.ExecContext(ctx, createAccountQuery, transaction.CustomerId, transaction.CreatedAt); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+	}
+
+	transaction.RemainingPoints = transaction.Points
+	if transaction.Points < 0 {
+		var points int = -transaction.Points
+		if points > balance {
+			if !allowPartial {
+				return nil, insufficientErr
+			}
+
+			points = balance
+		}
+
+		if points == 0 {
+			return nil, nil
+		}
+
+		transaction.Points, transaction.RemainingPoints = -points, 0
+
+		var preferredId int
+		if preferredCreditId != nil {
+			preferredId = *preferredCreditId
+		}
+
+		rows, err := tx.QueryContext(ctx, creditQuery, transaction.CustomerId, preferredId)
+		if err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		// The credits are read before they are updated, a connection only runs one statement at a time
+		var takenPoints map[int]int = make(map[int]int)
+		var creditIds []int
+		for rows.Next() && points > 0 {
+			var id, remainingPoints int
+			if err := rows.Scan(&id, &remainingPoints); err != nil {
+				rows.Close()
+				l.logger.Println(errLogMsg + err.Error())
+				return nil, internalErr
+			}
+
+			takenPoints[id] = min(points, remainingPoints)
+			creditIds = append(creditIds, id)
+			points -= takenPoints[id]
+		}
+		rows.Close()
+
+		if points > 0 {
+			return nil, insufficientErr
+		}
+
+		for _, id := range creditIds {
+			if _, err := tx.ExecContext(ctx, takeCreditQuery, takenPoints[id], id); err != nil {
+				l.logger.Println(errLogMsg + err.Error())
+				return nil, internalErr
+			}
+		}
+	}
+
+	if err := tx.QueryRowContext(ctx, balanceQuery, transaction.Points, transaction.CreatedAt, transaction.CustomerId).
+		Scan(&transaction.BalanceAfter); err != nil {
+
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := createLoyaltyTransaction(tx, &transaction, ctx); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	return &transaction, nil
+}
+
+// GetExpiredLoyaltyPoints implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetExpiredLoyaltyPoints(curTime time.Time, ctx context.Context) (*[]entity.ExpiredLoyaltyPoint, error) {
+	var table string = entity.LoyaltyTransaction{}.GetLoyaltyTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetExpiredLoyaltyPoints - "
+	var query string = "SELECT loyaltyTransactionId, customerId, remainingPoints FROM " + table +
+		" WHERE remainingPoints > 0 AND expiresAt <= @p1 ORDER BY loyaltyTransactionId ASC"
+
+	rows, err := l.db.QueryContext(ctx, query, curTime)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.ExpiredLoyaltyPoint
+	for rows.Next() {
+		var x entity.ExpiredLoyaltyPoint
+		if err := rows.Scan(&x.LoyaltyTransactionId, &x.CustomerId, &x.RemainingPoints); err != nil {
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// ExpireLoyaltyPoints implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) ExpireLoyaltyPoints(creditId int, curTime time.Time, ctx context.Context) (*entity.LoyaltyTransaction, error) {
+	var accountTable string = entity.LoyaltyAccount{}.GetLoyaltyAccountTable()
+	var transactionTable string = entity.LoyaltyTransaction{}.GetLoyaltyTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, transactionTable) + "ExpireLoyaltyPoints - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	var creditQuery string = "UPDATE " + transactionTable + " SET remainingPoints = 0 " +
+		"OUTPUT DELETED.customerId, DELETED.remainingPoints WHERE loyaltyTransactionId = @p1 AND remainingPoints > 0 AND expiresAt <= @p2"
+	var balanceQuery string = "UPDATE " + accountTable + " SET balance = balance + @p1, updatedAt = @p2 OUTPUT INSERTED.balance WHERE customerId = @p3"
+
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	var res entity.LoyaltyTransaction = entity.LoyaltyTransaction{
+		TransactionType: loyalty.EXPIRY,
+		ReferenceId:     &creditId,
+		Description:     fmt.Sprintf("Points of transaction %d expired", creditId),
+		CreatedAt:       curTime,
+	}
+
+	var points int
+	if err := tx.QueryRowContext(ctx, creditQuery, creditId, curTime).Scan(&res.CustomerId, &points); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	res.Points = -points
+	if err := tx.QueryRowContext(ctx, balanceQuery, res.Points, curTime, res.CustomerId).Scan(&res.BalanceAfter); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := createLoyaltyTransaction(tx, &res, ctx); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	return &res, nil
+}
+
+// GetExpiringLoyaltyPoints implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetExpiringLoyaltyPoints(customerId int, curTime, expiringBefore time.Time, ctx context.Context) (int, error) {
+	var table string = entity.LoyaltyTransaction{}.GetLoyaltyTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetExpiringLoyaltyPoints - "
+	var query string = "SELECT COALESCE(SUM(remainingPoints), 0) FROM " + table +
+		" WHERE customerId = @p1 AND remainingPoints > 0 AND expiresAt > @p2 AND expiresAt <= @p3"
+
+	var res int
+	if err := l.db.QueryRowContext(ctx, query, customerId, curTime, expiringBefore).Scan(&res); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// GetLoyaltyRules implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetLoyaltyRules(ctx context.Context) (*[]entity.LoyaltyRule, error) {
+	var table string = entity.LoyaltyRule{}.GetLoyaltyRuleTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetLoyaltyRules - "
+	var query string = "SELECT * FROM " + table + " ORDER BY loyaltyRuleId DESC"
+
+	rows, err := l.db.QueryContext(ctx, query)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.LoyaltyRule
+	for rows.Next() {
+		var x entity.LoyaltyRule
+		if err := rows.Scan(&x.LoyaltyRuleId, &x.Name, &x.ServiceId, &x.Multiplier, &x.StartsAt, &x.EndsAt, &x.IsActive,
+			&x.CreatedBy, &x.CreatedAt, &x.UpdatedAt); err != nil {
+
+			l.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetLoyaltyRuleById implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetLoyaltyRuleById(id int, ctx context.Context) (*entity.LoyaltyRule, error) {
+	var res entity.LoyaltyRule
+	var query string = "SELECT * FROM " + res.GetLoyaltyRuleTable() + " WHERE loyaltyRuleId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetLoyaltyRuleTable()) + "GetLoyaltyRuleById - "
+
+	if err := l.db.QueryRowContext(ctx, query, id).Scan(&res.LoyaltyRuleId, &res.Name, &res.ServiceId, &res.Multiplier,
+		&res.StartsAt, &res.EndsAt, &res.IsActive, &res.CreatedBy, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetLoyaltyMultiplier implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) GetLoyaltyMultiplier(serviceId int, curTime time.Time, ctx context.Context) (*float64, error) {
+	var table string = entity.LoyaltyRule{}.GetLoyaltyRuleTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetLoyaltyMultiplier - "
+	var query string = "SELECT MAX(multiplier) FROM " + table + " WHERE isActive = 1 AND (serviceId IS NULL OR serviceId = @p1) " +
+		"AND (startsAt IS NULL OR startsAt <= @p2) AND (endsAt IS NULL OR endsAt > @p2)"
+
+	var res *float64
+	if err := l.db.QueryRowContext(ctx, query, serviceId, curTime).Scan(&res); err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// CreateLoyaltyRule implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) CreateLoyaltyRule(rule entity.LoyaltyRule, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, rule.GetLoyaltyRuleTable()) + "CreateLoyaltyRule - "
+	var query string = "INSERT INTO " + rule.GetLoyaltyRuleTable() +
+		" (name, serviceId, multiplier, startsAt, endsAt, isActive, createdBy, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.loyaltyRuleId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9)"
+
+	var res int
+	if err := l.db.QueryRowContext(ctx, query, rule.Name, rule.ServiceId, rule.Multiplier, rule.StartsAt, rule.EndsAt,
+		rule.IsActive, rule.CreatedBy, rule.CreatedAt, rule.UpdatedAt).Scan(&res); err != nil {
+
+		l.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateLoyaltyRule implements repo.ILoyaltyRepo.
+func (l *loyaltyRepo) UpdateLoyaltyRule(rule entity.LoyaltyRule, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, rule.GetLoyaltyRuleTable()) + "UpdateLoyaltyRule - "
+	var query string = "UPDATE " + rule.GetLoyaltyRuleTable() + " SET name = @p1, serviceId = @p2, multiplier = @p3, " +
+		"startsAt = @p4, endsAt = @p5, isActive = @p6, updatedAt = @p7 WHERE loyaltyRuleId = @p8"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := l.db.ExecContext(ctx, query, rule.Name, rule.ServiceId, rule.Multiplier, rule.StartsAt, rule.EndsAt,
+		rule.IsActive, rule.UpdatedAt, rule.LoyaltyRuleId)
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		l.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, rule.GetLoyaltyRuleTable()))
+	}
+
+	return nil
+}
+
+func createLoyaltyTransaction(tx *sql.Tx, transaction *entity.LoyaltyTransaction, ctx context.Context) error {
+	var query string = "INSERT INTO " + transaction.GetLoyaltyTransactionTable() +
+		" (customerId, transactionType, points, remainingPoints, balanceAfter, multiplier, amount, referenceId, description, " +
+		"expiresAt, createdBy, createdAt) " +
+		"OUTPUT INSERTED.loyaltyTransactionId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12)"
+
+	return tx.QueryRowContext(ctx, query, transaction.CustomerId, transaction.TransactionType, transaction.Points,
+		transaction.RemainingPoints, transaction.BalanceAfter, transaction.Multiplier, transaction.Amount, transaction.ReferenceId,
+		transaction.Description, transaction.ExpiresAt, transaction.CreatedBy, transaction.CreatedAt).Scan(&transaction.LoyaltyTransactionId)
+}
+
+func scanLoyaltyTransaction(row interface{ Scan(dest ...any) error }, x *entity.LoyaltyTransaction) error {
+	return row.Scan(&x.LoyaltyTransactionId, &x.CustomerId, &x.TransactionType, &x.Points, &x.RemainingPoints, &x.BalanceAfter,
+		&x.Multiplier, &x.Amount, &x.ReferenceId, &x.Description, &x.ExpiresAt, &x.CreatedBy, &x.CreatedAt)
+}
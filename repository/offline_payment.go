@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type offlinePaymentRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeOfflinePaymentRepo(db *sql.DB, logger *log.Logger) repo.IOfflinePaymentRepo {
+	return &offlinePaymentRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetOfflinePayments implements repo.IOfflinePaymentRepo.
+func (o *offlinePaymentRepo) GetOfflinePayments(req request.GetOfflinePaymentsRequest, ctx context.Context) (*[]entity.OfflinePayment, int, int, error) {
+	var table string = entity.OfflinePayment{}.GetOfflinePaymentTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetOfflinePayments - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	builder.orderBy("recordedAt", order.DESCENDING_ORDER)
+
+	rows, err := o.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		o.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.OfflinePayment
+	for rows.Next() {
+		var x entity.OfflinePayment
+		if err := rows.Scan(
+			&x.OfflinePaymentId, &x.PaymentId, &x.TourGuideId, &x.Amount, &x.PaymentMethod, &x.Note, &x.Status,
+			&x.RecordedBy, &x.RecordedAt, &x.ConfirmedBy, &x.ConfirmedAt, &x.ApprovedBy, &x.ApprovedAt,
+			&x.RejectedBy, &x.RejectedAt, &x.RejectReason, &x.UpdatedAt); err != nil {
+
+			o.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	o.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetOfflinePaymentById implements repo.IOfflinePaymentRepo.
+func (o *offlinePaymentRepo) GetOfflinePaymentById(id int, ctx context.Context) (*entity.OfflinePayment, error) {
+	var res entity.OfflinePayment
+	var query string = "SELECT * FROM " + res.GetOfflinePaymentTable() + " WHERE offlinePaymentId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetOfflinePaymentTable()) + "GetOfflinePaymentById - "
+
+	if err := o.db.QueryRowContext(ctx, query, id).Scan(
+		&res.OfflinePaymentId, &res.PaymentId, &res.TourGuideId, &res.Amount, &res.PaymentMethod, &res.Note, &res.Status,
+		&res.RecordedBy, &res.RecordedAt, &res.ConfirmedBy, &res.ConfirmedAt, &res.ApprovedBy, &res.ApprovedAt,
+		&res.RejectedBy, &res.RejectedAt, &res.RejectReason, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		o.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateOfflinePayment implements repo.IOfflinePaymentRepo.
+func (o *offlinePaymentRepo) CreateOfflinePayment(payment entity.OfflinePayment, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + payment.GetOfflinePaymentTable() +
+		" (paymentId, tourGuideId, amount, paymentMethod, note, status, recordedBy, recordedAt, confirmedBy, confirmedAt, " +
+		"approvedBy, approvedAt, rejectedBy, rejectedAt, rejectReason, updatedAt) " +
+		"OUTPUT INSERTED.offlinePaymentId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13, @p14, @p15, @p16)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, payment.GetOfflinePaymentTable()) + "CreateOfflinePayment - "
+
+	var res int
+	if err := o.db.QueryRowContext(ctx, query, payment.PaymentId, payment.TourGuideId, payment.Amount, payment.PaymentMethod,
+		payment.Note, payment.Status, payment.RecordedBy, payment.RecordedAt, payment.ConfirmedBy, payment.ConfirmedAt,
+		payment.ApprovedBy, payment.ApprovedAt, payment.RejectedBy, payment.RejectedAt, payment.RejectReason, payment.UpdatedAt).Scan(&res); err != nil {
+
+		o.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateOfflinePayment implements repo.IOfflinePaymentRepo.
+func (o *offlinePaymentRepo) UpdateOfflinePayment(payment entity.OfflinePayment, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, payment.GetOfflinePaymentTable()) + "UpdateOfflinePayment - "
+	var query string = "UPDATE " + payment.GetOfflinePaymentTable() + " SET status = @p1, confirmedBy = @p2, confirmedAt = @p3, " +
+		"approvedBy = @p4, approvedAt = @p5, rejectedBy = @p6, rejectedAt = @p7, rejectReason = @p8, updatedAt = @p9 " +
+		"WHERE offlinePaymentId = @p10 AND status = @p11"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := o.db.ExecContext(ctx, query, payment.Status, payment.ConfirmedBy, payment.ConfirmedAt, payment.ApprovedBy,
+		payment.ApprovedAt, payment.RejectedBy, payment.RejectedAt, payment.RejectReason, payment.UpdatedAt,
+		payment.OfflinePaymentId, currentStatus)
+	if err != nil {
+		o.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		o.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// GetOfflinePaymentProof implements repo.IOfflinePaymentRepo.
+func (o *offlinePaymentRepo) GetOfflinePaymentProof(offlinePaymentId int, ctx context.Context) (*entity.OfflinePaymentProof, error) {
+	var res entity.OfflinePaymentProof
+	var query string = "SELECT * FROM " + res.GetOfflinePaymentProofTable() + " WHERE offlinePaymentId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetOfflinePaymentProofTable()) + "GetOfflinePaymentProof - "
+
+	if err := o.db.QueryRowContext(ctx, query, offlinePaymentId).Scan(
+		&res.OfflinePaymentId, &res.FileName, &res.Image, &res.UploadedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		o.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateOfflinePaymentProof implements repo.IOfflinePaymentRepo.
+func (o *offlinePaymentRepo) CreateOfflinePaymentProof(proof entity.OfflinePaymentProof, ctx context.Context) error {
+	var query string = "INSERT INTO " + proof.GetOfflinePaymentProofTable() +
+		" (offlinePaymentId, fileName, image, uploadedAt) VALUES (@p1, @p2, @p3, @p4)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, proof.GetOfflinePaymentProofTable()) + "CreateOfflinePaymentProof - "
+
+	if _, err := o.db.ExecContext(ctx, query, proof.OfflinePaymentId, proof.FileName, proof.Image, proof.UploadedAt); err != nil {
+		o.logger.Println(errLogMsg + err.Error())
+		return errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return nil
+}
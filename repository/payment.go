@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	filter_property "tourmate/payment-service/constant/filter_property"
 	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
 	"tourmate/payment-service/interface/repo"
 	"tourmate/payment-service/model/dto/request"
 	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/utils"
 
 	_ "github.com/lib/pq"
 )
@@ -89,41 +93,49 @@ func (p *paymentRepo) CreatePayment(payment entity.Payment, ctx context.Context)
 	return &payment, nil
 }
 
-// GetAllPayments implements repo.IPaymentRepo.
-func (p *paymentRepo) GetPayments(req request.GetPaymentsRequest, ctx context.Context) (*[]entity.Payment, int, int, error) {
-	var table string = entity.Payment{}.GetPaymentTable()
-	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayments - "
-	var limitRecords int = req.PageSize
+// ClaimInvoicePayment implements repo.IPaymentRepo.
+func (p *paymentRepo) ClaimInvoicePayment(payment entity.Payment, ctx context.Context) (*entity.Payment, error) {
+	var query string = "INSERT INTO " + payment.GetPaymentTable() +
+		" (customerId, invoiceId, " +
+		"price, paymentMethod, createdAt, serviceId, status) " +
+		"OUTPUT INSERTED.paymentId " +
+		"SELECT @p1, @p2, @p3, @p4, @p5, @p6, @p7 " +
+		"WHERE NOT EXISTS (SELECT 1 FROM " + payment.GetPaymentTable() + " WITH (UPDLOCK, HOLDLOCK) " +
+		"WHERE invoiceId = @p2 AND status IN (@p8, @p9))"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, payment.GetPaymentTable()) + "ClaimInvoicePayment - "
 
-	var queryCondition string = "WHERE "
-	var isHavePreviousCond bool = false
+	var paymentId int
+	if err := p.db.QueryRow(query, payment.CustomerId, payment.InvoiceId,
+		payment.Price, payment.PaymentMethod, payment.CreatedAt, payment.ServiceId, payment.Status,
+		domain_status.PAYMENT_PAID, domain_status.PAYMENT_PENDING).Scan(&paymentId); err != nil {
 
-	if req.Method != "" {
-		queryCondition += fmt.Sprintf("LOWER(paymentMethod) LIKE LOWER('%%%%%s%%%%')", req.Method)
-		isHavePreviousCond = true
-	}
-	if req.CustomerId != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
+		// Nothing was inserted, another payment of the invoice holds it
+		if err == sql.ErrNoRows {
+			return nil, errors.New(noti.INVOICE_PAYMENT_IN_PROGRESS_WARN_MSG)
 		}
 
-		queryCondition += fmt.Sprintf("customerId = %d", *req.CustomerId)
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
 	}
 
-	if queryCondition == "WHERE " {
-		queryCondition = ""
-	}
+	payment.PaymentId = paymentId
+	return &payment, nil
+}
 
-	var orderCondition string = generateOrderCondition(req.Request.FilterProp, req.Request.Order)
-	var query string = generateRetrieveQuery(table, queryCondition+orderCondition, limitRecords, req.Request.Page, false)
+// GetAllPayments implements repo.IPaymentRepo.
+func (p *paymentRepo) GetPayments(req request.GetPaymentsRequest, ctx context.Context) (*[]entity.Payment, int, int, error) {
+	var table string = entity.Payment{}.GetPaymentTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayments - "
+	var limitRecords int = req.PageSize
 
-	p.logger.Println("Query: ", query)
+	var builder *queryBuilder = generatePaymentQuery(req)
 
-	rows, err := p.db.Query(query)
+	rows, err := p.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
 	if err != nil {
 		p.logger.Println(errLogMsg + err.Error())
 		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
 	}
+	defer rows.Close()
 
 	var res []entity.Payment
 	for rows.Next() {
@@ -141,7 +153,10 @@ func (p *paymentRepo) GetPayments(req request.GetPaymentsRequest, ctx context.Co
 
 	// Track total records in table
 	var totalRecords int
-	p.db.QueryRow(generateRetrieveQuery(table, queryCondition, limitRecords, req.Request.Page, true)).Scan(&totalRecords)
+	if err := p.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords); err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
 
 	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
 }
@@ -168,12 +183,98 @@ func (p *paymentRepo) GetPaymentById(id int, ctx context.Context) (*entity.Payme
 	return &res, nil
 }
 
+// GetPaidPaymentByInvoiceId implements repo.IPaymentRepo.
+func (p *paymentRepo) GetPaidPaymentByInvoiceId(invoiceId int, ctx context.Context) (*entity.Payment, error) {
+	var res entity.Payment
+	var table string = res.GetPaymentTable()
+	var query string = "SELECT TOP 1 * FROM " + table + " WHERE invoiceId = @p1 AND status = @p2 ORDER BY paymentId DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaidPaymentByInvoiceId - "
+
+	if err := p.db.QueryRowContext(ctx, query, invoiceId, domain_status.PAYMENT_PAID).Scan(
+		&res.PaymentId, &res.Price, &res.CreatedAt,
+		&res.PaymentMethod, &res.InvoiceId, &res.CustomerId, &res.ServiceId, &res.Status); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetCustomerSpendingSummary implements repo.IPaymentRepo.
+func (p *paymentRepo) GetCustomerSpendingSummary(customerId int, ctx context.Context) (*entity.CustomerSpendingSummary, error) {
+	var table string = entity.Payment{}.GetPaymentTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetCustomerSpendingSummary - "
+	var query string = "SELECT ISNULL(SUM(CASE WHEN status = @p2 THEN price ELSE 0 END), 0), " +
+		"COUNT(DISTINCT CASE WHEN status = @p2 THEN invoiceId END), " +
+		"MAX(CASE WHEN status = @p2 THEN createdAt END), " +
+		"ISNULL(SUM(CASE WHEN status = @p3 THEN price ELSE 0 END), 0), " +
+		"COUNT(CASE WHEN status = @p3 THEN 1 END) " +
+		"FROM " + table + " WHERE customerId = @p1"
+
+	var res entity.CustomerSpendingSummary = entity.CustomerSpendingSummary{CustomerId: customerId}
+	if err := p.db.QueryRowContext(ctx, query, customerId, domain_status.PAYMENT_PAID, domain_status.PAYMENT_REFUNDED).Scan(
+		&res.LifetimeSpend, &res.PaidTours, &res.LastPaymentAt, &res.RefundedAmount, &res.RefundCount); err != nil {
+
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	res.LifetimeSpend = utils.RoundMoney(res.LifetimeSpend)
+	res.RefundedAmount = utils.RoundMoney(res.RefundedAmount)
+	if res.PaidTours > 0 {
+		res.AverageTicket = utils.RoundMoney(res.LifetimeSpend / float64(res.PaidTours))
+	}
+
+	return &res, nil
+}
+
+// GetCustomerPaymentHistory implements repo.IPaymentRepo.
+func (p *paymentRepo) GetCustomerPaymentHistory(req request.GetCustomerPaymentSummaryRequest, ctx context.Context) (*[]entity.Payment, int, int, error) {
+	var table string = entity.Payment{}.GetPaymentTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetCustomerPaymentHistory - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = generateCustomerPaymentHistoryQuery(req)
+
+	rows, err := p.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.Payment
+	for rows.Next() {
+		var x entity.Payment
+		if err := rows.Scan(
+			&x.PaymentId, &x.Price,
+			&x.CreatedAt, &x.PaymentMethod, &x.InvoiceId, &x.CustomerId, &x.ServiceId, &x.Status); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	p.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
 // UpdatePayment implements repo.IPaymentRepo.
 func (p *paymentRepo) UpdatePayment(payment entity.Payment, ctx context.Context) error {
 	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, payment.GetPaymentTable()) + "UpdatePayment - "
-	var query string = "UPDATE " + payment.GetPaymentTable() + " SET paymentMethod = @p1 WHERE paymentId = @p2"
+	var query string = "UPDATE " + payment.GetPaymentTable() + " SET paymentMethod = @p1, status = @p2 WHERE paymentId = @p3"
 
-	res, err := p.db.Exec(query, payment.PaymentMethod, payment.PaymentId)
+	res, err := p.db.Exec(query, payment.PaymentMethod, payment.Status, payment.PaymentId)
 
 	var INTERNALL_ERR_MSGMsg error = errors.New(noti.INTERNALL_ERR_MSG)
 
@@ -194,3 +295,68 @@ func (p *paymentRepo) UpdatePayment(payment entity.Payment, ctx context.Context)
 
 	return nil
 }
+
+// Payments matching the search filters, ordered by the sort column chosen by the client
+func generatePaymentQuery(req request.GetPaymentsRequest) *queryBuilder {
+	var res *queryBuilder = newQueryBuilder().whereLike(req.Request.Keyword, "paymentMethod", "status")
+
+	if req.Method != "" {
+		res.whereLike(req.Method, "paymentMethod")
+	}
+
+	if req.CustomerId != nil {
+		res.where("customerId = ?", *req.CustomerId)
+	}
+
+	res.whereIn("customerId", toParams(req.CustomerIds)...)
+
+	if req.Status != "" {
+		res.where("status = ?", req.Status)
+	}
+
+	if req.ServiceId != nil {
+		res.where("serviceId = ?", *req.ServiceId)
+	}
+
+	if req.InvoiceId != nil {
+		res.where("invoiceId = ?", *req.InvoiceId)
+	}
+
+	if req.From != nil {
+		res.where("createdAt >= ?", *req.From)
+	}
+
+	if req.To != nil {
+		res.where("createdAt < ?", *req.To)
+	}
+
+	if req.MinAmount != nil {
+		res.where("price >= ?", *req.MinAmount)
+	}
+
+	if req.MaxAmount != nil {
+		res.where("price <= ?", *req.MaxAmount)
+	}
+
+	res.sortBy(req.Request.FilterProp, req.Request.Order, filter_property.PAYMENT_SORT_COLUMNS, "createdAt")
+	return res
+}
+
+// Payments of a customer filtered by the status and the period, the latest first
+func generateCustomerPaymentHistoryQuery(req request.GetCustomerPaymentSummaryRequest) *queryBuilder {
+	var res *queryBuilder = newQueryBuilder().where("customerId = ?", req.CustomerId)
+	if req.Status != "" {
+		res.where("status = ?", req.Status)
+	}
+
+	if req.From != nil {
+		res.where("createdAt >= ?", *req.From)
+	}
+
+	if req.To != nil {
+		res.where("createdAt < ?", *req.To)
+	}
+
+	res.orderBy("createdAt", order.DESCENDING_ORDER)
+	return res
+}
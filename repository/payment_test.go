@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"tourmate/payment-service/model/dto/request"
+)
+
+func TestGeneratePaymentQuery(t *testing.T) {
+	var customerId, serviceId, invoiceId int = 7, 3, 42
+	var minAmount, maxAmount float64 = 100000, 500000
+	var from time.Time = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var to time.Time = time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       request.GetPaymentsRequest
+		wantPage  string
+		wantCount string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "no filter",
+			req:       request.GetPaymentsRequest{Request: request.SearchPaginationRequest{Page: 1}, PageSize: 10},
+			wantPage:  "SELECT * FROM Payment  ORDER BY createdAt ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment ",
+			wantArgs:  nil,
+		},
+		{
+			name: "keyword and method are searched as parameters",
+			req: request.GetPaymentsRequest{
+				Request:  request.SearchPaginationRequest{Page: 2, Keyword: " Pa%d ", FilterProp: "PRICE", Order: "desc"},
+				Method:   "payos",
+				PageSize: 20,
+			},
+			wantPage: "SELECT * FROM Payment WHERE (LOWER(paymentMethod) LIKE @p1 OR LOWER(status) LIKE @p1) AND (LOWER(paymentMethod) LIKE @p2) " +
+				"ORDER BY price DESC OFFSET 20 ROWS FETCH NEXT 20 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment WHERE (LOWER(paymentMethod) LIKE @p1 OR LOWER(status) LIKE @p1) AND (LOWER(paymentMethod) LIKE @p2)",
+			wantArgs:  []interface{}{"%pa[%]d%", "%payos%"},
+		},
+		{
+			name: "every filter keeps the placeholder order",
+			req: request.GetPaymentsRequest{
+				Request:     request.SearchPaginationRequest{Page: 1, FilterProp: "invoiceId", Order: "ASC"},
+				CustomerId:  &customerId,
+				CustomerIds: []int{8, 9},
+				Status:      "PAID",
+				ServiceId:   &serviceId,
+				InvoiceId:   &invoiceId,
+				From:        &from,
+				To:          &to,
+				MinAmount:   &minAmount,
+				MaxAmount:   &maxAmount,
+				PageSize:    10,
+			},
+			wantPage: "SELECT * FROM Payment WHERE customerId = @p1 AND customerId IN (@p2, @p3) AND status = @p4 AND serviceId = @p5 " +
+				"AND invoiceId = @p6 AND createdAt >= @p7 AND createdAt < @p8 AND price >= @p9 AND price <= @p10 " +
+				"ORDER BY invoiceId ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment WHERE customerId = @p1 AND customerId IN (@p2, @p3) AND status = @p4 AND serviceId = @p5 " +
+				"AND invoiceId = @p6 AND createdAt >= @p7 AND createdAt < @p8 AND price >= @p9 AND price <= @p10",
+			wantArgs: []interface{}{7, 8, 9, "PAID", 3, 42, from, to, minAmount, maxAmount},
+		},
+		{
+			name: "unknown sort column falls back to the date",
+			req: request.GetPaymentsRequest{
+				Request:  request.SearchPaginationRequest{Page: 1, FilterProp: "price; DROP TABLE Payment", Order: "DESC"},
+				PageSize: 10,
+			},
+			wantPage:  "SELECT * FROM Payment  ORDER BY createdAt DESC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment ",
+			wantArgs:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = generatePaymentQuery(tt.req)
+			if got := builder.pageQuery("Payment", tt.req.PageSize, tt.req.Request.Page); got != tt.wantPage {
+				t.Errorf("pageQuery() = %q, want %q", got, tt.wantPage)
+			}
+
+			if got := builder.countQuery("Payment"); got != tt.wantCount {
+				t.Errorf("countQuery() = %q, want %q", got, tt.wantCount)
+			}
+
+			if !reflect.DeepEqual(builder.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", builder.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestGenerateCustomerPaymentHistoryQuery(t *testing.T) {
+	var from time.Time = time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		req      request.GetCustomerPaymentSummaryRequest
+		wantPage string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "customer only",
+			req:      request.GetCustomerPaymentSummaryRequest{Request: request.SearchPaginationRequest{Page: 1}, CustomerId: 5, PageSize: 10},
+			wantPage: "SELECT * FROM Payment WHERE customerId = @p1 ORDER BY createdAt DESC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantArgs: []interface{}{5},
+		},
+		{
+			name: "status and period, the client order is ignored",
+			req: request.GetCustomerPaymentSummaryRequest{
+				Request:    request.SearchPaginationRequest{Page: 3, FilterProp: "PRICE", Order: "ASC"},
+				CustomerId: 5,
+				Status:     "REFUNDED",
+				From:       &from,
+				PageSize:   5,
+			},
+			wantPage: "SELECT * FROM Payment WHERE customerId = @p1 AND status = @p2 AND createdAt >= @p3 " +
+				"ORDER BY createdAt DESC OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY",
+			wantArgs: []interface{}{5, "REFUNDED", from},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = generateCustomerPaymentHistoryQuery(tt.req)
+			if got := builder.pageQuery("Payment", tt.req.PageSize, tt.req.Request.Page); got != tt.wantPage {
+				t.Errorf("pageQuery() = %q, want %q", got, tt.wantPage)
+			}
+
+			if !reflect.DeepEqual(builder.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", builder.args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+	"tourmate/payment-service/utils"
+)
+
+type payoutRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializePayoutRepo(db *sql.DB, logger *log.Logger) repo.IPayoutRepo {
+	return &payoutRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPayoutBatches implements repo.IPayoutRepo.
+func (p *payoutRepo) GetPayoutBatches(req request.GetPayoutBatchesRequest, ctx context.Context) (*[]entity.PayoutBatch, int, int, error) {
+	var table string = entity.PayoutBatch{}.GetPayoutBatchTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayoutBatches - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	builder.orderBy("createdAt", order.DESCENDING_ORDER)
+
+	rows, err := p.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.PayoutBatch
+	for rows.Next() {
+		var x entity.PayoutBatch
+		if err := rows.Scan(
+			&x.PayoutBatchId, &x.Status, &x.TotalAmount, &x.ItemCount, &x.Note,
+			&x.CreatedBy, &x.CreatedAt, &x.ApprovedBy, &x.ApprovedAt, &x.UpdatedAt); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	p.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetPayoutBatchById implements repo.IPayoutRepo.
+func (p *payoutRepo) GetPayoutBatchById(id int, ctx context.Context) (*entity.PayoutBatch, error) {
+	var res entity.PayoutBatch
+	var query string = "SELECT * FROM " + res.GetPayoutBatchTable() + " WHERE payoutBatchId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPayoutBatchTable()) + "GetPayoutBatchById - "
+
+	if err := p.db.QueryRowContext(ctx, query, id).Scan(
+		&res.PayoutBatchId, &res.Status, &res.TotalAmount, &res.ItemCount, &res.Note,
+		&res.CreatedBy, &res.CreatedAt, &res.ApprovedBy, &res.ApprovedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetPayoutItems implements repo.IPayoutRepo.
+func (p *payoutRepo) GetPayoutItems(batchId int, ctx context.Context) (*[]entity.PayoutItem, error) {
+	var table string = entity.PayoutItem{}.GetPayoutItemTable()
+	var query string = "SELECT * FROM " + table + " WHERE payoutBatchId = @p1 ORDER BY payoutItemId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayoutItems - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := p.db.QueryContext(ctx, query, batchId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.PayoutItem
+	for rows.Next() {
+		var x entity.PayoutItem
+		if err := rows.Scan(
+			&x.PayoutItemId, &x.PayoutBatchId, &x.TourGuideId, &x.Amount, &x.BankCode, &x.AccountNumber,
+			&x.AccountHolder, &x.Reference, &x.Status, &x.FailureReason, &x.ProcessedAt, &x.CreatedAt); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPaidPayoutItems implements repo.IPayoutRepo.
+func (p *payoutRepo) GetPaidPayoutItems(tourGuideId int, from time.Time, to time.Time, ctx context.Context) (*[]entity.PayoutItem, error) {
+	var table string = entity.PayoutItem{}.GetPayoutItemTable()
+	var query string = "SELECT * FROM " + table + " WHERE tourGuideId = @p1 AND status = @p2 AND processedAt >= @p3 AND processedAt < @p4 " +
+		"ORDER BY processedAt ASC, payoutItemId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaidPayoutItems - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := p.db.QueryContext(ctx, query, tourGuideId, domain_status.PAYOUT_ITEM_PAID, from, to)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.PayoutItem
+	for rows.Next() {
+		var x entity.PayoutItem
+		if err := rows.Scan(
+			&x.PayoutItemId, &x.PayoutBatchId, &x.TourGuideId, &x.Amount, &x.BankCode, &x.AccountNumber,
+			&x.AccountHolder, &x.Reference, &x.Status, &x.FailureReason, &x.ProcessedAt, &x.CreatedAt); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPayoutItemRevenueIds implements repo.IPayoutRepo.
+func (p *payoutRepo) GetPayoutItemRevenueIds(itemId int, ctx context.Context) ([]int, error) {
+	var table string = entity.PayoutItemRevenue{}.GetPayoutItemRevenueTable()
+	var query string = "SELECT revenueId FROM " + table + " WHERE payoutItemId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayoutItemRevenueIds - "
+
+	rows, err := p.db.QueryContext(ctx, query, itemId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			p.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+// CreatePayoutBatch implements repo.IPayoutRepo.
+func (p *payoutRepo) CreatePayoutBatch(batch entity.PayoutBatch, items []entity.PayoutItem, revenueIds, adjustmentIds, rewardIds [][]int, withholdings []entity.TaxWithholding, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, batch.GetPayoutBatchTable()) + "CreatePayoutBatch - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var batchQuery string = "INSERT INTO " + batch.GetPayoutBatchTable() +
+		" (status, totalAmount, itemCount, note, createdBy, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.payoutBatchId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7)"
+	var itemQuery string = "INSERT INTO " + entity.PayoutItem{}.GetPayoutItemTable() +
+		" (payoutBatchId, tourGuideId, amount, bankCode, accountNumber, accountHolder, " +
+		"reference, status, failureReason, createdAt) " +
+		"OUTPUT INSERTED.payoutItemId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10)"
+	// Revenues and rewards are claimed under lock so that two batches created at the same time cannot both pay them
+	var itemRevenueQuery string = "INSERT INTO " + entity.PayoutItemRevenue{}.GetPayoutItemRevenueTable() +
+		" (payoutItemId, revenueId) SELECT @p1, @p2 WHERE NOT EXISTS (" +
+		"SELECT 1 FROM " + entity.PayoutItemRevenue{}.GetPayoutItemRevenueTable() + " pir WITH (UPDLOCK, HOLDLOCK) " +
+		"JOIN " + entity.PayoutItem{}.GetPayoutItemTable() + " pi ON pi.payoutItemId = pir.payoutItemId " +
+		"WHERE pir.revenueId = @p2 AND pi.status IN (@p3, @p4))"
+	var rewardQuery string = "UPDATE " + entity.ReferralReward{}.GetReferralRewardTable() + " WITH (UPDLOCK, HOLDLOCK) SET payoutItemId = @p1 " +
+		"WHERE status = @p2 AND " + generateReferralRewardNotHeldCondition(3)
+	var adjustmentQuery string = "UPDATE " + entity.RevenueAdjustment{}.GetRevenueAdjustmentTable() + " WITH (UPDLOCK, HOLDLOCK) SET payoutItemId = @p1 " +
+		"WHERE settled = 0 AND (payoutItemId IS NULL OR payoutItemId NOT IN (SELECT payoutItemId FROM " + entity.PayoutItem{}.GetPayoutItemTable() +
+		" WHERE status IN (@p2, @p3)))"
+	var withholdingQuery string = "INSERT INTO " + entity.TaxWithholding{}.GetTaxWithholdingTable() +
+		" (payoutItemId, tourGuideId, taxpayerType, taxCode, grossAmount, taxRate, taxAmount, createdAt) " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8)"
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+	defer tx.Rollback()
+
+	var batchId int
+	if err := tx.QueryRowContext(ctx, batchQuery, batch.Status, batch.TotalAmount, batch.ItemCount, batch.Note,
+		batch.CreatedBy, batch.CreatedAt, batch.UpdatedAt).Scan(&batchId); err != nil {
+
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	for i, item := range items {
+		var itemId int
+		if err := tx.QueryRowContext(ctx, itemQuery, batchId, item.TourGuideId, item.Amount, item.BankCode, item.AccountNumber,
+			item.AccountHolder, utils.GeneratePayoutReference(batchId, item.TourGuideId), item.Status, item.FailureReason, item.CreatedAt).Scan(&itemId); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+
+		for _, revenueId := range revenueIds[i] {
+			res, err := tx.ExecContext(ctx, itemRevenueQuery, itemId, revenueId, domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID)
+			if err != nil {
+				p.logger.Println(errLogMsg + err.Error())
+				return 0, internalErr
+			}
+
+			if rowsAffected, err := res.RowsAffected(); err != nil || rowsAffected == 0 {
+				return 0, errors.New(noti.INVALID_STATUS_WARN_MSG)
+			}
+		}
+
+		if len(adjustmentIds[i]) > 0 {
+			var args []interface{} = []interface{}{itemId, domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID}
+			for _, adjustmentId := range adjustmentIds[i] {
+				args = append(args, adjustmentId)
+			}
+
+			res, err := tx.ExecContext(ctx, adjustmentQuery+" AND revenueAdjustmentId IN ("+generateInParams(4, len(adjustmentIds[i]))+")", args...)
+			if err != nil {
+				p.logger.Println(errLogMsg + err.Error())
+				return 0, internalErr
+			}
+
+			if rowsAffected, err := res.RowsAffected(); err != nil || rowsAffected != int64(len(adjustmentIds[i])) {
+				return 0, errors.New(noti.INVALID_STATUS_WARN_MSG)
+			}
+		}
+
+		if len(rewardIds[i]) > 0 {
+			var args []interface{} = []interface{}{itemId, domain_status.REFERRAL_REWARD_PENDING, domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID}
+			for _, rewardId := range rewardIds[i] {
+				args = append(args, rewardId)
+			}
+
+			res, err := tx.ExecContext(ctx, rewardQuery+" AND referralRewardId IN ("+generateInParams(5, len(rewardIds[i]))+")", args...)
+			if err != nil {
+				p.logger.Println(errLogMsg + err.Error())
+				return 0, internalErr
+			}
+
+			if rowsAffected, err := res.RowsAffected(); err != nil || rowsAffected != int64(len(rewardIds[i])) {
+				return 0, errors.New(noti.INVALID_STATUS_WARN_MSG)
+			}
+		}
+
+		var withholding entity.TaxWithholding = withholdings[i]
+		if _, err := tx.ExecContext(ctx, withholdingQuery, itemId, withholding.TourGuideId, withholding.TaxpayerType, withholding.TaxCode,
+			withholding.GrossAmount, withholding.TaxRate, withholding.TaxAmount, withholding.CreatedAt); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	return batchId, nil
+}
+
+// UpdatePayoutBatch implements repo.IPayoutRepo.
+func (p *payoutRepo) UpdatePayoutBatch(batch entity.PayoutBatch, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, batch.GetPayoutBatchTable()) + "UpdatePayoutBatch - "
+	var query string = "UPDATE " + batch.GetPayoutBatchTable() + " SET status = @p1, approvedBy = @p2, approvedAt = @p3, updatedAt = @p4 WHERE payoutBatchId = @p5"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := p.db.ExecContext(ctx, query, batch.Status, batch.ApprovedBy, batch.ApprovedAt, batch.UpdatedAt, batch.PayoutBatchId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, batch.GetPayoutBatchTable()))
+	}
+
+	return nil
+}
+
+// UpdatePayoutItem implements repo.IPayoutRepo.
+func (p *payoutRepo) UpdatePayoutItem(item entity.PayoutItem, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, item.GetPayoutItemTable()) + "UpdatePayoutItem - "
+	var query string = "UPDATE " + item.GetPayoutItemTable() + " SET status = @p1, failureReason = @p2, processedAt = @p3 WHERE payoutItemId = @p4"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := p.db.ExecContext(ctx, query, item.Status, item.FailureReason, item.ProcessedAt, item.PayoutItemId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, item.GetPayoutItemTable()))
+	}
+
+	return nil
+}
+
+// ProcessPayoutItem implements repo.IPayoutRepo.
+func (p *payoutRepo) ProcessPayoutItem(item entity.PayoutItem, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, item.GetPayoutItemTable()) + "ProcessPayoutItem - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var itemQuery string = "UPDATE " + item.GetPayoutItemTable() + " SET status = @p1, failureReason = @p2, processedAt = @p3 " +
+		"WHERE payoutItemId = @p4 AND status = @p5"
+	var revenueQuery string = "UPDATE " + entity.Revenue{}.GetRevenueTable() + " SET paymentStatus = 1 WHERE revenueId IN (" +
+		"SELECT revenueId FROM " + entity.PayoutItemRevenue{}.GetPayoutItemRevenueTable() + " WHERE payoutItemId = @p1)"
+	var adjustmentQuery string = "UPDATE " + entity.RevenueAdjustment{}.GetRevenueAdjustmentTable() + " SET settled = 1 WHERE payoutItemId = @p1"
+	var rewardQuery string = "UPDATE " + entity.ReferralReward{}.GetReferralRewardTable() + " SET status = @p1, paidAt = @p2 " +
+		"WHERE payoutItemId = @p3 AND status = @p4"
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, itemQuery, item.Status, item.FailureReason, item.ProcessedAt, item.PayoutItemId, domain_status.PAYOUT_ITEM_PENDING)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	// The revenues, adjustments and referral rewards held by a paid item are settled with it
+	if item.Status == domain_status.PAYOUT_ITEM_PAID {
+		if _, err := tx.ExecContext(ctx, revenueQuery, item.PayoutItemId); err != nil {
+			p.logger.Println(errLogMsg + err.Error())
+			return internalErr
+		}
+
+		if _, err := tx.ExecContext(ctx, adjustmentQuery, item.PayoutItemId); err != nil {
+			p.logger.Println(errLogMsg + err.Error())
+			return internalErr
+		}
+
+		if _, err := tx.ExecContext(ctx, rewardQuery, domain_status.REFERRAL_REWARD_PAID, item.ProcessedAt,
+			item.PayoutItemId, domain_status.REFERRAL_REWARD_PENDING); err != nil {
+
+			p.logger.Println(errLogMsg + err.Error())
+			return internalErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type payoutAccountRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializePayoutAccountRepo(db *sql.DB, logger *log.Logger) repo.IPayoutAccountRepo {
+	return &payoutAccountRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPayoutAccounts implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) GetPayoutAccounts(req request.GetPayoutAccountsRequest, ctx context.Context) (*[]entity.PayoutAccount, int, int, error) {
+	var table string = entity.PayoutAccount{}.GetPayoutAccountTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayoutAccounts - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	if req.TourGuideId != nil {
+		builder.where("tourGuideId = ?", *req.TourGuideId)
+	}
+
+	builder.orderBy("updatedAt", order.DESCENDING_ORDER)
+
+	rows, err := p.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanPayoutAccounts(rows)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	p.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetPayoutAccountsByTourGuide implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) GetPayoutAccountsByTourGuide(tourGuideId int, ctx context.Context) (*[]entity.PayoutAccount, error) {
+	var table string = entity.PayoutAccount{}.GetPayoutAccountTable()
+	var query string = "SELECT * FROM " + table + " WHERE tourGuideId = @p1 ORDER BY isDefault DESC, createdAt DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayoutAccountsByTourGuide - "
+
+	rows, err := p.db.QueryContext(ctx, query, tourGuideId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanPayoutAccounts(rows)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// GetPayoutAccountById implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) GetPayoutAccountById(id int, ctx context.Context) (*entity.PayoutAccount, error) {
+	var table string = entity.PayoutAccount{}.GetPayoutAccountTable()
+	var query string = "SELECT * FROM " + table + " WHERE payoutAccountId = @p1"
+
+	return p.getPayoutAccount(query, "GetPayoutAccountById - ", ctx, id)
+}
+
+// GetVerifiedPayoutAccount implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) GetVerifiedPayoutAccount(tourGuideId int, ctx context.Context) (*entity.PayoutAccount, error) {
+	var table string = entity.PayoutAccount{}.GetPayoutAccountTable()
+	var query string = "SELECT TOP 1 * FROM " + table + " WHERE tourGuideId = @p1 AND status = @p2 ORDER BY isDefault DESC, verifiedAt DESC"
+
+	return p.getPayoutAccount(query, "GetVerifiedPayoutAccount - ", ctx, tourGuideId, domain_status.PAYOUT_ACCOUNT_VERIFIED)
+}
+
+// CreatePayoutAccount implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) CreatePayoutAccount(account entity.PayoutAccount, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, account.GetPayoutAccountTable()) + "CreatePayoutAccount - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var query string = "INSERT INTO " + account.GetPayoutAccountTable() +
+		" (tourGuideId, bankCode, accountNumber, accountHolder, status, rejectReason, isDefault, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.payoutAccountId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9)"
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+	defer tx.Rollback()
+
+	if account.IsDefault {
+		if err := clearDefaultPayoutAccount(tx, account.TourGuideId, ctx); err != nil {
+			p.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+	}
+
+	var id int
+	if err := tx.QueryRowContext(ctx, query, account.TourGuideId, account.BankCode, account.AccountNumber, account.AccountHolder,
+		account.Status, account.RejectReason, account.IsDefault, account.CreatedAt, account.UpdatedAt).Scan(&id); err != nil {
+
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	return id, nil
+}
+
+// UpdatePayoutAccount implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) UpdatePayoutAccount(account entity.PayoutAccount, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, account.GetPayoutAccountTable()) + "UpdatePayoutAccount - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var query string = "UPDATE " + account.GetPayoutAccountTable() +
+		" SET bankCode = @p1, accountNumber = @p2, accountHolder = @p3, status = @p4, rejectReason = @p5, " +
+		"isDefault = @p6, verifiedBy = @p7, verifiedAt = @p8, updatedAt = @p9 WHERE payoutAccountId = @p10"
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+	defer tx.Rollback()
+
+	if account.IsDefault {
+		if err := clearDefaultPayoutAccount(tx, account.TourGuideId, ctx); err != nil {
+			p.logger.Println(errLogMsg + err.Error())
+			return internalErr
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, query, account.BankCode, account.AccountNumber, account.AccountHolder, account.Status,
+		account.RejectReason, account.IsDefault, account.VerifiedBy, account.VerifiedAt, account.UpdatedAt, account.PayoutAccountId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, account.GetPayoutAccountTable()))
+	}
+
+	if err := tx.Commit(); err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	return nil
+}
+
+// RemovePayoutAccount implements repo.IPayoutAccountRepo.
+func (p *payoutAccountRepo) RemovePayoutAccount(id, tourGuideId int, ctx context.Context) error {
+	var table string = entity.PayoutAccount{}.GetPayoutAccountTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "RemovePayoutAccount - "
+	var query string = "DELETE FROM " + table + " WHERE payoutAccountId = @p1 AND tourGuideId = @p2"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := p.db.ExecContext(ctx, query, id, tourGuideId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, table))
+	}
+
+	return nil
+}
+
+func (p *payoutAccountRepo) getPayoutAccount(query, method string, ctx context.Context, args ...interface{}) (*entity.PayoutAccount, error) {
+	var res entity.PayoutAccount
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPayoutAccountTable()) + method
+
+	if err := p.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.PayoutAccountId, &res.TourGuideId, &res.BankCode, &res.AccountNumber, &res.AccountHolder, &res.Status,
+		&res.RejectReason, &res.IsDefault, &res.VerifiedBy, &res.VerifiedAt, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+func scanPayoutAccounts(rows *sql.Rows) (*[]entity.PayoutAccount, error) {
+	var res []entity.PayoutAccount
+	for rows.Next() {
+		var x entity.PayoutAccount
+		if err := rows.Scan(
+			&x.PayoutAccountId, &x.TourGuideId, &x.BankCode, &x.AccountNumber, &x.AccountHolder, &x.Status,
+			&x.RejectReason, &x.IsDefault, &x.VerifiedBy, &x.VerifiedAt, &x.CreatedAt, &x.UpdatedAt); err != nil {
+
+			return nil, err
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// Only one default account per tour guide
+func clearDefaultPayoutAccount(tx *sql.Tx, tourGuideId int, ctx context.Context) error {
+	_, err := tx.ExecContext(ctx, "UPDATE "+entity.PayoutAccount{}.GetPayoutAccountTable()+" SET isDefault = 0 WHERE tourGuideId = @p1", tourGuideId)
+	return err
+}
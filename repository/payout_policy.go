@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type payoutPolicyRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializePayoutPolicyRepo(db *sql.DB, logger *log.Logger) repo.IPayoutPolicyRepo {
+	return &payoutPolicyRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPayoutPolicy implements repo.IPayoutPolicyRepo.
+func (p *payoutPolicyRepo) GetPayoutPolicy(ctx context.Context) (*entity.PayoutPolicy, error) {
+	var res entity.PayoutPolicy
+	var query string = "SELECT TOP 1 * FROM " + res.GetPayoutPolicyTable() + " ORDER BY payoutPolicyId DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetPayoutPolicyTable()) + "GetPayoutPolicy - "
+
+	if err := p.db.QueryRowContext(ctx, query).Scan(
+		&res.PayoutPolicyId, &res.MinPayableBalance, &res.HoldDays, &res.Schedule, &res.PayoutDay,
+		&res.IsAutoPayoutEnabled, &res.LastRunAt, &res.UpdatedBy, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		p.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreatePayoutPolicy implements repo.IPayoutPolicyRepo.
+func (p *payoutPolicyRepo) CreatePayoutPolicy(policy entity.PayoutPolicy, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, policy.GetPayoutPolicyTable()) + "CreatePayoutPolicy - "
+	var query string = "INSERT INTO " + policy.GetPayoutPolicyTable() +
+		" (minPayableBalance, holdDays, schedule, payoutDay, isAutoPayoutEnabled, lastRunAt, updatedBy, updatedAt) " +
+		"OUTPUT INSERTED.payoutPolicyId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8)"
+
+	var id int
+	if err := p.db.QueryRowContext(ctx, query, policy.MinPayableBalance, policy.HoldDays, policy.Schedule, policy.PayoutDay,
+		policy.IsAutoPayoutEnabled, policy.LastRunAt, policy.UpdatedBy, policy.UpdatedAt).Scan(&id); err != nil {
+
+		p.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return id, nil
+}
+
+// UpdatePayoutPolicy implements repo.IPayoutPolicyRepo.
+func (p *payoutPolicyRepo) UpdatePayoutPolicy(policy entity.PayoutPolicy, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, policy.GetPayoutPolicyTable()) + "UpdatePayoutPolicy - "
+	var query string = "UPDATE " + policy.GetPayoutPolicyTable() +
+		" SET minPayableBalance = @p1, holdDays = @p2, schedule = @p3, payoutDay = @p4, isAutoPayoutEnabled = @p5, " +
+		"lastRunAt = @p6, updatedBy = @p7, updatedAt = @p8 WHERE payoutPolicyId = @p9"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := p.db.ExecContext(ctx, query, policy.MinPayableBalance, policy.HoldDays, policy.Schedule, policy.PayoutDay,
+		policy.IsAutoPayoutEnabled, policy.LastRunAt, policy.UpdatedBy, policy.UpdatedAt, policy.PayoutPolicyId)
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		p.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, policy.GetPayoutPolicyTable()))
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	filter_property "tourmate/payment-service/constant/filter_property"
 	"tourmate/payment-service/constant/noti"
 	"tourmate/payment-service/interface/repo"
 	"tourmate/payment-service/model/dto/request"
@@ -69,32 +70,23 @@ func (p *platformFeedbackRepo) GetPlatformFeedbacks(req request.GetPlatformFeedb
 	var limitRecords int = *req.PageSize
 
 	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetplatformFeedbacks - "
-	var queryCondition string = "WHERE "
-	var isHavePreviousCond bool = false
+	var builder *queryBuilder = newQueryBuilder()
 	if req.CustomerId != nil {
-		queryCondition += fmt.Sprintf("customerId = %d", *req.CustomerId)
-		isHavePreviousCond = true
+		builder.where("customerId = ?", *req.CustomerId)
 	}
-	if req.Rating != nil {
-		if isHavePreviousCond {
-			queryCondition += " AND "
-		}
 
-		queryCondition += fmt.Sprintf("rating = %d", *req.Rating)
-	}
-
-	if queryCondition == "WHERE " {
-		queryCondition = ""
+	if req.Rating != nil {
+		builder.where("rating = ?", *req.Rating)
 	}
 
-	var orderCondition string = generateOrderCondition(req.Request.FilterProp, req.Request.Order)
-	var query string = generateRetrieveQuery(table, queryCondition+orderCondition, limitRecords, *req.PageIndex, false)
+	builder.sortBy(req.Request.FilterProp, req.Request.Order, filter_property.PLATFORM_FEEDBACK_SORT_COLUMNS, "createdAt")
 
-	rows, err := p.db.Query(query)
+	rows, err := p.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, *req.PageIndex), builder.args...)
 	if err != nil {
 		p.logger.Println(errLogMsg + err.Error())
 		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
 	}
+	defer rows.Close()
 
 	var res []entity.PlatformFeedback
 	for rows.Next() {
@@ -113,7 +105,7 @@ func (p *platformFeedbackRepo) GetPlatformFeedbacks(req request.GetPlatformFeedb
 
 	// Track total records in table
 	var totalRecords int
-	p.db.QueryRow(generateRetrieveQuery(table, queryCondition, limitRecords, *req.PageIndex, true)).Scan(&totalRecords)
+	p.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
 
 	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
 }
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"tourmate/payment-service/utils"
+)
+
+// Builds the conditions, order and paging of a list query. Values are always sent as parameters and the order is limited
+// to known columns, so nothing sent by the client becomes part of the query text
+type queryBuilder struct {
+	conditions []string
+	args       []interface{}
+	order      string
+}
+
+func newQueryBuilder() *queryBuilder {
+	return &queryBuilder{}
+}
+
+// Add a value as the next parameter and get its placeholder
+func (q *queryBuilder) param(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("@p%d", len(q.args))
+}
+
+// Add a condition, each ? is replaced by the placeholder of the next value
+func (q *queryBuilder) where(condition string, values ...interface{}) *queryBuilder {
+	var res strings.Builder
+	var index int = 0
+	for _, c := range condition {
+		if c == '?' && index < len(values) {
+			res.WriteString(q.param(values[index]))
+			index++
+			continue
+		}
+
+		res.WriteRune(c)
+	}
+
+	q.conditions = append(q.conditions, res.String())
+	return q
+}
+
+// Match any of the values, nothing is filtered when there is no value
+func (q *queryBuilder) whereIn(column string, values ...interface{}) *queryBuilder {
+	if len(values) == 0 {
+		return q
+	}
+
+	var params []string
+	for _, value := range values {
+		params = append(params, q.param(value))
+	}
+
+	q.conditions = append(q.conditions, column+" IN ("+strings.Join(params, ", ")+")")
+	return q
+}
+
+// Case insensitive search of the keyword in any of the columns, wildcards typed in the keyword are matched as they are
+func (q *queryBuilder) whereLike(keyword string, columns ...string) *queryBuilder {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" || len(columns) == 0 {
+		return q
+	}
+
+	var placeholder string = q.param("%" + escapeLikePattern(strings.ToLower(keyword)) + "%")
+
+	var matches []string
+	for _, column := range columns {
+		matches = append(matches, "LOWER("+column+") LIKE "+placeholder)
+	}
+
+	q.conditions = append(q.conditions, "("+strings.Join(matches, " OR ")+")")
+	return q
+}
+
+// Order by a column chosen by the repository, never pass a value of the request here
+func (q *queryBuilder) orderBy(column, ord string) *queryBuilder {
+	q.order = " ORDER BY " + column + " " + utils.AssignOrder(strings.ToUpper(ord))
+	return q
+}
+
+// Order by a column chosen by the client, either the filter property or its column. Anything outside of the sortable
+// columns is ordered by the default column
+func (q *queryBuilder) sortBy(column, ord string, sortable map[string]string, defaultColumn string) *queryBuilder {
+	if res, isExisted := sortable[strings.ToUpper(column)]; isExisted {
+		return q.orderBy(res, ord)
+	}
+
+	for _, res := range sortable {
+		if res == column {
+			return q.orderBy(res, ord)
+		}
+	}
+
+	return q.orderBy(defaultColumn, ord)
+}
+
+// WHERE clause of the conditions, it is empty when there is no condition
+func (q *queryBuilder) condition() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// Query of a page of records, the page starts at 1. Paging needs an order so the records are returned in any order when
+// none is set
+func (q *queryBuilder) pageQuery(source string, limitAmount, pageNumber int) string {
+	var order string = q.order
+	if order == "" {
+		order = " ORDER BY (SELECT NULL)"
+	}
+
+	return fmt.Sprintf("SELECT * FROM %s %s%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY",
+		source, q.condition(), order, getOffSetAmount(limitAmount, max(pageNumber, 1)), limitAmount)
+}
+
+// Query counting the records matching the conditions
+func (q *queryBuilder) countQuery(source string) string {
+	return "SELECT COUNT(*) FROM " + source + " " + q.condition()
+}
+
+// Escape the wildcards of a LIKE pattern
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer("[", "[[]", "%", "[%]", "_", "[_]").Replace(value)
+}
+
+// Values of a list as query parameters
+func toParams[T any](values []T) []interface{} {
+	var res []interface{}
+	for _, value := range values {
+		res = append(res, value)
+	}
+
+	return res
+}
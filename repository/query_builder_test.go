@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryBuilderWhere(t *testing.T) {
+	tests := []struct {
+		name          string
+		build         func() *queryBuilder
+		wantCondition string
+		wantArgs      []interface{}
+	}{
+		{
+			name:          "no condition",
+			build:         newQueryBuilder,
+			wantCondition: "",
+			wantArgs:      nil,
+		},
+		{
+			name: "placeholders are numbered across conditions",
+			build: func() *queryBuilder {
+				return newQueryBuilder().where("customerId = ?", 7).where("createdAt >= ? AND createdAt < ?", "from", "to")
+			},
+			wantCondition: "WHERE customerId = @p1 AND createdAt >= @p2 AND createdAt < @p3",
+			wantArgs:      []interface{}{7, "from", "to"},
+		},
+		{
+			name: "in continues the numbering",
+			build: func() *queryBuilder {
+				return newQueryBuilder().where("status = ?", "PAID").whereIn("customerId", toParams([]int{3, 4, 5})...)
+			},
+			wantCondition: "WHERE status = @p1 AND customerId IN (@p2, @p3, @p4)",
+			wantArgs:      []interface{}{"PAID", 3, 4, 5},
+		},
+		{
+			name: "in without values is not applied",
+			build: func() *queryBuilder {
+				return newQueryBuilder().whereIn("customerId", toParams([]int{})...).where("status = ?", "PAID")
+			},
+			wantCondition: "WHERE status = @p1",
+			wantArgs:      []interface{}{"PAID"},
+		},
+		{
+			name: "param after the conditions",
+			build: func() *queryBuilder {
+				var res *queryBuilder = newQueryBuilder().where("agencyId = ?", 1)
+				res.param("PAID")
+				return res
+			},
+			wantCondition: "WHERE agencyId = @p1",
+			wantArgs:      []interface{}{1, "PAID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = tt.build()
+			if got := builder.condition(); got != tt.wantCondition {
+				t.Errorf("condition() = %q, want %q", got, tt.wantCondition)
+			}
+
+			if !reflect.DeepEqual(builder.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", builder.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderWhereLike(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyword       string
+		columns       []string
+		wantCondition string
+		wantArgs      []interface{}
+	}{
+		{
+			name:          "keyword is lowered and wrapped",
+			keyword:       " PayOS ",
+			columns:       []string{"paymentMethod", "status"},
+			wantCondition: "WHERE (LOWER(paymentMethod) LIKE @p1 OR LOWER(status) LIKE @p1)",
+			wantArgs:      []interface{}{"%payos%"},
+		},
+		{
+			name:          "percent is escaped",
+			keyword:       "100%",
+			columns:       []string{"content"},
+			wantCondition: "WHERE (LOWER(content) LIKE @p1)",
+			wantArgs:      []interface{}{"%100[%]%"},
+		},
+		{
+			name:          "underscore is escaped",
+			keyword:       "bank_transfer",
+			columns:       []string{"paymentMethod"},
+			wantCondition: "WHERE (LOWER(paymentMethod) LIKE @p1)",
+			wantArgs:      []interface{}{"%bank[_]transfer%"},
+		},
+		{
+			name:          "bracket is escaped",
+			keyword:       "[a]",
+			columns:       []string{"content"},
+			wantCondition: "WHERE (LOWER(content) LIKE @p1)",
+			wantArgs:      []interface{}{"%[[]a]%"},
+		},
+		{
+			name:          "quote stays in the parameter",
+			keyword:       "' OR 1 = 1 --",
+			columns:       []string{"content"},
+			wantCondition: "WHERE (LOWER(content) LIKE @p1)",
+			wantArgs:      []interface{}{"%' or 1 = 1 --%"},
+		},
+		{
+			name:          "blank keyword is not applied",
+			keyword:       "  ",
+			columns:       []string{"content"},
+			wantCondition: "",
+			wantArgs:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = newQueryBuilder().whereLike(tt.keyword, tt.columns...)
+			if got := builder.condition(); got != tt.wantCondition {
+				t.Errorf("condition() = %q, want %q", got, tt.wantCondition)
+			}
+
+			if !reflect.DeepEqual(builder.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", builder.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderSortBy(t *testing.T) {
+	var sortable map[string]string = map[string]string{
+		"DATE":  "createdAt",
+		"PRICE": "price",
+	}
+
+	tests := []struct {
+		name      string
+		column    string
+		ord       string
+		wantOrder string
+	}{
+		{name: "filter property", column: "price", ord: "DESC", wantOrder: " ORDER BY price DESC"},
+		{name: "whitelisted column", column: "createdAt", ord: "asc", wantOrder: " ORDER BY createdAt ASC"},
+		{name: "unknown column falls back", column: "rate", ord: "DESC", wantOrder: " ORDER BY createdAt DESC"},
+		{name: "injected column falls back", column: "price; DROP TABLE Payment", ord: "DESC", wantOrder: " ORDER BY createdAt DESC"},
+		{name: "unknown order falls back", column: "DATE", ord: "DESC; --", wantOrder: " ORDER BY createdAt ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = newQueryBuilder().sortBy(tt.column, tt.ord, sortable, "createdAt")
+			if builder.order != tt.wantOrder {
+				t.Errorf("order = %q, want %q", builder.order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderPageAndCountQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		build     func() *queryBuilder
+		limit     int
+		page      int
+		wantPage  string
+		wantCount string
+	}{
+		{
+			name: "conditions and order",
+			build: func() *queryBuilder {
+				return newQueryBuilder().where("customerId = ?", 1).orderBy("createdAt", "DESC")
+			},
+			limit:     10,
+			page:      3,
+			wantPage:  "SELECT * FROM Payment WHERE customerId = @p1 ORDER BY createdAt DESC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment WHERE customerId = @p1",
+		},
+		{
+			name:      "paging without order",
+			build:     newQueryBuilder,
+			limit:     20,
+			page:      1,
+			wantPage:  "SELECT * FROM Payment  ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 20 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment ",
+		},
+		{
+			name: "page below 1 reads the first page",
+			build: func() *queryBuilder {
+				return newQueryBuilder().orderBy("paymentId", "ASC")
+			},
+			limit:     10,
+			page:      0,
+			wantPage:  "SELECT * FROM Payment  ORDER BY paymentId ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Payment ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = tt.build()
+			if got := builder.pageQuery("Payment", tt.limit, tt.page); got != tt.wantPage {
+				t.Errorf("pageQuery() = %q, want %q", got, tt.wantPage)
+			}
+
+			if got := builder.countQuery("Payment"); got != tt.wantCount {
+				t.Errorf("countQuery() = %q, want %q", got, tt.wantCount)
+			}
+		})
+	}
+}
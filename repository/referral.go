@@ -0,0 +1,585 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/constant/referral"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type referralRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeReferralRepo(db *sql.DB, logger *log.Logger) repo.IReferralRepo {
+	return &referralRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetReferralCodeById implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralCodeById(id int, ctx context.Context) (*entity.ReferralCode, error) {
+	return r.getReferralCode("referralCodeId = @p1", "GetReferralCodeById - ", ctx, id)
+}
+
+// GetReferralCodeByCode implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralCodeByCode(code string, ctx context.Context) (*entity.ReferralCode, error) {
+	return r.getReferralCode("code = @p1", "GetReferralCodeByCode - ", ctx, code)
+}
+
+// GetReferralCodeByOwner implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralCodeByOwner(ownerType string, ownerId int, ctx context.Context) (*entity.ReferralCode, error) {
+	return r.getReferralCode("ownerType = @p1 AND ownerId = @p2", "GetReferralCodeByOwner - ", ctx, ownerType, ownerId)
+}
+
+func (r *referralRepo) getReferralCode(condition string, method string, ctx context.Context, args ...interface{}) (*entity.ReferralCode, error) {
+	var res entity.ReferralCode
+	var query string = "SELECT * FROM " + res.GetReferralCodeTable() + " WHERE " + condition
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetReferralCodeTable()) + method
+
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.ReferralCodeId, &res.Code, &res.OwnerType, &res.OwnerId, &res.IsActive, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateReferralCode implements repo.IReferralRepo.
+func (r *referralRepo) CreateReferralCode(code entity.ReferralCode, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + code.GetReferralCodeTable() +
+		" (code, ownerType, ownerId, isActive, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.referralCodeId VALUES (@p1, @p2, @p3, @p4, @p5, @p6)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, code.GetReferralCodeTable()) + "CreateReferralCode - "
+
+	var res int
+	if err := r.db.QueryRowContext(ctx, query, code.Code, code.OwnerType, code.OwnerId, code.IsActive, code.CreatedAt,
+		code.UpdatedAt).Scan(&res); err != nil {
+
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateReferralCode implements repo.IReferralRepo.
+func (r *referralRepo) UpdateReferralCode(code entity.ReferralCode, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, code.GetReferralCodeTable()) + "UpdateReferralCode - "
+	var query string = "UPDATE " + code.GetReferralCodeTable() + " SET isActive = @p1, updatedAt = @p2 WHERE referralCodeId = @p3"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := r.db.ExecContext(ctx, query, code.IsActive, code.UpdatedAt, code.ReferralCodeId)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, code.GetReferralCodeTable()))
+	}
+
+	return nil
+}
+
+// IsNewReferee implements repo.IReferralRepo.
+func (r *referralRepo) IsNewReferee(refereeType string, refereeId int, ctx context.Context) (bool, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, entity.Referral{}.GetReferralTable()) + "IsNewReferee - "
+
+	// Refunded tours were paid once, so they count as well
+	var query string = "SELECT COUNT(*) FROM " + entity.Payment{}.GetPaymentTable() + " WHERE customerId = @p1 AND status IN (@p2, @p3)"
+	var args []interface{} = []interface{}{refereeId, domain_status.PAYMENT_PAID, domain_status.PAYMENT_REFUNDED}
+	if refereeType == referral.TOUR_GUIDE {
+		query = "SELECT COUNT(*) FROM " + entity.Revenue{}.GetRevenueTable() + " WHERE tourGuideId = @p1"
+		args = args[:1]
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return false, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return count == 0, nil
+}
+
+// CreateReferral implements repo.IReferralRepo.
+func (r *referralRepo) CreateReferral(referral entity.Referral, maxReferrals int, ctx context.Context) (int, error) {
+	var table string = referral.GetReferralTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "CreateReferral - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	// The code row is locked so that concurrent claims of the same code are counted one after another,
+	// the range lock keeps a concurrent claim of the same referee waiting
+	var lockQuery string = "SELECT referralCodeId FROM " + entity.ReferralCode{}.GetReferralCodeTable() + " WITH (UPDLOCK) WHERE referralCodeId = @p1"
+	var refereeQuery string = "SELECT COUNT(*) FROM " + table + " WITH (UPDLOCK, HOLDLOCK) WHERE refereeType = @p1 AND refereeId = @p2"
+	var countQuery string = "SELECT COUNT(*) FROM " + table + " WHERE referralCodeId = @p1"
+	var createQuery string = "INSERT INTO " + table +
+		" (referralCodeId, referrerType, referrerId, refereeType, refereeId, status, rewardedTours, firstPaymentId, createdAt, attributedAt) " +
+		"OUTPUT INSERTED.referralId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10)"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+	defer tx.Rollback()
+
+	var codeId int
+	if err := tx.QueryRowContext(ctx, lockQuery, referral.ReferralCodeId).Scan(&codeId); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, refereeQuery, referral.RefereeType, referral.RefereeId).Scan(&count); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if count > 0 {
+		return 0, errors.New(noti.REFERRAL_ALREADY_CLAIMED_WARN_MSG)
+	}
+
+	if err := tx.QueryRowContext(ctx, countQuery, referral.ReferralCodeId).Scan(&count); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if count >= maxReferrals {
+		return 0, errors.New(noti.REFERRAL_LIMIT_REACHED_WARN_MSG)
+	}
+
+	var res int
+	if err := tx.QueryRowContext(ctx, createQuery, referral.ReferralCodeId, referral.ReferrerType, referral.ReferrerId,
+		referral.RefereeType, referral.RefereeId, referral.Status, referral.RewardedTours, referral.FirstPaymentId,
+		referral.CreatedAt, referral.AttributedAt).Scan(&res); err != nil {
+
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	return res, nil
+}
+
+// GetReferralByReferee implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralByReferee(refereeType string, refereeId int, ctx context.Context) (*entity.Referral, error) {
+	var res entity.Referral
+	var query string = "SELECT * FROM " + res.GetReferralTable() + " WHERE refereeType = @p1 AND refereeId = @p2"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetReferralTable()) + "GetReferralByReferee - "
+
+	if err := r.db.QueryRowContext(ctx, query, refereeType, refereeId).Scan(
+		&res.ReferralId, &res.ReferralCodeId, &res.ReferrerType, &res.ReferrerId, &res.RefereeType, &res.RefereeId,
+		&res.Status, &res.RewardedTours, &res.FirstPaymentId, &res.CreatedAt, &res.AttributedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetReferralsByCodeId implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralsByCodeId(codeId int, ctx context.Context) (*[]entity.Referral, error) {
+	var table string = entity.Referral{}.GetReferralTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetReferralsByCodeId - "
+	var query string = "SELECT * FROM " + table + " WHERE referralCodeId = @p1 ORDER BY referralId DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, codeId)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.Referral
+	for rows.Next() {
+		var x entity.Referral
+		if err := rows.Scan(
+			&x.ReferralId, &x.ReferralCodeId, &x.ReferrerType, &x.ReferrerId, &x.RefereeType, &x.RefereeId,
+			&x.Status, &x.RewardedTours, &x.FirstPaymentId, &x.CreatedAt, &x.AttributedAt); err != nil {
+
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// AttributeReferral implements repo.IReferralRepo.
+func (r *referralRepo) AttributeReferral(id int, paymentId int, attributedAt time.Time, ctx context.Context) error {
+	var table string = entity.Referral{}.GetReferralTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "AttributeReferral - "
+	var query string = "UPDATE " + table + " SET status = @p1, firstPaymentId = @p2, attributedAt = @p3 WHERE referralId = @p4 AND status = @p5"
+
+	if _, err := r.db.ExecContext(ctx, query, domain_status.REFERRAL_ACTIVE, paymentId, attributedAt, id, domain_status.REFERRAL_PENDING); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return nil
+}
+
+// CreateReferralReward implements repo.IReferralRepo.
+func (r *referralRepo) CreateReferralReward(reward entity.ReferralReward, maxTours int, maxRewardAmount float64, ctx context.Context) (*entity.ReferralReward, error) {
+	var table string = reward.GetReferralRewardTable()
+	var referralTable string = entity.Referral{}.GetReferralTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "CreateReferralReward - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	// The code row is shared by every referral of the referrer, locking it serializes the cap check of the referrer
+	var lockQuery string = "SELECT r.rewardedTours FROM " + referralTable + " r WITH (UPDLOCK) " +
+		"JOIN " + entity.ReferralCode{}.GetReferralCodeTable() + " rc WITH (UPDLOCK) ON rc.referralCodeId = r.referralCodeId " +
+		"WHERE r.referralId = @p1"
+	// A tour paid in several parts, e.g. wallet and gateway, is counted once
+	var tourQuery string = "SELECT COUNT(*) FROM " + table + " WHERE referralId = @p1 AND invoiceId = @p2 AND status <> @p3"
+	var totalQuery string = "SELECT COALESCE(SUM(amount), 0) FROM " + table + " WHERE referrerType = @p1 AND referrerId = @p2 AND status <> @p3"
+	var countQuery string = "UPDATE " + referralTable + " SET rewardedTours = rewardedTours + 1, " +
+		"status = CASE WHEN rewardedTours + 1 >= @p1 THEN @p2 ELSE @p3 END WHERE referralId = @p4"
+	var createQuery string = "INSERT INTO " + table +
+		" (referralId, referrerType, referrerId, paymentId, invoiceId, revenueId, commissionAmount, rewardRate, amount, status, " +
+		"walletTransactionId, payoutItemId, createdAt, paidAt) " +
+		"OUTPUT INSERTED.referralRewardId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13, @p14)"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	var rewardedTours int
+	if err := tx.QueryRowContext(ctx, lockQuery, reward.ReferralId).Scan(&rewardedTours); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, tourQuery, reward.ReferralId, reward.InvoiceId, domain_status.REFERRAL_REWARD_CANCELLED).Scan(&count); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	var isNewTour bool = count == 0
+	if isNewTour && rewardedTours >= maxTours {
+		return nil, nil
+	}
+
+	var totalAmount float64
+	if err := tx.QueryRowContext(ctx, totalQuery, reward.ReferrerType, reward.ReferrerId, domain_status.REFERRAL_REWARD_CANCELLED).Scan(&totalAmount); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	reward.Amount = min(reward.Amount, maxRewardAmount-totalAmount)
+
+	if isNewTour {
+		if _, err := tx.ExecContext(ctx, countQuery, maxTours, domain_status.REFERRAL_COMPLETED, domain_status.REFERRAL_ACTIVE, reward.ReferralId); err != nil {
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+	}
+
+	// The tour is still counted when the referrer has reached the cap
+	if reward.Amount > 0 {
+		if err := tx.QueryRowContext(ctx, createQuery, reward.ReferralId, reward.ReferrerType, reward.ReferrerId, reward.PaymentId,
+			reward.InvoiceId, reward.RevenueId, reward.CommissionAmount, reward.RewardRate, reward.Amount, reward.Status,
+			reward.WalletTransactionId, reward.PayoutItemId, reward.CreatedAt, reward.PaidAt).Scan(&reward.ReferralRewardId); err != nil {
+
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if reward.Amount <= 0 {
+		return nil, nil
+	}
+
+	return &reward, nil
+}
+
+// GetReferralRewards implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralRewards(req request.GetReferralRewardsRequest, ctx context.Context) (*[]entity.ReferralReward, int, int, error) {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetReferralRewards - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	if req.ReferrerType != "" {
+		builder.where("referrerType = ?", req.ReferrerType)
+	}
+
+	if req.ReferrerId > 0 {
+		builder.where("referrerId = ?", req.ReferrerId)
+	}
+
+	builder.orderBy("referralRewardId", order.DESCENDING_ORDER)
+
+	rows, err := r.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanReferralRewards(rows)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	r.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetReferralRewardsByPaymentId implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralRewardsByPaymentId(paymentId int, ctx context.Context) (*[]entity.ReferralReward, error) {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetReferralRewardsByPaymentId - "
+	var query string = "SELECT * FROM " + table + " WHERE paymentId = @p1"
+
+	rows, err := r.db.QueryContext(ctx, query, paymentId)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanReferralRewards(rows)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// UpdateReferralReward implements repo.IReferralRepo.
+func (r *referralRepo) UpdateReferralReward(reward entity.ReferralReward, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, reward.GetReferralRewardTable()) + "UpdateReferralReward - "
+	var query string = "UPDATE " + reward.GetReferralRewardTable() + " SET status = @p1, walletTransactionId = @p2, paidAt = @p3 " +
+		"WHERE referralRewardId = @p4 AND status = @p5"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := r.db.ExecContext(ctx, query, reward.Status, reward.WalletTransactionId, reward.PaidAt, reward.ReferralRewardId, currentStatus)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// CancelReferralReward implements repo.IReferralRepo.
+func (r *referralRepo) CancelReferralReward(id int, ctx context.Context) error {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "CancelReferralReward - "
+	var query string = "UPDATE " + table + " SET status = @p1 WHERE referralRewardId = @p2 AND status = @p3 AND " +
+		generateReferralRewardNotHeldCondition(4)
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := r.db.ExecContext(ctx, query, domain_status.REFERRAL_REWARD_CANCELLED, id, domain_status.REFERRAL_REWARD_PENDING,
+		domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// GetReferralRewardTotals implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralRewardTotals(referrerType string, referrerId int, ctx context.Context) (float64, float64, error) {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetReferralRewardTotals - "
+	var query string = "SELECT COALESCE(SUM(CASE WHEN status = @p3 THEN amount ELSE 0 END), 0), " +
+		"COALESCE(SUM(CASE WHEN status = @p4 THEN amount ELSE 0 END), 0) " +
+		"FROM " + table + " WHERE referrerType = @p1 AND referrerId = @p2"
+
+	var paid, pending float64
+	if err := r.db.QueryRowContext(ctx, query, referrerType, referrerId, domain_status.REFERRAL_REWARD_PAID,
+		domain_status.REFERRAL_REWARD_PENDING).Scan(&paid, &pending); err != nil {
+
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return paid, pending, nil
+}
+
+// GetPayableReferralRewards implements repo.IReferralRepo.
+func (r *referralRepo) GetPayableReferralRewards(tourGuideId int, createdBefore time.Time, ctx context.Context) (*[]entity.ReferralReward, error) {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayableReferralRewards - "
+	var query string = "SELECT * FROM " + table + " WHERE referrerId = @p1 AND " + generatePayableReferralRewardCondition(2) + " ORDER BY createdAt ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, tourGuideId, referral.TOUR_GUIDE, domain_status.REFERRAL_REWARD_PENDING, createdBefore,
+		domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanReferralRewards(rows)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetPayableReferrerIds implements repo.IReferralRepo.
+func (r *referralRepo) GetPayableReferrerIds(createdBefore time.Time, ctx context.Context) ([]int, error) {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayableReferrerIds - "
+	var query string = "SELECT DISTINCT referrerId FROM " + table + " WHERE " + generatePayableReferralRewardCondition(1) + " ORDER BY referrerId ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, referral.TOUR_GUIDE, domain_status.REFERRAL_REWARD_PENDING, createdBefore,
+		domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+// GetReferralRewardIdsByPayoutItemId implements repo.IReferralRepo.
+func (r *referralRepo) GetReferralRewardIdsByPayoutItemId(itemId int, ctx context.Context) ([]int, error) {
+	var table string = entity.ReferralReward{}.GetReferralRewardTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetReferralRewardIdsByPayoutItemId - "
+	var query string = "SELECT referralRewardId FROM " + table + " WHERE payoutItemId = @p1"
+
+	rows, err := r.db.QueryContext(ctx, query, itemId)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+func scanReferralRewards(rows *sql.Rows) ([]entity.ReferralReward, error) {
+	var res []entity.ReferralReward
+	for rows.Next() {
+		var x entity.ReferralReward
+		if err := rows.Scan(
+			&x.ReferralRewardId, &x.ReferralId, &x.ReferrerType, &x.ReferrerId, &x.PaymentId, &x.InvoiceId, &x.RevenueId,
+			&x.CommissionAmount, &x.RewardRate, &x.Amount, &x.Status, &x.WalletTransactionId, &x.PayoutItemId,
+			&x.CreatedAt, &x.PaidAt); err != nil {
+
+			return nil, err
+		}
+
+		res = append(res, x)
+	}
+
+	return res, nil
+}
+
+// Pending rewards of tour guides created before the cutoff, parameters are the referrer type, the pending reward status,
+// the cutoff and the pending and paid payout item statuses
+func generatePayableReferralRewardCondition(start int) string {
+	return fmt.Sprintf("referrerType = @p%d AND status = @p%d AND createdAt <= @p%d AND ", start, start+1, start+2) +
+		generateReferralRewardNotHeldCondition(start+3)
+}
+
+// A reward is held while its payout item waits for the bank result or has been paid, parameters are the pending and paid
+// payout item statuses
+func generateReferralRewardNotHeldCondition(start int) string {
+	return fmt.Sprintf("(payoutItemId IS NULL OR payoutItemId NOT IN (SELECT payoutItemId FROM "+entity.PayoutItem{}.GetPayoutItemTable()+
+		" WHERE status IN (@p%d, @p%d)))", start, start+1)
+}
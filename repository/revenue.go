@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	filter_property "tourmate/payment-service/constant/filter_property"
+	"tourmate/payment-service/constant/granularity"
 	"tourmate/payment-service/constant/noti"
 	"tourmate/payment-service/interface/repo"
 	"tourmate/payment-service/model/dto/request"
@@ -26,38 +30,20 @@ func InitializeRevenueRepo(db *sql.DB, logger *log.Logger) repo.IRevenueRepo {
 
 // GetRevenues implements repo.IRevenueRepo.
 func (r *revenueRepo) GetRevenues(req request.GetRevenuesRequest, ctx context.Context) (*[]entity.Revenue, error) {
-	var table string = entity.Payment{}.GetPaymentTable()
+	var table string = entity.Revenue{}.GetRevenueTable()
 	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenues - "
 	var limitRecords int = *req.PageSize
 	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
 
-	var queryCondition string = fmt.Sprintf("WHERE tourGuideId = %d", req.TourGuideId)
-
-	if req.Year != nil {
-		queryCondition += fmt.Sprintf(" AND YEAR(createdAt) = %d", *req.Year)
-	}
-
-	if req.Month != nil {
-		queryCondition += fmt.Sprintf(" AND MONTH(createdAt) = %d", *req.Month)
-	}
+	var builder *queryBuilder = generateRevenueQuery(req)
+	builder.sortBy(req.FilterProp, req.Order, filter_property.REVENUE_SORT_COLUMNS, "createdAt")
 
-	if req.PaymentStatus != nil {
-		var intStatus int = 0
-		if *req.PaymentStatus {
-			intStatus = 1
-		}
-
-		queryCondition += fmt.Sprintf(" AND paymentStatus = %d", intStatus)
-	}
-
-	var orderCondition string = generateOrderCondition("createdAt", "DESC")
-	var query string = generateRetrieveQuery(table, queryCondition+orderCondition, limitRecords, *req.PageNumber, false)
-
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, builder.pageQuery(generateEffectiveRevenueSource(), limitRecords, *req.PageNumber), builder.args...)
 	if err != nil {
 		r.logger.Println(errLogMsg + err.Error())
 		return nil, internalErr
 	}
+	defer rows.Close()
 
 	var res []entity.Revenue
 	for rows.Next() {
@@ -80,7 +66,7 @@ func (r *revenueRepo) GetRevenues(req request.GetRevenuesRequest, ctx context.Co
 func (r *revenueRepo) GetRevenuesByMonth(tourGuideId int, year int, month int, ctx context.Context) (*[]entity.Revenue, error) {
 	var table string = entity.Revenue{}.GetRevenueTable()
 	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenuesByMonth - "
-	var query string = "SELECT * FROM " + table + " WHERE tourGuideId = @p1 AND YEAR(createdAt) = @p2 AND MONTH(createdAt) = @p3 ORDER BY createdAt DESC"
+	var query string = "SELECT * FROM " + generateEffectiveRevenueSource() + " WHERE tourGuideId = @p1 AND YEAR(createdAt) = @p2 AND MONTH(createdAt) = @p3 ORDER BY createdAt DESC"
 	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
 
 	rows, err := r.db.Query(query, tourGuideId, year, month)
@@ -106,48 +92,15 @@ func (r *revenueRepo) GetRevenuesByMonth(tourGuideId int, year int, month int, c
 	return &res, nil
 }
 
-// GetRevenueTotalAmountByMonth implements repo.IRevenueRepo.
-func (r *revenueRepo) GetRevenueTotalAmountByMonth(tourGuideId int, year int, month int, ctx context.Context) (float64, error) {
-	var table string = entity.Revenue{}.GetRevenueTable()
-	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenueTotalAmountByMoth - "
-	var query string = "SELECT COALESCE(SUM(totalAmount), 0) FROM " + table + " WHERE tourGuideId = @p1 AND YEAR(createdAt) = @p2 AND MONTH(createdAt) = @p3"
-
-	var totalAmount float64
-	if err := r.db.QueryRow(query, tourGuideId, year, month).Scan(&totalAmount); err != nil {
-		r.logger.Println(errLogMsg + err.Error())
-		return 0, err
-	}
-
-	return totalAmount, nil
-}
-
 // GetCountTotalRevenue implements repo.IRevenueRepo.
 func (r *revenueRepo) GetCountTotalRevenue(req request.GetRevenuesRequest, ctx context.Context) (int, error) {
-	var table string = entity.Payment{}.GetPaymentTable()
-	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenues - "
-	var queryCondition string = fmt.Sprintf("WHERE tourGuideId = %d", req.TourGuideId)
-
-	if req.Year != nil {
-		queryCondition += fmt.Sprintf(" AND YEAR(createdAt) = %d", *req.Year)
-	}
-
-	if req.Month != nil {
-		queryCondition += fmt.Sprintf(" AND MONTH(createdAt) = %d", *req.Month)
-	}
-
-	if req.PaymentStatus != nil {
-		var intStatus int = 0
-		if *req.PaymentStatus {
-			intStatus = 1
-		}
-
-		queryCondition += fmt.Sprintf(" AND paymentStatus = %d", intStatus)
-	}
-
-	var query string = generateRetrieveQuery(table, queryCondition, 0, 0, true)
+	var table string = entity.Revenue{}.GetRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetCountTotalRevenue - "
+	var builder *queryBuilder = generateRevenueQuery(req)
 
+	// Counted over the same source as GetRevenues so that the total matches the listed rows
 	var res int
-	if err := r.db.QueryRow(query).Scan(&res); err != nil {
+	if err := r.db.QueryRowContext(ctx, builder.countQuery(generateEffectiveRevenueSource()), builder.args...).Scan(&res); err != nil {
 		r.logger.Println(errLogMsg + err.Error())
 		return 0, errors.New(noti.INTERNALL_ERR_MSG)
 	}
@@ -178,7 +131,7 @@ func (r *revenueRepo) CreateRevenue(revenue entity.Revenue, ctx context.Context)
 // GetRevenue implements repo.IRevenueRepo.
 func (r *revenueRepo) GetRevenue(id int, ctx context.Context) (*entity.Revenue, error) {
 	var res entity.Revenue
-	var query string = "SELECT * FROM " + res.GetRevenueTable() + " WHERE revenueId = @p1"
+	var query string = "SELECT * FROM " + generateEffectiveRevenueSource() + " WHERE revenueId = @p1"
 	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetRevenueTable()) + "GetRevenue - "
 
 	if err := r.db.QueryRow(query, id).Scan(
@@ -196,33 +149,414 @@ func (r *revenueRepo) GetRevenue(id int, ctx context.Context) (*entity.Revenue,
 	return &res, nil
 }
 
-// RemoveRevenue implements repo.IRevenueRepo.
-func (r *revenueRepo) RemoveRevenue(id int, ctx context.Context) error {
-	var tmp entity.Revenue
-	var query string = "DELETE FROM " + tmp.GetRevenueTable() + " WHERE revenueId = @p1"
-	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, tmp.GetRevenueTable()) + "GetRevenue - "
+// GetRevenueByPaymentId implements repo.IRevenueRepo.
+func (r *revenueRepo) GetRevenueByPaymentId(paymentId int, ctx context.Context) (*entity.Revenue, error) {
+	var res entity.Revenue
+	var query string = "SELECT TOP 1 * FROM " + generateEffectiveRevenueSource() + " WHERE paymentId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetRevenueTable()) + "GetRevenueByPaymentId - "
+
+	if err := r.db.QueryRowContext(ctx, query, paymentId).Scan(
+		&res.RevenueId, &res.PaymentId, &res.TourGuideId, &res.InvoiceId,
+		&res.TotalAmount, &res.ActualReceived, &res.PlatformCommission, &res.PaymentStatus, &res.CreatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetRevenueDetails implements repo.IRevenueRepo.
+func (r *revenueRepo) GetRevenueDetails(tourGuideId int, from time.Time, to time.Time, ctx context.Context) (*[]entity.RevenueDetail, error) {
+	var table string = entity.Revenue{}.GetRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenueDetails - "
+	var query string = "SELECT rm.revenueId, rm.paymentId, rm.tourGuideId, rm.invoiceId, rm.totalAmount, rm.actualReceived, " +
+		"rm.platformCommission, rm.paymentStatus, rm.createdAt, p.serviceId FROM " + generateRevenueMovementSource() + " " +
+		"JOIN " + entity.Payment{}.GetPaymentTable() + " p ON p.paymentId = rm.paymentId " +
+		"WHERE rm.tourGuideId = @p1 AND rm.createdAt >= @p2 AND rm.createdAt < @p3 ORDER BY rm.createdAt ASC, rm.revenueId ASC"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := r.db.QueryContext(ctx, query, tourGuideId, from, to)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.RevenueDetail
+	for rows.Next() {
+		var x entity.RevenueDetail
+		if err := rows.Scan(
+			&x.RevenueId, &x.PaymentId, &x.TourGuideId, &x.InvoiceId, &x.TotalAmount, &x.ActualReceived,
+			&x.PlatformCommission, &x.PaymentStatus, &x.CreatedAt, &x.ServiceId); err != nil {
+
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetRevenueAdjustments implements repo.IRevenueRepo.
+func (r *revenueRepo) GetRevenueAdjustments(revenueId int, ctx context.Context) (*[]entity.RevenueAdjustment, error) {
+	var table string = entity.RevenueAdjustment{}.GetRevenueAdjustmentTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenueAdjustments - "
+	var query string = "SELECT * FROM " + table + " WHERE revenueId = @p1 ORDER BY createdAt ASC"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := r.db.QueryContext(ctx, query, revenueId)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.RevenueAdjustment
+	for rows.Next() {
+		var x entity.RevenueAdjustment
+		if err := rows.Scan(
+			&x.RevenueAdjustmentId, &x.RevenueId, &x.AdjustmentType, &x.TotalAmountDelta, &x.ActualReceivedDelta,
+			&x.PlatformCommissionDelta, &x.Reason, &x.CreatedBy, &x.CreatedAt, &x.PayoutItemId, &x.Settled); err != nil {
+
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// CreateRevenueAdjustment implements repo.IRevenueRepo.
+func (r *revenueRepo) CreateRevenueAdjustment(adjustment entity.RevenueAdjustment, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + adjustment.GetRevenueAdjustmentTable() +
+		" (revenueId, adjustmentType, totalAmountDelta, actualReceivedDelta, " +
+		"platformCommissionDelta, reason, createdBy, createdAt, settled) " +
+		"OUTPUT INSERTED.revenueAdjustmentId " +
+		"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, 0)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, adjustment.GetRevenueAdjustmentTable()) + "CreateRevenueAdjustment - "
+
+	var res int
+	if err := r.db.QueryRowContext(ctx, query, adjustment.RevenueId, adjustment.AdjustmentType, adjustment.TotalAmountDelta,
+		adjustment.ActualReceivedDelta, adjustment.PlatformCommissionDelta, adjustment.Reason, adjustment.CreatedBy, adjustment.CreatedAt).Scan(&res); err != nil {
+
+		r.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// IsRevenueHeldByPayout implements repo.IRevenueRepo.
+func (r *revenueRepo) IsRevenueHeldByPayout(id int, ctx context.Context) (bool, error) {
+	var table string = entity.PayoutItemRevenue{}.GetPayoutItemRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "IsRevenueHeldByPayout - "
+	var query string = "SELECT COUNT(*) FROM " + table + " pir " +
+		"JOIN " + entity.PayoutItem{}.GetPayoutItemTable() + " pi ON pi.payoutItemId = pir.payoutItemId " +
+		"WHERE pir.revenueId = @p1 AND pi.status = @p2"
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id, domain_status.PAYOUT_ITEM_PENDING).Scan(&count); err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return false, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return count > 0, nil
+}
+
+// GetPayableRevenues implements repo.IRevenueRepo.
+func (r *revenueRepo) GetPayableRevenues(tourGuideId int, createdBefore time.Time, ctx context.Context) (*[]entity.Revenue, error) {
+	var table string = entity.Revenue{}.GetRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayableRevenues - "
+	var query string = "SELECT * FROM " + table + " WHERE tourGuideId = @p1 AND " + generatePayableRevenueCondition(2) + " ORDER BY createdAt ASC"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := r.db.QueryContext(ctx, query, tourGuideId, createdBefore, domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.Revenue
+	for rows.Next() {
+		var x entity.Revenue
+		if err := rows.Scan(
+			&x.RevenueId, &x.PaymentId, &x.TourGuideId, &x.InvoiceId,
+			&x.TotalAmount, &x.ActualReceived, &x.PlatformCommission, &x.PaymentStatus, &x.CreatedAt); err != nil {
+
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPayableRevenueAdjustments implements repo.IRevenueRepo.
+func (r *revenueRepo) GetPayableRevenueAdjustments(tourGuideId int, createdBefore time.Time, ctx context.Context) (*[]entity.RevenueAdjustment, error) {
+	var table string = entity.RevenueAdjustment{}.GetRevenueAdjustmentTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayableRevenueAdjustments - "
+	var query string = "SELECT ra.* FROM " + table + " ra JOIN " + entity.Revenue{}.GetRevenueTable() + " rv ON rv.revenueId = ra.revenueId " +
+		"WHERE rv.tourGuideId = @p4 AND " + generatePayableRevenueAdjustmentCondition(1) + " ORDER BY ra.createdAt ASC"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := r.db.QueryContext(ctx, query, createdBefore, domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID, tourGuideId)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.RevenueAdjustment
+	for rows.Next() {
+		var x entity.RevenueAdjustment
+		if err := rows.Scan(
+			&x.RevenueAdjustmentId, &x.RevenueId, &x.AdjustmentType, &x.TotalAmountDelta, &x.ActualReceivedDelta,
+			&x.PlatformCommissionDelta, &x.Reason, &x.CreatedBy, &x.CreatedAt, &x.PayoutItemId, &x.Settled); err != nil {
+
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPayableTourGuideIds implements repo.IRevenueRepo.
+func (r *revenueRepo) GetPayableTourGuideIds(createdBefore time.Time, ctx context.Context) ([]int, error) {
+	var table string = entity.Revenue{}.GetRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPayableTourGuideIds - "
+	var query string = "SELECT tourGuideId FROM " + table + " WHERE " + generatePayableRevenueCondition(1) +
+		" UNION SELECT rv.tourGuideId FROM " + entity.RevenueAdjustment{}.GetRevenueAdjustmentTable() + " ra " +
+		"JOIN " + table + " rv ON rv.revenueId = ra.revenueId WHERE " + generatePayableRevenueAdjustmentCondition(1) +
+		" ORDER BY tourGuideId ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, createdBefore, domain_status.PAYOUT_ITEM_PENDING, domain_status.PAYOUT_ITEM_PAID)
+	if err != nil {
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+// GetRevenueSummary implements repo.IRevenueRepo.
+func (r *revenueRepo) GetRevenueSummary(from time.Time, to time.Time, ctx context.Context) (*entity.RevenueSummary, error) {
+	var table string = entity.Revenue{}.GetRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenueSummary - "
+	var query string = "SELECT COALESCE(SUM(totalAmount), 0), COALESCE(SUM(actualReceived), 0), COALESCE(SUM(platformCommission), 0), " +
+		"COALESCE(SUM(revenueCount), 0), " +
+		"COALESCE(SUM(CASE WHEN paymentStatus = 1 THEN actualReceived ELSE 0 END), 0), " +
+		"COALESCE(SUM(CASE WHEN paymentStatus = 0 THEN actualReceived ELSE 0 END), 0) " +
+		"FROM " + generateRevenueMovementSource() + " WHERE createdAt >= @p1 AND createdAt < @p2"
+
+	var res entity.RevenueSummary
+	if err := r.db.QueryRowContext(ctx, query, from, to).Scan(
+		&res.TotalAmount, &res.ActualReceived, &res.PlatformCommission, &res.RevenueCount,
+		&res.SettledAmount, &res.PendingAmount); err != nil {
+
+		r.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetTopTourGuideRevenues implements repo.IRevenueRepo.
+func (r *revenueRepo) GetTopTourGuideRevenues(from time.Time, to time.Time, limit int, ctx context.Context) (*[]entity.RevenueGroup, error) {
+	var query string = "SELECT TOP (@p3) tourGuideId, SUM(totalAmount), SUM(actualReceived), SUM(platformCommission), SUM(revenueCount) " +
+		"FROM " + generateRevenueMovementSource() + " WHERE createdAt >= @p1 AND createdAt < @p2 " +
+		"GROUP BY tourGuideId ORDER BY SUM(totalAmount) DESC, tourGuideId ASC"
+
+	return r.getRevenueGroups(query, "GetTopTourGuideRevenues - ", ctx, from, to, limit)
+}
+
+// GetServiceRevenues implements repo.IRevenueRepo.
+func (r *revenueRepo) GetServiceRevenues(from time.Time, to time.Time, ctx context.Context) (*[]entity.RevenueGroup, error) {
+	var query string = "SELECT p.serviceId, SUM(rm.totalAmount), SUM(rm.actualReceived), SUM(rm.platformCommission), SUM(rm.revenueCount) " +
+		"FROM " + generateRevenueMovementSource() + " JOIN " + entity.Payment{}.GetPaymentTable() + " p ON p.paymentId = rm.paymentId " +
+		"WHERE rm.createdAt >= @p1 AND rm.createdAt < @p2 " +
+		"GROUP BY p.serviceId ORDER BY SUM(rm.totalAmount) DESC, p.serviceId ASC"
+
+	return r.getRevenueGroups(query, "GetServiceRevenues - ", ctx, from, to)
+}
+
+func (r *revenueRepo) getRevenueGroups(query, method string, ctx context.Context, args ...interface{}) (*[]entity.RevenueGroup, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, entity.Revenue{}.GetRevenueTable()) + method
 	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
 
-	res, err := r.db.Exec(query, id)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Println(errLogMsg + err.Error())
-		return internalErr
+		return nil, internalErr
 	}
+	defer rows.Close()
 
-	rowsAffected, err := res.RowsAffected()
+	var res []entity.RevenueGroup
+	for rows.Next() {
+		var x entity.RevenueGroup
+		if err := rows.Scan(&x.GroupId, &x.TotalAmount, &x.ActualReceived, &x.PlatformCommission, &x.RevenueCount); err != nil {
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetRevenueSeries implements repo.IRevenueRepo.
+func (r *revenueRepo) GetRevenueSeries(tourGuideId *int, bucketSize string, from time.Time, to time.Time, ctx context.Context) (*[]entity.RevenueBucket, error) {
+	var table string = entity.Revenue{}.GetRevenueTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRevenueSeries - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	bucketExpression, err := generateBucketExpression(bucketSize, "rm.createdAt")
+	if err != nil {
+		return nil, err
+	}
+
+	var args []interface{} = []interface{}{from, to}
+	var guideCondition string
+	if tourGuideId != nil {
+		guideCondition = " AND rm.tourGuideId = @p3"
+		args = append(args, *tourGuideId)
+	}
+
+	var query string = "SELECT bucketStart, SUM(totalAmount), SUM(actualReceived), SUM(platformCommission), SUM(revenueCount), " +
+		"SUM(CASE WHEN paymentStatus = 1 THEN revenueCount ELSE 0 END) FROM (" +
+		"SELECT " + bucketExpression + " AS bucketStart, rm.totalAmount, rm.actualReceived, rm.platformCommission, rm.revenueCount, rm.paymentStatus " +
+		"FROM " + generateRevenueMovementSource() + " WHERE rm.createdAt >= @p1 AND rm.createdAt < @p2" + guideCondition +
+		") b GROUP BY bucketStart ORDER BY bucketStart ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Println(errLogMsg + err.Error())
-		return internalErr
+		return nil, internalErr
 	}
+	defer rows.Close()
 
-	if rowsAffected == 0 {
-		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, tmp.GetRevenueTable()))
+	var res []entity.RevenueBucket
+	for rows.Next() {
+		var x entity.RevenueBucket
+		if err := rows.Scan(&x.BucketStart, &x.TotalAmount, &x.ActualReceived, &x.PlatformCommission, &x.RevenueCount, &x.SettledCount); err != nil {
+			r.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
 	}
 
-	return nil
+	return &res, nil
 }
 
-// UpdateRevenue implements repo.IRevenueRepo.
-func (r *revenueRepo) UpdateRevenue(revenue entity.Revenue, ctx context.Context) error {
-	panic("unimplemented")
+// Condition of unsettled revenues with a positive original amount which are not held by a pending or paid payout item,
+// the parameters are createdBefore, pending item status and paid item status starting from @p{start}
+func generatePayableRevenueCondition(start int) string {
+	return fmt.Sprintf("paymentStatus = 0 AND actualReceived > 0 AND createdAt <= @p%d AND revenueId NOT IN ("+
+		"SELECT pir.revenueId FROM "+entity.PayoutItemRevenue{}.GetPayoutItemRevenueTable()+" pir "+
+		"JOIN "+entity.PayoutItem{}.GetPayoutItemTable()+" pi ON pi.payoutItemId = pir.payoutItemId "+
+		"WHERE pi.status IN (@p%d, @p%d))", start, start+1, start+2)
+}
+
+// Condition of unsettled adjustments (alias ra) of revenues (alias rv) created before the cutoff which are not held by a
+// pending or paid payout item, the parameters are createdBefore, pending item status and paid item status starting from @p{start}.
+// Adjustments made after the revenue was paid out are netted into the next payout of the tour guide
+func generatePayableRevenueAdjustmentCondition(start int) string {
+	return fmt.Sprintf("ra.settled = 0 AND ra.actualReceivedDelta <> 0 AND rv.createdAt <= @p%d AND (ra.payoutItemId IS NULL OR "+
+		"ra.payoutItemId NOT IN (SELECT payoutItemId FROM "+entity.PayoutItem{}.GetPayoutItemTable()+" WHERE status IN (@p%d, @p%d)))",
+		start, start+1, start+2)
+}
+
+// Revenues of a tour guide filtered by the period and the payment status
+func generateRevenueQuery(req request.GetRevenuesRequest) *queryBuilder {
+	var res *queryBuilder = newQueryBuilder().where("tourGuideId = ?", req.TourGuideId)
+	if req.Year != nil {
+		res.where("YEAR(createdAt) = ?", *req.Year)
+	}
+
+	if req.Month != nil {
+		res.where("MONTH(createdAt) = ?", *req.Month)
+	}
+
+	if req.PaymentStatus != nil {
+		res.where("paymentStatus = ?", *req.PaymentStatus)
+	}
+
+	return res
+}
+
+// Revenue rows and their adjustments as separate movements, each dated by its own createdAt so that an adjustment is
+// reported in the period it was made and a closed period never changes. Aggregates count revenues with revenueCount
+func generateRevenueMovementSource() string {
+	var table string = entity.Revenue{}.GetRevenueTable()
+
+	return "(SELECT revenueId, paymentId, tourGuideId, invoiceId, totalAmount, actualReceived, platformCommission, " +
+		"paymentStatus, createdAt, 1 AS revenueCount FROM " + table +
+		" UNION ALL " +
+		"SELECT rv.revenueId, rv.paymentId, rv.tourGuideId, rv.invoiceId, ra.totalAmountDelta, ra.actualReceivedDelta, " +
+		"ra.platformCommissionDelta, ra.settled, ra.createdAt, 0 AS revenueCount " +
+		"FROM " + entity.RevenueAdjustment{}.GetRevenueAdjustmentTable() + " ra " +
+		"JOIN " + table + " rv ON rv.revenueId = ra.revenueId) rm"
+}
+
+// Revenue rows with their adjustments applied, the columns keep the order of the Revenue table
+func generateEffectiveRevenueSource() string {
+	var adjustmentTable string = entity.RevenueAdjustment{}.GetRevenueAdjustmentTable()
+
+	return "(SELECT rv.revenueId, rv.paymentId, rv.tourGuideId, rv.invoiceId, " +
+		"rv.totalAmount + COALESCE(ra.totalAmountDelta, 0) AS totalAmount, " +
+		"rv.actualReceived + COALESCE(ra.actualReceivedDelta, 0) AS actualReceived, " +
+		"rv.platformCommission + COALESCE(ra.platformCommissionDelta, 0) AS platformCommission, " +
+		"rv.paymentStatus, rv.createdAt " +
+		"FROM " + entity.Revenue{}.GetRevenueTable() + " rv LEFT JOIN (" +
+		"SELECT revenueId, SUM(totalAmountDelta) AS totalAmountDelta, SUM(actualReceivedDelta) AS actualReceivedDelta, " +
+		"SUM(platformCommissionDelta) AS platformCommissionDelta FROM " + adjustmentTable + " GROUP BY revenueId" +
+		") ra ON ra.revenueId = rv.revenueId) er"
+}
+
+// Truncate the date column to the start of its bucket, weeks start on Monday (1900-01-01 is a Monday)
+func generateBucketExpression(value, column string) (string, error) {
+	var day string = "CAST(" + column + " AS DATE)"
+
+	switch value {
+	case granularity.DAY:
+		return day, nil
+	case granularity.WEEK:
+		return "DATEADD(DAY, -(DATEDIFF(DAY, '19000101', " + column + ") % 7), " + day + ")", nil
+	case granularity.MONTH:
+		return "DATEFROMPARTS(YEAR(" + column + "), MONTH(" + column + "), 1)", nil
+	case granularity.QUARTER:
+		return "DATEFROMPARTS(YEAR(" + column + "), (DATEPART(QUARTER, " + column + ") - 1) * 3 + 1, 1)", nil
+	case granularity.YEAR:
+		return "DATEFROMPARTS(YEAR(" + column + "), 1, 1)", nil
+	default:
+		return "", errors.New(noti.UNSUPPORTED_GRANULARITY_WARN_MSG)
+	}
 }
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	filter_property "tourmate/payment-service/constant/filter_property"
+	"tourmate/payment-service/model/dto/request"
+)
+
+func TestGenerateRevenueQuery(t *testing.T) {
+	var year, month int = 2026, 4
+	var settled bool = true
+
+	tests := []struct {
+		name      string
+		req       request.GetRevenuesRequest
+		page      int
+		wantPage  string
+		wantCount string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "tour guide only",
+			req:       request.GetRevenuesRequest{TourGuideId: 11},
+			page:      1,
+			wantPage:  "SELECT * FROM Revenue WHERE tourGuideId = @p1 ORDER BY createdAt ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Revenue WHERE tourGuideId = @p1",
+			wantArgs:  []interface{}{11},
+		},
+		{
+			name: "period and payment status",
+			req:  request.GetRevenuesRequest{TourGuideId: 11, Year: &year, Month: &month, PaymentStatus: &settled, FilterProp: "ACTUAL_RECEIVED", Order: "DESC"},
+			page: 2,
+			wantPage: "SELECT * FROM Revenue WHERE tourGuideId = @p1 AND YEAR(createdAt) = @p2 AND MONTH(createdAt) = @p3 AND paymentStatus = @p4 " +
+				"ORDER BY actualReceived DESC OFFSET 10 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Revenue WHERE tourGuideId = @p1 AND YEAR(createdAt) = @p2 AND MONTH(createdAt) = @p3 AND paymentStatus = @p4",
+			wantArgs:  []interface{}{11, 2026, 4, true},
+		},
+		{
+			name:      "unknown sort column falls back to the date",
+			req:       request.GetRevenuesRequest{TourGuideId: 11, FilterProp: "tourGuideId", Order: "DESC"},
+			page:      1,
+			wantPage:  "SELECT * FROM Revenue WHERE tourGuideId = @p1 ORDER BY createdAt DESC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantCount: "SELECT COUNT(*) FROM Revenue WHERE tourGuideId = @p1",
+			wantArgs:  []interface{}{11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder *queryBuilder = generateRevenueQuery(tt.req)
+			builder.sortBy(tt.req.FilterProp, tt.req.Order, filter_property.REVENUE_SORT_COLUMNS, "createdAt")
+
+			if got := builder.pageQuery("Revenue", 10, tt.page); got != tt.wantPage {
+				t.Errorf("pageQuery() = %q, want %q", got, tt.wantPage)
+			}
+
+			if got := builder.countQuery("Revenue"); got != tt.wantCount {
+				t.Errorf("countQuery() = %q, want %q", got, tt.wantCount)
+			}
+
+			if !reflect.DeepEqual(builder.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", builder.args, tt.wantArgs)
+			}
+		})
+	}
+}
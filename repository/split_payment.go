@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type splitPaymentRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeSplitPaymentRepo(db *sql.DB, logger *log.Logger) repo.ISplitPaymentRepo {
+	return &splitPaymentRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetSplitPaymentById implements repo.ISplitPaymentRepo.
+func (s *splitPaymentRepo) GetSplitPaymentById(id int, ctx context.Context) (*entity.SplitPayment, error) {
+	var table string = entity.SplitPayment{}.GetSplitPaymentTable()
+	var query string = "SELECT * FROM " + table + " WHERE splitPaymentId = @p1"
+
+	return s.getSplitPayment(query, "GetSplitPaymentById - ", ctx, id)
+}
+
+// GetPendingSplitPaymentByInvoiceId implements repo.ISplitPaymentRepo.
+func (s *splitPaymentRepo) GetPendingSplitPaymentByInvoiceId(invoiceId int, ctx context.Context) (*entity.SplitPayment, error) {
+	var table string = entity.SplitPayment{}.GetSplitPaymentTable()
+	var query string = "SELECT TOP 1 sp.* FROM " + table + " sp " +
+		"JOIN " + entity.Payment{}.GetPaymentTable() + " p ON p.paymentId = sp.paymentId " +
+		"WHERE p.invoiceId = @p1 AND sp.status = @p2 ORDER BY sp.splitPaymentId DESC"
+
+	return s.getSplitPayment(query, "GetPendingSplitPaymentByInvoiceId - ", ctx, invoiceId, domain_status.SPLIT_PAYMENT_PENDING)
+}
+
+// GetPendingSplitPayments implements repo.ISplitPaymentRepo.
+func (s *splitPaymentRepo) GetPendingSplitPayments(ctx context.Context) (*[]entity.SplitPayment, error) {
+	var table string = entity.SplitPayment{}.GetSplitPaymentTable()
+	var query string = "SELECT * FROM " + table + " WHERE status = @p1 ORDER BY createdAt ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPendingSplitPayments - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := s.db.QueryContext(ctx, query, domain_status.SPLIT_PAYMENT_PENDING)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.SplitPayment
+	for rows.Next() {
+		var x entity.SplitPayment
+		if err := rows.Scan(
+			&x.SplitPaymentId, &x.PaymentId, &x.TourGuideId, &x.Points, &x.GatewayAmount,
+			&x.OrderCode, &x.CheckoutUrl, &x.Status, &x.CreatedAt, &x.CompletedAt); err != nil {
+
+			s.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// CreateSplitPayment implements repo.ISplitPaymentRepo.
+func (s *splitPaymentRepo) CreateSplitPayment(payment entity.SplitPayment, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + payment.GetSplitPaymentTable() +
+		" (paymentId, tourGuideId, points, gatewayAmount, orderCode, checkoutUrl, status, createdAt, completedAt) " +
+		"OUTPUT INSERTED.splitPaymentId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, payment.GetSplitPaymentTable()) + "CreateSplitPayment - "
+
+	var res int
+	if err := s.db.QueryRowContext(ctx, query, payment.PaymentId, payment.TourGuideId, payment.Points, payment.GatewayAmount,
+		payment.OrderCode, payment.CheckoutUrl, payment.Status, payment.CreatedAt, payment.CompletedAt).Scan(&res); err != nil {
+
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateSplitPayment implements repo.ISplitPaymentRepo.
+func (s *splitPaymentRepo) UpdateSplitPayment(payment entity.SplitPayment, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, payment.GetSplitPaymentTable()) + "UpdateSplitPayment - "
+	var query string = "UPDATE " + payment.GetSplitPaymentTable() + " SET status = @p1, completedAt = @p2 WHERE splitPaymentId = @p3 AND status = @p4"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := s.db.ExecContext(ctx, query, payment.Status, payment.CompletedAt, payment.SplitPaymentId, currentStatus)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+func (s *splitPaymentRepo) getSplitPayment(query, method string, ctx context.Context, args ...interface{}) (*entity.SplitPayment, error) {
+	var res entity.SplitPayment
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetSplitPaymentTable()) + method
+
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.SplitPaymentId, &res.PaymentId, &res.TourGuideId, &res.Points, &res.GatewayAmount,
+		&res.OrderCode, &res.CheckoutUrl, &res.Status, &res.CreatedAt, &res.CompletedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
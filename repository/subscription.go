@@ -0,0 +1,546 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type subscriptionRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeSubscriptionRepo(db *sql.DB, logger *log.Logger) repo.ISubscriptionRepo {
+	return &subscriptionRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetSubscriptionPlans implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetSubscriptionPlans(ctx context.Context) (*[]entity.SubscriptionPlan, error) {
+	var table string = entity.SubscriptionPlan{}.GetSubscriptionPlanTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetSubscriptionPlans - "
+	var query string = "SELECT * FROM " + table + " ORDER BY price ASC, subscriptionPlanId ASC"
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.SubscriptionPlan
+	for rows.Next() {
+		var x entity.SubscriptionPlan
+		if err := rows.Scan(
+			&x.SubscriptionPlanId, &x.Name, &x.Description, &x.Price, &x.CycleMonths, &x.CommissionRate, &x.IsFeatured,
+			&x.IsActive, &x.CreatedAt, &x.UpdatedAt); err != nil {
+
+			s.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetSubscriptionPlanById implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetSubscriptionPlanById(id int, ctx context.Context) (*entity.SubscriptionPlan, error) {
+	var res entity.SubscriptionPlan
+	var query string = "SELECT * FROM " + res.GetSubscriptionPlanTable() + " WHERE subscriptionPlanId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetSubscriptionPlanTable()) + "GetSubscriptionPlanById - "
+
+	if err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&res.SubscriptionPlanId, &res.Name, &res.Description, &res.Price, &res.CycleMonths, &res.CommissionRate, &res.IsFeatured,
+		&res.IsActive, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateSubscriptionPlan implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) CreateSubscriptionPlan(plan entity.SubscriptionPlan, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + plan.GetSubscriptionPlanTable() +
+		" (name, description, price, cycleMonths, commissionRate, isFeatured, isActive, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.subscriptionPlanId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, plan.GetSubscriptionPlanTable()) + "CreateSubscriptionPlan - "
+
+	var res int
+	if err := s.db.QueryRowContext(ctx, query, plan.Name, plan.Description, plan.Price, plan.CycleMonths, plan.CommissionRate,
+		plan.IsFeatured, plan.IsActive, plan.CreatedAt, plan.UpdatedAt).Scan(&res); err != nil {
+
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateSubscriptionPlan implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) UpdateSubscriptionPlan(plan entity.SubscriptionPlan, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, plan.GetSubscriptionPlanTable()) + "UpdateSubscriptionPlan - "
+	var query string = "UPDATE " + plan.GetSubscriptionPlanTable() + " SET name = @p1, description = @p2, price = @p3, " +
+		"cycleMonths = @p4, commissionRate = @p5, isFeatured = @p6, isActive = @p7, updatedAt = @p8 WHERE subscriptionPlanId = @p9"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := s.db.ExecContext(ctx, query, plan.Name, plan.Description, plan.Price, plan.CycleMonths, plan.CommissionRate,
+		plan.IsFeatured, plan.IsActive, plan.UpdatedAt, plan.SubscriptionPlanId)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, plan.GetSubscriptionPlanTable()))
+	}
+
+	return nil
+}
+
+// GetSubscriptionById implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetSubscriptionById(id int, ctx context.Context) (*entity.Subscription, error) {
+	return s.getSubscription("subscriptionId = @p1", "GetSubscriptionById - ", ctx, id)
+}
+
+// GetOpenSubscription implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetOpenSubscription(tourGuideId int, ctx context.Context) (*entity.Subscription, error) {
+	return s.getSubscription("tourGuideId = @p1 AND status IN (@p2, @p3, @p4)", "GetOpenSubscription - ", ctx,
+		tourGuideId, domain_status.SUBSCRIPTION_PENDING, domain_status.SUBSCRIPTION_ACTIVE, domain_status.SUBSCRIPTION_PAST_DUE)
+}
+
+func (s *subscriptionRepo) getSubscription(condition string, method string, ctx context.Context, args ...interface{}) (*entity.Subscription, error) {
+	var res entity.Subscription
+	var query string = "SELECT TOP 1 * FROM " + res.GetSubscriptionTable() + " WHERE " + condition + " ORDER BY subscriptionId DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetSubscriptionTable()) + method
+
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.SubscriptionId, &res.TourGuideId, &res.SubscriptionPlanId, &res.BillingEmail, &res.Status, &res.CurrentPeriodStart,
+		&res.CurrentPeriodEnd, &res.CancelAtPeriodEnd, &res.CreatedAt, &res.UpdatedAt, &res.EndedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateSubscription implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) CreateSubscription(subscription entity.Subscription, invoice entity.SubscriptionInvoice, ctx context.Context) (int, int, error) {
+	var table string = subscription.GetSubscriptionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "CreateSubscription - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	// The range lock keeps a concurrent subscription of the same tour guide waiting
+	var openQuery string = "SELECT COUNT(*) FROM " + table + " WITH (UPDLOCK, HOLDLOCK) WHERE tourGuideId = @p1 AND status IN (@p2, @p3, @p4)"
+	var createQuery string = "INSERT INTO " + table +
+		" (tourGuideId, subscriptionPlanId, billingEmail, status, currentPeriodStart, currentPeriodEnd, cancelAtPeriodEnd, createdAt, updatedAt, endedAt) " +
+		"OUTPUT INSERTED.subscriptionId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10)"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, 0, internalErr
+	}
+	defer tx.Rollback()
+
+	var openCount int
+	if err := tx.QueryRowContext(ctx, openQuery, subscription.TourGuideId, domain_status.SUBSCRIPTION_PENDING,
+		domain_status.SUBSCRIPTION_ACTIVE, domain_status.SUBSCRIPTION_PAST_DUE).Scan(&openCount); err != nil {
+
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, 0, internalErr
+	}
+
+	if openCount > 0 {
+		return 0, 0, errors.New(noti.SUBSCRIPTION_EXISTED_WARN_MSG)
+	}
+
+	var subscriptionId int
+	if err := tx.QueryRowContext(ctx, createQuery, subscription.TourGuideId, subscription.SubscriptionPlanId, subscription.BillingEmail,
+		subscription.Status, subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd, subscription.CancelAtPeriodEnd,
+		subscription.CreatedAt, subscription.UpdatedAt, subscription.EndedAt).Scan(&subscriptionId); err != nil {
+
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, 0, internalErr
+	}
+
+	invoice.SubscriptionId = subscriptionId
+	invoiceId, err := createSubscriptionInvoice(tx, invoice, ctx)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, 0, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, 0, internalErr
+	}
+
+	return subscriptionId, invoiceId, nil
+}
+
+// UpdateSubscription implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) UpdateSubscription(subscription entity.Subscription, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, subscription.GetSubscriptionTable()) + "UpdateSubscription - "
+	var query string = "UPDATE " + subscription.GetSubscriptionTable() + " SET status = @p1, currentPeriodStart = @p2, " +
+		"currentPeriodEnd = @p3, cancelAtPeriodEnd = @p4, updatedAt = @p5, endedAt = @p6 WHERE subscriptionId = @p7 AND status = @p8"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := s.db.ExecContext(ctx, query, subscription.Status, subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd,
+		subscription.CancelAtPeriodEnd, subscription.UpdatedAt, subscription.EndedAt, subscription.SubscriptionId, currentStatus)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// GetSubscriptions implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetSubscriptions(req request.GetSubscriptionsRequest, ctx context.Context) (*[]entity.Subscription, int, int, error) {
+	var table string = entity.Subscription{}.GetSubscriptionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetSubscriptions - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	if req.TourGuideId > 0 {
+		builder.where("tourGuideId = ?", req.TourGuideId)
+	}
+
+	if req.SubscriptionPlanId > 0 {
+		builder.where("subscriptionPlanId = ?", req.SubscriptionPlanId)
+	}
+
+	builder.orderBy("subscriptionId", order.DESCENDING_ORDER)
+
+	rows, err := s.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanSubscriptions(rows)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	s.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetRenewableSubscriptions implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetRenewableSubscriptions(before time.Time, ctx context.Context) (*[]entity.Subscription, error) {
+	var table string = entity.Subscription{}.GetSubscriptionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetRenewableSubscriptions - "
+	var query string = "SELECT * FROM " + table + " WHERE status = @p1 AND currentPeriodEnd <= @p2 ORDER BY currentPeriodEnd ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, domain_status.SUBSCRIPTION_ACTIVE, before)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanSubscriptions(rows)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateSubscriptionInvoice implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) CreateSubscriptionInvoice(invoice entity.SubscriptionInvoice, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, invoice.GetSubscriptionInvoiceTable()) + "CreateSubscriptionInvoice - "
+
+	res, err := createSubscriptionInvoice(s.db, invoice, ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New(noti.INVALID_STATUS_WARN_MSG)
+		}
+
+		s.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// Nothing is inserted when the cycle is already invoiced, sql.ErrNoRows is returned in that case
+func createSubscriptionInvoice(db interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}, invoice entity.SubscriptionInvoice, ctx context.Context) (int, error) {
+	var table string = invoice.GetSubscriptionInvoiceTable()
+	var query string = "INSERT INTO " + table +
+		" (subscriptionId, tourGuideId, amount, periodStart, periodEnd, orderCode, checkoutUrl, status, dueAt, " +
+		"reminderCount, lastRemindedAt, paidAt, createdAt) " +
+		"OUTPUT INSERTED.subscriptionInvoiceId " +
+		"SELECT @p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13 " +
+		"WHERE NOT EXISTS (SELECT 1 FROM " + table + " WITH (UPDLOCK, HOLDLOCK) WHERE subscriptionId = @p1 AND periodStart = @p4 AND status <> @p14)"
+
+	var res int
+	err := db.QueryRowContext(ctx, query, invoice.SubscriptionId, invoice.TourGuideId, invoice.Amount, invoice.PeriodStart,
+		invoice.PeriodEnd, invoice.OrderCode, invoice.CheckoutUrl, invoice.Status, invoice.DueAt, invoice.ReminderCount,
+		invoice.LastRemindedAt, invoice.PaidAt, invoice.CreatedAt, domain_status.SUBSCRIPTION_INVOICE_VOID).Scan(&res)
+
+	return res, err
+}
+
+// GetSubscriptionInvoiceById implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetSubscriptionInvoiceById(id int, ctx context.Context) (*entity.SubscriptionInvoice, error) {
+	return s.getSubscriptionInvoice("subscriptionInvoiceId = @p1", "GetSubscriptionInvoiceById - ", ctx, id)
+}
+
+// GetPendingSubscriptionInvoice implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetPendingSubscriptionInvoice(subscriptionId int, ctx context.Context) (*entity.SubscriptionInvoice, error) {
+	return s.getSubscriptionInvoice("subscriptionId = @p1 AND status = @p2", "GetPendingSubscriptionInvoice - ", ctx,
+		subscriptionId, domain_status.SUBSCRIPTION_INVOICE_PENDING)
+}
+
+func (s *subscriptionRepo) getSubscriptionInvoice(condition string, method string, ctx context.Context, args ...interface{}) (*entity.SubscriptionInvoice, error) {
+	var res entity.SubscriptionInvoice
+	var query string = "SELECT TOP 1 * FROM " + res.GetSubscriptionInvoiceTable() + " WHERE " + condition + " ORDER BY subscriptionInvoiceId DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetSubscriptionInvoiceTable()) + method
+
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.SubscriptionInvoiceId, &res.SubscriptionId, &res.TourGuideId, &res.Amount, &res.PeriodStart, &res.PeriodEnd,
+		&res.OrderCode, &res.CheckoutUrl, &res.Status, &res.DueAt, &res.ReminderCount, &res.LastRemindedAt, &res.PaidAt,
+		&res.CreatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetPendingSubscriptionInvoices implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetPendingSubscriptionInvoices(ctx context.Context) (*[]entity.SubscriptionInvoice, error) {
+	var table string = entity.SubscriptionInvoice{}.GetSubscriptionInvoiceTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPendingSubscriptionInvoices - "
+	var query string = "SELECT * FROM " + table + " WHERE status = @p1 ORDER BY dueAt ASC, subscriptionInvoiceId ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, domain_status.SUBSCRIPTION_INVOICE_PENDING)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanSubscriptionInvoices(rows)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetSubscriptionInvoices implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetSubscriptionInvoices(req request.GetSubscriptionInvoicesRequest, ctx context.Context) (*[]entity.SubscriptionInvoice, int, int, error) {
+	var table string = entity.SubscriptionInvoice{}.GetSubscriptionInvoiceTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetSubscriptionInvoices - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder()
+	if req.Status != "" {
+		builder.where("status = ?", req.Status)
+	}
+
+	if req.TourGuideId > 0 {
+		builder.where("tourGuideId = ?", req.TourGuideId)
+	}
+
+	if req.SubscriptionId > 0 {
+		builder.where("subscriptionId = ?", req.SubscriptionId)
+	}
+
+	builder.orderBy("subscriptionInvoiceId", order.DESCENDING_ORDER)
+
+	rows, err := s.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	res, err := scanSubscriptionInvoices(rows)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	s.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// UpdateSubscriptionInvoice implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) UpdateSubscriptionInvoice(invoice entity.SubscriptionInvoice, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, invoice.GetSubscriptionInvoiceTable()) + "UpdateSubscriptionInvoice - "
+	var query string = "UPDATE " + invoice.GetSubscriptionInvoiceTable() + " SET orderCode = @p1, checkoutUrl = @p2, status = @p3, " +
+		"reminderCount = @p4, lastRemindedAt = @p5, paidAt = @p6 WHERE subscriptionInvoiceId = @p7 AND status = @p8"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := s.db.ExecContext(ctx, query, invoice.OrderCode, invoice.CheckoutUrl, invoice.Status, invoice.ReminderCount,
+		invoice.LastRemindedAt, invoice.PaidAt, invoice.SubscriptionInvoiceId, currentStatus)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
+
+// GetActiveSubscriptionPlan implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetActiveSubscriptionPlan(tourGuideId int, ctx context.Context) (*entity.SubscriptionPlan, error) {
+	var res entity.SubscriptionPlan
+	var query string = "SELECT TOP 1 sp.* FROM " + res.GetSubscriptionPlanTable() + " sp " +
+		"JOIN " + entity.Subscription{}.GetSubscriptionTable() + " s ON s.subscriptionPlanId = sp.subscriptionPlanId " +
+		"WHERE s.tourGuideId = @p1 AND s.status IN (@p2, @p3) ORDER BY s.subscriptionId DESC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetSubscriptionPlanTable()) + "GetActiveSubscriptionPlan - "
+
+	if err := s.db.QueryRowContext(ctx, query, tourGuideId, domain_status.SUBSCRIPTION_ACTIVE, domain_status.SUBSCRIPTION_PAST_DUE).Scan(
+		&res.SubscriptionPlanId, &res.Name, &res.Description, &res.Price, &res.CycleMonths, &res.CommissionRate, &res.IsFeatured,
+		&res.IsActive, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetFeaturedTourGuideIds implements repo.ISubscriptionRepo.
+func (s *subscriptionRepo) GetFeaturedTourGuideIds(ctx context.Context) ([]int, error) {
+	var table string = entity.Subscription{}.GetSubscriptionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetFeaturedTourGuideIds - "
+	var query string = "SELECT DISTINCT s.tourGuideId FROM " + table + " s " +
+		"JOIN " + entity.SubscriptionPlan{}.GetSubscriptionPlanTable() + " sp ON sp.subscriptionPlanId = s.subscriptionPlanId " +
+		"WHERE sp.isFeatured = 1 AND s.status IN (@p1, @p2) ORDER BY s.tourGuideId ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, domain_status.SUBSCRIPTION_ACTIVE, domain_status.SUBSCRIPTION_PAST_DUE)
+	if err != nil {
+		s.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			s.logger.Println(errLogMsg + err.Error())
+			return nil, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+func scanSubscriptions(rows *sql.Rows) ([]entity.Subscription, error) {
+	var res []entity.Subscription
+	for rows.Next() {
+		var x entity.Subscription
+		if err := rows.Scan(
+			&x.SubscriptionId, &x.TourGuideId, &x.SubscriptionPlanId, &x.BillingEmail, &x.Status, &x.CurrentPeriodStart,
+			&x.CurrentPeriodEnd, &x.CancelAtPeriodEnd, &x.CreatedAt, &x.UpdatedAt, &x.EndedAt); err != nil {
+
+			return nil, err
+		}
+
+		res = append(res, x)
+	}
+
+	return res, nil
+}
+
+func scanSubscriptionInvoices(rows *sql.Rows) ([]entity.SubscriptionInvoice, error) {
+	var res []entity.SubscriptionInvoice
+	for rows.Next() {
+		var x entity.SubscriptionInvoice
+		if err := rows.Scan(
+			&x.SubscriptionInvoiceId, &x.SubscriptionId, &x.TourGuideId, &x.Amount, &x.PeriodStart, &x.PeriodEnd,
+			&x.OrderCode, &x.CheckoutUrl, &x.Status, &x.DueAt, &x.ReminderCount, &x.LastRemindedAt, &x.PaidAt,
+			&x.CreatedAt); err != nil {
+
+			return nil, err
+		}
+
+		res = append(res, x)
+	}
+
+	return res, nil
+}
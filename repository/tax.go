@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	domain_status "tourmate/payment-service/constant/domain_status"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/tax"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/entity"
+)
+
+type taxRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeTaxRepo(db *sql.DB, logger *log.Logger) repo.ITaxRepo {
+	return &taxRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetTaxProfile implements repo.ITaxRepo.
+func (t *taxRepo) GetTaxProfile(tourGuideId int, ctx context.Context) (*entity.TaxProfile, error) {
+	var res entity.TaxProfile
+	var query string = "SELECT TOP 1 * FROM " + res.GetTaxProfileTable() + " WHERE tourGuideId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetTaxProfileTable()) + "GetTaxProfile - "
+
+	if err := t.db.QueryRowContext(ctx, query, tourGuideId).Scan(
+		&res.TaxProfileId, &res.TourGuideId, &res.TaxpayerType, &res.TaxCode, &res.LegalName,
+		&res.IdentityNumber, &res.Address, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		t.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// SaveTaxProfile implements repo.ITaxRepo.
+func (t *taxRepo) SaveTaxProfile(profile entity.TaxProfile, ctx context.Context) (int, error) {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, profile.GetTaxProfileTable()) + "SaveTaxProfile - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	if profile.TaxProfileId == 0 {
+		var id int
+		var query string = "INSERT INTO " + profile.GetTaxProfileTable() +
+			" (tourGuideId, taxpayerType, taxCode, legalName, identityNumber, address, createdAt, updatedAt) " +
+			"OUTPUT INSERTED.taxProfileId " +
+			"values (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8)"
+
+		if err := t.db.QueryRowContext(ctx, query, profile.TourGuideId, profile.TaxpayerType, profile.TaxCode, profile.LegalName,
+			profile.IdentityNumber, profile.Address, profile.CreatedAt, profile.UpdatedAt).Scan(&id); err != nil {
+
+			t.logger.Println(errLogMsg + err.Error())
+			return 0, internalErr
+		}
+
+		return id, nil
+	}
+
+	var query string = "UPDATE " + profile.GetTaxProfileTable() +
+		" SET taxpayerType = @p1, taxCode = @p2, legalName = @p3, identityNumber = @p4, address = @p5, updatedAt = @p6 WHERE taxProfileId = @p7"
+
+	res, err := t.db.ExecContext(ctx, query, profile.TaxpayerType, profile.TaxCode, profile.LegalName,
+		profile.IdentityNumber, profile.Address, profile.UpdatedAt, profile.TaxProfileId)
+	if err != nil {
+		t.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		t.logger.Println(errLogMsg + err.Error())
+		return 0, internalErr
+	}
+
+	if rowsAffected == 0 {
+		return 0, errors.New(fmt.Sprintf(noti.UNDEFINED_OBJECT_WARN_MSG, profile.GetTaxProfileTable()))
+	}
+
+	return profile.TaxProfileId, nil
+}
+
+// GetTaxWithholdingByPayoutItemId implements repo.ITaxRepo.
+func (t *taxRepo) GetTaxWithholdingByPayoutItemId(payoutItemId int, ctx context.Context) (*entity.TaxWithholding, error) {
+	var res entity.TaxWithholding
+	var query string = "SELECT TOP 1 * FROM " + res.GetTaxWithholdingTable() + " WHERE payoutItemId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetTaxWithholdingTable()) + "GetTaxWithholdingByPayoutItemId - "
+
+	if err := t.db.QueryRowContext(ctx, query, payoutItemId).Scan(
+		&res.TaxWithholdingId, &res.PayoutItemId, &res.TourGuideId, &res.TaxpayerType, &res.TaxCode,
+		&res.GrossAmount, &res.TaxRate, &res.TaxAmount, &res.CreatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		t.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetTaxWithholdingsByPayoutBatchId implements repo.ITaxRepo.
+func (t *taxRepo) GetTaxWithholdingsByPayoutBatchId(payoutBatchId int, ctx context.Context) (*[]entity.TaxWithholding, error) {
+	var table string = entity.TaxWithholding{}.GetTaxWithholdingTable()
+	var query string = "SELECT tw.* FROM " + table + " tw " +
+		"JOIN " + entity.PayoutItem{}.GetPayoutItemTable() + " pi ON pi.payoutItemId = tw.payoutItemId " +
+		"WHERE pi.payoutBatchId = @p1 ORDER BY tw.payoutItemId ASC"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetTaxWithholdingsByPayoutBatchId - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	rows, err := t.db.QueryContext(ctx, query, payoutBatchId)
+	if err != nil {
+		t.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.TaxWithholding
+	for rows.Next() {
+		var x entity.TaxWithholding
+		if err := rows.Scan(
+			&x.TaxWithholdingId, &x.PayoutItemId, &x.TourGuideId, &x.TaxpayerType, &x.TaxCode,
+			&x.GrossAmount, &x.TaxRate, &x.TaxAmount, &x.CreatedAt); err != nil {
+
+			t.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetPaidTaxWithholdings implements repo.ITaxRepo.
+func (t *taxRepo) GetPaidTaxWithholdings(tourGuideId *int, from time.Time, to time.Time, ctx context.Context) (*[]entity.PaidTaxWithholding, error) {
+	var table string = entity.TaxWithholding{}.GetTaxWithholdingTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetPaidTaxWithholdings - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var args []interface{} = []interface{}{domain_status.PAYOUT_ITEM_PAID, from, to}
+
+	var guideCondition string
+	if tourGuideId != nil {
+		guideCondition = " AND tw.tourGuideId = @p4"
+		args = append(args, *tourGuideId)
+	}
+
+	var query string = "SELECT tw.*, pi.reference, pi.processedAt FROM " + table + " tw " +
+		"JOIN " + entity.PayoutItem{}.GetPayoutItemTable() + " pi ON pi.payoutItemId = tw.payoutItemId " +
+		"WHERE pi.status = @p1 AND pi.processedAt >= @p2 AND pi.processedAt < @p3" + guideCondition +
+		" ORDER BY pi.processedAt ASC, tw.taxWithholdingId ASC"
+
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		t.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.PaidTaxWithholding
+	for rows.Next() {
+		var x entity.PaidTaxWithholding
+		if err := rows.Scan(
+			&x.TaxWithholdingId, &x.PayoutItemId, &x.TourGuideId, &x.TaxpayerType, &x.TaxCode,
+			&x.GrossAmount, &x.TaxRate, &x.TaxAmount, &x.CreatedAt, &x.Reference, &x.PaidAt); err != nil {
+
+			t.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
+
+// GetIndividualIncomeSummaries implements repo.ITaxRepo.
+func (t *taxRepo) GetIndividualIncomeSummaries(from time.Time, to time.Time, ctx context.Context) (*[]entity.TaxIncomeSummary, error) {
+	var table string = entity.TaxWithholding{}.GetTaxWithholdingTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetIndividualIncomeSummaries - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+	var query string = "SELECT tw.tourGuideId, SUM(tw.grossAmount), " +
+		"SUM(CASE WHEN tw.taxAmount > 0 THEN tw.grossAmount ELSE 0 END), SUM(tw.taxAmount), COUNT(*) " +
+		"FROM " + table + " tw " +
+		"JOIN " + entity.PayoutItem{}.GetPayoutItemTable() + " pi ON pi.payoutItemId = tw.payoutItemId " +
+		"WHERE tw.taxpayerType = @p1 AND pi.status = @p2 AND pi.processedAt >= @p3 AND pi.processedAt < @p4 " +
+		"GROUP BY tw.tourGuideId ORDER BY tw.tourGuideId ASC"
+
+	rows, err := t.db.QueryContext(ctx, query, tax.INDIVIDUAL_TAXPAYER, domain_status.PAYOUT_ITEM_PAID, from, to)
+	if err != nil {
+		t.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer rows.Close()
+
+	var res []entity.TaxIncomeSummary
+	for rows.Next() {
+		var x entity.TaxIncomeSummary
+		if err := rows.Scan(&x.TourGuideId, &x.TotalIncome, &x.TaxableIncome, &x.TaxAmount, &x.PaymentCount); err != nil {
+			t.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		res = append(res, x)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"tourmate/payment-service/constant/noti"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/interface/repo"
+	"tourmate/payment-service/model/dto/request"
+	"tourmate/payment-service/model/entity"
+)
+
+type walletRepo struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func InitializeWalletRepo(db *sql.DB, logger *log.Logger) repo.IWalletRepo {
+	return &walletRepo{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetWalletByCustomerId implements repo.IWalletRepo.
+func (w *walletRepo) GetWalletByCustomerId(customerId int, ctx context.Context) (*entity.Wallet, error) {
+	var res entity.Wallet
+	var query string = "SELECT * FROM " + res.GetWalletTable() + " WHERE customerId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetWalletTable()) + "GetWalletByCustomerId - "
+
+	if err := w.db.QueryRowContext(ctx, query, customerId).Scan(
+		&res.WalletId, &res.CustomerId, &res.Balance, &res.CreatedAt, &res.UpdatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// ChangeWalletBalance implements repo.IWalletRepo.
+func (w *walletRepo) ChangeWalletBalance(transaction entity.WalletTransaction, ctx context.Context) (*entity.WalletTransaction, error) {
+	var table string = entity.Wallet{}.GetWalletTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "ChangeWalletBalance - "
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	// The balance condition is checked by the update itself so that concurrent debits cannot both pass
+	var updateQuery string = "UPDATE " + table + " SET balance = balance + @p1, updatedAt = @p2 " +
+		"OUTPUT INSERTED.walletId, INSERTED.balance WHERE customerId = @p3 AND balance + @p1 >= 0"
+	// The range lock keeps a concurrent first credit waiting until this one has created the wallet
+	var existQuery string = "SELECT COUNT(*) FROM " + table + " WITH (UPDLOCK, HOLDLOCK) WHERE customerId = @p1"
+	var createQuery string = "INSERT INTO " + table + " (customerId, balance, createdAt, updatedAt) " +
+		"OUTPUT INSERTED.walletId, INSERTED.balance VALUES (@p1, @p2, @p3, @p4)"
+	var transactionQuery string = "INSERT INTO " + transaction.GetWalletTransactionTable() +
+		" (walletId, customerId, transactionType, amount, balanceAfter, referenceId, description, createdBy, createdAt) " +
+		"OUTPUT INSERTED.walletTransactionId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9)"
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, updateQuery, transaction.Amount, transaction.CreatedAt, transaction.CustomerId).
+		Scan(&transaction.WalletId, &transaction.BalanceAfter)
+
+	if err == sql.ErrNoRows {
+		var count int
+		if err := tx.QueryRowContext(ctx, existQuery, transaction.CustomerId).Scan(&count); err != nil {
+			w.logger.Println(errLogMsg + err.Error())
+			return nil, internalErr
+		}
+
+		if count > 0 || transaction.Amount < 0 {
+			return nil, errors.New(noti.INSUFFICIENT_WALLET_BALANCE_WARN_MSG)
+		}
+
+		err = tx.QueryRowContext(ctx, createQuery, transaction.CustomerId, transaction.Amount, transaction.CreatedAt, transaction.CreatedAt).
+			Scan(&transaction.WalletId, &transaction.BalanceAfter)
+	}
+
+	if err != nil {
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := tx.QueryRowContext(ctx, transactionQuery, transaction.WalletId, transaction.CustomerId, transaction.TransactionType,
+		transaction.Amount, transaction.BalanceAfter, transaction.ReferenceId, transaction.Description, transaction.CreatedBy,
+		transaction.CreatedAt).Scan(&transaction.WalletTransactionId); err != nil {
+
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, internalErr
+	}
+
+	return &transaction, nil
+}
+
+// GetWalletTransactionByReference implements repo.IWalletRepo.
+func (w *walletRepo) GetWalletTransactionByReference(transactionType string, referenceId int, ctx context.Context) (*entity.WalletTransaction, error) {
+	var res entity.WalletTransaction
+	var table string = res.GetWalletTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetWalletTransactionByReference - "
+	var query string = "SELECT TOP 1 * FROM " + table + " WHERE transactionType = @p1 AND referenceId = @p2 ORDER BY walletTransactionId DESC"
+
+	if err := w.db.QueryRowContext(ctx, query, transactionType, referenceId).Scan(
+		&res.WalletTransactionId, &res.WalletId, &res.CustomerId, &res.TransactionType, &res.Amount, &res.BalanceAfter,
+		&res.ReferenceId, &res.Description, &res.CreatedBy, &res.CreatedAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// GetWalletTransactions implements repo.IWalletRepo.
+func (w *walletRepo) GetWalletTransactions(req request.GetWalletTransactionsRequest, ctx context.Context) (*[]entity.WalletTransaction, int, int, error) {
+	var table string = entity.WalletTransaction{}.GetWalletTransactionTable()
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, table) + "GetWalletTransactions - "
+	var limitRecords int = req.PageSize
+
+	var builder *queryBuilder = newQueryBuilder().where("customerId = ?", req.CustomerId)
+
+	builder.orderBy("walletTransactionId", order.DESCENDING_ORDER)
+
+	rows, err := w.db.QueryContext(ctx, builder.pageQuery(table, limitRecords, req.Request.Page), builder.args...)
+	if err != nil {
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+	defer rows.Close()
+
+	var res []entity.WalletTransaction
+	for rows.Next() {
+		var x entity.WalletTransaction
+		if err := rows.Scan(
+			&x.WalletTransactionId, &x.WalletId, &x.CustomerId, &x.TransactionType, &x.Amount, &x.BalanceAfter,
+			&x.ReferenceId, &x.Description, &x.CreatedBy, &x.CreatedAt); err != nil {
+
+			w.logger.Println(errLogMsg + err.Error())
+			return nil, 0, 0, errors.New(noti.INTERNALL_ERR_MSG)
+		}
+
+		res = append(res, x)
+	}
+
+	// Track total records in table
+	var totalRecords int
+	w.db.QueryRowContext(ctx, builder.countQuery(table), builder.args...).Scan(&totalRecords)
+
+	return &res, caculateTotalPages(totalRecords, limitRecords), totalRecords, nil
+}
+
+// GetWalletTopUpById implements repo.IWalletRepo.
+func (w *walletRepo) GetWalletTopUpById(id int, ctx context.Context) (*entity.WalletTopUp, error) {
+	var res entity.WalletTopUp
+	var query string = "SELECT * FROM " + res.GetWalletTopUpTable() + " WHERE walletTopUpId = @p1"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, res.GetWalletTopUpTable()) + "GetWalletTopUpById - "
+
+	if err := w.db.QueryRowContext(ctx, query, id).Scan(
+		&res.WalletTopUpId, &res.CustomerId, &res.Amount, &res.OrderCode, &res.CheckoutUrl, &res.Status,
+		&res.CreatedAt, &res.PaidAt); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		w.logger.Println(errLogMsg + err.Error())
+		return nil, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return &res, nil
+}
+
+// CreateWalletTopUp implements repo.IWalletRepo.
+func (w *walletRepo) CreateWalletTopUp(topUp entity.WalletTopUp, ctx context.Context) (int, error) {
+	var query string = "INSERT INTO " + topUp.GetWalletTopUpTable() +
+		" (customerId, amount, orderCode, checkoutUrl, status, createdAt, paidAt) " +
+		"OUTPUT INSERTED.walletTopUpId VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7)"
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, topUp.GetWalletTopUpTable()) + "CreateWalletTopUp - "
+
+	var res int
+	if err := w.db.QueryRowContext(ctx, query, topUp.CustomerId, topUp.Amount, topUp.OrderCode, topUp.CheckoutUrl,
+		topUp.Status, topUp.CreatedAt, topUp.PaidAt).Scan(&res); err != nil {
+
+		w.logger.Println(errLogMsg + err.Error())
+		return 0, errors.New(noti.INTERNALL_ERR_MSG)
+	}
+
+	return res, nil
+}
+
+// UpdateWalletTopUp implements repo.IWalletRepo.
+func (w *walletRepo) UpdateWalletTopUp(topUp entity.WalletTopUp, currentStatus string, ctx context.Context) error {
+	var errLogMsg string = fmt.Sprintf(noti.REPO_ERR_MSG, topUp.GetWalletTopUpTable()) + "UpdateWalletTopUp - "
+	var query string = "UPDATE " + topUp.GetWalletTopUpTable() + " SET status = @p1, paidAt = @p2 WHERE walletTopUpId = @p3 AND status = @p4"
+	var internalErr error = errors.New(noti.INTERNALL_ERR_MSG)
+
+	res, err := w.db.ExecContext(ctx, query, topUp.Status, topUp.PaidAt, topUp.WalletTopUpId, currentStatus)
+	if err != nil {
+		w.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		w.logger.Println(errLogMsg + err.Error())
+		return internalErr
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(noti.INVALID_STATUS_WARN_MSG)
+	}
+
+	return nil
+}
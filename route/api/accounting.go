@@ -0,0 +1,25 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeAccountingHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/accounting"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/accounting"
+	}
+
+	// Define Accounting endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("/accounts", handler.GetAccountMappings)
+	adminAuthGroup.GET("/journal/export", handler.ExportJournal)
+}
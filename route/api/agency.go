@@ -0,0 +1,33 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeAgencyHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/agencies"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/agencies"
+	}
+
+	// Define Agency endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetAgencies)
+	adminAuthGroup.POST("", handler.CreateAgency)
+	adminAuthGroup.PUT("/:id", handler.UpdateAgency)
+	adminAuthGroup.GET("/:id/report", handler.GetAgencyReport)
+	adminAuthGroup.GET("/:id/revenues", handler.GetAgencyRevenues)
+	adminAuthGroup.GET("/:id/payouts", handler.GetAgencyPayouts)
+	adminAuthGroup.POST("/:id/payouts", handler.CreateAgencyPayout)
+	adminAuthGroup.GET("/payouts/:id", handler.GetAgencyPayout)
+	adminAuthGroup.PUT("/payouts/:id/paid", handler.ConfirmAgencyPayout)
+	adminAuthGroup.PUT("/payouts/:id/cancel", handler.CancelAgencyPayout)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeBankStatementHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/bank-statements"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/bank-statements"
+	}
+
+	// Define Bank Statement endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.POST("/import", handler.ImportBankStatement)
+	adminAuthGroup.GET("/transactions", handler.GetBankTransactions)
+	adminAuthGroup.PUT("/transactions/:id/accept", handler.AcceptBankTransaction)
+	adminAuthGroup.PUT("/transactions/:id/reject", handler.RejectBankTransaction)
+	adminAuthGroup.PUT("/pending-invoices/:id/confirm", handler.ConfirmPendingInvoice)
+
+	// Define Bank Statement endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.POST("/pending-invoices", handler.RegisterPendingInvoice)
+	authGroup.PUT("/pending-invoices/:id/cancel", handler.CancelPendingInvoice)
+	authGroup.POST("/vietqr", handler.CreateVietQrTransaction)
+	authGroup.GET("/pending-invoices/:id/vietqr", handler.GetVietQrImage)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeEInvoiceHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/einvoices"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/einvoices"
+	}
+
+	// Define E-Invoice endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.POST("/:id/adjust", handler.AdjustEInvoice)
+	adminAuthGroup.POST("/:id/replace", handler.ReplaceEInvoice)
+
+	// Define E-Invoice endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/:id", handler.GetEInvoice)
+	authGroup.GET("/:id/export", handler.ExportEInvoice)
+	authGroup.GET("/payment/:id", handler.GetPaymentEInvoices)
+	authGroup.POST("", handler.IssueEInvoice)
+}
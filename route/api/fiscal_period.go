@@ -0,0 +1,27 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeFiscalPeriodHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/fiscal-periods"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/fiscal-periods"
+	}
+
+	// Define Fiscal Period endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetFiscalPeriods)
+	adminAuthGroup.GET("/:id/audits", handler.GetFiscalPeriodAudits)
+	adminAuthGroup.PUT("/close", handler.CloseFiscalPeriod)
+	adminAuthGroup.PUT("/reopen", handler.ReopenFiscalPeriod)
+}
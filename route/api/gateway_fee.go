@@ -0,0 +1,32 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeGatewayFeeHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/gateway-fees"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/gateway-fees"
+	}
+
+	// Define Gateway fee endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetPaymentFees)
+	adminAuthGroup.GET("/methods", handler.GetPaymentMethodFees)
+	adminAuthGroup.PUT("/methods/:method", handler.UpsertPaymentMethodFee)
+	adminAuthGroup.GET("/report", handler.GetGatewayFeeReport)
+	adminAuthGroup.POST("/settlements", handler.ImportSettlement)
+
+	// Define Gateway fee endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/surcharge", handler.GetSurcharge)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeGiftCardHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/gift-cards"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/gift-cards"
+	}
+
+	// Define Gift Card endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetGiftCards)
+	adminAuthGroup.GET("/liability", handler.GetGiftCardLiability)
+
+	// Define Gift Card endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.POST("", handler.PurchaseGiftCard)
+	authGroup.GET("/code/:code", handler.GetGiftCardByCode)
+	authGroup.PUT("/:id/confirm", handler.ConfirmGiftCardPurchase)
+	authGroup.POST("/redeem", handler.RedeemGiftCard)
+	authGroup.POST("/pay", handler.PayWithGiftCard)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeLedgerHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/ledger"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/ledger"
+	}
+
+	// Define Ledger endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("/accounts", handler.GetLedgerAccountBalances)
+	adminAuthGroup.GET("/accounts/:account/balance", handler.GetLedgerAccountBalance)
+	adminAuthGroup.GET("/accounts/:account/statement", handler.GetLedgerAccountStatement)
+	adminAuthGroup.GET("/invariants", handler.CheckLedgerInvariants)
+	adminAuthGroup.POST("/adjustments", handler.CreateLedgerAdjustment)
+}
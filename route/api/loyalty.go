@@ -0,0 +1,31 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeLoyaltyHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/loyalty"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/loyalty"
+	}
+
+	// Define Loyalty endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("/rules", handler.GetLoyaltyRules)
+	adminAuthGroup.POST("/rules", handler.CreateLoyaltyRule)
+	adminAuthGroup.PUT("/rules/:id", handler.UpdateLoyaltyRule)
+
+	// Define Loyalty endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/customer/:id", handler.GetLoyaltyBalance)
+	authGroup.GET("/customer/:id/transactions", handler.GetLoyaltyTransactions)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeOfflinePaymentHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/offline-payments"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/offline-payments"
+	}
+
+	// Define Offline Payment endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.POST("", handler.CreateOfflinePayment)
+	adminAuthGroup.GET("", handler.GetOfflinePayments)
+	adminAuthGroup.GET("/:id/proof", handler.GetOfflinePaymentProof)
+	adminAuthGroup.PUT("/:id/confirm", handler.ConfirmOfflinePayment)
+	adminAuthGroup.PUT("/:id/reject", handler.RejectOfflinePayment)
+}
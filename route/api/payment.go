@@ -22,13 +22,16 @@ func InitializePaymentHandlerRoute(server *gin.Engine, service string) {
 	var adminAuthGroup = server.Group(contextPath)
 	adminAuthGroup.GET("", handler.GetAllPayments)
 	adminAuthGroup.PUT("/update", handler.UpdatePayment)
+	adminAuthGroup.PUT("/refund", handler.RefundPayment)
 
 	// Define Payment endpoints with basic required
 	var authGroup = server.Group(contextPath)
 	authGroup.GET("/customer/:id", handler.GetPaymentsByUser)
+	authGroup.GET("/customer/:id/summary", handler.GetCustomerPaymentSummary)
 	authGroup.GET("/:id", handler.GetPaymentById)
 	authGroup.POST("/create", handler.CreatePayment)
 	authGroup.GET("/with-service-name/:id", handler.GetPaymentWithService)
+	authGroup.PUT("/split/:id/confirm", handler.ConfirmSplitPayment)
 
 	var norGroup = server.Group(contextPath)
 	norGroup.POST("/create-embedded-payment-link", handler.CreatePayosTransaction)
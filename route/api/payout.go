@@ -0,0 +1,32 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializePayoutHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/payouts"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/payouts"
+	}
+
+	// Define Payout endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetPayoutBatches)
+	adminAuthGroup.GET("/policy", handler.GetPayoutPolicy)
+	adminAuthGroup.PUT("/policy", handler.UpdatePayoutPolicy)
+	adminAuthGroup.GET("/:id", handler.GetPayoutBatch)
+	adminAuthGroup.POST("", handler.CreatePayoutBatch)
+	adminAuthGroup.PUT("/:id/approve", handler.ApprovePayoutBatch)
+	adminAuthGroup.PUT("/:id/cancel", handler.CancelPayoutBatch)
+	adminAuthGroup.GET("/:id/export", handler.ExportPayoutBatch)
+	adminAuthGroup.POST("/:id/import", handler.ImportPayoutResult)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializePayoutAccountHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/payout-accounts"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/payout-accounts"
+	}
+
+	// Define Payout Account endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetPayoutAccounts)
+	adminAuthGroup.PUT("/:id/verify", handler.VerifyPayoutAccount)
+	adminAuthGroup.PUT("/:id/reject", handler.RejectPayoutAccount)
+
+	// Define Payout Account endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/tourGuide/:id", handler.GetTourGuidePayoutAccounts)
+	authGroup.POST("", handler.CreatePayoutAccount)
+	authGroup.PUT("", handler.UpdatePayoutAccount)
+	authGroup.DELETE("", handler.RemovePayoutAccount)
+}
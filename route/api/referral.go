@@ -0,0 +1,31 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeReferralHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/referrals"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/referrals"
+	}
+
+	// Define Referral endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("/rewards", handler.GetReferralRewards)
+	adminAuthGroup.PUT("/codes/:id/status", handler.UpdateReferralCodeStatus)
+
+	// Define Referral endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.POST("/codes", handler.GetReferralCode)
+	authGroup.POST("/claim", handler.ClaimReferralCode)
+	authGroup.GET("/summary", handler.GetReferralSummary)
+}
@@ -18,13 +18,24 @@ func InitializeRevenueHandlerRoute(server *gin.Engine, service string) {
 		contextPath = service + "/api/v1/revenues"
 	}
 
+	// Define Revenue endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("/platform/summary", handler.GetPlatformRevenueSummary)
+	adminAuthGroup.GET("/platform/top-guides", handler.GetTopTourGuideRevenues)
+	adminAuthGroup.GET("/platform/services", handler.GetServiceRevenues)
+	adminAuthGroup.GET("/platform/areas", handler.GetAreaRevenues)
+
 	// Define Feedback endpoints with basic required
 	var authGroup = server.Group(contextPath)
 	authGroup.GET("", handler.GetRevenues)
 	authGroup.GET("/monthly/:id", handler.GetMonthlyRevenue)
 	authGroup.GET("/growth/:id", handler.GetGrowthPercentage)
 	authGroup.GET("/stats/:id", handler.GetRevenueStats)
+	authGroup.GET("/series/:id", handler.GetRevenueSeries)
+	authGroup.GET("/payout-summary/:id", handler.GetPayoutSummary)
+	authGroup.GET("/statement/:id", handler.GetEarningsStatement)
 	authGroup.GET("/:id", handler.GetRevenue)
+	authGroup.GET("/:id/adjustments", handler.GetRevenueAdjustments)
 	authGroup.POST("", handler.CreateRevenue)
 	authGroup.PUT("/:id", handler.UpdateRevenue)
 	authGroup.DELETE("/:id", handler.RemoveRevenue)
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeSubscriptionHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/subscriptions"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/subscriptions"
+	}
+
+	// Define Subscription endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("", handler.GetSubscriptions)
+	adminAuthGroup.POST("/plans", handler.CreateSubscriptionPlan)
+	adminAuthGroup.PUT("/plans/:id", handler.UpdateSubscriptionPlan)
+	adminAuthGroup.GET("/invoices", handler.GetSubscriptionInvoices)
+	adminAuthGroup.POST("/billing", handler.ProcessSubscriptions)
+
+	// Define Subscription endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/plans", handler.GetSubscriptionPlans)
+	authGroup.POST("", handler.CreateSubscription)
+	authGroup.GET("/tour-guides/:id", handler.GetTourGuideSubscription)
+	authGroup.PUT("/:id/cancel", handler.CancelSubscription)
+	authGroup.PUT("/invoices/:id/confirm", handler.ConfirmSubscriptionInvoice)
+	authGroup.GET("/featured", handler.GetFeaturedTourGuideIds)
+}
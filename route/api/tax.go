@@ -0,0 +1,31 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeTaxHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/taxes"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/taxes"
+	}
+
+	// Define Tax endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.GET("/withholdings", handler.GetTaxWithholdings)
+	adminAuthGroup.GET("/declaration/export", handler.ExportTaxDeclaration)
+
+	// Define Tax endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/profiles/:id", handler.GetTaxProfile)
+	authGroup.PUT("/profiles", handler.SaveTaxProfile)
+	authGroup.GET("/certificates/:id", handler.GetTaxCertificate)
+}
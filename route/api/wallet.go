@@ -0,0 +1,32 @@
+package api
+
+import (
+	"os"
+	"tourmate/payment-service/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitializeWalletHandlerRoute(server *gin.Engine, service string) {
+	//Context path
+	var contextPath string
+	if os.Getenv("DOCKER_COMPOSE") == "true" {
+		// When running with Traefik, the prefix is already stripped
+		contextPath = "/api/v1/wallets"
+	} else {
+		// When running standalone, include the service prefix
+		contextPath = service + "/api/v1/wallets"
+	}
+
+	// Define Wallet endpoints with admin required
+	var adminAuthGroup = server.Group(contextPath)
+	adminAuthGroup.POST("/adjust", handler.AdjustWallet)
+
+	// Define Wallet endpoints with basic required
+	var authGroup = server.Group(contextPath)
+	authGroup.GET("/customer/:id", handler.GetWallet)
+	authGroup.GET("/customer/:id/transactions", handler.GetWalletTransactions)
+	authGroup.POST("/top-up", handler.CreateWalletTopUp)
+	authGroup.PUT("/top-up/:id/confirm", handler.ConfirmWalletTopUp)
+	authGroup.POST("/pay", handler.PayWithWallet)
+}
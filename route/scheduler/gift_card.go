@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	"context"
+	business_logic "tourmate/payment-service/business_logic"
+	business_logic_interface "tourmate/payment-service/interface/business_logic"
+)
+
+// Forfeit the balance of gift cards past their expiry
+func newGiftCardExpiryJob() func(ctx context.Context) error {
+	return lazyJob(business_logic.GenerateGiftCardService, func(service business_logic_interface.IGiftCardService, ctx context.Context) error {
+		_, err := service.ExpireGiftCards(ctx)
+		return err
+	})
+}
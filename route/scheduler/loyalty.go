@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	"context"
+	business_logic "tourmate/payment-service/business_logic"
+	business_logic_interface "tourmate/payment-service/interface/business_logic"
+)
+
+// Take the loyalty points past their expiry
+func newLoyaltyExpiryJob() func(ctx context.Context) error {
+	return lazyJob(business_logic.GenerateLoyaltyService, func(service business_logic_interface.ILoyaltyService, ctx context.Context) error {
+		_, err := service.ExpireLoyaltyPoints(ctx)
+		return err
+	})
+}
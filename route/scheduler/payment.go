@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	"context"
+	business_logic "tourmate/payment-service/business_logic"
+	business_logic_interface "tourmate/payment-service/interface/business_logic"
+)
+
+// Settle split payments whose payment link was paid in the meantime and give back the held part of cancelled or expired ones
+func newSplitPaymentReleaseJob() func(ctx context.Context) error {
+	return lazyJob(business_logic.GeneratePaymentService, func(service business_logic_interface.IPaymentService, ctx context.Context) error {
+		_, err := service.ReleaseExpiredSplitPayments(ctx)
+		return err
+	})
+}
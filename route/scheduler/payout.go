@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	"context"
+	business_logic "tourmate/payment-service/business_logic"
+	business_logic_interface "tourmate/payment-service/interface/business_logic"
+)
+
+// Create draft payout batches when the payout policy schedule is due
+func newPayoutJob() func(ctx context.Context) error {
+	return lazyJob(business_logic.GeneratePayoutService, func(service business_logic_interface.IPayoutService, ctx context.Context) error {
+		_, err := service.CreateScheduledPayoutBatch(ctx)
+		return err
+	})
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"tourmate/payment-service/constant/noti"
+)
+
+// Interval between two runs of every background job
+const jobInterval time.Duration = time.Hour
+
+type job struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func InitializeSchedulers(logger *log.Logger) {
+	var jobs []job = []job{
+		{name: "payout", run: newPayoutJob()},
+		{name: "gift card expiry", run: newGiftCardExpiryJob()},
+		{name: "loyalty point expiry", run: newLoyaltyExpiryJob()},
+		{name: "subscription billing", run: newSubscriptionBillingJob()},
+		{name: "split payment release", run: newSplitPaymentReleaseJob()},
+	}
+
+	for _, j := range jobs {
+		go schedule(j, logger)
+	}
+}
+
+// Run the job right away, then once every interval
+func schedule(j job, logger *log.Logger) {
+	var ticker = time.NewTicker(jobInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(context.Background()); err != nil {
+			logger.Println(fmt.Sprintf(noti.SCHEDULER_ERR_MSG, j.name) + err.Error())
+		}
+
+		<-ticker.C
+	}
+}
+
+// Build the service of a job on its first run and reuse it on the next ones, a failed build is tried again on the next run
+func lazyJob[T any](generate func() (T, error), run func(service T, ctx context.Context) error) func(ctx context.Context) error {
+	var service T
+	var isReady bool
+
+	return func(ctx context.Context) error {
+		if !isReady {
+			res, err := generate()
+			if err != nil {
+				return err
+			}
+
+			service, isReady = res, true
+		}
+
+		return run(service, ctx)
+	}
+}
@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	"context"
+	business_logic "tourmate/payment-service/business_logic"
+	business_logic_interface "tourmate/payment-service/interface/business_logic"
+)
+
+// Invoice renewals, remind unpaid invoices and downgrade subscriptions past their grace period
+func newSubscriptionBillingJob() func(ctx context.Context) error {
+	return lazyJob(business_logic.GenerateSubscriptionService, func(service business_logic_interface.ISubscriptionService, ctx context.Context) error {
+		_, err := service.ProcessSubscriptions(ctx)
+		return err
+	})
+}
@@ -75,6 +75,8 @@ func processSuccessPostReponse(res interface{}, postType string, ctx *gin.Contex
 		processInformResponse(res, ctx)
 	case action_type.CREATE_ACTION:
 		ctx.IndentedJSON(http.StatusCreated, res)
+	case action_type.FILE_DOWNLOAD:
+		processFileResponse(res, ctx)
 	default:
 		ctx.IndentedJSON(http.StatusOK, response.MessageApiResponse{
 			Message: "success",
@@ -92,6 +94,17 @@ func processInformResponse(message interface{}, ctx *gin.Context) {
 	})
 }
 
+func processFileResponse(res interface{}, ctx *gin.Context) {
+	file, ok := res.(response.FileResponse)
+	if !ok {
+		processFailResponse(errors.New(noti.INTERNALL_ERR_MSG), ctx)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
 func processSuccessResponse(data interface{}, postType string, ctx *gin.Context) {
 	var codeResponse int
 	switch postType {
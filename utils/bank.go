@@ -0,0 +1,29 @@
+package utils
+
+import "tourmate/payment-service/constant/bank"
+
+func IsBankCodeValid(code string) bool {
+	var res bool = true
+
+	switch code {
+	case bank.VIETCOMBANK:
+	case bank.TECHCOMBANK:
+	case bank.BIDV:
+	case bank.VIETINBANK:
+	case bank.AGRIBANK:
+	case bank.ACB:
+	case bank.MB_BANK:
+	case bank.VP_BANK:
+	case bank.TP_BANK:
+	case bank.SACOMBANK:
+	case bank.SHB:
+	case bank.HD_BANK:
+	case bank.VIB:
+	case bank.OCB:
+	case bank.MSB:
+	default:
+		res = false
+	}
+
+	return res
+}
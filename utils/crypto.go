@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"tourmate/payment-service/constant/env"
+)
+
+// Encrypt plain text with AES-GCM, output is base64 of nonce + cipher text
+func EncryptString(plainText string) (string, error) {
+	gcm, err := generateCipher()
+	if err != nil {
+		return "", err
+	}
+
+	var nonce []byte = make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plainText), nil)), nil
+}
+
+// Decrypt text produced by EncryptString
+func DecryptString(cipherText string) (string, error) {
+	gcm, err := generateCipher()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("cipher text too short")
+	}
+
+	plainText, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plainText), nil
+}
+
+func generateCipher() (cipher.AEAD, error) {
+	var secret string = os.Getenv(env.DATA_ENCRYPTION_KEY)
+	if secret == "" {
+		return nil, errors.New(env.DATA_ENCRYPTION_KEY + " is not set")
+	}
+
+	// Derive a 32 bytes key so that any secret length can be used
+	var key [32]byte = sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strings"
+	file_support "tourmate/payment-service/constant/file/file_support"
+	"tourmate/payment-service/constant/noti"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// Formatted amounts such as 1.250.000 or -50.000 are right aligned in PDF tables
+var amountRegex *regexp.Regexp = regexp.MustCompile(`^-?\d{1,3}(\.\d{3})*(,\d+)?$`)
+
+// UTF-8 byte order mark so that Excel opens Vietnamese text correctly
+var utf8Bom []byte = []byte{0xEF, 0xBB, 0xBF}
+
+// Generate CSV file content from header and rows
+func GenerateCsvFile(headers []string, rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.Write(utf8Bom)
+
+	var writer = csv.NewWriter(&buffer)
+	if err := writer.Write(headers); err != nil {
+		return nil, err
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Generate XLSX file content with a single sheet from header and rows
+func GenerateXlsxFile(sheet string, headers []string, rows [][]string) ([]byte, error) {
+	var file = excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), sheet); err != nil {
+		return nil, err
+	}
+
+	for i, row := range append([][]string{headers}, rows...) {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return nil, err
+		}
+
+		var values []interface{}
+		for _, v := range row {
+			values = append(values, v)
+		}
+
+		if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Generate a PDF document with a title, a few description lines, a table and label/value summary rows.
+// The built-in PDF fonts have no Vietnamese glyphs so the text is written without accents
+func GeneratePdfFile(title string, descriptions []string, headers []string, rows [][]string, summaries [][]string) ([]byte, error) {
+	const lineHeight float64 = 7
+
+	var pdf = gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, RemoveVietnameseAccents(title), "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, description := range descriptions {
+		pdf.CellFormat(0, 6, RemoveVietnameseAccents(description), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Column widths follow the widest cell and are scaled to the printable width
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+
+	pdf.SetFont("Helvetica", "", 9)
+	var widths []float64 = make([]float64, len(headers))
+	var totalWidth float64
+	for i := range headers {
+		widths[i] = pdf.GetStringWidth(RemoveVietnameseAccents(headers[i]))
+		for _, row := range rows {
+			widths[i] = math.Max(widths[i], pdf.GetStringWidth(RemoveVietnameseAccents(GetCellValue(row, i))))
+		}
+
+		widths[i] += 4
+		totalWidth += widths[i]
+	}
+
+	for i := range widths {
+		widths[i] = widths[i] * (pageWidth - left - right) / totalWidth
+	}
+
+	var writeRow = func(row []string, fill bool) {
+		for i := range headers {
+			var value string = RemoveVietnameseAccents(GetCellValue(row, i))
+			var align string = "L"
+			if !fill && amountRegex.MatchString(value) {
+				align = "R"
+			}
+
+			pdf.CellFormat(widths[i], lineHeight, value, "1", 0, align, fill, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	writeRow(headers, true)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, row := range rows {
+		writeRow(row, false)
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	for _, summary := range summaries {
+		pdf.CellFormat(60, lineHeight, RemoveVietnameseAccents(GetCellValue(summary, 0)), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, lineHeight, RemoveVietnameseAccents(GetCellValue(summary, 1)), "", 1, "R", false, 0, "")
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Generate file content based on the requested format (csv or xlsx)
+func GenerateTabularFile(format, sheet string, headers []string, rows [][]string) ([]byte, string, error) {
+	switch format {
+	case file_support.CSV_FORMAT:
+		data, err := GenerateCsvFile(headers, rows)
+		return data, file_support.CSV_CONTENT_TYPE, err
+	case file_support.XLSX_FORMAT:
+		data, err := GenerateXlsxFile(sheet, headers, rows)
+		return data, file_support.XLSX_CONTENT_TYPE, err
+	default:
+		return nil, "", errors.New(noti.UNSUPPORTED_FILE_FORMAT_WARN_MSG)
+	}
+}
+
+// Read rows from an uploaded CSV or XLSX file, the first sheet is used for XLSX
+func ReadTabularFile(fileName string, content []byte) ([][]string, error) {
+	switch GetFileExtension(fileName) {
+	case file_support.CSV_FORMAT:
+		var reader = csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, utf8Bom)))
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		return reader.ReadAll()
+	case file_support.XLSX_FORMAT:
+		file, err := excelize.OpenReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return file.GetRows(file.GetSheetName(0))
+	default:
+		return nil, errors.New(noti.UNSUPPORTED_FILE_FORMAT_WARN_MSG)
+	}
+}
+
+// Get lower case file extension without the dot
+func GetFileExtension(fileName string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+}
+
+// Find the index of the first column whose header matches one of the given names
+func FindColumnIndex(headers []string, names ...string) int {
+	for i, header := range headers {
+		for _, name := range names {
+			if ToNormalizedString(header) == ToNormalizedString(name) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// Get cell value at index, empty string if row is shorter
+func GetCellValue(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[index])
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"math"
+	"tourmate/payment-service/constant/ledger"
+)
+
+func IsLedgerAccountValid(account string) bool {
+	var res bool = true
+
+	switch account {
+	case ledger.CUSTOMER_RECEIVABLE:
+	case ledger.GUIDE_PAYABLE:
+	case ledger.PLATFORM_COMMISSION:
+	case ledger.GATEWAY_CLEARING:
+	case ledger.REFUNDS:
+	case ledger.TAX_PAYABLE:
+	case ledger.CUSTOMER_WALLET:
+	case ledger.GIFT_CARD_LIABILITY:
+	case ledger.AGENCY_PAYABLE:
+	case ledger.SUBSCRIPTION_REVENUE:
+	case ledger.GATEWAY_FEE:
+	default:
+		res = false
+	}
+
+	return res
+}
+
+// Asset, expense and contra income accounts grow on the debit side, liability and income accounts on the credit side
+func IsDebitNormalAccount(account string) bool {
+	return account == ledger.CUSTOMER_RECEIVABLE || account == ledger.GATEWAY_CLEARING || account == ledger.GATEWAY_FEE ||
+		account == ledger.REFUNDS
+}
+
+// Get balance of an account on its normal side
+func GetLedgerBalance(account string, debit, credit float64) float64 {
+	if IsDebitNormalAccount(account) {
+		return RoundMoney(debit - credit)
+	}
+
+	return RoundMoney(credit - debit)
+}
+
+// Round money to 2 decimal places to avoid floating point noise
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 )
 
 func IsNumericString(s string) bool {
@@ -16,3 +19,125 @@ func GenerateNumber() int {
 	number, _ := strconv.Atoi(millisStr[len(millisStr)-6:])
 	return number
 }
+
+// Get growth in percent compared to the previous amount, nil when there is nothing to compare with
+func GetGrowthPercentage(current, previous float64) *float64 {
+	if previous <= 0 {
+		return nil
+	}
+
+	var res float64 = math.Round((current-previous)/previous*10000) / 100
+	return &res
+}
+
+// Parse an amount written by a bank such as 1,250,000 or 1.250.000,50 or 1250000 VND, the last separator is
+// taken as the decimal mark only when it is not followed by exactly three digits
+func ParseMoney(s string) (float64, bool) {
+	s = strings.NewReplacer(" ", "", "VND", "", "vnd", "", "đ", "", "+", "").Replace(strings.TrimSpace(s))
+	if s == "" {
+		return 0, false
+	}
+
+	var lastSeparator int = strings.LastIndexAny(s, ".,")
+	if lastSeparator >= 0 && len(s)-lastSeparator-1 != 3 {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s[:lastSeparator]) + "." + s[lastSeparator+1:]
+	} else {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s)
+	}
+
+	res, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return res, true
+}
+
+// Format money without decimals using dot as thousands separator, e.g. 1250000 becomes 1.250.000
+func FormatMoney(amount float64) string {
+	var digits string = strconv.FormatFloat(math.Abs(math.Round(amount)), 'f', 0, 64)
+	var res string
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			res += "."
+		}
+		res += string(digit)
+	}
+
+	if math.Round(amount) < 0 {
+		return "-" + res
+	}
+
+	return res
+}
+
+var vietnameseDigits []string = []string{"không", "một", "hai", "ba", "bốn", "năm", "sáu", "bảy", "tám", "chín"}
+
+var vietnameseUnits []string = []string{"", "nghìn", "triệu", "tỷ", "nghìn tỷ", "triệu tỷ"}
+
+// Read an amount of money in Vietnamese words as printed on invoices, e.g. 1250000 becomes "Một triệu hai trăm năm mươi nghìn đồng"
+func AmountToVietnameseWords(amount float64) string {
+	var number int64 = int64(math.Round(math.Abs(amount)))
+	if number == 0 {
+		return "Không đồng"
+	}
+
+	var groups []int
+	for number > 0 {
+		groups = append(groups, int(number%1000))
+		number /= 1000
+	}
+
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+
+		// Hundreds are read in full for every group except the leading one, e.g. 1005000 is "một triệu không trăm linh năm nghìn"
+		words = append(words, readVietnameseHundreds(groups[i], i < len(groups)-1)...)
+		if vietnameseUnits[i] != "" {
+			words = append(words, vietnameseUnits[i])
+		}
+	}
+
+	var res string = strings.Join(words, " ") + " đồng"
+	if amount < 0 {
+		res = "âm " + res
+	}
+
+	var runes []rune = []rune(res)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	return string(runes)
+}
+
+func readVietnameseHundreds(number int, isFull bool) []string {
+	var hundreds, tens, units int = number / 100, number / 10 % 10, number % 10
+	var res []string
+
+	if isFull || hundreds > 0 {
+		res = append(res, vietnameseDigits[hundreds], "trăm")
+	}
+
+	switch {
+	case tens == 0 && units > 0 && (isFull || hundreds > 0):
+		res = append(res, "linh")
+	case tens == 1:
+		res = append(res, "mười")
+	case tens > 1:
+		res = append(res, vietnameseDigits[tens], "mươi")
+	}
+
+	switch {
+	case units == 0:
+	case units == 1 && tens > 1:
+		res = append(res, "mốt")
+	case units == 5 && tens > 0:
+		res = append(res, "lăm")
+	default:
+		res = append(res, vietnameseDigits[units])
+	}
+
+	return res
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strings"
+	"tourmate/payment-service/constant/order"
+	"tourmate/payment-service/constant/pagination"
+)
+
+// Resolve the sort column of a list from its whitelist, an unknown filter property falls back to the default column so
+// only known columns reach the ORDER BY clause
+func ParseSortSpec(filterProp, ord string, columns map[string]string, defaultColumn, defaultOrder string) (string, string) {
+	column, isExisted := columns[strings.ToUpper(strings.TrimSpace(filterProp))]
+	if !isExisted {
+		column = defaultColumn
+	}
+
+	ord = strings.ToUpper(strings.TrimSpace(ord))
+	if ord != order.ASCENDING_ORDER && ord != order.DESCENDING_ORDER {
+		ord = defaultOrder
+	}
+
+	return column, ord
+}
+
+// Page size chosen by the client, it is capped so one request cannot load a whole table
+func ParsePageSize(pageSize int) int {
+	if pageSize < 1 {
+		return pagination.DEFAULT_PAGE_SIZE
+	}
+
+	return min(pageSize, pagination.MAX_PAGE_SIZE)
+}
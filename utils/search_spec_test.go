@@ -0,0 +1,25 @@
+package utils
+
+import "testing"
+
+func TestParsePageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize int
+		want     int
+	}{
+		{name: "not sent", pageSize: 0, want: 10},
+		{name: "negative", pageSize: -5, want: 10},
+		{name: "chosen", pageSize: 25, want: 25},
+		{name: "at the cap", pageSize: 100, want: 100},
+		{name: "over the cap", pageSize: 5000, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParsePageSize(tt.pageSize); got != tt.want {
+				t.Errorf("ParsePageSize(%d) = %d, want %d", tt.pageSize, got, tt.want)
+			}
+		})
+	}
+}
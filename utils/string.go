@@ -1,6 +1,18 @@
 package utils
 
-import "strings"
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	gift_card "tourmate/payment-service/constant/gift_card"
+	"tourmate/payment-service/constant/referral"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
 
 func ToCombinedString(src []string, sepChar string) string {
 	if len(src) < 1 {
@@ -27,3 +39,117 @@ func ToNormalizedString(s string) string {
 	s = strings.TrimSpace(s)
 	return strings.ToLower(s)
 }
+
+// Generate payout transfer reference, e.g. TM12G501 for tour guide 501 in batch 12
+func GeneratePayoutReference(batchId, tourGuideId int) string {
+	return fmt.Sprintf("TM%dG%d", batchId, tourGuideId)
+}
+
+// Generate agency payout transfer reference, e.g. TM7A3 for payout 7 of agency 3
+func GenerateAgencyPayoutReference(payoutId, agencyId int) string {
+	return fmt.Sprintf("TM%dA%d", payoutId, agencyId)
+}
+
+// Mask account number except the last 4 digits, e.g. ******6789
+func MaskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+
+	return strings.Repeat("*", len(accountNumber)-4) + accountNumber[len(accountNumber)-4:]
+}
+
+// Remove Vietnamese diacritics, e.g. "Nguyễn Văn Đức" becomes "Nguyen Van Duc"
+func RemoveVietnameseAccents(s string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ':
+			builder.WriteRune('d')
+		case r == 'Đ':
+			builder.WriteRune('D')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
+// Invoice number written in the transfer note, e.g. "Invoice 123" also found as "INVOICE123" once the bank strips spaces
+var invoiceNotePattern *regexp.Regexp = regexp.MustCompile(`(?i)invoice[\s#:\-]*(\d+)`)
+
+// Generate the transfer note of an invoice, customers paying by bank transfer are asked to write the same note
+func GenerateInvoiceNote(invoiceId int) string {
+	return fmt.Sprintf("Invoice %d", invoiceId)
+}
+
+// Get distinct invoice IDs written in a transfer note in the order they appear
+func GetNoteInvoiceIds(note string) []int {
+	var res []int
+	var isExisted map[int]bool = make(map[int]bool)
+	for _, match := range invoiceNotePattern.FindAllStringSubmatch(note, -1) {
+		id, err := strconv.Atoi(match[1])
+		if err != nil || id <= 0 || isExisted[id] {
+			continue
+		}
+
+		isExisted[id] = true
+		res = append(res, id)
+	}
+
+	return res
+}
+
+// Generate a random gift card code, e.g. TM-7KQ2-HX9M-4RTB
+func GenerateGiftCardCode() (string, error) {
+	var groups []string = []string{gift_card.CODE_PREFIX}
+	for i := 0; i < gift_card.CODE_GROUPS; i++ {
+		group, err := generateRandomCode(gift_card.CODE_GROUP_LENGTH)
+		if err != nil {
+			return "", err
+		}
+
+		groups = append(groups, group)
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// Generate a random referral code, e.g. REF-7KQ2HX9M
+func GenerateReferralCode() (string, error) {
+	code, err := generateRandomCode(referral.CODE_LENGTH)
+	if err != nil {
+		return "", err
+	}
+
+	return referral.CODE_PREFIX + "-" + code, nil
+}
+
+// Random characters of the code alphabet, which leaves out characters that are easy to mistake for each other
+func generateRandomCode(length int) (string, error) {
+	var alphabetSize *big.Int = big.NewInt(int64(len(gift_card.CODE_ALPHABET)))
+	var res []byte = make([]byte, length)
+	for i := range res {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+
+		res[i] = gift_card.CODE_ALPHABET[index.Int64()]
+	}
+
+	return string(res), nil
+}
+
+// Gift card codes are accepted in any case and with surrounding spaces
+func NormalizeGiftCardCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Referral codes are accepted in any case and with surrounding spaces
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
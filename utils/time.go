@@ -1,6 +1,14 @@
 package utils
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tourmate/payment-service/constant/granularity"
+	"tourmate/payment-service/constant/noti"
+	payout_schedule "tourmate/payment-service/constant/payout_schedule"
+)
 
 const (
 	NormalActionDuration time.Duration = time.Minute * 15   // 15'
@@ -8,11 +16,168 @@ const (
 	RefreshDuration      time.Duration = AccessDuration * 7 // 1 tuần
 )
 
+// Layout of dates without time, e.g. 2006-01-02
+const DATE_FORMAT string = "2006-01-02"
+
+// Date layouts found in bank statements, day first as used in Vietnam
+var statementDateLayouts []string = []string{
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"2/1/2006",
+	"02-01-2006 15:04:05",
+	"02-01-2006",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	DATE_FORMAT,
+}
+
 func GetPrimitiveTime() time.Time {
 	// 1/1/1900 - 00:00:00
 	return time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
 }
 
+// Validate an optional period, the end date is inclusive so it is moved to the next day
+func GenerateDatePeriod(from, to *time.Time) (*time.Time, *time.Time, error) {
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, errors.New(noti.INVALID_DATE_RANGE_WARN_MSG)
+	}
+
+	if to != nil {
+		var end time.Time = to.AddDate(0, 0, 1)
+		to = &end
+	}
+
+	return from, to, nil
+}
+
 func IsActionExpired(exp time.Time) bool {
 	return time.Now().After(exp)
 }
+
+// Get the next payout date on or after "from" according to the schedule.
+// payoutDay is the weekday (0 = Sunday) for weekly schedules and the day of month for monthly schedule.
+func GetNextPayoutDate(schedule string, payoutDay int, lastRunAt *time.Time, from time.Time) time.Time {
+	var minDate time.Time = GetStartOfDay(from)
+
+	// Never pay twice within the same cycle
+	if lastRunAt != nil {
+		var gap int = 1
+		if schedule == payout_schedule.BIWEEKLY {
+			gap = 14
+		}
+
+		if nextAllowedDate := GetStartOfDay(*lastRunAt).AddDate(0, 0, gap); nextAllowedDate.After(minDate) {
+			minDate = nextAllowedDate
+		}
+	}
+
+	if schedule == payout_schedule.MONTHLY {
+		var res time.Time = time.Date(minDate.Year(), minDate.Month(), payoutDay, 0, 0, 0, 0, minDate.Location())
+		if res.Before(minDate) {
+			res = res.AddDate(0, 1, 0)
+		}
+
+		return res
+	}
+
+	var dayGap int = (payoutDay - int(minDate.Weekday()) + 7) % 7
+	return minDate.AddDate(0, 0, dayGap)
+}
+
+// Get 00:00:00 of the given date
+func GetStartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// Check whether the time series granularity is supported
+func IsGranularityValid(value string) bool {
+	switch value {
+	case granularity.DAY, granularity.WEEK, granularity.MONTH, granularity.QUARTER, granularity.YEAR:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get the start of the bucket containing the given time, weeks start on Monday
+func GetBucketStart(value string, t time.Time) time.Time {
+	var day time.Time = GetStartOfDay(t)
+
+	switch value {
+	case granularity.WEEK:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case granularity.MONTH:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	case granularity.QUARTER:
+		return time.Date(day.Year(), (day.Month()-1)/3*3+1, 1, 0, 0, 0, 0, day.Location())
+	case granularity.YEAR:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+	default:
+		return day
+	}
+}
+
+// Get the start of the bucket following the bucket which starts at the given time
+func GetNextBucketStart(value string, bucketStart time.Time) time.Time {
+	switch value {
+	case granularity.WEEK:
+		return bucketStart.AddDate(0, 0, 7)
+	case granularity.MONTH:
+		return bucketStart.AddDate(0, 1, 0)
+	case granularity.QUARTER:
+		return bucketStart.AddDate(0, 3, 0)
+	case granularity.YEAR:
+		return bucketStart.AddDate(1, 0, 0)
+	default:
+		return bucketStart.AddDate(0, 0, 1)
+	}
+}
+
+// Get the start of the bucket preceding the bucket which starts at the given time
+func GetPreviousBucketStart(value string, bucketStart time.Time) time.Time {
+	switch value {
+	case granularity.WEEK:
+		return bucketStart.AddDate(0, 0, -7)
+	case granularity.MONTH:
+		return bucketStart.AddDate(0, -1, 0)
+	case granularity.QUARTER:
+		return bucketStart.AddDate(0, -3, 0)
+	case granularity.YEAR:
+		return bucketStart.AddDate(-1, 0, 0)
+	default:
+		return bucketStart.AddDate(0, 0, -1)
+	}
+}
+
+// Get the start of the same bucket one year earlier, weeks are compared with the week 52 weeks before
+func GetYearAgoBucketStart(value string, bucketStart time.Time) time.Time {
+	if value == granularity.WEEK {
+		return bucketStart.AddDate(0, 0, -364)
+	}
+
+	return GetBucketStart(value, bucketStart.AddDate(-1, 0, 0))
+}
+
+// Get [from, to) of a month, or of the whole year when month is 0, with its label such as 03/2025 or 2025
+func GetReportPeriod(year, month int) (time.Time, time.Time, string) {
+	if month == 0 {
+		var from time.Time = time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+		return from, from.AddDate(1, 0, 0), fmt.Sprint(year)
+	}
+
+	var from time.Time = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	return from, from.AddDate(0, 1, 0), fmt.Sprintf("%02d/%d", month, year)
+}
+
+// Parse a date of a bank statement in one of the supported layouts
+func ParseStatementDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range statementDateLayouts {
+		if res, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return res, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -20,3 +20,8 @@ func IsPasswordSecure(password string) bool {
 		digRgx.MatchString(password) &&
 		speRgx.MatchString(password)
 }
+
+// Check Vietnamese tax code, e.g. 0101234567, 0101234567-001 or a 12-digit personal identification number
+func IsTaxCodeValid(taxCode string) bool {
+	return regexp.MustCompile(`^(\d{10}(-\d{3})?|\d{12})$`).MatchString(taxCode)
+}